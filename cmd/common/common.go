@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"strings"
@@ -18,11 +19,13 @@ import (
 	types3 "github.com/make-os/kit/modules/types"
 	rr "github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/repo"
+	remotetypes "github.com/make-os/kit/remote/types"
 	"github.com/make-os/kit/rpc/client"
 	types2 "github.com/make-os/kit/rpc/types"
 	api2 "github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/util/api"
 	"github.com/make-os/kit/util/colorfmt"
+	io2 "github.com/make-os/kit/util/io"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cast"
@@ -36,6 +39,46 @@ var (
 	ErrSigningKeyPassRequired = fmt.Errorf("passphrase of signing key is required")
 )
 
+// SelectPostTemplate finds the post template with the given name. If a name is not
+// provided and a selector is set, the user is asked to pick one of the templates.
+// It returns nil if no template was named or picked.
+func SelectPostTemplate(
+	r rr.LocalRepo,
+	postType, name string,
+	getter rr.PostTemplatesGetter,
+	selector io2.SelectInputReader) (*rr.PostTemplate, error) {
+
+	if name == "" && selector == nil {
+		return nil, nil
+	}
+
+	templates, err := getter(r, postType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get templates")
+	}
+
+	if name != "" {
+		tpl := templates.Find(name)
+		if tpl == nil {
+			return nil, fmt.Errorf("template (%s) not found", name)
+		}
+		return tpl, nil
+	}
+
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	selected, err := selector("Choose a template", append([]string{"None"}, templates.Names()...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select template")
+	} else if selected == 0 {
+		return nil, nil
+	}
+
+	return templates[selected-1], nil
+}
+
 // pagerWriter describes a function for writing a specified content to a pager program
 type PagerWriter func(pagerCmd string, content io.Reader, stdOut, stdErr io.Writer)
 
@@ -174,6 +217,21 @@ func UnlockKey(cfg *config.AppConfig, args *UnlockKeyArgs) (types.StoredKey, err
 	return key, nil
 }
 
+// IndentLines prefixes every non-empty line of text with 4 spaces per depth level
+func IndentLines(text string, depth int) string {
+	if depth <= 0 {
+		return text
+	}
+	prefix := strings.Repeat("    ", depth)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // MakeRepoScopedEnvVar returns a repo-specific env variable
 func MakeRepoScopedEnvVar(appName, repoName, varName string) string {
 	return strings.ToUpper(fmt.Sprintf("%s_%s_%s", appName, repoName, varName))
@@ -282,6 +340,28 @@ func GetRemoteAddrFromRepo(repo rr.LocalRepo, remoteName string) (string, int, b
 	return "", 0, false
 }
 
+// GetRepoNameFromRemote returns the name of the repository the URLs of a
+// remote point to. It returns an empty string if the remote has no URL or
+// the URL points to a repository in a non-default namespace, since the name
+// in such URLs is a namespace domain and not the repository name.
+func GetRepoNameFromRemote(repo rr.LocalRepo, remote string) string {
+	if remote == "" {
+		remote = "origin"
+	}
+	for _, u := range repo.GetRemoteURLs(remote) {
+		remoteURL, err := url.Parse(u)
+		if err != nil {
+			continue
+		}
+		parts := strings.Split(strings.Trim(remoteURL.Path, "/"), "/")
+		if len(parts) < 2 || parts[0] != remotetypes.DefaultNS {
+			continue
+		}
+		return strings.TrimSuffix(parts[1], ".git")
+	}
+	return ""
+}
+
 // GetRepoAndClient opens a the repository on the current working directory
 // and returns an RPC client.
 func GetRepoAndClient(cmd *cobra.Command, cfg *config.AppConfig, repoDir string) (rr.LocalRepo, types2.Client) {
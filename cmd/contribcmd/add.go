@@ -118,7 +118,7 @@ func AddCmd(cfg *config.AppConfig, args *AddArgs) error {
 		Fee:           args.Fee,
 		Namespace:     args.Namespace,
 		NamespaceOnly: args.NamespaceOnly,
-		Policies:      nil,
+		Policies:      args.Policies,
 		SigningKey:    key.GetKey(),
 	}
 
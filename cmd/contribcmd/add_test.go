@@ -15,6 +15,7 @@ import (
 	"github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/testutil"
 	"github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/state"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -85,6 +86,26 @@ var _ = Describe("ContribCmd", func() {
 			Expect(err.Error()).To(ContainSubstring("failed to add contributors: error"))
 		})
 
+		It("should pass contributor policies to the transaction", func() {
+			policies := []*state.ContributorPolicy{{Object: "refs/heads/dev", Action: "write", EndHeight: 100}}
+			args := &AddArgs{SigningKey: "sk", SigningKeyPass: "sk_pass", Policies: policies}
+			mockKey := mocks.NewMockStoredKey(ctrl)
+			mockKey.EXPECT().GetUserAddress().Return(key.Addr().String())
+			mockKey.EXPECT().GetKey().Return(key)
+			args.KeyUnlocker = func(cfg *config.AppConfig, args2 *common.UnlockKeyArgs) (kstypes.StoredKey, error) {
+				return mockKey, nil
+			}
+			args.GetNextNonce = func(address string, rpcClient types.Client) (string, error) {
+				return "10", nil
+			}
+			args.AddRepoContributors = func(req *api.BodyAddRepoContribs, rpcClient types.Client) (hash string, err error) {
+				Expect(req.Policies).To(Equal(policies))
+				return "", fmt.Errorf("error")
+			}
+			err := AddCmd(cfg, args)
+			Expect(err).ToNot(BeNil())
+		})
+
 		Describe("on success", func() {
 			var err error
 			args := &AddArgs{SigningKey: "sk", SigningKeyPass: "sk_pass"}
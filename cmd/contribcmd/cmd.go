@@ -96,7 +96,7 @@ func init() {
 	contribF.Float64("feeCap", 0, "Max. amount of repo balance the contributor(s) can spend on fees")
 	contribF.String("namespace", "", "Add contributor(s) to the given repo-owned namespace")
 	contribF.String("namespaceOnly", "", "Only add contributor(s) to the given repo-owned namespace")
-	contribF.String("policies", "", "Set repository policies")
+	contribF.String("policies", "", "Set contributor policies (optionally bounded by startHeight and endHeight)")
 	contribF.Float64P("value", "v", 0, "The proposal fee to be paid if required by the repository")
 
 	contribF.Float64P("fee", "f", 0, "Set the network transaction fee")
@@ -10,11 +10,13 @@ import (
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/repo"
+	"github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/util"
 	cmdutil "github.com/make-os/kit/util/cmd"
 	"github.com/make-os/kit/util/io"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/thoas/go-funk"
 )
 
@@ -40,10 +42,13 @@ var issueCreateCmd = &cobra.Command{
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 		util.FatalOnError(cmdutil.RejectFlagCombo(cmd, "close", "reopen"))
+		util.FatalOnError(cmdutil.RejectFlagCombo(cmd, "reply", "edit", "delete"))
 
 		title, _ := cmd.Flags().GetString("title")
 		body, _ := cmd.Flags().GetString("body")
 		commentCommitID, _ := cmd.Flags().GetString("reply")
+		editHash, _ := cmd.Flags().GetString("edit")
+		deleteHash, _ := cmd.Flags().GetString("delete")
 		useEditor, _ := cmd.Flags().GetBool("use-editor")
 		noBody, _ := cmd.Flags().GetBool("no-body")
 		cls, _ := cmd.Flags().GetBool("close")
@@ -51,6 +56,8 @@ var issueCreateCmd = &cobra.Command{
 		force, _ := cmd.Flags().GetBool("force")
 		reopen, _ := cmd.Flags().GetBool("reopen")
 		editorPath, _ := cmd.Flags().GetString("editor")
+		template, _ := cmd.Flags().GetString("template")
+		noTemplate, _ := cmd.Flags().GetBool("no-template")
 		labels, _ := cmd.Flags().GetString("labels")
 		reactions, _ := cmd.Flags().GetStringSlice("reactions")
 		assignees, _ := cmd.Flags().GetString("assignees")
@@ -83,6 +90,8 @@ var issueCreateCmd = &cobra.Command{
 			Body:               body,
 			NoBody:             noBody,
 			ReplyHash:          commentCommitID,
+			EditHash:           editHash,
+			DeleteHash:         deleteHash,
 			Reactions:          funk.UniqString(reactions),
 			UseEditor:          useEditor,
 			EditorPath:         editorPath,
@@ -92,6 +101,12 @@ var issueCreateCmd = &cobra.Command{
 			PostCommentCreator: plumbing.CreatePostCommit,
 			EditorReader:       util.ReadFromEditor,
 			InputReader:        io.ReadInput,
+			Template:           template,
+			TemplatesGetter:    plumbing.GetPostTemplates,
+		}
+
+		if !noTemplate {
+			issueCreateArgs.TemplateSelector = io.SelectInput
 		}
 
 		if cmd.Flags().Changed("close") {
@@ -137,6 +152,12 @@ var issueListCmd = &cobra.Command{
 		dateFmt, _ := cmd.Flags().GetString("date")
 		format, _ := cmd.Flags().GetString("format")
 		noPager, _ := cmd.Flags().GetBool("no-pager")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		assignees, _ := cmd.Flags().GetStringSlice("assignee")
+		author, _ := cmd.Flags().GetString("author")
+		state, _ := cmd.Flags().GetString("state")
+		search, _ := cmd.Flags().GetString("search")
+		sort, _ := cmd.Flags().GetString("sort")
 
 		curRepo, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
 		if err != nil {
@@ -155,6 +176,17 @@ var issueListCmd = &cobra.Command{
 			StdErr:     os.Stderr,
 		}
 
+		if len(labels) > 0 || len(assignees) > 0 || author != "" || state != "" || search != "" || sort != "" {
+			issueArgs.Query = &plumbing.PostQuery{
+				Labels:    funk.UniqString(labels),
+				Assignees: funk.UniqString(assignees),
+				Author:    author,
+				State:     state,
+				Search:    search,
+				Sort:      sort,
+			}
+		}
+
 		issues, err := IssueListCmd(curRepo, issueArgs)
 		if err != nil {
 			log.Fatal(err.Error())
@@ -178,24 +210,46 @@ var issueReadCmd = &cobra.Command{
 		format, _ := cmd.Flags().GetString("format")
 		noPager, _ := cmd.Flags().GetBool("no-pager")
 		noCloseStatus, _ := cmd.Flags().GetBool("no-close-status")
+		tree, _ := cmd.Flags().GetBool("tree")
 
 		curRepo, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
 		if err != nil {
 			log.Fatal(errors.Wrap(err, "failed to open repo at cwd").Error())
 		}
 
+		client, err := common.GetRPCClient(cmd, curRepo)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		// Cross references are indexed by the remote node. When the node
+		// is unreachable, the issue is rendered without them.
+		repoName := common.GetRepoNameFromRemote(curRepo, viper.GetString("remote.name"))
+		getXRefs := func(target string) ([]*plumbing.CrossReference, error) {
+			if repoName == "" {
+				return nil, nil
+			}
+			res, err := client.Repo().GetReferencedBy(&api.BodyRepoGetReferencedBy{RepoName: repoName, Target: target})
+			if err != nil {
+				return nil, nil
+			}
+			return res.References, nil
+		}
+
 		if _, err = IssueReadCmd(curRepo, &IssueReadArgs{
-			Reference:     NormalizeIssueReferenceName(curRepo, args),
-			Limit:         limit,
-			Reverse:       reverse,
-			DateFmt:       dateFmt,
-			Format:        format,
-			PagerWrite:    common.WriteToPager,
-			PostGetter:    plumbing.GetPosts,
-			NoPager:       noPager,
-			NoCloseStatus: noCloseStatus,
-			StdOut:        os.Stdout,
-			StdErr:        os.Stderr,
+			Reference:             NormalizeIssueReferenceName(curRepo, args),
+			Limit:                 limit,
+			Reverse:               reverse,
+			DateFmt:               dateFmt,
+			Format:                format,
+			PagerWrite:            common.WriteToPager,
+			PostGetter:            plumbing.PostGetterWithCommitCloses(plumbing.GetPosts, getXRefs),
+			CrossReferencesGetter: getXRefs,
+			NoPager:               noPager,
+			NoCloseStatus:         noCloseStatus,
+			Tree:                  tree,
+			StdOut:                os.Stdout,
+			StdErr:                os.Stderr,
 		}); err != nil {
 			log.Fatal(err.Error())
 		}
@@ -283,6 +337,8 @@ func init() {
 	issueCreateCmd.Flags().StringP("title", "t", "", "The title of the issue (max. 250 B)")
 	issueCreateCmd.Flags().StringP("body", "b", "", "The body of the issue (max. 8 KB)")
 	issueCreateCmd.Flags().StringP("reply", "r", "", "Specify the hash of a comment to respond to")
+	issueCreateCmd.Flags().String("edit", "", "Specify the hash of a comment to replace with the new body")
+	issueCreateCmd.Flags().String("delete", "", "Specify the hash of a comment to delete")
 	issueCreateCmd.Flags().StringSliceP("reactions", "e", nil, "Add reactions to a reply (max. 10)")
 	issueCreateCmd.Flags().StringP("labels", "l", "", "Specify labels to add to the issue/comment (max. 10)")
 	issueCreateCmd.Flags().StringP("assignees", "a", "", "Specify push key of assignees to add to the issue/comment (max. 10)")
@@ -293,8 +349,17 @@ func init() {
 	issueCreateCmd.Flags().IntP("id", "i", 0, "Specify a target issue number")
 	issueCreateCmd.Flags().BoolP("close", "c", false, "Close the issue")
 	issueCreateCmd.Flags().BoolP("reopen", "o", false, "Open a closed issue")
+	issueCreateCmd.Flags().StringP("template", "T", "", "Specify the name of an issue template to use")
+	issueCreateCmd.Flags().Bool("no-template", false, "Skip prompt for an issue template")
 	issueCreateCmd.Flags().BoolP("force", "f", false, "Forcefully create the close comment (uncommitted changes will be lost)")
 	issueReadCmd.Flags().Bool("no-close-status", false, "Hide the close status indicator")
+	issueListCmd.Flags().StringSliceP("label", "l", nil, "Only list issues that have the label(s)")
+	issueListCmd.Flags().StringSliceP("assignee", "a", nil, "Only list issues assigned to the push key(s)")
+	issueListCmd.Flags().String("author", "", "Only list issues created by an author (name, email or push key ID)")
+	issueListCmd.Flags().StringP("state", "s", "", "Only list issues in a state (open or closed)")
+	issueListCmd.Flags().StringP("search", "q", "", "Only list issues whose title or comments contain a text")
+	issueListCmd.Flags().String("sort", "", "Sort issues by 'newest', 'oldest' or 'updated' (default: newest)")
+	issueReadCmd.Flags().Bool("tree", false, "Nest replies under the comments they reply to")
 
 	issueCloseCmd.Flags().BoolP("force", "f", false, "Forcefully create the close comment (uncommitted changes will be lost)")
 	issueReopenCmd.Flags().BoolP("force", "f", false, "Forcefully create the close comment (uncommitted changes will be lost)")
@@ -33,6 +33,12 @@ type IssueCreateArgs struct {
 	// ReplyHash is the hash of a comment commit
 	ReplyHash string
 
+	// EditHash is the hash of a comment commit whose content should be replaced
+	EditHash string
+
+	// DeleteHash is the hash of a comment commit that should be retracted
+	DeleteHash string
+
 	// Reactions adds or removes reactions to/from a comment commit
 	// Negated reactions indicate removal request
 	Reactions []string
@@ -72,6 +78,15 @@ type IssueCreateArgs struct {
 
 	// InputReader is a function that reads input from stdin
 	InputReader io2.InputReader
+
+	// Template is the name of a template to apply to a new issue
+	Template string
+
+	// TemplatesGetter is used to get the issue templates of the repository
+	TemplatesGetter plumbing.PostTemplatesGetter
+
+	// TemplateSelector is used to pick a template when one was not named
+	TemplateSelector io2.SelectInputReader
 }
 
 type IssueCreateResult struct {
@@ -91,6 +106,12 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 		args.StdOut = ioutil.Discard
 	}
 
+	// A comment can either be edited or deleted, not both
+	if args.EditHash != "" && args.DeleteHash != "" {
+		return nil, fmt.Errorf("a comment cannot be edited and deleted at the same time")
+	}
+	isRevision := args.EditHash != "" || args.DeleteHash != ""
+
 	if args.ID != 0 {
 
 		// Get the issue reference
@@ -98,7 +119,7 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 		issueRefHash, err = r.RefGet(issueRef)
 
 		// When issue does not exist and this is a reply intent, return error
-		if err != nil && err == plumbing.ErrRefNotFound && args.ReplyHash != "" {
+		if err != nil && err == plumbing.ErrRefNotFound && (args.ReplyHash != "" || isRevision) {
 			return nil, fmt.Errorf("issue (%d) was not found", args.ID)
 		} else if err != nil && err != plumbing.ErrRefNotFound {
 			return nil, err
@@ -120,6 +141,14 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 		if args.ReplyHash != "" && r.IsAncestor(args.ReplyHash, issueRefHash) != nil {
 			return nil, fmt.Errorf("target comment hash (%s) is unknown", args.ReplyHash)
 		}
+
+		// When the intent is to edit or delete a comment, ensure the target
+		// comment hash exist in the issue
+		for _, hash := range []string{args.EditHash, args.DeleteHash} {
+			if hash != "" && r.IsAncestor(hash, issueRefHash) != nil {
+				return nil, fmt.Errorf("target comment hash (%s) is unknown", hash)
+			}
+		}
 	}
 
 	// Ensure the reactions are all supported
@@ -152,6 +181,17 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 		return nil, fmt.Errorf("issue number is required when adding a comment")
 	}
 
+	// When intent is to edit or delete a comment, a issue number is required
+	if args.ID == 0 && isRevision {
+		return nil, fmt.Errorf("issue number is required when editing or deleting a comment")
+	}
+
+	// A deleted comment has no body
+	if args.DeleteHash != "" {
+		args.NoBody = true
+		args.UseEditor = false
+	}
+
 	// Hook to syscall.SIGINT signal to close args.StdIn on interrupt
 	if args.StdIn != nil {
 		sigs := make(chan os.Signal, 1)
@@ -159,8 +199,26 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 		go func() { <-sigs; args.StdIn.Close() }()
 	}
 
+	// Find or select a template when creating a new issue.
+	// Only prompt for a template when title and body were not provided.
+	var template *plumbing.PostTemplate
+	if numComments == 0 && args.ReplyHash == "" && !isRevision && args.TemplatesGetter != nil {
+		selector := args.TemplateSelector
+		if args.Title != "" || args.Body != "" {
+			selector = nil
+		}
+		template, err = common.SelectPostTemplate(r, plumbing.IssueBranchPrefix, args.Template, args.TemplatesGetter, selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The template may provide a default title and body
+	var hasTplTitle = template != nil && template.Title != ""
+	var hasTplBody = template != nil && template.Body != ""
+
 	// Prompt user for title only if was not provided via flag and this is not a comment
-	if len(args.Title) == 0 && args.ReplyHash == "" && numComments == 0 {
+	if len(args.Title) == 0 && !hasTplTitle && args.ReplyHash == "" && !isRevision && numComments == 0 {
 		if args.InputReader != nil {
 			args.Title, _ = args.InputReader("\033[1;32m? \033[1;37mTitle> \u001B[0m", &io2.InputReaderArgs{
 				After: func(input string) { fmt.Fprintf(args.StdOut, "\033[36m%s\033[0m\n", input) },
@@ -172,7 +230,7 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 	}
 
 	// Read body from stdIn only if an editor is not requested and --no-body is unset
-	if len(args.Body) == 0 && !args.UseEditor && !args.NoBody {
+	if len(args.Body) == 0 && !hasTplBody && !args.UseEditor && !args.NoBody {
 		if args.InputReader != nil {
 			args.Body, _ = args.InputReader("\033[1;32m? \033[1;37mBody> \u001B[0m", &io2.InputReaderArgs{
 				After: func(input string) { fmt.Fprintf(args.StdOut, "\033[36m%s\033[0m\n", input) },
@@ -196,7 +254,12 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 	}
 
 	// Body is required for a new issue
-	if numComments == 0 && args.Body == "" {
+	if numComments == 0 && args.Body == "" && !hasTplBody {
+		return nil, common.ErrBodyRequired
+	}
+
+	// Body is required when editing a comment
+	if args.EditHash != "" && args.Body == "" {
 		return nil, common.ErrBodyRequired
 	}
 
@@ -205,6 +268,8 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 		Content:   []byte(args.Body),
 		Title:     args.Title,
 		ReplyTo:   args.ReplyHash,
+		Edit:      args.EditHash,
+		Delete:    args.DeleteHash,
 		Reactions: args.Reactions,
 		IssueFields: &plumbing.IssueFields{
 			Labels:    args.Labels,
@@ -218,9 +283,10 @@ func IssueCreateCmd(r plumbing.LocalRepo, args *IssueCreateArgs) (*IssueCreateRe
 		Type:          plumbing.IssueBranchPrefix,
 		ID:            args.ID,
 		Body:          postBody,
-		IsComment:     args.ReplyHash != "",
+		IsComment:     args.ReplyHash != "" || isRevision,
 		Force:         args.Force,
 		GetFreePostID: plumbing.GetFreePostID,
+		Template:      template,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create or add new comment to issue")
@@ -72,6 +72,20 @@ var _ = Describe("IssueCreate", func() {
 				Expect(err).To(MatchError("assignee (*assign&ee) is not a valid push key address"))
 			})
 
+			It("should return error when edit and delete hashes are both set", func() {
+				args := &issuecmd.IssueCreateArgs{EditHash: "02we", DeleteHash: "02we"}
+				_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("a comment cannot be edited and deleted at the same time"))
+			})
+
+			It("should return error when edit hash is set but issue number is not set", func() {
+				args := &issuecmd.IssueCreateArgs{EditHash: "02we"}
+				_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("issue number is required when editing or deleting a comment"))
+			})
+
 			It("should return error when reply hash is set but issue number is not set", func() {
 				args := &issuecmd.IssueCreateArgs{ReplyHash: "02we"}
 				_, err := issuecmd.IssueCreateCmd(mockRepo, args)
@@ -130,6 +144,101 @@ var _ = Describe("IssueCreate", func() {
 				Expect(err).To(Equal(common.ErrBodyRequired))
 			})
 
+			When("templates are available", func() {
+				var templates = plumbing.PostTemplates{
+					{Name: "bug", Title: "Bug report", Labels: []string{"bug"}, Body: "Steps to reproduce"},
+					{Name: "feature", Body: "Describe the feature"},
+				}
+				var templatesGetter = func(_ plumbing.LocalRepo, postType string) (plumbing.PostTemplates, error) {
+					Expect(postType).To(Equal(plumbing.IssueBranchPrefix))
+					return templates, nil
+				}
+
+				It("should return error when the named template does not exist", func() {
+					args := &issuecmd.IssueCreateArgs{StdOut: bytes.NewBuffer(nil), Template: "unknown", TemplatesGetter: templatesGetter}
+					_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+					Expect(err).To(MatchError("template (unknown) not found"))
+				})
+
+				It("should return error when unable to get templates", func() {
+					args := &issuecmd.IssueCreateArgs{StdOut: bytes.NewBuffer(nil), Template: "bug",
+						TemplatesGetter: func(_ plumbing.LocalRepo, _ string) (plumbing.PostTemplates, error) {
+							return nil, fmt.Errorf("error")
+						},
+					}
+					_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+					Expect(err).To(MatchError("failed to get templates: error"))
+				})
+
+				It("should pass the named template to the post creator and not prompt for title and body", func() {
+					var tpl *plumbing.PostTemplate
+					args := &issuecmd.IssueCreateArgs{StdOut: bytes.NewBuffer(nil), Template: "bug", TemplatesGetter: templatesGetter,
+						PostCommentCreator: func(_ plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+							tpl = args.Template
+							return true, "refs/heads/issues/1", nil
+						},
+						InputReader: func(title string, args *io2.InputReaderArgs) (string, error) {
+							Fail("should not prompt for input")
+							return "", nil
+						},
+					}
+					_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+					Expect(err).To(BeNil())
+					Expect(tpl).To(Equal(templates[0]))
+				})
+
+				It("should pass the selected template to the post creator", func() {
+					var tpl *plumbing.PostTemplate
+					args := &issuecmd.IssueCreateArgs{StdOut: bytes.NewBuffer(nil), TemplatesGetter: templatesGetter,
+						TemplateSelector: func(title string, options []string) (int, error) {
+							Expect(options).To(Equal([]string{"None", "bug", "feature"}))
+							return 2, nil
+						},
+						PostCommentCreator: func(_ plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+							tpl = args.Template
+							return true, "refs/heads/issues/1", nil
+						},
+						InputReader: func(title string, args *io2.InputReaderArgs) (string, error) {
+							return testutil.ReturnStringOnCallCount(&inpReaderCallCount, "my title"), nil
+						},
+					}
+					_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+					Expect(err).To(BeNil())
+					Expect(args.Title).To(Equal("my title"))
+					Expect(tpl).To(Equal(templates[1]))
+				})
+
+				It("should not apply a template when 'None' is selected", func() {
+					var tpl = &plumbing.PostTemplate{}
+					args := &issuecmd.IssueCreateArgs{StdOut: bytes.NewBuffer(nil), TemplatesGetter: templatesGetter,
+						TemplateSelector: func(title string, options []string) (int, error) { return 0, nil },
+						PostCommentCreator: func(_ plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+							tpl = args.Template
+							return true, "refs/heads/issues/1", nil
+						},
+						InputReader: func(title string, args *io2.InputReaderArgs) (string, error) {
+							return testutil.ReturnStringOnCallCount(&inpReaderCallCount, "my title", "my body"), nil
+						},
+					}
+					_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+					Expect(err).To(BeNil())
+					Expect(tpl).To(BeNil())
+				})
+
+				It("should not prompt for a template when title or body is provided", func() {
+					args := &issuecmd.IssueCreateArgs{StdOut: bytes.NewBuffer(nil), Title: "my title", Body: "my body",
+						TemplatesGetter: templatesGetter,
+						TemplateSelector: func(title string, options []string) (int, error) {
+							Fail("should not prompt for a template")
+							return 0, nil
+						},
+						PostCommentCreator: noopPostCommentCreator,
+					}
+					_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+					Expect(err).To(BeNil())
+				})
+			})
+
 			When("custom editor is requested", func() {
 				var args *issuecmd.IssueCreateArgs
 				BeforeEach(func() {
@@ -225,6 +334,45 @@ var _ = Describe("IssueCreate", func() {
 				Expect(err).To(MatchError("target comment hash (reply_hash) is unknown"))
 			})
 
+			It("should return error when edit hash does not exist in issue branch", func() {
+				args := &issuecmd.IssueCreateArgs{ID: 1, EditHash: "edit_hash"}
+				ref := plumbing.MakeIssueReference(args.ID)
+				mockRepo.EXPECT().RefGet(ref).Return("xyz", nil)
+				mockRepo.EXPECT().NumCommits(ref, false).Return(1, nil)
+				mockRepo.EXPECT().IsAncestor("edit_hash", "xyz").Return(fmt.Errorf("bad"))
+				_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("target comment hash (edit_hash) is unknown"))
+			})
+
+			It("should return ErrBodyRequired when intent is an edit and body is unset", func() {
+				args := &issuecmd.IssueCreateArgs{ID: 1, EditHash: "edit_hash", NoBody: true}
+				ref := plumbing.MakeIssueReference(args.ID)
+				mockRepo.EXPECT().RefGet(ref).Return("xyz", nil)
+				mockRepo.EXPECT().NumCommits(ref, false).Return(1, nil)
+				mockRepo.EXPECT().IsAncestor("edit_hash", "xyz").Return(nil)
+				_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(Equal(common.ErrBodyRequired))
+			})
+
+			It("should create a comment with a delete directive when intent is a delete", func() {
+				var createArgs *plumbing.CreatePostCommitArgs
+				ref := plumbing.MakeIssueReference(1)
+				args := &issuecmd.IssueCreateArgs{ID: 1, DeleteHash: "delete_hash", StdOut: bytes.NewBuffer(nil),
+					PostCommentCreator: func(_ plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+						createArgs = args
+						return false, ref, nil
+					}}
+				mockRepo.EXPECT().RefGet(ref).Return("xyz", nil)
+				mockRepo.EXPECT().NumCommits(ref, false).Return(1, nil)
+				mockRepo.EXPECT().IsAncestor("delete_hash", "xyz").Return(nil)
+				_, err := issuecmd.IssueCreateCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(createArgs.IsComment).To(BeTrue())
+				Expect(createArgs.Body).To(ContainSubstring("delete: delete_hash"))
+			})
+
 			It("should not return ErrBodyRequired when NoBody=true and intent is a reply", func() {
 				issueNumber := 1
 				ref := plumbing.MakeIssueReference(issueNumber)
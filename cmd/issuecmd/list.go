@@ -25,6 +25,14 @@ type IssueListArgs struct {
 	// DateFmt is the date format to use for displaying dates
 	DateFmt string
 
+	// Query contains filters and sort options applied to the issues.
+	// If unset, all issues are returned, newest first.
+	Query *pl.PostQuery
+
+	// PostDataGetter provides indexed labels, assignees and close status
+	// of the issues when filtering. If unset, they are derived from comments.
+	PostDataGetter pl.PostDataGetter
+
 	// PostGetter is the function used to get issue posts
 	PostGetter pl.PostGetter
 
@@ -65,8 +73,16 @@ func IssueListCmd(targetRepo pl.LocalRepo, args *IssueListArgs) (pl.Posts, error
 		return nil, errors.Wrap(err, "failed to get issue posts")
 	}
 
-	// Sort by their first post time
-	issues.SortByFirstPostCreationTimeDesc()
+	// Filter and sort the issues if a query is provided,
+	// otherwise, sort by their first post time
+	if args.Query != nil {
+		issues, err = pl.QueryPosts(issues, args.Query, args.PostDataGetter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query issues")
+		}
+	} else {
+		issues.SortByFirstPostCreationTimeDesc()
+	}
 
 	// Reverse issues if requested
 	if args.Reverse {
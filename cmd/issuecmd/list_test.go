@@ -153,6 +153,58 @@ var _ = Describe("IssueList", func() {
 			Expect(res).To(HaveLen(1))
 			Expect(res[0].GetName()).To(Equal("b"))
 		})
+
+		When("a query is provided", func() {
+			var posts []plumbing3.PostEntry
+
+			BeforeEach(func() {
+				posts = []plumbing3.PostEntry{
+					&plumbing3.Post{
+						Name:  "a",
+						Title: "How to open a file",
+						Comment: &plumbing3.Comment{
+							Body:      plumbing3.NewEmptyPostBody(),
+							CreatedAt: time.Now().Add(-10 * time.Second),
+							Author:    "ada",
+						},
+					},
+					&plumbing3.Post{
+						Name:  "b",
+						Title: "Remove examples",
+						Comment: &plumbing3.Comment{
+							Body:      plumbing3.NewEmptyPostBody(),
+							CreatedAt: time.Now().Add(-5 * time.Second),
+							Author:    "ben",
+						},
+					},
+				}
+			})
+
+			It("should return only issues that match the query", func() {
+				args := &issuecmd.IssueListArgs{
+					Query: &plumbing3.PostQuery{Author: "ada"},
+					PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+						return posts, nil
+					},
+				}
+				res, err := issuecmd.IssueListCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(res).To(HaveLen(1))
+				Expect(res[0].GetName()).To(Equal("a"))
+			})
+
+			It("should return err when query is not valid", func() {
+				args := &issuecmd.IssueListArgs{
+					Query: &plumbing3.PostQuery{State: "unknown"},
+					PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+						return posts, nil
+					},
+				}
+				_, err := issuecmd.IssueListCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("failed to query issues: unknown state (unknown); expected 'open' or 'closed'"))
+			})
+		})
 	})
 
 	Describe(".FormatAndPrintIssueList", func() {
@@ -35,6 +35,10 @@ type IssueReadArgs struct {
 	// PostGetter is the function used to get issue posts
 	PostGetter pl.PostGetter
 
+	// CrossReferencesGetter is the function used to get commits and posts that
+	// reference the issue. Cross references are not rendered when not set.
+	CrossReferencesGetter pl.CrossReferencesGetter
+
 	// PagerWrite is the function used to write to a pager
 	PagerWrite common.PagerWriter
 
@@ -54,6 +58,8 @@ type IssueReadArgs struct {
 	// - %R 	- The full commit hash the current comment is replying to.
 	// - %rs 	- The comment's reactions.
 	// - %cl 	- Flag for close status of the post (true/false)
+	// - %ed 	- The short hash of the most recent edit of the comment
+	// - %dl 	- Flag for deletion status of the comment (true/false)
 	Format string
 
 	// NoPager indicates that output must not be piped into a pager
@@ -62,6 +68,9 @@ type IssueReadArgs struct {
 	// NoCloseStatus indicates that the close status must not be rendered
 	NoCloseStatus bool
 
+	// Tree indicates that replies should be nested under the comments they reply to
+	Tree bool
+
 	StdOut io.Writer
 	StdErr io.Writer
 }
@@ -93,6 +102,15 @@ func IssueReadCmd(targetRepo pl.LocalRepo, args *IssueReadArgs) (pl.Comments, er
 		return nil, errors.Wrap(err, "failed to get comments")
 	}
 
+	// Get commits and posts that reference the issue
+	var xrefs []*pl.CrossReference
+	if args.CrossReferencesGetter != nil {
+		xrefs, err = args.CrossReferencesGetter(args.Reference)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get cross references")
+		}
+	}
+
 	// Reverse issues if requested
 	if args.Reverse {
 		comments.Reverse()
@@ -105,11 +123,15 @@ func IssueReadCmd(targetRepo pl.LocalRepo, args *IssueReadArgs) (pl.Comments, er
 
 	// Format and print if stdout is provided
 	if args.StdOut != nil {
-		if err = formatAndPrintIssueComments(targetRepo, args, isClosed, issues[0].GetTitle(), comments); err != nil {
+		if err = formatAndPrintIssueComments(targetRepo, args, isClosed, issues[0].GetTitle(), comments, xrefs); err != nil {
 			return nil, err
 		}
 	}
 
+	if args.Tree {
+		return comments.Tree(), nil
+	}
+
 	return comments, nil
 }
 
@@ -119,7 +141,8 @@ func formatAndPrintIssueComments(
 	args *IssueReadArgs,
 	isClosed bool,
 	title string,
-	comments pl.Comments) error {
+	comments pl.Comments,
+	xrefs []*pl.CrossReference) error {
 
 	buf := bytes.NewBuffer(nil)
 
@@ -130,7 +153,26 @@ func formatAndPrintIssueComments(
 		buf.WriteString("\n")
 	}
 
+	// Determine the display index of each comment. In tree mode, comments
+	// are reordered so that replies follow the comment they reply to.
+	var indexes = make(map[string]int, len(comments))
 	for i, comment := range comments {
+		if !args.Reverse {
+			i = int(math.Abs(float64(i - len(comments) + 1)))
+		}
+		indexes[comment.Hash] = i
+	}
+	var depths = map[string]int{}
+	if args.Tree {
+		var ordered pl.Comments
+		comments.Tree().Walk(func(comment *pl.Comment, depth int) {
+			ordered = append(ordered, comment)
+			depths[comment.Hash] = depth
+		})
+		comments = ordered
+	}
+
+	for _, comment := range comments {
 
 		// Format date if date format is specified
 		date := comment.CreatedAt.String()
@@ -150,16 +192,23 @@ func formatAndPrintIssueComments(
 		}
 
 		content := strings.TrimSpace(string(comment.Body.Content))
-
-		if !args.Reverse {
-			i = int(math.Abs(float64(i - len(comments) + 1)))
+		if comment.Deleted {
+			content = "[deleted]"
 		}
 
+		i := indexes[comment.Hash]
+
 		titleCpy := title
 		if i > 0 {
 			titleCpy = "RE: " + title
 		}
 
+		var edited, editedFmt string
+		if comment.IsEdited() && !comment.Deleted {
+			edited = comment.Edits[len(comment.Edits)-1][:7]
+			editedFmt = "\nEdited:     %ed"
+		}
+
 		var replyToFmt, replyTo string
 		if comment.Body.ReplyTo != "" {
 			replyToFmt = "\nReplyTo:    %R"
@@ -214,6 +263,8 @@ func formatAndPrintIssueComments(
 			"r":  comment.Body.ReplyTo,
 			"rs": reactions,
 			"cl": isClosed,
+			"ed": edited,
+			"dl": comment.Deleted,
 		}
 
 		// Get format or use default
@@ -222,13 +273,26 @@ func formatAndPrintIssueComments(
 			format = `` + fmt2.YellowString("comments %H #%i") + `
 Author:     %a <%e>
 Title:      %t
-Date:       %d` + replyToFmt + `` + assigneeFmt + `` + labelsFmt + `` + reactionsFmt + `
+Date:       %d` + replyToFmt + `` + editedFmt + `` + assigneeFmt + `` + labelsFmt + `` + reactionsFmt + `
 
 %c
 `
 		}
 
-		buf.WriteString(util.ParseVerbs(format, data))
+		buf.WriteString(common.IndentLines(util.ParseVerbs(format, data), depths[comment.Hash]))
+		buf.WriteString("\n")
+	}
+
+	// Render cross references only in the default format
+	if len(xrefs) > 0 && args.Format == "" {
+		buf.WriteString(fmt2.YellowString("Referenced by:") + "\n")
+		for _, xref := range xrefs {
+			var closes string
+			if xref.Closes {
+				closes = " (closes)"
+			}
+			buf.WriteString(fmt.Sprintf("  %s %s in %s%s\n", xref.Type, xref.Hash[:7], xref.Source, closes))
+		}
 		buf.WriteString("\n")
 	}
 
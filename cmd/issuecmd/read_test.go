@@ -1,6 +1,7 @@
 package issuecmd_test
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 
@@ -89,5 +90,124 @@ var _ = Describe("IssueReadCmd", func() {
 			Expect(err).ToNot(BeNil())
 			Expect(err).To(MatchError("failed to get comments: error"))
 		})
+
+		It("should return err when unable to get cross references", func() {
+			args := &issuecmd.IssueReadArgs{
+				Reference: plumbing2.MakeIssueReference(1),
+				PostGetter: func(plumbing2.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing2.Posts, error) {
+					post := mocks.NewMockPostEntry(ctrl)
+					post.EXPECT().IsClosed().Return(false, nil)
+					post.EXPECT().GetComments().Return(plumbing2.Comments{}, nil)
+					return plumbing2.Posts{post}, nil
+				},
+				CrossReferencesGetter: func(string) ([]*plumbing2.CrossReference, error) {
+					return nil, fmt.Errorf("error")
+				},
+			}
+			_, err := issuecmd.IssueReadCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to get cross references: error"))
+		})
+
+		When("the issue is referenced by other commits", func() {
+			var args *issuecmd.IssueReadArgs
+			var issueRef = plumbing2.MakeIssueReference(1)
+			var xrefs []*plumbing2.CrossReference
+			var closed bool
+
+			BeforeEach(func() {
+				closed = false
+				xrefs = []*plumbing2.CrossReference{
+					{Target: issueRef, Type: plumbing2.CrossRefSourceCommit, Source: plumbing2.DefaultBranch, Hash: "aaaaaaaaaa", Closes: true},
+				}
+				args = &issuecmd.IssueReadArgs{
+					Reference: issueRef,
+					NoPager:   true,
+					PostGetter: func(plumbing2.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing2.Posts, error) {
+						post := mocks.NewMockPostEntry(ctrl)
+						post.EXPECT().IsClosed().Return(closed, nil)
+						post.EXPECT().GetComments().Return(plumbing2.Comments{}, nil)
+						post.EXPECT().GetTitle().Return("title").AnyTimes()
+						return plumbing2.Posts{post}, nil
+					},
+					CrossReferencesGetter: func(target string) ([]*plumbing2.CrossReference, error) {
+						Expect(target).To(Equal(issueRef))
+						return xrefs, nil
+					},
+				}
+			})
+
+			It("should render the cross references", func() {
+				out := bytes.NewBuffer(nil)
+				args.StdOut = out
+				closed = true
+				mockRepo.EXPECT().Var("GIT_PAGER").Return("", nil)
+				_, err := issuecmd.IssueReadCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(ContainSubstring("CLOSED"))
+				Expect(out.String()).To(ContainSubstring("Referenced by:"))
+				Expect(out.String()).To(ContainSubstring("commit aaaaaaa in refs/heads/master (closes)"))
+			})
+
+			It("should take the close status from the post and not from the cross references", func() {
+				out := bytes.NewBuffer(nil)
+				args.StdOut = out
+				mockRepo.EXPECT().Var("GIT_PAGER").Return("", nil)
+				_, err := issuecmd.IssueReadCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(out.String()).ToNot(ContainSubstring("CLOSED"))
+				Expect(out.String()).To(ContainSubstring("commit aaaaaaa in refs/heads/master (closes)"))
+			})
+		})
+
+		When("tree mode is requested", func() {
+			var comments plumbing2.Comments
+			var args *issuecmd.IssueReadArgs
+
+			BeforeEach(func() {
+				noReactions := func() map[string]int { return nil }
+				body := func(content, replyTo string) *plumbing2.PostBody {
+					b := plumbing2.NewEmptyPostBody()
+					b.Content, b.ReplyTo = []byte(content), replyTo
+					return b
+				}
+				comments = plumbing2.Comments{
+					{Hash: "3333333333", Body: body("reply", "1111111111"), GetReactions: noReactions},
+					{Hash: "2222222222", Body: body("", ""), Deleted: true, Edits: []string{"4444444444"}, GetReactions: noReactions},
+					{Hash: "1111111111", Body: body("first", ""), GetReactions: noReactions},
+				}
+				args = &issuecmd.IssueReadArgs{
+					Reference: plumbing2.MakeIssueReference(1),
+					Tree:      true,
+					NoPager:   true,
+					PostGetter: func(plumbing2.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing2.Posts, error) {
+						post := mocks.NewMockPostEntry(ctrl)
+						post.EXPECT().IsClosed().Return(false, nil)
+						post.EXPECT().GetComments().Return(comments, nil)
+						post.EXPECT().GetTitle().Return("title").AnyTimes()
+						return plumbing2.Posts{post}, nil
+					},
+				}
+			})
+
+			It("should return the comments as a tree", func() {
+				res, err := issuecmd.IssueReadCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(res).To(HaveLen(2))
+				Expect(res[1].Hash).To(Equal("1111111111"))
+				Expect(res[1].Replies).To(HaveLen(1))
+				Expect(res[1].Replies[0].Hash).To(Equal("3333333333"))
+			})
+
+			It("should indent replies and hide the content of deleted comments", func() {
+				out := bytes.NewBuffer(nil)
+				args.StdOut = out
+				args.Format = "%h:%c\n"
+				mockRepo.EXPECT().Var("GIT_PAGER").Return("", nil)
+				_, err := issuecmd.IssueReadCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(Equal("2222222:[deleted]\n\n1111111:first\n\n    3333333:reply\n\n"))
+			})
+		})
 	})
 })
@@ -40,15 +40,20 @@ var mergeReqCreateCmd = &cobra.Command{
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 		util.FatalOnError(cmdutil.RejectFlagCombo(cmd, "close", "reopen"))
+		util.FatalOnError(cmdutil.RejectFlagCombo(cmd, "reply", "edit", "delete"))
 
 		title, _ := cmd.Flags().GetString("title")
 		body, _ := cmd.Flags().GetString("body")
 		commentCommitID, _ := cmd.Flags().GetString("reply")
+		editHash, _ := cmd.Flags().GetString("edit")
+		deleteHash, _ := cmd.Flags().GetString("delete")
 		useEditor, _ := cmd.Flags().GetBool("use-editor")
 		noBody, _ := cmd.Flags().GetBool("no-body")
 		cls, _ := cmd.Flags().GetBool("close")
 		forceNew, _ := cmd.Flags().GetBool("new")
 		editorPath, _ := cmd.Flags().GetString("editor")
+		template, _ := cmd.Flags().GetString("template")
+		noTemplate, _ := cmd.Flags().GetBool("no-template")
 		reactions, _ := cmd.Flags().GetStringSlice("reactions")
 		targetPostID, _ := cmd.Flags().GetInt("id")
 		baseBranch, _ := cmd.Flags().GetString("base")
@@ -143,6 +148,8 @@ var mergeReqCreateCmd = &cobra.Command{
 			Body:               body,
 			NoBody:             noBody,
 			ReplyHash:          commentCommitID,
+			EditHash:           editHash,
+			DeleteHash:         deleteHash,
 			Reactions:          funk.UniqString(reactions),
 			UseEditor:          useEditor,
 			EditorPath:         editorPath,
@@ -156,6 +163,12 @@ var mergeReqCreateCmd = &cobra.Command{
 			PostCommentCreator: plumbing.CreatePostCommit,
 			EditorReader:       util.ReadFromEditor,
 			InputReader:        io.ReadInput,
+			Template:           template,
+			TemplatesGetter:    plumbing.GetPostTemplates,
+		}
+
+		if !noTemplate {
+			mrCreateArgs.TemplateSelector = io.SelectInput
 		}
 
 		if cmd.Flags().Changed("close") {
@@ -220,6 +233,7 @@ var mergeReqReadCmd = &cobra.Command{
 		format, _ := cmd.Flags().GetString("format")
 		noPager, _ := cmd.Flags().GetBool("no-pager")
 		noCloseStatus, _ := cmd.Flags().GetBool("no-close-status")
+		tree, _ := cmd.Flags().GetBool("tree")
 
 		curRepo, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
 		if err != nil {
@@ -236,6 +250,7 @@ var mergeReqReadCmd = &cobra.Command{
 			PostGetter:    plumbing.GetPosts,
 			NoPager:       noPager,
 			NoCloseStatus: noCloseStatus,
+			Tree:          tree,
 			StdOut:        os.Stdout,
 			StdErr:        os.Stderr,
 		}); err != nil {
@@ -399,6 +414,8 @@ func init() {
 	mergeReqCreateCmd.Flags().StringP("title", "t", "", "The merge request title (max. 250 B)")
 	mergeReqCreateCmd.Flags().StringP("body", "b", "", "The merge request message (max. 8 KB)")
 	mergeReqCreateCmd.Flags().StringP("reply", "r", "", "Specify the hash of a comment to respond to")
+	mergeReqCreateCmd.Flags().String("edit", "", "Specify the hash of a comment to replace with the new body")
+	mergeReqCreateCmd.Flags().String("delete", "", "Specify the hash of a comment to delete")
 	mergeReqCreateCmd.Flags().StringSliceP("reactions", "e", nil, "Add reactions to a reply (max. 10)")
 	mergeReqCreateCmd.Flags().BoolP("use-editor", "u", false, "Use git's `core.editor` program to write the body")
 	mergeReqCreateCmd.Flags().Bool("no-body", false, "Skip prompt for the merge request body")
@@ -411,9 +428,12 @@ func init() {
 	mergeReqCreateCmd.Flags().String("baseHash", "", "Specify the current hash of the base branch")
 	mergeReqCreateCmd.Flags().String("target", "", "Specify the target branch name")
 	mergeReqCreateCmd.Flags().String("targetHash", "", "Specify the hash of the target branch")
+	mergeReqCreateCmd.Flags().StringP("template", "T", "", "Specify the name of a merge request template to use")
+	mergeReqCreateCmd.Flags().Bool("no-template", false, "Skip prompt for a merge request template")
 	mergeReqCreateCmd.Flags().BoolP("force", "f", false, "Forcefully create the close comment (uncommitted changes will be lost)")
 
 	mergeReqReadCmd.Flags().Bool("no-close-status", false, "Hide the close status indicator")
+	mergeReqReadCmd.Flags().Bool("tree", false, "Nest replies under the comments they reply to")
 
 	mergeReqCheckoutCmd.Flags().BoolP("force-checkout", "f", false, "Forcefully checkout while ignoring unsaved local changes")
 	mergeReqCheckoutCmd.Flags().Bool("force-fetch", false, "Forcefully fetch the branch (uncommitted changes will be lost)")
@@ -31,6 +31,12 @@ type MergeRequestCreateArgs struct {
 	// ReplyHash is the hash of a comment commit
 	ReplyHash string
 
+	// EditHash is the hash of a comment commit whose content should be replaced
+	EditHash string
+
+	// DeleteHash is the hash of a comment commit that should be retracted
+	DeleteHash string
+
 	// Reactions adds or removes reactions to/from a comment commit
 	// Negated reactions indicate removal request
 	Reactions []string
@@ -76,6 +82,15 @@ type MergeRequestCreateArgs struct {
 
 	// InputReader is a function that reads input from stdin
 	InputReader io2.InputReader
+
+	// Template is the name of a template to apply to a new merge request
+	Template string
+
+	// TemplatesGetter is used to get the merge request templates of the repository
+	TemplatesGetter plumbing.PostTemplatesGetter
+
+	// TemplateSelector is used to pick a template when one was not named
+	TemplateSelector io2.SelectInputReader
 }
 
 type MergeRequestCreateResult struct {
@@ -96,13 +111,19 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 		args.StdOut = ioutil.Discard
 	}
 
+	// A comment can either be edited or deleted, not both
+	if args.EditHash != "" && args.DeleteHash != "" {
+		return nil, fmt.Errorf("a comment cannot be edited and deleted at the same time")
+	}
+	isRevision := args.EditHash != "" || args.DeleteHash != ""
+
 	if args.ID != 0 {
 		// Get the merge request reference name and attempt to get it
 		mrRef = plumbing.MakeMergeRequestReference(args.ID)
 		mrRefHash, err = r.RefGet(mrRef)
 
 		// When the merge request does not exist and this is a reply intent, return error
-		if err != nil && err == plumbing.ErrRefNotFound && args.ReplyHash != "" {
+		if err != nil && err == plumbing.ErrRefNotFound && (args.ReplyHash != "" || isRevision) {
 			return nil, fmt.Errorf("merge request (%d) was not found", args.ID)
 		} else if err != nil && err != plumbing.ErrRefNotFound {
 			return nil, err
@@ -125,6 +146,14 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 			return nil, fmt.Errorf("target comment hash (%s) is unknown", args.ReplyHash)
 		}
 
+		// When the intent is to edit or delete a comment, ensure the target
+		// comment hash exist in the merge request
+		for _, hash := range []string{args.EditHash, args.DeleteHash} {
+			if hash != "" && r.IsAncestor(hash, mrRefHash) != nil {
+				return nil, fmt.Errorf("target comment hash (%s) is unknown", hash)
+			}
+		}
+
 		// Base and target information are required for new merge request
 		if nComments == 0 {
 			if args.Title == "" {
@@ -157,13 +186,42 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 		return nil, fmt.Errorf("merge request number is required when adding a comment")
 	}
 
+	// When intent is to edit or delete a comment, a merge request number is required
+	if args.ID == 0 && isRevision {
+		return nil, fmt.Errorf("merge request number is required when editing or deleting a comment")
+	}
+
+	// A deleted comment has no body
+	if args.DeleteHash != "" {
+		args.NoBody = true
+		args.UseEditor = false
+	}
+
 	// Hook to syscall.SIGINT signal so we close args.StdIn
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT)
 	go func() { <-sigs; args.StdIn.Close() }()
 
+	// Find or select a template when creating a new merge request.
+	// Only prompt for a template when title and body were not provided.
+	var template *plumbing.PostTemplate
+	if nComments == 0 && args.ReplyHash == "" && !isRevision && args.TemplatesGetter != nil {
+		selector := args.TemplateSelector
+		if args.Title != "" || args.Body != "" {
+			selector = nil
+		}
+		template, err = common.SelectPostTemplate(r, plumbing.MergeRequestBranchPrefix, args.Template, args.TemplatesGetter, selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The template may provide a default title and body
+	var hasTplTitle = template != nil && template.Title != ""
+	var hasTplBody = template != nil && template.Body != ""
+
 	// Prompt user for title only if was not provided via flag and this is not a comment
-	if len(args.Title) == 0 && args.ReplyHash == "" && nComments == 0 {
+	if len(args.Title) == 0 && !hasTplTitle && args.ReplyHash == "" && !isRevision && nComments == 0 {
 		if args.InputReader != nil {
 			args.Title, _ = args.InputReader("\033[1;32m? \033[1;37mTitle> \u001B[0m", &io2.InputReaderArgs{
 				After: func(input string) { fmt.Fprintf(args.StdOut, "\033[36m%s\033[0m\n", input) },
@@ -175,7 +233,7 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 	}
 
 	// Read body from stdIn only if an editor is not requested and --no-body is unset
-	if len(args.Body) == 0 && args.UseEditor == false && !args.NoBody {
+	if len(args.Body) == 0 && !hasTplBody && args.UseEditor == false && !args.NoBody {
 		if args.InputReader != nil {
 			args.Body, _ = args.InputReader("\033[1;32m? \033[1;37mBody> \u001B[0m", &io2.InputReaderArgs{
 				After: func(input string) { fmt.Fprintf(args.StdOut, "\033[36m%s\033[0m\n", input) },
@@ -199,7 +257,12 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 	}
 
 	// Body is required for a new merge request
-	if nComments == 0 && args.Body == "" {
+	if nComments == 0 && args.Body == "" && !hasTplBody {
+		return nil, common.ErrBodyRequired
+	}
+
+	// Body is required when editing a comment
+	if args.EditHash != "" && args.Body == "" {
 		return nil, common.ErrBodyRequired
 	}
 
@@ -208,6 +271,8 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 		Content:   []byte(args.Body),
 		Title:     args.Title,
 		ReplyTo:   args.ReplyHash,
+		Edit:      args.EditHash,
+		Delete:    args.DeleteHash,
 		Reactions: args.Reactions,
 		MergeRequestFields: &plumbing.MergeRequestFields{
 			BaseBranch:       args.Base,
@@ -223,9 +288,10 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 		Type:          plumbing.MergeRequestBranchPrefix,
 		ID:            args.ID,
 		Body:          postBody,
-		IsComment:     args.ReplyHash != "",
+		IsComment:     args.ReplyHash != "" || isRevision,
 		Force:         args.Force,
 		GetFreePostID: plumbing.GetFreePostID,
+		Template:      template,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create or add new comment to merge request request")
@@ -65,6 +65,31 @@ var _ = Describe("MergeRequestCreate", func() {
 				Expect(err).To(MatchError("merge request number is required when adding a comment"))
 			})
 
+			It("should return error when edit and delete hashes are both set", func() {
+				args := &mergecmd.MergeRequestCreateArgs{EditHash: "02we", DeleteHash: "02we"}
+				_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("a comment cannot be edited and deleted at the same time"))
+			})
+
+			It("should return error when delete hash is set but merge request number is not set", func() {
+				args := &mergecmd.MergeRequestCreateArgs{DeleteHash: "02we"}
+				_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("merge request number is required when editing or deleting a comment"))
+			})
+
+			It("should return error when edit hash does not exist in merge request branch", func() {
+				args := &mergecmd.MergeRequestCreateArgs{ID: 1, EditHash: "edit_hash"}
+				ref := plumbing.MakeMergeRequestReference(args.ID)
+				mockRepo.EXPECT().RefGet(ref).Return("xyz", nil)
+				mockRepo.EXPECT().NumCommits(ref, false).Return(1, nil)
+				mockRepo.EXPECT().IsAncestor("edit_hash", "xyz").Return(fmt.Errorf("bad"))
+				_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("target comment hash (edit_hash) is unknown"))
+			})
+
 			When("title is not set AND reply hash is not set AND merge request did not previously exist", func() {
 				It("should read title and body from stdIn", func() {
 					mockStdOut := mocks.NewMockFileWriter(ctrl)
@@ -115,6 +140,41 @@ var _ = Describe("MergeRequestCreate", func() {
 				Expect(err).To(Equal(common.ErrBodyRequired))
 			})
 
+			When("templates are available", func() {
+				var templates = plumbing.PostTemplates{{Name: "default", Title: "Change", Body: "What does this change?"}}
+				var templatesGetter = func(_ plumbing.LocalRepo, postType string) (plumbing.PostTemplates, error) {
+					Expect(postType).To(Equal(plumbing.MergeRequestBranchPrefix))
+					return templates, nil
+				}
+
+				It("should return error when the named template does not exist", func() {
+					args := &mergecmd.MergeRequestCreateArgs{StdOut: bytes.NewBuffer(nil), Template: "unknown", TemplatesGetter: templatesGetter}
+					_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+					Expect(err).To(MatchError("template (unknown) not found"))
+				})
+
+				It("should pass the selected template to the post creator and not prompt for title and body", func() {
+					var tpl *plumbing.PostTemplate
+					args := &mergecmd.MergeRequestCreateArgs{StdOut: bytes.NewBuffer(nil), TemplatesGetter: templatesGetter,
+						TemplateSelector: func(title string, options []string) (int, error) {
+							Expect(options).To(Equal([]string{"None", "default"}))
+							return 1, nil
+						},
+						PostCommentCreator: func(_ plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+							tpl = args.Template
+							return true, "refs/heads/merges/1", nil
+						},
+						InputReader: func(title string, args *io2.InputReaderArgs) (string, error) {
+							Fail("should not prompt for input")
+							return "", nil
+						},
+					}
+					_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+					Expect(err).To(BeNil())
+					Expect(tpl).To(Equal(templates[0]))
+				})
+			})
+
 			When("custom editor is requested", func() {
 				var args *mergecmd.MergeRequestCreateArgs
 				BeforeEach(func() {
@@ -25,6 +25,14 @@ type MergeRequestListArgs struct {
 	// DateFmt is the date format to use for displaying dates
 	DateFmt string
 
+	// Query contains filters and sort options applied to the merge requests.
+	// If unset, all merge requests are returned, newest first.
+	Query *pl.PostQuery
+
+	// PostDataGetter provides indexed labels, assignees and close status
+	// of the merge requests when filtering. If unset, they are derived from comments.
+	PostDataGetter pl.PostDataGetter
+
 	// PostGetter is the function used to get merge-request posts
 	PostGetter pl.PostGetter
 
@@ -69,8 +77,16 @@ func MergeRequestListCmd(targetRepo pl.LocalRepo, args *MergeRequestListArgs) (p
 		return nil, errors.Wrap(err, "failed to get merge requests posts")
 	}
 
-	// Sort by their first post time
-	mergeReqs.SortByFirstPostCreationTimeDesc()
+	// Filter and sort the merge requests if a query is provided,
+	// otherwise, sort by their first post time
+	if args.Query != nil {
+		mergeReqs, err = pl.QueryPosts(mergeReqs, args.Query, args.PostDataGetter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query merge requests")
+		}
+	} else {
+		mergeReqs.SortByFirstPostCreationTimeDesc()
+	}
 
 	// Reverse merge requests if requested
 	if args.Reverse {
@@ -59,6 +59,8 @@ type MergeRequestReadArgs struct {
 	// - %R 	- The full commit hash the current comment is replying to.
 	// - %rs 	- The comment's reactions.
 	// - %cl 	- Flag for close status of the post (true/false)
+	// - %ed 	- The short hash of the most recent edit of the comment
+	// - %dl 	- Flag for deletion status of the comment (true/false)
 	Format string
 
 	// NoPager indicates that output must not be piped into a pager
@@ -67,6 +69,9 @@ type MergeRequestReadArgs struct {
 	// NoCloseStatus indicates that the close status must not be rendered
 	NoCloseStatus bool
 
+	// Tree indicates that replies should be nested under the comments they reply to
+	Tree bool
+
 	StdOut io.Writer
 	StdErr io.Writer
 }
@@ -114,6 +119,10 @@ func MergeRequestReadCmd(targetRepo pl.LocalRepo, args *MergeRequestReadArgs) (p
 		}
 	}
 
+	if args.Tree {
+		return comments.Tree(), nil
+	}
+
 	return comments, nil
 }
 
@@ -133,7 +142,26 @@ func formatAndPrintMergeRequestComments(
 		buf.WriteString("\n")
 	}
 
+	// Determine the display index of each comment. In tree mode, comments
+	// are reordered so that replies follow the comment they reply to.
+	var indexes = make(map[string]int, len(comments))
 	for i, comment := range comments {
+		if !args.Reverse {
+			i = int(math.Abs(float64(i - len(comments) + 1)))
+		}
+		indexes[comment.Hash] = i
+	}
+	var depths = map[string]int{}
+	if args.Tree {
+		var ordered pl.Comments
+		comments.Tree().Walk(func(comment *pl.Comment, depth int) {
+			ordered = append(ordered, comment)
+			depths[comment.Hash] = depth
+		})
+		comments = ordered
+	}
+
+	for _, comment := range comments {
 
 		// Format date if date format is specified
 		date := comment.CreatedAt.String()
@@ -153,16 +181,23 @@ func formatAndPrintMergeRequestComments(
 		}
 
 		content := strings.TrimSpace(string(comment.Body.Content))
-
-		if !args.Reverse {
-			i = int(math.Abs(float64(i - len(comments) + 1)))
+		if comment.Deleted {
+			content = "[deleted]"
 		}
 
+		i := indexes[comment.Hash]
+
 		titleCpy := title
 		if i > 0 {
 			titleCpy = "RE: " + title
 		}
 
+		var edited, editedFmt string
+		if comment.IsEdited() && !comment.Deleted {
+			edited = comment.Edits[len(comment.Edits)-1][:7]
+			editedFmt = "\nEdited:         %ed"
+		}
+
 		var replyToFmt, replyTo string
 		if comment.Body.ReplyTo != "" {
 			replyToFmt = "\nReplyTo:    %R"
@@ -223,6 +258,8 @@ func formatAndPrintMergeRequestComments(
 			"r":  comment.Body.ReplyTo,
 			"rs": reactions,
 			"cl": isClosed,
+			"ed": edited,
+			"dl": comment.Deleted,
 		}
 
 		// Get format or use default
@@ -231,13 +268,13 @@ func formatAndPrintMergeRequestComments(
 			format = `` + fmt2.YellowString("comment %H #%i") + `
 Title:          %t` + baseFmt + `` + baseHashFmt + `` + targetFmt + `` + targetHashFmt + `
 Author:         %a <%e> 
-Date:           %d` + replyToFmt + `` + reactionsFmt + `
+Date:           %d` + replyToFmt + `` + editedFmt + `` + reactionsFmt + `
 
 %c
 `
 		}
 
-		buf.WriteString(util.ParseVerbs(format, data))
+		buf.WriteString(common.IndentLines(util.ParseVerbs(format, data), depths[comment.Hash]))
 		buf.WriteString("\n")
 	}
 
@@ -0,0 +1,69 @@
+package milestonecmd
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/pkg/errors"
+)
+
+// MilestoneCloseArgs contains parameters for MilestoneCloseCmd
+type MilestoneCloseArgs struct {
+
+	// Reference is the full reference path to the milestone
+	Reference string
+
+	// PostCommentCreator is the post commit creating function
+	PostCommentCreator plumbing.PostCommitCreator
+
+	// ReadPostBody is a function for reading post body in a commit
+	ReadPostBody plumbing.PostBodyReader
+
+	// Force indicates that uncommitted changes should be ignored
+	Force bool
+}
+
+type MilestoneCloseResult struct {
+	Reference string
+}
+
+// MilestoneCloseCmdFunc describes MilestoneCloseCmd function signature
+type MilestoneCloseCmdFunc func(r plumbing.LocalRepo, args *MilestoneCloseArgs) (*MilestoneCloseResult, error)
+
+// MilestoneCloseCmd adds a close directive
+func MilestoneCloseCmd(r plumbing.LocalRepo, args *MilestoneCloseArgs) (*MilestoneCloseResult, error) {
+
+	// Ensure the milestone reference exist
+	recentCommentHash, err := r.RefGet(args.Reference)
+	if err != nil {
+		if err == plumbing.ErrRefNotFound {
+			return nil, fmt.Errorf("milestone not found")
+		}
+		return nil, err
+	}
+
+	pb, _, err := args.ReadPostBody(r, recentCommentHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read recent comment")
+	} else if pointer.GetBool(pb.Close) {
+		return nil, fmt.Errorf("already closed")
+	}
+
+	// Create the post body
+	cls := true
+	postBody := plumbing.PostBodyToString(&plumbing.PostBody{Close: &cls})
+
+	// Create a new comment using the post body
+	_, ref, err := args.PostCommentCreator(r, &plumbing.CreatePostCommitArgs{
+		Type:  plumbing.MilestoneBranchPrefix,
+		ID:    args.Reference,
+		Body:  postBody,
+		Force: args.Force,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create or add new close comment")
+	}
+
+	return &MilestoneCloseResult{Reference: ref}, nil
+}
@@ -0,0 +1,237 @@
+package milestonecmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/AlekSi/pointer"
+	"github.com/make-os/kit/cmd/common"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	"github.com/make-os/kit/util"
+	cmdutil "github.com/make-os/kit/util/cmd"
+	"github.com/make-os/kit/util/io"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cfg = config.GetConfig()
+	log = cfg.G().Log
+)
+
+// MilestoneCmd represents the milestone command
+var MilestoneCmd = &cobra.Command{
+	Use:   "milestone",
+	Short: "Create, read, list and close milestones",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// milestoneCreateCmd represents a sub-command to create a milestone
+var milestoneCreateCmd = &cobra.Command{
+	Use:   "create [flags]",
+	Short: "Create a milestone or update an existing milestone",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		util.FatalOnError(cmdutil.RejectFlagCombo(cmd, "close", "reopen"))
+
+		title, _ := cmd.Flags().GetString("title")
+		body, _ := cmd.Flags().GetString("body")
+		due, _ := cmd.Flags().GetString("due")
+		issues, _ := cmd.Flags().GetString("issues")
+		useEditor, _ := cmd.Flags().GetBool("use-editor")
+		noBody, _ := cmd.Flags().GetBool("no-body")
+		cls, _ := cmd.Flags().GetBool("close")
+		forceNew, _ := cmd.Flags().GetBool("new")
+		force, _ := cmd.Flags().GetBool("force")
+		reopen, _ := cmd.Flags().GetBool("reopen")
+		editorPath, _ := cmd.Flags().GetString("editor")
+		targetID, _ := cmd.Flags().GetInt("id")
+
+		curRepo, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to open repo at cwd").Error())
+		}
+
+		// When target post ID is unset and the current HEAD is a milestone reference,
+		// use the reference short name as the post ID
+		if !forceNew && targetID == 0 {
+			head, err := curRepo.Head()
+			if err != nil {
+				log.Fatal(errors.Wrap(err, "failed to get HEAD").Error())
+			} else if plumbing.IsMilestoneReference(head) {
+				id := plumbing.GetReferenceShortName(head)
+				targetID, _ = strconv.Atoi(id)
+			}
+		}
+
+		if editorPath != "" {
+			useEditor = true
+		}
+
+		milestoneCreateArgs := &MilestoneCreateArgs{
+			ID:                 targetID,
+			Title:              title,
+			Body:               body,
+			DueDate:            due,
+			NoBody:             noBody,
+			UseEditor:          useEditor,
+			EditorPath:         editorPath,
+			Force:              force,
+			StdOut:             os.Stdout,
+			StdIn:              os.Stdin,
+			PostCommentCreator: plumbing.CreatePostCommit,
+			EditorReader:       util.ReadFromEditor,
+			InputReader:        io.ReadInput,
+		}
+
+		if cmd.Flags().Changed("issues") {
+			milestoneCreateArgs.Issues, err = ParseIssueNumbers(issues)
+			if err != nil {
+				log.Fatal(err.Error())
+			}
+		}
+
+		if cmd.Flags().Changed("close") {
+			milestoneCreateArgs.Close = &cls
+		}
+
+		if cmd.Flags().Changed("reopen") {
+			milestoneCreateArgs.Close = pointer.ToBool(!reopen)
+		}
+
+		if _, err := MilestoneCreateCmd(curRepo, milestoneCreateArgs); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+// milestoneListCmd represents a sub-command to list all milestones
+var milestoneListCmd = &cobra.Command{
+	Use:   "list [flags]",
+	Short: "List all milestones in the current repository",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		reverse, _ := cmd.Flags().GetBool("reverse")
+		dateFmt, _ := cmd.Flags().GetString("date")
+		format, _ := cmd.Flags().GetString("format")
+		noPager, _ := cmd.Flags().GetBool("no-pager")
+
+		curRepo, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to open repo at cwd").Error())
+		}
+
+		listArgs := &MilestoneListArgs{
+			Limit:           limit,
+			Reverse:         reverse,
+			DateFmt:         dateFmt,
+			PostGetter:      plumbing.GetPosts,
+			MilestoneGetter: plumbing.GetMilestone,
+			PagerWrite:      common.WriteToPager,
+			Format:          format,
+			NoPager:         noPager,
+			StdOut:          os.Stdout,
+			StdErr:          os.Stderr,
+		}
+
+		milestones, err := MilestoneListCmd(curRepo, listArgs)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		if err = FormatAndPrintMilestoneList(curRepo, listArgs, milestones); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+// milestoneReadCmd represents a sub-command to read a milestone
+var milestoneReadCmd = &cobra.Command{
+	Use:   "read [flags] [<milestoneId>]",
+	Short: "Read a milestone and its progress",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		dateFmt, _ := cmd.Flags().GetString("date")
+		format, _ := cmd.Flags().GetString("format")
+		noPager, _ := cmd.Flags().GetBool("no-pager")
+
+		curRepo, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to open repo at cwd").Error())
+		}
+
+		if _, err = MilestoneReadCmd(curRepo, &MilestoneReadArgs{
+			Reference:       NormalizeMilestoneReferenceName(curRepo, args),
+			DateFmt:         dateFmt,
+			Format:          format,
+			PagerWrite:      common.WriteToPager,
+			PostGetter:      plumbing.GetPosts,
+			MilestoneGetter: plumbing.GetMilestone,
+			NoPager:         noPager,
+			StdOut:          os.Stdout,
+			StdErr:          os.Stderr,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+// milestoneCloseCmd represents a sub-command to close a milestone
+var milestoneCloseCmd = &cobra.Command{
+	Use:   "close [flags] [<milestoneId>]",
+	Short: "Close a milestone",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+
+		curRepo, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to open repo at cwd").Error())
+		}
+
+		if _, err = MilestoneCloseCmd(curRepo, &MilestoneCloseArgs{
+			Reference:          NormalizeMilestoneReferenceName(curRepo, args),
+			PostCommentCreator: plumbing.CreatePostCommit,
+			ReadPostBody:       plumbing.ReadPostBody,
+			Force:              force,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+func init() {
+	MilestoneCmd.AddCommand(milestoneCreateCmd)
+	MilestoneCmd.AddCommand(milestoneReadCmd)
+	MilestoneCmd.AddCommand(milestoneListCmd)
+	MilestoneCmd.AddCommand(milestoneCloseCmd)
+
+	MilestoneCmd.PersistentFlags().Bool("no-pager", false, "Prevent output from being piped into a pager")
+	milestoneCreateCmd.Flags().StringP("title", "t", "", "The title of the milestone (max. 250 B)")
+	milestoneCreateCmd.Flags().StringP("body", "b", "", "The description of the milestone or a comment (max. 8 KB)")
+	milestoneCreateCmd.Flags().StringP("due", "d", "", "Set the due date of the milestone (YYYY-MM-DD)")
+	milestoneCreateCmd.Flags().StringP("issues", "s", "", "Specify issue numbers to link to the milestone (prefix with '-' to unlink)")
+	milestoneCreateCmd.Flags().BoolP("use-editor", "u", false, "Use git's `core.editor` program to write the body")
+	milestoneCreateCmd.Flags().Bool("no-body", false, "Skip prompt for milestone body")
+	milestoneCreateCmd.Flags().Bool("new", false, "Force a new milestone to be created instead of updating HEAD")
+	milestoneCreateCmd.Flags().String("editor", "", "Specify an editor to use instead of the git configured editor")
+	milestoneCreateCmd.Flags().IntP("id", "i", 0, "Specify a target milestone number")
+	milestoneCreateCmd.Flags().BoolP("close", "c", false, "Close the milestone")
+	milestoneCreateCmd.Flags().BoolP("reopen", "o", false, "Open a closed milestone")
+	milestoneCreateCmd.Flags().BoolP("force", "f", false, "Forcefully create the comment (uncommitted changes will be lost)")
+	milestoneCloseCmd.Flags().BoolP("force", "f", false, "Forcefully create the close comment (uncommitted changes will be lost)")
+
+	milestoneListCmd.Flags().IntP("limit", "n", 0, "Limit the number of records returned")
+	milestoneListCmd.Flags().Bool("reverse", false, "Return the result in reversed order")
+
+	for _, cmd := range []*cobra.Command{milestoneListCmd, milestoneReadCmd} {
+		cmd.Flags().StringP("date", "d", "Mon Jan _2 15:04:05 2006 -0700", "Set date format")
+		cmd.Flags().StringP("format", "f", "", "Set output format")
+	}
+}
@@ -0,0 +1,186 @@
+package milestonecmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/make-os/kit/cmd/common"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/util"
+	fmt2 "github.com/make-os/kit/util/colorfmt"
+	io2 "github.com/make-os/kit/util/io"
+	"github.com/pkg/errors"
+)
+
+type MilestoneCreateArgs struct {
+	// ID is the unique milestone ID
+	ID int
+
+	// Title is the title of the milestone
+	Title string
+
+	// Body is the milestone's description or comment
+	Body string
+
+	// DueDate is the date (YYYY-MM-DD) the milestone is expected to be completed
+	DueDate string
+
+	// Issues are the IDs of issues to link to the milestone.
+	// Negative IDs unlink previously linked issues.
+	Issues []int
+
+	// UseEditor indicates that the body of the milestone should be collected using a text editor.
+	UseEditor bool
+
+	// EditorPath indicates the path to an editor program
+	EditorPath string
+
+	// NoBody prevents prompting user for milestone body
+	NoBody bool
+
+	// Close sets `close` status to 1.
+	Close *bool
+
+	// Force indicates that uncommitted changes should be ignored
+	Force bool
+
+	// StdOut receives the output
+	StdOut io.Writer
+
+	// StdIn receives input
+	StdIn io.ReadCloser
+
+	// PostCommentCreator is the post commit creating function
+	PostCommentCreator plumbing.PostCommitCreator
+
+	// EditorReader is used to read from an editor program
+	EditorReader util.EditorReaderFunc
+
+	// InputReader is a function that reads input from stdin
+	InputReader io2.InputReader
+}
+
+type MilestoneCreateResult struct {
+	Reference string
+}
+
+// MilestoneCreateCmdFunc describes the MilestoneCreateCmd signature
+type MilestoneCreateCmdFunc func(r plumbing.LocalRepo, args *MilestoneCreateArgs) (*MilestoneCreateResult, error)
+
+// MilestoneCreateCmd creates a new milestone or adds a comment commit to an existing milestone
+func MilestoneCreateCmd(r plumbing.LocalRepo, args *MilestoneCreateArgs) (*MilestoneCreateResult, error) {
+	var numComments int
+	var err error
+
+	if args.StdOut == nil {
+		args.StdOut = ioutil.Discard
+	}
+
+	if args.ID != 0 {
+
+		// Get the number of comments
+		numComments, err = r.NumCommits(plumbing.MakeMilestoneReference(args.ID), false)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to count comments in milestone")
+		}
+
+		// Title is not required when the intent is to add a comment
+		if numComments > 0 && args.Title != "" {
+			return nil, fmt.Errorf("title not required when adding a comment to a milestone")
+		}
+	}
+
+	// Ensure the due date is valid
+	if args.DueDate != "" {
+		if _, err := time.Parse(plumbing.MilestoneDueDateFormat, args.DueDate); err != nil {
+			return nil, fmt.Errorf("due date (%s) is not valid; expected format YYYY-MM-DD", args.DueDate)
+		}
+	}
+
+	// Ensure issue numbers are valid
+	for _, id := range args.Issues {
+		if id == 0 {
+			return nil, fmt.Errorf("issue number (0) is not valid")
+		}
+	}
+
+	// Prompt user for title only if was not provided via flag and this is not a comment
+	if len(args.Title) == 0 && numComments == 0 {
+		if args.InputReader != nil {
+			args.Title, _ = args.InputReader("\033[1;32m? \033[1;37mTitle> \u001B[0m", &io2.InputReaderArgs{
+				After: func(input string) { fmt.Fprintf(args.StdOut, "\033[36m%s\033[0m\n", input) },
+			})
+		}
+		if len(args.Title) == 0 {
+			return nil, common.ErrTitleRequired
+		}
+	}
+
+	// Read body from stdIn only if an editor is not requested and --no-body is unset
+	if len(args.Body) == 0 && !args.UseEditor && !args.NoBody {
+		if args.InputReader != nil {
+			args.Body, _ = args.InputReader("\033[1;32m? \033[1;37mBody> \u001B[0m", &io2.InputReaderArgs{
+				After: func(input string) { fmt.Fprintf(args.StdOut, "\033[36m%s\033[0m\n", input) },
+			})
+		}
+	}
+
+	// Read body from editor if requested
+	if args.UseEditor {
+		var editor = args.EditorPath
+		if editor == "" {
+			editor = r.GetGitConfigOption("core.editor")
+		}
+		args.Body, err = args.EditorReader(editor, args.StdIn, os.Stdout, os.Stderr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed read body from editor")
+		}
+		if args.Body != "" {
+			fmt.Fprint(args.StdOut, "\u001B[1;32m? \u001B[1;37mBody> \033[0m\u001B[36m[Received]\u001B[0m\n")
+		}
+	}
+
+	// Body is required for a new milestone
+	if numComments == 0 && args.Body == "" {
+		return nil, common.ErrBodyRequired
+	}
+
+	// Create the post body
+	postBody := plumbing.PostBodyToString(&plumbing.PostBody{
+		Content: []byte(args.Body),
+		Title:   args.Title,
+		MilestoneFields: &plumbing.MilestoneFields{
+			DueDate: args.DueDate,
+			Issues:  args.Issues,
+		},
+		Close: args.Close,
+	})
+
+	// Create a new milestone or add comment commit to existing milestone
+	newMilestone, ref, err := args.PostCommentCreator(r, &plumbing.CreatePostCommitArgs{
+		Type:          plumbing.MilestoneBranchPrefix,
+		ID:            args.ID,
+		Body:          postBody,
+		Force:         args.Force,
+		GetFreePostID: plumbing.GetFreePostID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create or add new comment to milestone")
+	}
+
+	if newMilestone {
+		fmt.Fprintln(args.StdOut, fmt2.NewColor(aurora.Green, aurora.Bold).Sprint("✅ New milestone created!"))
+		fmt.Fprint(args.StdOut, fmt.Sprintf("%s#0\n", ref))
+	} else {
+		fmt.Fprintln(args.StdOut, fmt2.NewColor(aurora.Green, aurora.Bold).Sprint("✅ Milestone updated!"))
+		fmt.Fprint(args.StdOut, fmt.Sprintf("%s#%d\n", ref, numComments))
+	}
+
+	return &MilestoneCreateResult{
+		Reference: ref,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package milestonecmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/cmd/common"
+	"github.com/make-os/kit/cmd/milestonecmd"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/testutil"
+	io2 "github.com/make-os/kit/util/io"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MilestoneCreate", func() {
+	var err error
+	var cfg *config.AppConfig
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockLocalRepo
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockLocalRepo(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".MilestoneCreateCmd", func() {
+		It("should return error when due date is not valid", func() {
+			args := &milestonecmd.MilestoneCreateArgs{DueDate: "01/10/2020"}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("due date (01/10/2020) is not valid; expected format YYYY-MM-DD"))
+		})
+
+		It("should return error when an issue number is zero", func() {
+			args := &milestonecmd.MilestoneCreateArgs{Issues: []int{1, 0}}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("issue number (0) is not valid"))
+		})
+
+		It("should return error when title is not provided for a new milestone", func() {
+			args := &milestonecmd.MilestoneCreateArgs{StdOut: bytes.NewBuffer(nil),
+				InputReader: func(title string, args *io2.InputReaderArgs) (string, error) { return "", nil }}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(common.ErrTitleRequired))
+		})
+
+		It("should return error when body is not provided for a new milestone", func() {
+			args := &milestonecmd.MilestoneCreateArgs{Title: "v1.0", NoBody: true}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(common.ErrBodyRequired))
+		})
+
+		It("should return error when unable to count comments of an existing milestone", func() {
+			mockRepo.EXPECT().NumCommits(plumbing.MakeMilestoneReference(1), false).Return(0, fmt.Errorf("error"))
+			args := &milestonecmd.MilestoneCreateArgs{ID: 1}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to count comments in milestone: error"))
+		})
+
+		It("should return error when title is set while adding a comment", func() {
+			mockRepo.EXPECT().NumCommits(plumbing.MakeMilestoneReference(1), false).Return(1, nil)
+			args := &milestonecmd.MilestoneCreateArgs{ID: 1, Title: "v1.0"}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("title not required when adding a comment to a milestone"))
+		})
+
+		It("should create the correct post body for a new milestone", func() {
+			args := &milestonecmd.MilestoneCreateArgs{
+				Title:   "v1.0",
+				Body:    "first release",
+				DueDate: "2020-10-01",
+				Issues:  []int{1, 2},
+				PostCommentCreator: func(r plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+					Expect(args.Type).To(Equal(plumbing.MilestoneBranchPrefix))
+					Expect(args.Body).To(Equal("---\ndue: \"2020-10-01\"\nissues: [1, 2]\ntitle: v1.0\n---\nfirst release"))
+					return true, plumbing.MakeMilestoneReference(1), nil
+				},
+			}
+			res, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).To(BeNil())
+			Expect(res.Reference).To(Equal(plumbing.MakeMilestoneReference(1)))
+		})
+
+		It("should allow an existing milestone to be updated without a body", func() {
+			mockRepo.EXPECT().NumCommits(plumbing.MakeMilestoneReference(1), false).Return(1, nil)
+			args := &milestonecmd.MilestoneCreateArgs{
+				ID:     1,
+				NoBody: true,
+				Issues: []int{-2},
+				PostCommentCreator: func(r plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+					Expect(args.ID).To(Equal(1))
+					Expect(args.Body).To(Equal("---\nissues: [-2]\n---\n"))
+					return false, plumbing.MakeMilestoneReference(1), nil
+				},
+			}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).To(BeNil())
+		})
+
+		It("should return error when unable to create post commit", func() {
+			args := &milestonecmd.MilestoneCreateArgs{
+				Title: "v1.0",
+				Body:  "first release",
+				PostCommentCreator: func(r plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+					return false, "", fmt.Errorf("error")
+				},
+			}
+			_, err := milestonecmd.MilestoneCreateCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to create or add new comment to milestone: error"))
+		})
+	})
+
+	Describe(".ParseIssueNumbers", func() {
+		It("should parse issue numbers", func() {
+			issues, err := milestonecmd.ParseIssueNumbers("1, #2,-3,")
+			Expect(err).To(BeNil())
+			Expect(issues).To(Equal([]int{1, 2, -3}))
+		})
+
+		It("should return error when an issue number is not valid", func() {
+			_, err := milestonecmd.ParseIssueNumbers("1,abc")
+			Expect(err).To(MatchError("issue number (abc) is not valid"))
+		})
+	})
+})
@@ -0,0 +1,71 @@
+package milestonecmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/pkg/errors"
+)
+
+// NormalizeMilestoneReferenceName normalizes a reference from args[0] to one that
+// is a valid full milestone reference name.
+func NormalizeMilestoneReferenceName(curRepo plumbing.LocalRepo, args []string) string {
+	var ref string
+	var err error
+
+	if len(args) > 0 {
+		ref = args[0]
+	}
+
+	// If reference is not set, use the HEAD as the reference.
+	// But only if the reference is a valid milestone reference.
+	if ref == "" {
+		ref, err = curRepo.Head()
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to get HEAD").Error())
+		}
+		if !plumbing.IsMilestoneReference(ref) {
+			log.Fatal(fmt.Sprintf("not a milestone path (%s)", ref))
+		}
+	}
+
+	// If the reference begins with 'milestones',
+	// Add the full prefix 'refs/heads/' to make it `refs/heads/milestones/<ref>`
+	ref = strings.ToLower(ref)
+	if strings.HasPrefix(ref, plumbing.MilestoneBranchPrefix) {
+		ref = fmt.Sprintf("refs/heads/%s", ref)
+	}
+
+	// If the reference does not begin with 'refs/heads/milestones',
+	// convert to 'refs/heads/milestones/<ref>'
+	if !plumbing.IsMilestoneReferencePath(ref) {
+		ref = plumbing.MakeMilestoneReference(ref)
+	}
+
+	// Finally, if reference is not of the form `refs/heads/milestones/*`
+	if !plumbing.IsMilestoneReference(ref) {
+		log.Fatal(fmt.Sprintf("not a milestone path (%s)", ref))
+	}
+
+	return ref
+}
+
+// ParseIssueNumbers parses a comma-separated list of issue numbers.
+// A number prefixed with '-' unlinks the issue.
+func ParseIssueNumbers(str string) ([]int, error) {
+	var issues []int
+	for _, val := range strings.Split(str, ",") {
+		val = strings.TrimPrefix(strings.TrimSpace(val), "#")
+		if val == "" {
+			continue
+		}
+		id, err := strconv.Atoi(val)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("issue number (%s) is not valid", val)
+		}
+		issues = append(issues, id)
+	}
+	return issues, nil
+}
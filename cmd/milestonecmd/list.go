@@ -0,0 +1,181 @@
+package milestonecmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/make-os/kit/cmd/common"
+	pl "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/util"
+	cf "github.com/make-os/kit/util/colorfmt"
+	"github.com/pkg/errors"
+)
+
+type MilestoneListArgs struct {
+
+	// Limit sets a hard limit on the number of milestones to display
+	Limit int
+
+	// Reverse indicates that the milestones should be listed in reverse order
+	Reverse bool
+
+	// DateFmt is the date format to use for displaying dates
+	DateFmt string
+
+	// PostGetter is the function used to get milestone posts
+	PostGetter pl.PostGetter
+
+	// MilestoneGetter is the function used to compute the state of a milestone
+	MilestoneGetter pl.MilestoneGetter
+
+	// PagerWrite is the function used to write to a pager
+	PagerWrite common.PagerWriter
+
+	// Format specifies a format to use for generating each milestone output to Stdout.
+	// The following placeholders are supported:
+	// - %i    	- Index of the milestone
+	// - %a 	- Author of the milestone
+	// - %t 	- Title of the milestone
+	// - %d 	- Date of creation
+	// - %du 	- Due date
+	// - %H    	- The full hash of the first comment
+	// - %h    	- The short hash of the first comment
+	// - %n  	- The reference name of the milestone
+	// - %p  	- Percentage of linked issues that are closed
+	// - %pc  	- Number of linked issues that are closed
+	// - %pt  	- Number of linked issues
+	// - %cl 	- Flag for close status of the milestone (true/false)
+	Format string
+
+	// NoPager indicates that output must not be piped into a pager
+	NoPager bool
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// MilestoneListCmdFunc describes MilestoneListCmd function signature
+type MilestoneListCmdFunc func(targetRepo pl.LocalRepo, args *MilestoneListArgs) ([]*pl.Milestone, error)
+
+// MilestoneListCmd lists all milestones and their progress
+func MilestoneListCmd(targetRepo pl.LocalRepo, args *MilestoneListArgs) ([]*pl.Milestone, error) {
+
+	// Get milestone posts
+	posts, err := args.PostGetter(targetRepo, func(ref plumbing.ReferenceName) bool {
+		return pl.IsMilestoneReference(ref.String())
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get milestone posts")
+	}
+
+	// Sort by their first post time
+	posts.SortByFirstPostCreationTimeDesc()
+
+	// Reverse milestones if requested
+	if args.Reverse {
+		posts.Reverse()
+	}
+
+	// Limited the milestones if requested
+	if args.Limit > 0 && args.Limit < len(posts) {
+		posts = posts[:args.Limit]
+	}
+
+	var milestones = []*pl.Milestone{}
+	for _, post := range posts {
+		ms, err := args.MilestoneGetter(targetRepo, post)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get milestone (%s)", post.GetName())
+		}
+		milestones = append(milestones, ms)
+	}
+
+	return milestones, nil
+}
+
+// FormatAndPrintMilestoneList prints out milestones to stdout
+func FormatAndPrintMilestoneList(targetRepo pl.LocalRepo, args *MilestoneListArgs, milestones []*pl.Milestone) error {
+	buf := bytes.NewBuffer(nil)
+	for i, ms := range milestones {
+
+		dueDate := ms.DueDate
+		if dueDate == "" {
+			dueDate = "none"
+		}
+
+		// Get format or use default
+		var format = args.Format
+		if format == "" {
+			status := "open"
+			if ms.Closed {
+				status = "closed"
+			}
+			format = `` + cf.YellowString("milestone %H %n") + `
+Title:    %t
+Author:   %a
+Date:     %d
+Due:      %du
+Status:   ` + status + `
+Progress: %p% (%pc/%pt issues closed)
+`
+		}
+
+		// Define the data for format parsing
+		data := map[string]interface{}{
+			"i":  i,
+			"a":  ms.Author,
+			"t":  ms.Title,
+			"d":  formatDate(ms.CreatedAt, args.DateFmt),
+			"du": dueDate,
+			"H":  ms.Hash,
+			"h":  ms.Hash[:7],
+			"n":  plumbing.ReferenceName(ms.Name).Short(),
+			"p":  ms.Progress.Percent,
+			"pc": ms.Progress.Closed,
+			"pt": ms.Progress.Total,
+			"cl": ms.Closed,
+		}
+
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		_, err := buf.WriteString(util.ParseVerbs(format, data))
+		if err != nil {
+			return err
+		}
+	}
+
+	pagerCmd, err := targetRepo.Var("GIT_PAGER")
+	if err != nil {
+		return err
+	}
+
+	if args.NoPager {
+		fmt.Fprint(args.StdOut, buf)
+	} else {
+		args.PagerWrite(pagerCmd, buf, args.StdOut, args.StdErr)
+	}
+	return nil
+}
+
+// formatDate formats a date using the given date format
+func formatDate(date time.Time, dateFmt string) string {
+	switch dateFmt {
+	case "":
+		return date.String()
+	case "unix":
+		return fmt.Sprintf("%d", date.Unix())
+	case "utc":
+		return date.UTC().String()
+	case "rfc3339":
+		return date.Format(time.RFC3339)
+	case "rfc822":
+		return date.Format(time.RFC822)
+	default:
+		return date.Format(dateFmt)
+	}
+}
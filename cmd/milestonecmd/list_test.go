@@ -0,0 +1,125 @@
+package milestonecmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/cmd/milestonecmd"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/mocks"
+	plumbing3 "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/testutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MilestoneList", func() {
+	var err error
+	var cfg *config.AppConfig
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockLocalRepo
+	var posts plumbing3.Posts
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockLocalRepo(ctrl)
+		posts = plumbing3.Posts{
+			&plumbing3.Post{Name: plumbing3.MakeMilestoneReference(1), Title: "v1.0",
+				Comment: &plumbing3.Comment{CreatedAt: time.Now().Add(-10 * time.Second), Hash: "e31992a88829f3cb70ab5f5e964597a6c8f17047"}},
+			&plumbing3.Post{Name: plumbing3.MakeMilestoneReference(2), Title: "v2.0",
+				Comment: &plumbing3.Comment{CreatedAt: time.Now(), Hash: "b7c2ee8e3b3bc0b9d6d2c5d2b2a1c6c0e5e2f1a4"}},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	var milestoneGetter = func(repo plumbing3.LocalRepo, post plumbing3.PostEntry) (*plumbing3.Milestone, error) {
+		return &plumbing3.Milestone{
+			Name:      post.GetName(),
+			Title:     post.GetTitle(),
+			Hash:      post.GetComment().Hash,
+			CreatedAt: post.GetComment().CreatedAt,
+			DueDate:   "2020-10-01",
+			Issues:    []int{1, 2},
+			Progress:  &plumbing3.MilestoneProgress{Total: 2, Closed: 1, Percent: 50},
+		}, nil
+	}
+
+	Describe(".MilestoneListCmd", func() {
+		It("should return error when unable to get milestone posts", func() {
+			args := &milestonecmd.MilestoneListArgs{
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return nil, fmt.Errorf("error")
+				},
+			}
+			_, err := milestonecmd.MilestoneListCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to get milestone posts: error"))
+		})
+
+		It("should return error when unable to get a milestone", func() {
+			args := &milestonecmd.MilestoneListArgs{
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return posts, nil
+				},
+				MilestoneGetter: func(plumbing3.LocalRepo, plumbing3.PostEntry) (*plumbing3.Milestone, error) {
+					return nil, fmt.Errorf("error")
+				},
+			}
+			_, err := milestonecmd.MilestoneListCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to get milestone (refs/heads/milestones/2): error"))
+		})
+
+		It("should return milestones sorted by creation time in descending order", func() {
+			args := &milestonecmd.MilestoneListArgs{
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return posts, nil
+				},
+				MilestoneGetter: milestoneGetter,
+			}
+			res, err := milestonecmd.MilestoneListCmd(mockRepo, args)
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].Title).To(Equal("v2.0"))
+			Expect(res[1].Title).To(Equal("v1.0"))
+		})
+
+		It("should return reversed and limited milestones when requested", func() {
+			args := &milestonecmd.MilestoneListArgs{
+				Reverse: true,
+				Limit:   1,
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return posts, nil
+				},
+				MilestoneGetter: milestoneGetter,
+			}
+			res, err := milestonecmd.MilestoneListCmd(mockRepo, args)
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Title).To(Equal("v1.0"))
+		})
+	})
+
+	Describe(".FormatAndPrintMilestoneList", func() {
+		It("should print the milestones using the format", func() {
+			out := bytes.NewBuffer(nil)
+			mockRepo.EXPECT().Var("GIT_PAGER").Return("", nil)
+			ms, _ := milestoneGetter(mockRepo, posts[0])
+			args := &milestonecmd.MilestoneListArgs{NoPager: true, StdOut: out, Format: "%n %t %du %p% (%pc/%pt)"}
+			err := milestonecmd.FormatAndPrintMilestoneList(mockRepo, args, []*plumbing3.Milestone{ms})
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(Equal("milestones/1 v1.0 2020-10-01 50% (1/2)"))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package milestonecmd_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMilestonecmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MilestoneCmd Suite")
+}
@@ -0,0 +1,165 @@
+package milestonecmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/logrusorgru/aurora"
+	"github.com/make-os/kit/cmd/common"
+	pl "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/util"
+	fmt2 "github.com/make-os/kit/util/colorfmt"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+// MilestoneReadArgs contains arguments used by MilestoneReadCmd function
+type MilestoneReadArgs struct {
+
+	// Reference is the full reference path to the milestone
+	Reference string
+
+	// DateFmt is the date format to use for displaying dates
+	DateFmt string
+
+	// PostGetter is the function used to get milestone posts
+	PostGetter pl.PostGetter
+
+	// MilestoneGetter is the function used to compute the state of a milestone
+	MilestoneGetter pl.MilestoneGetter
+
+	// PagerWrite is the function used to write to a pager
+	PagerWrite common.PagerWriter
+
+	// Format specifies a format to use for generating the milestone output to Stdout.
+	// The following placeholders are supported:
+	// - %a 	- Author of the milestone
+	// - %t 	- Title of the milestone
+	// - %c 	- The description of the milestone
+	// - %d 	- Date of creation
+	// - %du 	- Due date
+	// - %H    	- The full hash of the first comment
+	// - %h    	- The short hash of the first comment
+	// - %n  	- The reference name of the milestone
+	// - %p  	- Percentage of linked issues that are closed
+	// - %pc  	- Number of linked issues that are closed
+	// - %pt  	- Number of linked issues
+	// - %is  	- The linked issues and their status
+	// - %cl 	- Flag for close status of the milestone (true/false)
+	Format string
+
+	// NoPager indicates that output must not be piped into a pager
+	NoPager bool
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// MilestoneReadCmdFunc describes MilestoneReadCmd function signature
+type MilestoneReadCmdFunc func(targetRepo pl.LocalRepo, args *MilestoneReadArgs) (*pl.Milestone, error)
+
+// MilestoneReadCmd reads a milestone and computes its progress
+func MilestoneReadCmd(targetRepo pl.LocalRepo, args *MilestoneReadArgs) (*pl.Milestone, error) {
+
+	// Find the target milestone
+	posts, err := args.PostGetter(targetRepo, func(ref plumbing.ReferenceName) bool {
+		return pl.IsMilestoneReference(ref.String()) && ref.String() == args.Reference
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find milestone")
+	} else if len(posts) == 0 {
+		return nil, fmt.Errorf("milestone not found")
+	}
+
+	ms, err := args.MilestoneGetter(targetRepo, posts[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get milestone")
+	}
+
+	// Format and print if stdout is provided
+	if args.StdOut != nil {
+		if err = formatAndPrintMilestone(targetRepo, args, ms); err != nil {
+			return nil, err
+		}
+	}
+
+	return ms, nil
+}
+
+// formatAndPrintMilestone prints out a milestone to stdout
+func formatAndPrintMilestone(targetRepo pl.LocalRepo, args *MilestoneReadArgs, ms *pl.Milestone) error {
+	buf := bytes.NewBuffer(nil)
+
+	dueDate := ms.DueDate
+	if dueDate == "" {
+		dueDate = "none"
+	}
+
+	// List the linked issues and their status
+	var issues []string
+	for _, id := range ms.Issues {
+		status := "closed"
+		if funk.ContainsInt(ms.Progress.Open, id) {
+			status = "open"
+		} else if funk.ContainsInt(ms.Progress.Missing, id) {
+			status = "missing"
+		}
+		issues = append(issues, fmt.Sprintf("  #%d (%s)", id, status))
+	}
+
+	var format = args.Format
+	if format == "" {
+		status := "open"
+		if ms.Closed {
+			status = fmt2.NewColor(aurora.Bold, aurora.BgBlue, aurora.White).Sprint(" CLOSED ")
+		}
+		var issuesFmt string
+		if len(issues) > 0 {
+			issuesFmt = "\nIssues:\n%is"
+		}
+		format = `` + fmt2.YellowString("milestone %H %n") + `
+Title:    %t
+Author:   %a
+Date:     %d
+Due:      %du
+Status:   ` + status + `
+Progress: %p% (%pc/%pt issues closed)` + issuesFmt + `
+
+%c
+`
+	}
+
+	data := map[string]interface{}{
+		"a":  ms.Author,
+		"t":  ms.Title,
+		"c":  strings.TrimSpace(ms.Description),
+		"d":  formatDate(ms.CreatedAt, args.DateFmt),
+		"du": dueDate,
+		"H":  ms.Hash,
+		"h":  ms.Hash[:7],
+		"n":  plumbing.ReferenceName(ms.Name).Short(),
+		"p":  ms.Progress.Percent,
+		"pc": ms.Progress.Closed,
+		"pt": ms.Progress.Total,
+		"is": strings.Join(issues, "\n"),
+		"cl": ms.Closed,
+	}
+
+	buf.WriteString(util.ParseVerbs(format, data))
+
+	pagerCmd, err := targetRepo.Var("GIT_PAGER")
+	if err != nil {
+		return err
+	}
+
+	if args.NoPager {
+		fmt.Fprint(args.StdOut, buf)
+	} else {
+		args.PagerWrite(pagerCmd, buf, args.StdOut, args.StdErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package milestonecmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/cmd/milestonecmd"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/mocks"
+	plumbing3 "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/testutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MilestoneRead", func() {
+	var err error
+	var cfg *config.AppConfig
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockLocalRepo
+	var ref = plumbing3.MakeMilestoneReference(1)
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockLocalRepo(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".MilestoneReadCmd", func() {
+		It("should return error when unable to find milestone", func() {
+			args := &milestonecmd.MilestoneReadArgs{
+				Reference: ref,
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return nil, fmt.Errorf("error")
+				},
+			}
+			_, err := milestonecmd.MilestoneReadCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to find milestone: error"))
+		})
+
+		It("should return error when milestone does not exist", func() {
+			args := &milestonecmd.MilestoneReadArgs{
+				Reference: ref,
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return plumbing3.Posts{}, nil
+				},
+			}
+			_, err := milestonecmd.MilestoneReadCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("milestone not found"))
+		})
+
+		It("should return error when unable to get the milestone", func() {
+			args := &milestonecmd.MilestoneReadArgs{
+				Reference: ref,
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return plumbing3.Posts{&plumbing3.Post{Name: ref}}, nil
+				},
+				MilestoneGetter: func(plumbing3.LocalRepo, plumbing3.PostEntry) (*plumbing3.Milestone, error) {
+					return nil, fmt.Errorf("error")
+				},
+			}
+			_, err := milestonecmd.MilestoneReadCmd(mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to get milestone: error"))
+		})
+
+		It("should return the milestone and print the status of linked issues", func() {
+			out := bytes.NewBuffer(nil)
+			mockRepo.EXPECT().Var("GIT_PAGER").Return("", nil)
+			args := &milestonecmd.MilestoneReadArgs{
+				Reference: ref,
+				NoPager:   true,
+				StdOut:    out,
+				Format:    "%t %p%\n%is",
+				PostGetter: func(plumbing3.LocalRepo, func(ref plumbing.ReferenceName) bool) (plumbing3.Posts, error) {
+					return plumbing3.Posts{&plumbing3.Post{Name: ref}}, nil
+				},
+				MilestoneGetter: func(plumbing3.LocalRepo, plumbing3.PostEntry) (*plumbing3.Milestone, error) {
+					return &plumbing3.Milestone{
+						Name:      ref,
+						Title:     "v1.0",
+						Hash:      "e31992a88829f3cb70ab5f5e964597a6c8f17047",
+						CreatedAt: time.Now(),
+						Issues:    []int{1, 2, 3},
+						Progress: &plumbing3.MilestoneProgress{Total: 2, Closed: 1, Percent: 50,
+							Open: []int{2}, Missing: []int{3}},
+					}, nil
+				},
+			}
+			ms, err := milestonecmd.MilestoneReadCmd(mockRepo, args)
+			Expect(err).To(BeNil())
+			Expect(ms.Title).To(Equal("v1.0"))
+			Expect(out.String()).To(Equal("v1.0 50%\n  #1 (closed)\n  #2 (open)\n  #3 (missing)"))
+		})
+	})
+})
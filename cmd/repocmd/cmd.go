@@ -176,6 +176,75 @@ func setupRepoVoteCmd(cmd *cobra.Command) {
 	_ = cmd.MarkFlagRequired("signing-key")
 }
 
+// repoProposeCmd represents a command for creating repository proposals
+var repoProposeCmd = &cobra.Command{
+	Use:   "propose",
+	Short: "Create repository proposals",
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+// repoProposeSpendCmd represents a sub-command for proposing a spend from a repository's balance
+var repoProposeSpendCmd = &cobra.Command{
+	Use:   "spend [flags] <address>",
+	Short: "Propose a transfer of coins from a repository's balance to an address",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("recipient address is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		repoName, _ := cmd.Flags().GetString("repo")
+		id, _ := cmd.Flags().GetString("id")
+		amount, _ := cmd.Flags().GetFloat64("amount")
+		value, _ := cmd.Flags().GetFloat64("value")
+		fee, _ := cmd.Flags().GetFloat64("fee")
+		signingKey, _ := cmd.Flags().GetString("signing-key")
+		signingKeyPass, _ := cmd.Flags().GetString("signing-key-pass")
+		nonce, _ := cmd.Flags().GetUint64("nonce")
+
+		_, client := common.GetRepoAndClient(cmd, cfg, "")
+		if err := ProposeSpendCmd(cfg, &ProposeSpendArgs{
+			RepoName:            repoName,
+			ProposalID:          id,
+			To:                  args[0],
+			Amount:              amount,
+			Value:               value,
+			Fee:                 fee,
+			SigningKey:          signingKey,
+			SigningKeyPass:      signingKeyPass,
+			Nonce:               nonce,
+			RPCClient:           client,
+			KeyUnlocker:         common.UnlockKey,
+			GetNextNonce:        api.GetNextNonceOfAccount,
+			SpendProposer:       api.ProposeRepoSpend,
+			ShowTxStatusTracker: common.ShowTxStatusTracker,
+			Stdout:              os.Stdout,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+func setupRepoProposeSpendCmd(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringP("repo", "r", "", "The name of the repository")
+	f.StringP("id", "i", "", "The unique ID of the proposal")
+	f.Float64P("amount", "a", 0, "The amount of coins to transfer from the repository's balance")
+	f.Float64P("value", "v", 0, "The proposal fee to pay")
+	f.Float64P("fee", "f", 0, "Set the network transaction fee")
+	f.Uint64P("nonce", "n", 0, "Set the next nonce of the signing account signing")
+	f.StringP("signing-key", "u", "", "Address or index of local account to use for signing transaction")
+	f.StringP("signing-key-pass", "p", "", "Passphrase for unlocking the signing account")
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("id")
+	_ = cmd.MarkFlagRequired("amount")
+	_ = cmd.MarkFlagRequired("fee")
+	_ = cmd.MarkFlagRequired("signing-key")
+}
+
 // repoConfigCmd represents a command for configuring a repository
 var repoConfigCmd = &cobra.Command{
 	Use:     "config [flags] [<directory>]",
@@ -401,16 +470,201 @@ func setupRepoInitCmd(cmd *cobra.Command) {
 	setupRepoConfigCmd(cmd)
 }
 
+// repoPolicyCmd represents a command for inspecting repository policies
+var repoPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Inspect repository policies",
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+// repoPolicyCheckCmd represents a command for simulating a policy check
+var repoPolicyCheckCmd = &cobra.Command{
+	Use:   "check [flags] <reference>",
+	Short: "Check whether a push key can perform an action on a reference",
+	Long: `Check whether a push key can perform an action on a reference and
+print every policy that was evaluated, its level and the final verdict.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("reference is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		repoName, _ := cmd.Flags().GetString("repo")
+		namespace, _ := cmd.Flags().GetString("namespace")
+		pushKeyID, _ := cmd.Flags().GetString("push-key")
+		action, _ := cmd.Flags().GetString("action")
+
+		_, client := common.GetRepoAndClient(cmd, cfg, "")
+		if err := PolicyCheckCmd(&PolicyCheckArgs{
+			RepoName:    repoName,
+			Namespace:   namespace,
+			PushKeyID:   pushKeyID,
+			Reference:   args[0],
+			Action:      action,
+			RPCClient:   client,
+			CheckPolicy: api.CheckRepoPolicy,
+			Stdout:      os.Stdout,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+func setupRepoPolicyCheckCmd(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringP("repo", "r", "", "The name of the repository")
+	f.String("namespace", "", "The namespace through which the repository is accessed")
+	f.StringP("push-key", "k", "", "The ID of the push key to check")
+	f.StringP("action", "a", "write", "The action to check (e.g write, delete, update)")
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("push-key")
+}
+
+// repoProposalsCmd represents a command for inspecting and tracking repository proposals
+var repoProposalsCmd = &cobra.Command{
+	Use:     "proposal",
+	Aliases: []string{"proposals"},
+	Short:   "Inspect and track repository proposals",
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+// repoProposalsListCmd represents a sub-command for listing the proposals of a repository
+var repoProposalsListCmd = &cobra.Command{
+	Use:   "list [flags] <repo>",
+	Short: "List and filter the proposals of a repository",
+	Long: `List the proposals of a repository, newest first. Proposals can be
+filtered by status (deposit, voting or closed), action type and creator.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("repository name is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		status, _ := cmd.Flags().GetString("status")
+		action, _ := cmd.Flags().GetString("action")
+		creator, _ := cmd.Flags().GetString("creator")
+		offset, _ := cmd.Flags().GetInt("offset")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		_, client := common.GetRepoAndClient(cmd, cfg, "")
+		if err := ListProposalsCmd(&ListProposalsArgs{
+			RepoName:      args[0],
+			Status:        status,
+			Action:        action,
+			Creator:       creator,
+			Offset:        offset,
+			Limit:         limit,
+			RPCClient:     client,
+			ListProposals: api.ListRepoProposals,
+			Stdout:        os.Stdout,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+func setupRepoProposalsListCmd(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringP("status", "s", "", "Only list proposals with the given status (deposit, voting or closed)")
+	f.StringP("action", "a", "", "Only list proposals of the given action (e.g spend, update, rename)")
+	f.StringP("creator", "c", "", "Only list proposals created by the given address")
+	f.Int("offset", 0, "The number of proposals to skip")
+	f.IntP("limit", "n", 20, "The maximum number of proposals to list (0 for all)")
+}
+
+// repoProposalsShowCmd represents a sub-command for showing a proposal and its votes
+var repoProposalsShowCmd = &cobra.Command{
+	Use:   "show [flags] <repo> <id>",
+	Short: "Show a proposal and the votes cast on it",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("repository name and proposal ID are required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		offset, _ := cmd.Flags().GetInt("offset")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		_, client := common.GetRepoAndClient(cmd, cfg, "")
+		if err := ShowProposalCmd(&ShowProposalArgs{
+			RepoName:    args[0],
+			ProposalID:  args[1],
+			Offset:      offset,
+			Limit:       limit,
+			RPCClient:   client,
+			GetProposal: api.GetRepoProposal,
+			Stdout:      os.Stdout,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+func setupRepoProposalsShowCmd(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.Int("offset", 0, "The number of votes to skip")
+	f.IntP("limit", "n", 20, "The maximum number of votes to list (0 for all)")
+}
+
+// repoProposalsWatchCmd represents a sub-command for streaming proposal lifecycle events
+var repoProposalsWatchCmd = &cobra.Command{
+	Use:   "watch [flags] [<repo>...]",
+	Short: "Stream lifecycle events of repository proposals",
+	Long: `Stream lifecycle events (created, voted, fee deposited, ended, applied) of
+proposals of the given repositories. If no repository is given, proposals of all
+repositories are watched.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		notify, _ := cmd.Flags().GetBool("notify")
+
+		_, client := common.GetRepoAndClient(cmd, cfg, "")
+		if err := WatchProposalsCmd(&WatchProposalsArgs{
+			RepoNames:      args,
+			Notify:         notify,
+			RPCClient:      client,
+			WatchProposals: api.WatchRepoProposals,
+			Notifier:       util.Notify,
+			Stdout:         os.Stdout,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
+func setupRepoProposalsWatchCmd(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.Bool("notify", false, "Display a desktop notification for each event")
+}
+
 func init() {
 	RepoCmd.AddCommand(repoCreateCmd)
 	RepoCmd.AddCommand(repoVoteCmd)
+	RepoCmd.AddCommand(repoProposeCmd)
+	repoProposeCmd.AddCommand(repoProposeSpendCmd)
 	RepoCmd.AddCommand(repoConfigCmd)
 	RepoCmd.AddCommand(repoHookCmd)
 	RepoCmd.AddCommand(repoInitCmd)
+	RepoCmd.AddCommand(repoPolicyCmd)
+	repoPolicyCmd.AddCommand(repoPolicyCheckCmd)
+	RepoCmd.AddCommand(repoProposalsCmd)
+	repoProposalsCmd.AddCommand(repoProposalsListCmd)
+	repoProposalsCmd.AddCommand(repoProposalsShowCmd)
+	repoProposalsCmd.AddCommand(repoProposalsWatchCmd)
 
 	setupRepoCreateCmd(repoCreateCmd)
 	setupRepoVoteCmd(repoVoteCmd)
+	setupRepoProposeSpendCmd(repoProposeSpendCmd)
 	setupRepoConfigCmd(repoConfigCmd)
 	setupRepoInitCmd(repoInitCmd)
 	setupRepoHookCmd(repoHookCmd)
+	setupRepoPolicyCheckCmd(repoPolicyCheckCmd)
+	setupRepoProposalsListCmd(repoProposalsListCmd)
+	setupRepoProposalsShowCmd(repoProposalsShowCmd)
+	setupRepoProposalsWatchCmd(repoProposalsWatchCmd)
 }
@@ -0,0 +1,104 @@
+package repocmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/make-os/kit/rpc/types"
+	api2 "github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/util/api"
+	fmt2 "github.com/make-os/kit/util/colorfmt"
+	"github.com/pkg/errors"
+)
+
+// PolicyCheckArgs contains arguments for PolicyCheckCmd.
+type PolicyCheckArgs struct {
+
+	// RepoName is the name of the repository
+	RepoName string
+
+	// Namespace is the namespace through which the repository is accessed
+	Namespace string
+
+	// PushKeyID is the ID of the push key whose access is checked
+	PushKeyID string
+
+	// Reference is the target reference
+	Reference string
+
+	// Action is the action to check
+	Action string
+
+	// RpcClient is the RPC client
+	RPCClient types.Client
+
+	// CheckPolicy is a function for simulating a repository policy check
+	CheckPolicy api.RepoPolicyChecker
+
+	Stdout io.Writer
+}
+
+// PolicyCheckCmd simulates a policy check for a push key and prints
+// every policy lookup performed and the final verdict.
+func PolicyCheckCmd(args *PolicyCheckArgs) error {
+
+	res, err := args.CheckPolicy(&api2.BodyRepoCheckPolicy{
+		RepoName:  args.RepoName,
+		Namespace: args.Namespace,
+		PushKeyID: args.PushKeyID,
+		Reference: args.Reference,
+		Action:    args.Action,
+	}, args.RPCClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to check policy")
+	}
+
+	if args.Stdout == nil {
+		return nil
+	}
+
+	fmt.Fprintln(args.Stdout, "Reference:  ", fmt2.CyanString("%s", res.Reference), fmt.Sprintf("(root: %s)", res.RootDir))
+	fmt.Fprintln(args.Stdout, "Action:     ", res.Action)
+	fmt.Fprintln(args.Stdout, "Push Key:   ", res.PushKeyID)
+	fmt.Fprintln(args.Stdout, "Contributor:", yesOrNo(res.IsContributor))
+	fmt.Fprintln(args.Stdout, "Ref Creator:", yesOrNo(res.IsRefCreator))
+	fmt.Fprintln(args.Stdout, "")
+
+	w := tabwriter.NewWriter(args.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SUBJECT\tOBJECT\tACTION\tLEVEL\tRESULT")
+	for _, step := range res.Steps {
+		result, level := "no match", "-"
+		if step.Matched {
+			level = fmt.Sprintf("%d", step.Level)
+			result = "deny"
+			if step.Allowed {
+				result = "allow"
+			}
+			if step.Applied {
+				result += " (applied)"
+			} else {
+				result += " (overridden)"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", step.Subject, step.Object, step.Action, level, result)
+	}
+	_ = w.Flush()
+
+	fmt.Fprintln(args.Stdout, "")
+	if res.Allowed {
+		fmt.Fprintln(args.Stdout, "Verdict:", fmt2.GreenString("allowed"))
+	} else {
+		fmt.Fprintln(args.Stdout, "Verdict:", fmt2.RedString("denied"))
+	}
+
+	return nil
+}
+
+// yesOrNo returns 'yes' if v is true, otherwise 'no'
+func yesOrNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,53 @@
+package repocmd
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/make-os/kit/rpc/types"
+	"github.com/make-os/kit/types/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PolicyCmd", func() {
+	Describe(".PolicyCheckCmd", func() {
+		It("should return error when policy check failed", func() {
+			args := &PolicyCheckArgs{RepoName: "repo1", PushKeyID: "pk1abc", Reference: "refs/heads/master", Action: "write"}
+			args.CheckPolicy = func(req *api.BodyRepoCheckPolicy, c types.Client) (*api.ResultCheckPolicy, error) {
+				Expect(req.RepoName).To(Equal(args.RepoName))
+				Expect(req.PushKeyID).To(Equal(args.PushKeyID))
+				Expect(req.Reference).To(Equal(args.Reference))
+				Expect(req.Action).To(Equal(args.Action))
+				return nil, fmt.Errorf("error")
+			}
+			err := PolicyCheckCmd(args)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("failed to check policy: error"))
+		})
+
+		It("should print evaluation trace and verdict", func() {
+			out := bytes.NewBuffer(nil)
+			args := &PolicyCheckArgs{RepoName: "repo1", PushKeyID: "pk1abc", Reference: "refs/heads/master", Action: "write", Stdout: out}
+			args.CheckPolicy = func(req *api.BodyRepoCheckPolicy, c types.Client) (*api.ResultCheckPolicy, error) {
+				return &api.ResultCheckPolicy{
+					Reference: "refs/heads/master",
+					RootDir:   "refs/heads",
+					Action:    "write",
+					PushKeyID: "pk1abc",
+					Steps: []*api.ResultPolicyTraceStep{
+						{Subject: "all", Object: "refs/heads/master", Action: "write", Level: -1},
+						{Subject: "all", Object: "refs/heads", Action: "write", Matched: true, Level: 2, Allowed: true, Applied: true},
+						{Subject: "pk1abc", Object: "refs/heads/master", Action: "deny-write", Matched: true, Level: 0, Applied: true},
+					},
+				}, nil
+			}
+			err := PolicyCheckCmd(args)
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(ContainSubstring("no match"))
+			Expect(out.String()).To(ContainSubstring("allow (applied)"))
+			Expect(out.String()).To(ContainSubstring("deny (applied)"))
+			Expect(out.String()).To(ContainSubstring("denied"))
+		})
+	})
+})
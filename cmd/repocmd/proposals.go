@@ -0,0 +1,295 @@
+package repocmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/make-os/kit/rpc/types"
+	types2 "github.com/make-os/kit/types"
+	api2 "github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util/api"
+	fmt2 "github.com/make-os/kit/util/colorfmt"
+	"github.com/pkg/errors"
+)
+
+// WatchProposalsArgs contains arguments for WatchProposalsCmd.
+type WatchProposalsArgs struct {
+
+	// RepoNames are the names of the repositories whose proposals are watched.
+	// If empty, proposals of all repositories are watched.
+	RepoNames []string
+
+	// Notify indicates that a desktop notification should be displayed for each event
+	Notify bool
+
+	// RpcClient is the RPC client
+	RPCClient types.Client
+
+	// WatchProposals is a function for watching lifecycle events of repository proposals
+	WatchProposals api.RepoProposalsWatcher
+
+	// Notifier is a function for displaying a desktop notification
+	Notifier func(val ...interface{})
+
+	Stdout io.Writer
+}
+
+// WatchProposalsCmd prints lifecycle events of repository proposals as they
+// occur and optionally displays a desktop notification for each event.
+func WatchProposalsCmd(args *WatchProposalsArgs) error {
+	err := args.WatchProposals(args.RepoNames, func(evt *core.ProposalEvent) error {
+		msg := FormatProposalEvent(evt)
+		if args.Stdout != nil {
+			fmt.Fprintln(args.Stdout, fmt2.CyanString("[%d]", evt.Height), msg)
+		}
+		if args.Notify && args.Notifier != nil {
+			args.Notifier(msg)
+		}
+		return nil
+	}, args.RPCClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to watch proposals")
+	}
+	return nil
+}
+
+// FormatProposalEvent returns a human-readable description of a proposal event
+func FormatProposalEvent(evt *core.ProposalEvent) string {
+	prop := fmt.Sprintf("%s/%s", evt.RepoName, evt.ProposalID)
+	switch evt.Type {
+	case core.ProposalEventCreated:
+		return fmt.Sprintf("Proposal %s was created by %s", prop, evt.Actor)
+	case core.ProposalEventVoted:
+		vote := "unknown"
+		if evt.Vote != nil {
+			if choice, ok := voteChoices[*evt.Vote]; ok {
+				vote = choice
+			}
+		}
+		return fmt.Sprintf("%s voted '%s' on proposal %s", evt.Actor, vote, prop)
+	case core.ProposalEventFeeDeposited:
+		return fmt.Sprintf("%s deposited %s to proposal %s", evt.Actor, evt.Value, prop)
+	case core.ProposalEventEnded:
+		outcome, ok := proposalOutcomes[evt.Outcome]
+		if !ok {
+			outcome = "unknown"
+		}
+		return fmt.Sprintf("Proposal %s ended (%s)", prop, outcome)
+	case core.ProposalEventApplied:
+		return fmt.Sprintf("Proposal %s was applied", prop)
+	case core.ProposalEventFailed:
+		return fmt.Sprintf("Proposal %s was accepted but could not be applied", prop)
+	default:
+		return fmt.Sprintf("Proposal %s: %s", prop, evt.Type)
+	}
+}
+
+// ListProposalsArgs contains arguments for ListProposalsCmd.
+type ListProposalsArgs struct {
+
+	// RepoName is the name of the repository
+	RepoName string
+
+	// Status filters proposals by status (deposit, voting or closed)
+	Status string
+
+	// Action filters proposals by the name or code of their action type
+	Action string
+
+	// Creator filters proposals by the address of their creator
+	Creator string
+
+	// Offset is the number of matched proposals to skip
+	Offset int
+
+	// Limit is the maximum number of proposals to print
+	Limit int
+
+	// RpcClient is the RPC client
+	RPCClient types.Client
+
+	// ListProposals is a function for listing the proposals of a repository
+	ListProposals api.RepoProposalsLister
+
+	Stdout io.Writer
+}
+
+// ListProposalsCmd prints the proposals of a repository that match the given filters
+func ListProposalsCmd(args *ListProposalsArgs) error {
+
+	var action types2.TxCode
+	if args.Action != "" {
+		code, ok := proposalActions[args.Action]
+		if !ok {
+			n, err := strconv.Atoi(args.Action)
+			if err != nil {
+				return fmt.Errorf("unknown proposal action (%s)", args.Action)
+			}
+			code = types2.TxCode(n)
+		}
+		action = code
+	}
+
+	res, err := args.ListProposals(&api2.BodyRepoListProposals{
+		RepoName: args.RepoName,
+		Status:   args.Status,
+		Action:   action,
+		Creator:  args.Creator,
+		Offset:   args.Offset,
+		Limit:    args.Limit,
+	}, args.RPCClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to list proposals")
+	}
+
+	if args.Stdout == nil {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(args.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tACTION\tCREATOR\tHEIGHT\tEND\tOUTCOME")
+	for _, p := range res.Proposals {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n", p.ID, p.Status, proposalActionName(p.Action),
+			p.Creator, p.Height, p.EndAt, proposalOutcomeName(p.Outcome))
+	}
+	_ = w.Flush()
+
+	fmt.Fprintln(args.Stdout, "")
+	fmt.Fprintf(args.Stdout, "Showing %d of %d proposal(s)\n", len(res.Proposals), res.Total)
+
+	return nil
+}
+
+// ShowProposalArgs contains arguments for ShowProposalCmd.
+type ShowProposalArgs struct {
+
+	// RepoName is the name of the repository
+	RepoName string
+
+	// ProposalID is the ID of the proposal
+	ProposalID string
+
+	// Offset is the number of votes to skip
+	Offset int
+
+	// Limit is the maximum number of votes to print
+	Limit int
+
+	// RpcClient is the RPC client
+	RPCClient types.Client
+
+	// GetProposal is a function for fetching a repository proposal
+	GetProposal api.RepoProposalGetter
+
+	Stdout io.Writer
+}
+
+// ShowProposalCmd prints a repository proposal and the votes cast on it
+func ShowProposalCmd(args *ShowProposalArgs) error {
+
+	p, err := args.GetProposal(&api2.BodyRepoGetProposal{
+		RepoName:   args.RepoName,
+		ProposalID: args.ProposalID,
+		Offset:     args.Offset,
+		Limit:      args.Limit,
+	}, args.RPCClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to get proposal")
+	}
+
+	if args.Stdout == nil {
+		return nil
+	}
+
+	fmt.Fprintln(args.Stdout, "Proposal:", fmt2.CyanString("%s/%s", args.RepoName, p.ID))
+	fmt.Fprintln(args.Stdout, "Status:  ", p.Status)
+	fmt.Fprintln(args.Stdout, "Action:  ", proposalActionName(p.Action))
+	fmt.Fprintln(args.Stdout, "Creator: ", p.Creator)
+	fmt.Fprintln(args.Stdout, "Height:  ", p.Height)
+	if p.IsFeeDepositEnabled() {
+		fmt.Fprintln(args.Stdout, "Deposit: ", fmt.Sprintf("ends at %d", p.FeeDepositEndAt))
+	}
+	fmt.Fprintln(args.Stdout, "Voting:  ", fmt.Sprintf("ends at %d", p.EndAt))
+	if p.IsFinalized() {
+		fmt.Fprintln(args.Stdout, "Outcome: ", proposalOutcomeName(p.Outcome))
+	}
+	fmt.Fprintln(args.Stdout, "Tally:   ", fmt.Sprintf("yes=%v no=%v noWithVeto=%v noWithVetoByOwners=%v abstain=%v",
+		p.Yes, p.No, p.NoWithVeto, p.NoWithVetoByOwners, p.Abstain))
+	fmt.Fprintln(args.Stdout, "")
+
+	w := tabwriter.NewWriter(args.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VOTER\tVOTE\tPOWER")
+	for _, v := range p.Votes {
+		vote, ok := voteChoices[v.Vote]
+		if !ok {
+			vote = "unknown"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%v\n", v.Voter, vote, v.Power)
+	}
+	_ = w.Flush()
+
+	fmt.Fprintln(args.Stdout, "")
+	fmt.Fprintf(args.Stdout, "Showing %d of %d vote(s)\n", len(p.Votes), p.TotalVotes)
+
+	return nil
+}
+
+// proposalActionName returns the name of a proposal action
+func proposalActionName(action types2.TxCode) string {
+	for name, code := range proposalActions {
+		if code == action {
+			return name
+		}
+	}
+	return strconv.Itoa(int(action))
+}
+
+// proposalOutcomeName returns the name of a proposal outcome or '-'
+// if the proposal has no outcome
+func proposalOutcomeName(outcome state.ProposalOutcome) string {
+	if outcome == 0 {
+		return "-"
+	}
+	if name, ok := proposalOutcomes[outcome]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+var proposalActions = map[string]types2.TxCode{
+	"upsertOwner":     txns.TxTypeRepoProposalUpsertOwner,
+	"update":          txns.TxTypeRepoProposalUpdate,
+	"registerPushKey": txns.TxTypeRepoProposalRegisterPushKey,
+	"mergeRequest":    txns.TxTypeMergeRequestProposalAction,
+	"spend":           txns.TxTypeRepoProposalSpend,
+	"archive":         txns.TxTypeRepoProposalArchive,
+	"delete":          txns.TxTypeRepoProposalDelete,
+	"rename":          txns.TxTypeRepoProposalRename,
+	"updateNamespace": txns.TxTypeRepoProposalUpdateNamespace,
+}
+
+var voteChoices = map[int]string{
+	state.ProposalVoteYes:        "yes",
+	state.ProposalVoteNo:         "no",
+	state.ProposalVoteNoWithVeto: "no with veto",
+	state.ProposalVoteAbstain:    "abstain",
+}
+
+var proposalOutcomes = map[state.ProposalOutcome]string{
+	state.ProposalOutcomeAccepted:                 "accepted",
+	state.ProposalOutcomeRejected:                 "rejected",
+	state.ProposalOutcomeRejectedWithVeto:         "rejected with veto",
+	state.ProposalOutcomeRejectedWithVetoByOwners: "rejected with veto by owners",
+	state.ProposalOutcomeQuorumNotMet:             "quorum not met",
+	state.ProposalOutcomeBelowThreshold:           "below threshold",
+	state.ProposalOutcomeInsufficientDeposit:      "insufficient deposit",
+	state.ProposalOutcomeWithdrawn:                "withdrawn",
+	state.ProposalOutcomeQueued:                   "accepted and queued for execution",
+	state.ProposalOutcomeCancelled:                "cancelled",
+	state.ProposalOutcomeFailed:                   "failed",
+}
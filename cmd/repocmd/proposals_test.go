@@ -0,0 +1,198 @@
+package repocmd
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/make-os/kit/rpc/types"
+	"github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProposalsCmd", func() {
+	Describe(".WatchProposalsCmd", func() {
+		It("should return error when watching failed", func() {
+			args := &WatchProposalsArgs{RepoNames: []string{"repo1"}}
+			args.WatchProposals = func(names []string, handler func(evt *core.ProposalEvent) error, c types.Client) error {
+				Expect(names).To(Equal(args.RepoNames))
+				return fmt.Errorf("error")
+			}
+			err := WatchProposalsCmd(args)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("failed to watch proposals: error"))
+		})
+
+		It("should print events and display notifications when notify is set", func() {
+			out := bytes.NewBuffer(nil)
+			var notifications []string
+			args := &WatchProposalsArgs{Notify: true, Stdout: out}
+			args.Notifier = func(val ...interface{}) { notifications = append(notifications, fmt.Sprint(val...)) }
+			args.WatchProposals = func(names []string, handler func(evt *core.ProposalEvent) error, c types.Client) error {
+				Expect(names).To(BeEmpty())
+				return handler(&core.ProposalEvent{Type: core.ProposalEventApplied, RepoName: "repo1", ProposalID: "1", Height: 10})
+			}
+			err := WatchProposalsCmd(args)
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(ContainSubstring("[10]"))
+			Expect(out.String()).To(ContainSubstring("Proposal repo1/1 was applied"))
+			Expect(notifications).To(Equal([]string{"Proposal repo1/1 was applied"}))
+		})
+
+		It("should not display notifications when notify is not set", func() {
+			var notified bool
+			args := &WatchProposalsArgs{}
+			args.Notifier = func(val ...interface{}) { notified = true }
+			args.WatchProposals = func(names []string, handler func(evt *core.ProposalEvent) error, c types.Client) error {
+				return handler(&core.ProposalEvent{Type: core.ProposalEventCreated, RepoName: "repo1", ProposalID: "1"})
+			}
+			err := WatchProposalsCmd(args)
+			Expect(err).To(BeNil())
+			Expect(notified).To(BeFalse())
+		})
+	})
+
+	Describe(".ListProposalsCmd", func() {
+		It("should return error when action is unknown", func() {
+			args := &ListProposalsArgs{RepoName: "repo1", Action: "unknown"}
+			err := ListProposalsCmd(args)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("unknown proposal action (unknown)"))
+		})
+
+		It("should return error when listing failed", func() {
+			args := &ListProposalsArgs{RepoName: "repo1", Status: "voting", Action: "spend", Creator: "addr1", Offset: 1, Limit: 2}
+			args.ListProposals = func(req *api.BodyRepoListProposals, c types.Client) (*api.ResultRepoProposals, error) {
+				Expect(req).To(Equal(&api.BodyRepoListProposals{
+					RepoName: "repo1",
+					Status:   "voting",
+					Action:   txns.TxTypeRepoProposalSpend,
+					Creator:  "addr1",
+					Offset:   1,
+					Limit:    2,
+				}))
+				return nil, fmt.Errorf("error")
+			}
+			err := ListProposalsCmd(args)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("failed to list proposals: error"))
+		})
+
+		It("should accept a numeric action", func() {
+			args := &ListProposalsArgs{RepoName: "repo1", Action: "18"}
+			args.ListProposals = func(req *api.BodyRepoListProposals, c types.Client) (*api.ResultRepoProposals, error) {
+				Expect(int(req.Action)).To(Equal(18))
+				return &api.ResultRepoProposals{}, nil
+			}
+			Expect(ListProposalsCmd(args)).To(BeNil())
+		})
+
+		It("should print the proposals", func() {
+			out := bytes.NewBuffer(nil)
+			args := &ListProposalsArgs{RepoName: "repo1", Stdout: out}
+			args.ListProposals = func(req *api.BodyRepoListProposals, c types.Client) (*api.ResultRepoProposals, error) {
+				p := &api.ResultRepoProposal{ID: "1", Status: state.ProposalStatusClosed}
+				p.Action = txns.TxTypeRepoProposalRename
+				p.Creator = "addr1"
+				p.Outcome = state.ProposalOutcomeAccepted
+				return &api.ResultRepoProposals{Proposals: []*api.ResultRepoProposal{p}, Total: 4}, nil
+			}
+			err := ListProposalsCmd(args)
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(ContainSubstring("rename"))
+			Expect(out.String()).To(ContainSubstring("addr1"))
+			Expect(out.String()).To(ContainSubstring("accepted"))
+			Expect(out.String()).To(ContainSubstring("Showing 1 of 4 proposal(s)"))
+		})
+	})
+
+	Describe(".ShowProposalCmd", func() {
+		It("should return error when fetching proposal failed", func() {
+			args := &ShowProposalArgs{RepoName: "repo1", ProposalID: "1", Limit: 5}
+			args.GetProposal = func(req *api.BodyRepoGetProposal, c types.Client) (*api.ResultRepoProposal, error) {
+				Expect(req).To(Equal(&api.BodyRepoGetProposal{RepoName: "repo1", ProposalID: "1", Limit: 5}))
+				return nil, fmt.Errorf("error")
+			}
+			err := ShowProposalCmd(args)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("failed to get proposal: error"))
+		})
+
+		It("should print the proposal and its votes", func() {
+			out := bytes.NewBuffer(nil)
+			args := &ShowProposalArgs{RepoName: "repo1", ProposalID: "1", Stdout: out}
+			args.GetProposal = func(req *api.BodyRepoGetProposal, c types.Client) (*api.ResultRepoProposal, error) {
+				p := &api.ResultRepoProposal{ID: "1", Status: state.ProposalStatusVoting, TotalVotes: 2}
+				p.Action = txns.TxTypeRepoProposalSpend
+				p.Votes = []*core.ProposalVote{{Voter: "addr1", Vote: 1, Power: 2.5}, {Voter: "addr2", Vote: state.ProposalVoteNoWithVeto, Power: 1}}
+				return p, nil
+			}
+			err := ShowProposalCmd(args)
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(ContainSubstring("repo1/1"))
+			Expect(out.String()).To(ContainSubstring("spend"))
+			Expect(out.String()).To(ContainSubstring("addr1  yes"))
+			Expect(out.String()).To(ContainSubstring("no with veto"))
+			Expect(out.String()).To(ContainSubstring("Showing 2 of 2 vote(s)"))
+		})
+
+		It("should print the choice of each vote type", func() {
+			out := bytes.NewBuffer(nil)
+			args := &ShowProposalArgs{RepoName: "repo1", ProposalID: "1", Stdout: out}
+			args.GetProposal = func(req *api.BodyRepoGetProposal, c types.Client) (*api.ResultRepoProposal, error) {
+				p := &api.ResultRepoProposal{ID: "1", Status: state.ProposalStatusVoting, TotalVotes: 5}
+				p.Action = txns.TxTypeRepoProposalSpend
+				p.Votes = []*core.ProposalVote{
+					{Voter: "addr1", Vote: state.ProposalVoteYes, Power: 1},
+					{Voter: "addr2", Vote: state.ProposalVoteNo, Power: 1},
+					{Voter: "addr3", Vote: state.ProposalVoteNoWithVeto, Power: 1},
+					{Voter: "addr4", Vote: state.ProposalVoteAbstain, Power: 1},
+					{Voter: "addr5", Vote: -1, Power: 1},
+				}
+				return p, nil
+			}
+			err := ShowProposalCmd(args)
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(ContainSubstring("addr1  yes"))
+			Expect(out.String()).To(ContainSubstring("addr2  no "))
+			Expect(out.String()).To(ContainSubstring("addr3  no with veto"))
+			Expect(out.String()).To(ContainSubstring("addr4  abstain"))
+			Expect(out.String()).To(ContainSubstring("addr5  unknown"))
+		})
+	})
+
+	Describe(".FormatProposalEvent", func() {
+		It("should describe each event type", func() {
+			Expect(FormatProposalEvent(&core.ProposalEvent{Type: core.ProposalEventCreated, RepoName: "r", ProposalID: "1", Actor: "addr"})).
+				To(Equal("Proposal r/1 was created by addr"))
+			Expect(FormatProposalEvent(&core.ProposalEvent{Type: core.ProposalEventFeeDeposited, RepoName: "r", ProposalID: "1", Actor: "addr", Value: "10"})).
+				To(Equal("addr deposited 10 to proposal r/1"))
+			Expect(FormatProposalEvent(&core.ProposalEvent{Type: core.ProposalEventEnded, RepoName: "r", ProposalID: "1", Outcome: state.ProposalOutcomeQuorumNotMet})).
+				To(Equal("Proposal r/1 ended (quorum not met)"))
+			Expect(FormatProposalEvent(&core.ProposalEvent{Type: core.ProposalEventEnded, RepoName: "r", ProposalID: "1", Outcome: state.ProposalOutcomeFailed})).
+				To(Equal("Proposal r/1 ended (failed)"))
+			Expect(FormatProposalEvent(&core.ProposalEvent{Type: core.ProposalEventFailed, RepoName: "r", ProposalID: "1"})).
+				To(Equal("Proposal r/1 was accepted but could not be applied"))
+		})
+
+		It("should describe each vote type", func() {
+			votes := map[int]string{
+				state.ProposalVoteYes:        "yes",
+				state.ProposalVoteNo:         "no",
+				state.ProposalVoteNoWithVeto: "no with veto",
+				state.ProposalVoteAbstain:    "abstain",
+				-1:                           "unknown",
+			}
+			for vote, choice := range votes {
+				evt := &core.ProposalEvent{Type: core.ProposalEventVoted, RepoName: "r", ProposalID: "1", Actor: "addr", Vote: pointer.ToInt(vote)}
+				Expect(FormatProposalEvent(evt)).To(Equal(fmt.Sprintf("addr voted '%s' on proposal r/1", choice)))
+			}
+			evt := &core.ProposalEvent{Type: core.ProposalEventVoted, RepoName: "r", ProposalID: "1", Actor: "addr"}
+			Expect(FormatProposalEvent(evt)).To(Equal("addr voted 'unknown' on proposal r/1"))
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/make-os/kit/cmd/issuecmd"
 	"github.com/make-os/kit/cmd/keycmd"
 	"github.com/make-os/kit/cmd/mergecmd"
+	"github.com/make-os/kit/cmd/milestonecmd"
 	"github.com/make-os/kit/cmd/passcmd"
 	"github.com/make-os/kit/cmd/pkcmd"
 	"github.com/make-os/kit/cmd/repocmd"
@@ -217,6 +218,7 @@ func init() {
 		pkcmd.PushKeyRegCmd,
 		keycmd.KeysCmd,
 		mergecmd.MergeReqCmd,
+		milestonecmd.MilestoneCmd,
 		passcmd.PassAgentCmd,
 		usercmd.UserCmd,
 	)
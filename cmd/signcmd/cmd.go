@@ -2,6 +2,7 @@ package signcmd
 
 import (
 	"os"
+	"strings"
 
 	"github.com/make-os/kit/cmd/common"
 	"github.com/make-os/kit/cmd/signcmd/types"
@@ -20,8 +21,8 @@ var (
 // SignCmd represents the commit command
 var SignCmd = &cobra.Command{
 	Use:   "sign [command]",
-	Short: "Sign a commit, tag or note",
-	Long:  `Sign a commit, tag or note. Run 'kit sign' to sign the current commit.`,
+	Short: "Sign a commit, tag, note or approval",
+	Long:  `Sign a commit, tag, note or approval. Run 'kit sign' to sign the current commit.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		signCommitCmd.Run(cmd, args)
 	},
@@ -151,6 +152,44 @@ var signNoteCmd = &cobra.Command{
 	},
 }
 
+var signApprovalCmd = &cobra.Command{
+	Use:   "approve [<commit>]",
+	Short: "Approve a commit for a protected branch",
+	Long: `Sign an approval of a commit (defaults to the recent commit) for a branch (defaults
+to the current branch) and add it to the approval note. The approval is bound to the repository
+and branch; Unless provided, the repository name is taken from the URL of the remote.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		signingKey, _ := cmd.Flags().GetString("signing-key")
+		signingKeyPass, _ := cmd.Flags().GetString("signing-key-pass")
+		remotes, _ := cmd.Flags().GetString("remote")
+		reference, _ := cmd.Flags().GetString("branch")
+		repoName, _ := cmd.Flags().GetString("repo")
+
+		targetRepo, _ := common.GetRepoAndClient(cmd, cfg, "")
+		if targetRepo == nil {
+			log.Fatal("no repository found in current directory")
+		}
+
+		var commit string
+		if len(args) > 0 {
+			commit = args[0]
+		}
+
+		if err := SignApprovalCmd(cfg, targetRepo, &types.SignApprovalArgs{
+			Commit:      commit,
+			Reference:   reference,
+			RepoName:    repoName,
+			Remote:      strings.Split(remotes, ",")[0],
+			SigningKey:  signingKey,
+			PushKeyPass: signingKeyPass,
+			KeyUnlocker: common.UnlockKey,
+			Stdout:      os.Stdout,
+		}); err != nil {
+			log.Fatal(err.Error())
+		}
+	},
+}
+
 func setupSignCommitCmd(cmd *cobra.Command) {
 	cmd.Flags().StringP("merge-id", "m", "", "Provide a merge proposal ID for merge fulfilment")
 	cmd.Flags().String("head", "", "Specify the branch to use as git HEAD")
@@ -160,6 +199,7 @@ func init() {
 	SignCmd.AddCommand(signTagCmd)
 	SignCmd.AddCommand(signCommitCmd)
 	SignCmd.AddCommand(signNoteCmd)
+	SignCmd.AddCommand(signApprovalCmd)
 
 	pf := SignCmd.PersistentFlags()
 
@@ -171,6 +211,9 @@ func init() {
 	setupSignCommitCmd(signCommitCmd)
 	setupSignCommitCmd(SignCmd)
 
+	signApprovalCmd.Flags().StringP("branch", "b", "", "Specify the branch to approve the commit for (default: current branch)")
+	signApprovalCmd.Flags().String("repo", "", "Specify the name of the target repository (default: taken from the remote URL)")
+
 	pf.Float64P("fee", "f", 0, "Set the network transaction fee")
 	pf.Uint64P("nonce", "n", 0, "Set the next nonce of the signing account signing")
 	pf.StringP("signing-key", "u", "", "Address or index of local account to use for signing transaction")
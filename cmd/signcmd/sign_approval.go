@@ -0,0 +1,113 @@
+package signcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/make-os/kit/cmd/common"
+	types3 "github.com/make-os/kit/cmd/signcmd/types"
+	"github.com/make-os/kit/config"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+	fmt2 "github.com/make-os/kit/util/colorfmt"
+	"github.com/pkg/errors"
+)
+
+var ErrMissingRepoName = fmt.Errorf("unable to determine the repository name from the remote; provide it explicitly")
+
+// SignApprovalCmd signs an approval of a commit for a branch of a repository
+// with a push key and records it in the approval note. The note must be pushed
+// for the approval to be counted when the commit is pushed to the branch.
+func SignApprovalCmd(cfg *config.AppConfig, repo plumbing2.LocalRepo, args *types3.SignApprovalArgs) error {
+
+	populateSignApprovalArgsFromRepoConfig(repo, args)
+
+	// Ensure a signing key was provided or found in the git config
+	if args.SigningKey == "" {
+		return ErrMissingPushKeyID
+	}
+
+	// Use the current branch if no reference was specified
+	if args.Reference == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return errors.Wrap(err, "failed to get current branch")
+		}
+		args.Reference = head
+	} else if !strings.HasPrefix(args.Reference, "refs/") {
+		args.Reference = "refs/heads/" + args.Reference
+	}
+
+	// Determine the repository name from the remote if not specified
+	if args.RepoName == "" {
+		args.RepoName = common.GetRepoNameFromRemote(repo, args.Remote)
+		if args.RepoName == "" {
+			return ErrMissingRepoName
+		}
+	}
+
+	// Use the recent commit if no commit was specified; Otherwise, expand the hash
+	var err error
+	if args.Commit == "" {
+		args.Commit, err = repo.GetRecentCommitHash()
+	} else {
+		args.Commit, err = repo.ExpandShortHash(args.Commit)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get commit")
+	}
+
+	// Get and unlock the pusher key
+	key, err := args.KeyUnlocker(cfg, &common.UnlockKeyArgs{
+		KeyStoreID: args.SigningKey,
+		Passphrase: args.PushKeyPass,
+		NoPrompt:   args.NoPrompt,
+		TargetRepo: repo,
+		Stdout:     args.Stdout,
+		Prompt:     "Enter passphrase to unlock the signing key\n",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to unlock push key")
+	}
+
+	// Sign the approval message
+	pushKeyID := key.GetPushKeyAddress()
+	sig, err := key.GetKey().PrivKey().Sign(plumbing2.MakeApprovalMsg(args.RepoName, args.Reference, args.Commit))
+	if err != nil {
+		return errors.Wrap(err, "failed to sign approval")
+	}
+
+	// Get existing approvals of the commit, replacing any previous
+	// approval of the same reference by the signer
+	existing, err := plumbing2.GetApprovals(repo, args.Commit)
+	if err != nil {
+		return errors.Wrap(err, "failed to get existing approvals")
+	}
+	var lines []string
+	for _, a := range existing {
+		if a.PushKeyID != pushKeyID || a.Reference != args.Reference {
+			lines = append(lines, a.String())
+		}
+	}
+	lines = append(lines, (&plumbing2.Approval{PushKeyID: pushKeyID, Reference: args.Reference, Signature: sig}).String())
+
+	if err = repo.AddEntryToNote(plumbing2.ApprovalNoteRef, args.Commit, strings.Join(lines, "\n")); err != nil {
+		return err
+	}
+
+	if args.Stdout != nil {
+		fmt.Fprintf(args.Stdout, "Approved commit %s for %s\n", fmt2.CyanString(args.Commit), fmt2.CyanString(args.Reference))
+		fmt.Fprintf(args.Stdout, "Push %s to share the approval\n", fmt2.CyanString(plumbing2.ApprovalNoteRef))
+	}
+
+	return nil
+}
+
+// populateSignApprovalArgsFromRepoConfig populates empty arguments field from repo config.
+func populateSignApprovalArgsFromRepoConfig(repo plumbing2.LocalRepo, args *types3.SignApprovalArgs) {
+	if args.SigningKey == "" {
+		args.SigningKey = repo.GetGitConfigOption("user.signingKey")
+	}
+	if args.PushKeyPass == "" {
+		args.PushKeyPass = repo.GetGitConfigOption("user.passphrase")
+	}
+}
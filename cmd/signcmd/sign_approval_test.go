@@ -0,0 +1,125 @@
+package signcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/golang/mock/gomock"
+	types2 "github.com/make-os/kit/cmd/signcmd/types"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/mocks"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/testutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SignApproval", func() {
+	var err error
+	var cfg *config.AppConfig
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockLocalRepo
+	var key *ed25519.Key
+	var commitHash = "a3a1bc46b2a3c1a4a8e1a2f6a5a1e9b3c1d2e3f4"
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockLocalRepo(ctrl)
+		key = ed25519.NewKeyFromIntSeed(1)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".SignApprovalCmd", func() {
+		It("should return error when push key ID is not provided", func() {
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).AnyTimes()
+			args := &types2.SignApprovalArgs{}
+			err := SignApprovalCmd(cfg, mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(ErrMissingPushKeyID))
+		})
+
+		It("should return error when unable to get recent commit", func() {
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).Return(key.PushAddr().String()).AnyTimes()
+			mockRepo.EXPECT().GetRecentCommitHash().Return("", fmt.Errorf("error"))
+			args := &types2.SignApprovalArgs{RepoName: "repo1", Reference: "refs/heads/master"}
+			err := SignApprovalCmd(cfg, mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to get commit: error"))
+		})
+
+		It("should return error when failed to unlock the signing key", func() {
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).Return(key.PushAddr().String()).AnyTimes()
+			mockRepo.EXPECT().ExpandShortHash("a3a1bc4").Return(commitHash, nil)
+			args := &types2.SignApprovalArgs{Commit: "a3a1bc4", RepoName: "repo1", Reference: "refs/heads/master"}
+			args.KeyUnlocker = testPushKeyUnlocker(nil, fmt.Errorf("error"))
+			err := SignApprovalCmd(cfg, mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to unlock push key: error"))
+		})
+
+		It("should return error when unable to get the current branch", func() {
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).Return(key.PushAddr().String()).AnyTimes()
+			mockRepo.EXPECT().Head().Return("", fmt.Errorf("error"))
+			args := &types2.SignApprovalArgs{RepoName: "repo1"}
+			err := SignApprovalCmd(cfg, mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to get current branch: error"))
+		})
+
+		It("should return error when the repository name cannot be determined from the remote", func() {
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).Return(key.PushAddr().String()).AnyTimes()
+			mockRepo.EXPECT().GetRemoteURLs("origin").Return([]string{"https://host:9002/ns1/domain"})
+			args := &types2.SignApprovalArgs{Reference: "master"}
+			err := SignApprovalCmd(cfg, mockRepo, args)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(ErrMissingRepoName))
+		})
+
+		It("should add a signed approval entry to the approval note", func() {
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).Return(key.PushAddr().String()).AnyTimes()
+			mockRepo.EXPECT().Head().Return("refs/heads/master", nil)
+			mockRepo.EXPECT().GetRemoteURLs("origin").Return([]string{"https://host:9002/r/repo1"})
+			mockRepo.EXPECT().ExpandShortHash(commitHash).Return(commitHash, nil)
+			mockStoredKey := mocks.NewMockStoredKey(ctrl)
+			mockStoredKey.EXPECT().GetPushKeyAddress().Return(key.PushAddr().String())
+			mockStoredKey.EXPECT().GetKey().Return(key)
+			mockRepo.EXPECT().Reference(plumbing.ReferenceName(plumbing2.ApprovalNoteRef), true).Return(nil, plumbing.ErrReferenceNotFound)
+
+			sig, _ := key.PrivKey().Sign(plumbing2.MakeApprovalMsg("repo1", "refs/heads/master", commitHash))
+			expected := (&plumbing2.Approval{PushKeyID: key.PushAddr().String(), Reference: "refs/heads/master", Signature: sig}).String()
+			mockRepo.EXPECT().AddEntryToNote(plumbing2.ApprovalNoteRef, commitHash, expected).Return(nil)
+
+			args := &types2.SignApprovalArgs{Commit: commitHash}
+			args.KeyUnlocker = testPushKeyUnlocker(mockStoredKey, nil)
+			err := SignApprovalCmd(cfg, mockRepo, args)
+			Expect(err).To(BeNil())
+		})
+
+		It("should expand a short branch name and use the given repository name", func() {
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).Return(key.PushAddr().String()).AnyTimes()
+			mockRepo.EXPECT().ExpandShortHash(commitHash).Return(commitHash, nil)
+			mockStoredKey := mocks.NewMockStoredKey(ctrl)
+			mockStoredKey.EXPECT().GetPushKeyAddress().Return(key.PushAddr().String())
+			mockStoredKey.EXPECT().GetKey().Return(key)
+			mockRepo.EXPECT().Reference(plumbing.ReferenceName(plumbing2.ApprovalNoteRef), true).Return(nil, plumbing.ErrReferenceNotFound)
+
+			sig, _ := key.PrivKey().Sign(plumbing2.MakeApprovalMsg("repo2", "refs/heads/dev", commitHash))
+			expected := (&plumbing2.Approval{PushKeyID: key.PushAddr().String(), Reference: "refs/heads/dev", Signature: sig}).String()
+			mockRepo.EXPECT().AddEntryToNote(plumbing2.ApprovalNoteRef, commitHash, expected).Return(nil)
+
+			args := &types2.SignApprovalArgs{Commit: commitHash, Reference: "dev", RepoName: "repo2"}
+			args.KeyUnlocker = testPushKeyUnlocker(mockStoredKey, nil)
+			err := SignApprovalCmd(cfg, mockRepo, args)
+			Expect(err).To(BeNil())
+		})
+	})
+})
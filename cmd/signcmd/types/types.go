@@ -147,3 +147,33 @@ type SignTagArgs struct {
 }
 
 type SignTagFunc func(cfg *config.AppConfig, cmdArg []string, repo plumbing.LocalRepo, args *SignTagArgs) error
+
+type SignApprovalArgs struct {
+	// Commit is the hash of the commit to approve
+	Commit string
+
+	// Reference is the branch the commit is approved for (default: current branch)
+	Reference string
+
+	// RepoName is the name of the target repository (default: taken from the remote URL)
+	RepoName string
+
+	// Remote is the remote whose URL is used to determine the repository name
+	Remote string
+
+	// PushKeyID is the signers push key ID
+	SigningKey string
+
+	// PushKeyPass is the signers push key passphrase
+	PushKeyPass string
+
+	// NoPrompt prevents key unlocker prompt
+	NoPrompt bool
+
+	// KeyUnlocker is a function for getting and unlocking a push key from keystore
+	KeyUnlocker common.UnlockKeyFunc
+
+	Stdout io.Writer
+}
+
+type SignApprovalFunc func(cfg *config.AppConfig, repo plumbing.LocalRepo, args *SignApprovalArgs) error
@@ -63,6 +63,7 @@ func setStartFlags(cmd *cobra.Command) {
 	f.String("rpc.tmaddress", config.DefaultTMRPCAddress, "Set tendermint RPC listening address")
 	f.Bool("node.validator", false, "Run the node in validator mode")
 	f.String("dht.address", config.DefaultDHTAddress, "Set the DHT listening address")
+	f.String("remote.sshaddress", config.DefaultRemoteSSHAddress, "Set the SSH listening address of the remote server (empty to disable)")
 	f.String("node.addpeer", "", "Connect to one or more persistent node")
 	f.Bool("dht.on", true, "Run the DHT service and join the network")
 	f.String("dht.addpeer", "", "Register bootstrap peers for joining the DHT network")
@@ -70,6 +71,7 @@ func setStartFlags(cmd *cobra.Command) {
 	f.StringSliceP("repo.track", "t", []string{}, "Specify one or more repositories to track")
 	f.StringSliceP("repo.untrack", "u", []string{}, "Untrack one or more repositories")
 	f.BoolP("repo.untrackall", "x", false, "Untrack all previously tracked repositories")
+	f.Int("repo.fetchdepth", 0, "Limit the number of ancestor commits fetched for pushed references (0 = no limit)")
 
 	// Light node primary
 	f.Bool("node.light", false, "Run the node in light mode")
@@ -49,6 +49,10 @@ var (
 	// DefaultRemoteServerAddress is the default remote server listening address
 	DefaultRemoteServerAddress = ":9002"
 
+	// DefaultRemoteSSHAddress is the default remote server SSH listening address.
+	// It is empty so the SSH server is disabled unless an address is set.
+	DefaultRemoteSSHAddress = ""
+
 	// DefaultDHTAddress is the default DHT listening address
 	DefaultDHTAddress = ":9003"
 
@@ -87,6 +87,10 @@ type RepoConfig struct {
 
 	// UntrackAll indicates that all currently tracked repositories are to be untracked
 	UntrackAll bool `json:"untrackall" mapstructure:"untrackall"`
+
+	// FetchDepth is the maximum number of ancestor commits of a pushed
+	// reference to fetch from the network. Zero means no limit.
+	FetchDepth int `json:"fetchdepth" mapstructure:"fetchdepth"`
 }
 
 // VersionInfo describes the clients
@@ -150,8 +154,9 @@ type DHTConfig struct {
 
 // RemoteConfig describes repository manager config parameters
 type RemoteConfig struct {
-	Address string `json:"address" mapstructure:"address"`
-	Name    string `json:"name" mapstructure:"name"`
+	Address    string `json:"address" mapstructure:"address"`
+	SSHAddress string `json:"sshaddress" mapstructure:"sshaddress"`
+	Name       string `json:"name" mapstructure:"name"`
 }
 
 // MempoolConfig describes mempool config parameters
@@ -276,6 +281,11 @@ func (c *AppConfig) GetDBRootDir() string {
 	return filepath.Join(c.NetDataDir(), "data")
 }
 
+// GetSSHHostKeyPath returns the path of the remote server's SSH host key
+func (c *AppConfig) GetSSHHostKeyPath() string {
+	return filepath.Join(c.NetDataDir(), "config", "ssh_host_ed25519_key")
+}
+
 // GetRepoRoot returns the repo root directory
 func (c *AppConfig) GetRepoRoot() string {
 	return c.repoDir
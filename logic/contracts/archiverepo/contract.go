@@ -0,0 +1,87 @@
+package archiverepo
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/contracts/common"
+	"github.com/make-os/kit/logic/proposals"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/txns"
+	"github.com/pkg/errors"
+)
+
+// Contract implements core.ProposalContract. It is a system contract that
+// creates a proposal to archive a repository, making it read-only.
+type Contract struct {
+	core.Keepers
+	tx          *txns.TxRepoProposalArchive
+	chainHeight uint64
+	contracts   *[]core.SystemContract
+}
+
+// NewContract creates a new instance of Contract
+func NewContract(contracts *[]core.SystemContract) *Contract {
+	return &Contract{contracts: contracts}
+}
+
+func (c *Contract) CanExec(typ types.TxCode) bool {
+	return typ == txns.TxTypeRepoProposalArchive
+}
+
+// Init initialize the contract
+func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight uint64) core.SystemContract {
+	c.Keepers = keepers
+	c.tx = tx.(*txns.TxRepoProposalArchive)
+	c.chainHeight = curChainHeight
+	return c
+}
+
+// Exec executes the contract
+func (c *Contract) Exec() error {
+
+	// Get the repo
+	repoKeeper := c.RepoKeeper()
+	repo := repoKeeper.Get(c.tx.RepoName)
+
+	// Create a proposal
+	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
+	proposal := proposals.MakeProposal(spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
+	proposal.Action = txns.TxTypeRepoProposalArchive
+
+	// Deduct network fee + proposal fee from sender
+	totalFee := c.tx.Fee.Decimal().Add(c.tx.Value.Decimal())
+	common.DebitAccount(c, spk, totalFee, c.chainHeight)
+
+	// Attempt to apply the proposal action
+	applied, err := proposals.MaybeApplyProposal(&proposals.ApplyProposalArgs{
+		Keepers:     c,
+		RepoName:    c.tx.RepoName,
+		ProposalID:  c.tx.ID,
+		Proposal:    proposal,
+		Repo:        repo,
+		ChainHeight: c.chainHeight,
+		Contracts:   *c.contracts,
+	})
+	if err != nil {
+		return errors.Wrap(err, common.ErrFailedToApplyProposal)
+	} else if applied {
+		goto update
+	}
+
+	// Index the proposal against its end height so it can be tracked
+	// and finalized at that height.
+	if err = repoKeeper.IndexProposalEnd(c.tx.RepoName, proposal.ID, proposal.EndAt.UInt64()); err != nil {
+		return errors.Wrap(err, common.ErrFailedToIndexProposal)
+	}
+
+update:
+	repoKeeper.Update(c.tx.RepoName, repo)
+	return nil
+}
+
+// Apply applies the proposal by marking the repository as archived.
+// The caller is responsible for persisting the repo.
+func (c *Contract) Apply(args *core.ProposalApplyArgs) error {
+	args.Repo.Archived = true
+	return nil
+}
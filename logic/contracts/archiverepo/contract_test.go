@@ -0,0 +1,150 @@
+package archiverepo_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	logic2 "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/contracts"
+	"github.com/make-os/kit/logic/contracts/archiverepo"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+func TestArchiveRepo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ArchiveRepo Suite")
+}
+
+var _ = Describe("Contract", func() {
+	var appDB storagetypes.Engine
+	var stateTreeDB tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var logic *logic2.Logic
+	var ctrl *gomock.Controller
+	var sender = ed25519.NewKeyFromIntSeed(1)
+	var key2 = ed25519.NewKeyFromIntSeed(2)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateTreeDB = testutil.GetDB()
+		logic = logic2.New(appDB, stateTreeDB, cfg)
+		err := logic.SysKeeper().SaveBlockInfo(&state.BlockInfo{Height: 1})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(appDB.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CanExec", func() {
+		It("should return true when able to execute tx type", func() {
+			ct := archiverepo.NewContract(nil)
+			Expect(ct.CanExec(txns.TxTypeRepoProposalArchive)).To(BeTrue())
+			Expect(ct.CanExec(txns.TxTypeHostTicket)).To(BeFalse())
+		})
+	})
+
+	Describe(".Exec", func() {
+		var err error
+		var repoUpd *state.Repository
+		var repoName = "repo"
+		var propID = "1"
+
+		BeforeEach(func() {
+			logic.AccountKeeper().Update(sender.Addr(), &state.Account{
+				Balance:             "10",
+				Stakes:              state.BareAccountStakes(),
+				DelegatorCommission: 10,
+			})
+			repoUpd = state.BareRepository()
+			repoUpd.Config = state.DefaultRepoConfig
+			repoUpd.Config.Gov.Voter = pointer.ToInt(int(state.VoterOwner))
+		})
+
+		When("sender is the only owner", func() {
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = archiverepo.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalArchive{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: "1", RepoName: repoName},
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is finalized and self accepted", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals).To(HaveLen(1))
+				Expect(repo.Proposals.Get(propID).Outcome).To(Equal(state.ProposalOutcomeAccepted))
+			})
+
+			Specify("that the repo is archived", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Archived).To(BeTrue())
+			})
+
+			Specify("that network fee + proposal fee was deducted", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("7.5"))
+			})
+		})
+
+		When("sender is not the only owner", func() {
+			curHeight := uint64(0)
+
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				repoUpd.AddOwner(key2.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = archiverepo.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalArchive{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: "1", RepoName: repoName},
+				}, curHeight).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is not finalized and the repo is not archived", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals.Get(propID).IsFinalized()).To(BeFalse())
+				Expect(repo.Archived).To(BeFalse())
+			})
+
+			Specify("that the proposal was indexed against its end height", func() {
+				res := logic.RepoKeeper().GetProposalsEndingAt(util.PtrStrToUInt64(repoUpd.Config.Gov.PropDuration) + curHeight + 1)
+				Expect(res).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe(".Apply", func() {
+		It("should mark the repo as archived", func() {
+			repoUpd := state.BareRepository()
+			err = archiverepo.NewContract(nil).Apply(&core.ProposalApplyArgs{
+				Proposal: &state.RepoProposal{},
+				Repo:     repoUpd,
+				Keepers:  logic,
+			})
+			Expect(err).To(BeNil())
+			Expect(repoUpd.Archived).To(BeTrue())
+		})
+	})
+})
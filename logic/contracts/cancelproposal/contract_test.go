@@ -0,0 +1,149 @@
+package cancelproposal_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	logic2 "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/contracts/cancelproposal"
+	"github.com/make-os/kit/params"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/shopspring/decimal"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+func TestCancelProposal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CancelProposal Suite")
+}
+
+var _ = Describe("Contract", func() {
+	var appDB storagetypes.Engine
+	var stateTreeDB tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var logic *logic2.Logic
+	var ctrl *gomock.Controller
+	var sender = ed25519.NewKeyFromIntSeed(1)
+	var key2 = ed25519.NewKeyFromIntSeed(2)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateTreeDB = testutil.GetDB()
+		logic = logic2.New(appDB, stateTreeDB, cfg)
+		err := logic.SysKeeper().SaveBlockInfo(&state.BlockInfo{Height: 1})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(appDB.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CanExec", func() {
+		It("should return true when able to execute tx type", func() {
+			ct := cancelproposal.NewContract()
+			Expect(ct.CanExec(txns.TxTypeRepoProposalCancel)).To(BeTrue())
+			Expect(ct.CanExec(txns.TxTypeRepoProposalVote)).To(BeFalse())
+		})
+	})
+
+	Describe(".Exec", func() {
+		var repoUpd *state.Repository
+		var repoName = "repo"
+		var propID = "1"
+
+		BeforeEach(func() {
+			logic.AccountKeeper().Update(sender.Addr(), &state.Account{Balance: "10", DelegatorCommission: 0})
+			logic.AccountKeeper().Update(key2.Addr(), &state.Account{Balance: "10", DelegatorCommission: 0})
+			repoUpd = state.BareRepository()
+			repoUpd.Config = state.MakeDefaultRepoConfig()
+		})
+
+		exec := func() {
+			err = cancelproposal.NewContract().Init(logic, &txns.TxRepoProposalCancel{
+				TxCommon:   &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+				RepoName:   repoName,
+				ProposalID: propID,
+			}, 0).Exec()
+		}
+
+		When("the refund type refunds cancelled proposals", func() {
+			BeforeEach(func() {
+				prop := state.BareRepoProposal()
+				prop.Creator = key2.Addr().String()
+				prop.Outcome = state.ProposalOutcomeQueued
+				repoUpd.Config.Gov.PropFeeRefundType = state.ProposalFeeRefundOnAcceptAllReject.Ptr()
+				prop.Fees.Add(sender.Addr().String(), "2")
+				prop.Fees.Add(key2.Addr().String(), "3")
+				repoUpd.Proposals.Add(propID, prop)
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				exec()
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should set the proposal outcome to cancelled", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals.Get(propID).Outcome).To(Equal(state.ProposalOutcomeCancelled))
+				Expect(repo.Proposals.Get(propID).IsFinalized()).To(BeTrue())
+			})
+
+			It("should mark the proposal as closed", func() {
+				closed, err := logic.RepoKeeper().IsProposalClosed(repoName, propID)
+				Expect(err).To(BeNil())
+				Expect(closed).To(BeTrue())
+			})
+
+			It("should deduct network fee and refund deposited fees", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("10.5"))
+				acct = logic.AccountKeeper().Get(key2.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("13"))
+			})
+		})
+
+		When("the refund type does not refund cancelled proposals", func() {
+			BeforeEach(func() {
+				prop := state.BareRepoProposal()
+				prop.Creator = key2.Addr().String()
+				prop.Outcome = state.ProposalOutcomeQueued
+				repoUpd.Config.Gov.PropFeeRefundType = state.ProposalFeeRefundNo.Ptr()
+				prop.Fees.Add(sender.Addr().String(), "2")
+				repoUpd.Proposals.Add(propID, prop)
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				exec()
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should only deduct network fee from sender", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("8.5"))
+			})
+
+			It("should distribute the deposited fees to the repo", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				repoCut := decimal.NewFromFloat(params.TargetRepoProposalFeeSplit).Mul(decimal.NewFromFloat(2))
+				Expect(repo.Balance.Decimal().Equal(repoCut)).To(BeTrue())
+			})
+		})
+	})
+})
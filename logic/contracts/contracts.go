@@ -1,21 +1,29 @@
 package contracts
 
 import (
+	"github.com/make-os/kit/logic/contracts/archiverepo"
+	"github.com/make-os/kit/logic/contracts/cancelproposal"
 	"github.com/make-os/kit/logic/contracts/createrepo"
+	"github.com/make-os/kit/logic/contracts/delegatevote"
+	"github.com/make-os/kit/logic/contracts/deleterepo"
 	"github.com/make-os/kit/logic/contracts/depositproposalfee"
 	"github.com/make-os/kit/logic/contracts/gitpush"
 	"github.com/make-os/kit/logic/contracts/purchaseticket"
 	"github.com/make-os/kit/logic/contracts/registernamespace"
 	"github.com/make-os/kit/logic/contracts/registerpushkey"
 	"github.com/make-os/kit/logic/contracts/registerrepopushkeys"
+	"github.com/make-os/kit/logic/contracts/renamerepo"
 	"github.com/make-os/kit/logic/contracts/setdelcommission"
+	"github.com/make-os/kit/logic/contracts/spendrepo"
 	"github.com/make-os/kit/logic/contracts/transfercoin"
 	"github.com/make-os/kit/logic/contracts/unbondticket"
 	"github.com/make-os/kit/logic/contracts/updatedelpushkey"
+	"github.com/make-os/kit/logic/contracts/updatenamespace"
 	"github.com/make-os/kit/logic/contracts/updatenamespacedomains"
 	"github.com/make-os/kit/logic/contracts/updaterepo"
 	"github.com/make-os/kit/logic/contracts/upsertowner"
 	"github.com/make-os/kit/logic/contracts/voteproposal"
+	"github.com/make-os/kit/logic/contracts/withdrawproposal"
 	"github.com/make-os/kit/types/core"
 )
 
@@ -39,5 +47,13 @@ func init() {
 		upsertowner.NewContract(&SystemContracts),
 		updaterepo.NewContract(&SystemContracts),
 		registerrepopushkeys.NewContract(&SystemContracts),
+		spendrepo.NewContract(&SystemContracts),
+		withdrawproposal.NewContract(),
+		delegatevote.NewContract(),
+		cancelproposal.NewContract(),
+		archiverepo.NewContract(&SystemContracts),
+		deleterepo.NewContract(&SystemContracts),
+		renamerepo.NewContract(&SystemContracts),
+		updatenamespace.NewContract(&SystemContracts),
 	}...)
 }
@@ -0,0 +1,52 @@
+package delegatevote
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/contracts/common"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/txns"
+)
+
+// Contract implements core.SystemContract. It is a system contract for
+// delegating the proposal voting power of a repository owner.
+type Contract struct {
+	core.Keepers
+	tx          *txns.TxRepoVoteDelegate
+	chainHeight uint64
+}
+
+// NewContract creates a new instance of Contract
+func NewContract() *Contract {
+	return &Contract{}
+}
+
+func (c *Contract) CanExec(typ types.TxCode) bool {
+	return typ == txns.TxTypeRepoVoteDelegate
+}
+
+// Init initialize the contract
+func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight uint64) core.SystemContract {
+	c.Keepers = keepers
+	c.tx = tx.(*txns.TxRepoVoteDelegate)
+	c.chainHeight = curChainHeight
+	return c
+}
+
+// Exec executes the contract
+func (c *Contract) Exec() error {
+	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
+
+	// Get the repo and set (or unset) the delegate of the sender
+	repoKeeper := c.RepoKeeper()
+	repo := repoKeeper.Get(c.tx.RepoName)
+	owner := repo.Owners.Get(spk.Addr().String())
+	owner.Delegate = c.tx.Delegate
+
+	// Deduct network fee from sender
+	common.DebitAccount(c, spk, c.tx.Fee.Decimal(), c.chainHeight)
+
+	repoKeeper.Update(c.tx.RepoName, repo)
+
+	return nil
+}
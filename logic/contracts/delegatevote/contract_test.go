@@ -0,0 +1,118 @@
+package delegatevote_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	logic2 "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/contracts/delegatevote"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+func TestDelegateVote(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DelegateVote Suite")
+}
+
+var _ = Describe("Contract", func() {
+	var appDB storagetypes.Engine
+	var stateTreeDB tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var logic *logic2.Logic
+	var ctrl *gomock.Controller
+	var sender = ed25519.NewKeyFromIntSeed(1)
+	var key2 = ed25519.NewKeyFromIntSeed(2)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateTreeDB = testutil.GetDB()
+		logic = logic2.New(appDB, stateTreeDB, cfg)
+		err := logic.SysKeeper().SaveBlockInfo(&state.BlockInfo{Height: 1})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(appDB.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CanExec", func() {
+		It("should return true when able to execute tx type", func() {
+			ct := delegatevote.NewContract()
+			Expect(ct.CanExec(txns.TxTypeRepoVoteDelegate)).To(BeTrue())
+			Expect(ct.CanExec(txns.TxTypeRepoProposalVote)).To(BeFalse())
+		})
+	})
+
+	Describe(".Exec", func() {
+		var repoName = "repo"
+
+		BeforeEach(func() {
+			logic.AccountKeeper().Update(sender.Addr(), &state.Account{Balance: "10", DelegatorCommission: 0})
+			repoUpd := state.BareRepository()
+			repoUpd.Config = state.MakeDefaultRepoConfig()
+			repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+			logic.RepoKeeper().Update(repoName, repoUpd)
+		})
+
+		exec := func(delegate string) {
+			err = delegatevote.NewContract().Init(logic, &txns.TxRepoVoteDelegate{
+				TxCommon: &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+				RepoName: repoName,
+				Delegate: delegate,
+			}, 0).Exec()
+		}
+
+		When("delegate is set", func() {
+			BeforeEach(func() {
+				exec(key2.Addr().String())
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should set the delegate of the sender", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Owners.Get(sender.Addr().String()).Delegate).To(Equal(key2.Addr().String()))
+			})
+
+			It("should deduct network fee from sender", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("8.5"))
+			})
+		})
+
+		When("delegate is empty", func() {
+			BeforeEach(func() {
+				exec(key2.Addr().String())
+				Expect(err).To(BeNil())
+				exec("")
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should remove the delegate of the sender", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Owners.Get(sender.Addr().String()).Delegate).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -94,6 +94,11 @@ func (c *Contract) execReference(repo *state.Repository, repoName string, ref *p
 		}
 	}
 
+	// Set close status for milestone reference
+	if plumbing.IsMilestoneReference(ref.Name) && ref.Data.Close != nil {
+		r.Data.Closed = *ref.Data.Close
+	}
+
 	// For only new merge request reference, call the merge request contract to handle it
 	if plumbing.IsMergeRequestReference(ref.Name) {
 
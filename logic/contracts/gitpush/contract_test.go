@@ -239,6 +239,26 @@ var _ = Describe("Contract", func() {
 			})
 		})
 
+		When("pushed reference is a milestone reference", func() {
+			It("should set close status from pushed reference", func() {
+				ref := plumbing.MakeMilestoneReference(1)
+				cls := true
+				refs = []*types.PushedReference{{
+					Name: ref,
+					Fee:  "1",
+					Data: &remotetypes.ReferenceData{Close: &cls},
+				}}
+				err = gitpush.NewContract().Init(logic, &txns.TxPush{
+					TxCommon: &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey()},
+					Note:     &types.Note{RepoName: repo, References: refs, PushKeyID: rawPkID},
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+				rep := logic.RepoKeeper().Get(repo)
+				Expect(rep.References.Get(ref).Creator).To(Equal(ed25519.PushKey(rawPkID)))
+				Expect(rep.References[ref].Data.Closed).To(BeTrue())
+			})
+		})
+
 		When("pushed reference is a merge request reference", func() {
 			It("should add new proposal", func() {
 				ref := plumbing.MakeMergeRequestReference(1)
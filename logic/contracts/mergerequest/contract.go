@@ -97,6 +97,8 @@ func (c *Contract) Exec() error {
 		// Attempt to apply the proposal action
 		applied, err := proposals.MaybeApplyProposal(&proposals.ApplyProposalArgs{
 			Keepers:     c,
+			RepoName:    c.data.RepoName,
+			ProposalID:  id,
 			Proposal:    proposal,
 			Repo:        c.data.Repo,
 			ChainHeight: c.chainHeight,
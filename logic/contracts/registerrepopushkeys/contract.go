@@ -71,6 +71,8 @@ func (c *Contract) Exec() error {
 	// Attempt to apply the proposal action
 	applied, err := proposals.MaybeApplyProposal(&proposals.ApplyProposalArgs{
 		Keepers:     c,
+		RepoName:    c.tx.RepoName,
+		ProposalID:  c.tx.ID,
 		Proposal:    proposal,
 		Repo:        repo,
 		ChainHeight: c.chainHeight,
@@ -0,0 +1,153 @@
+package renamerepo
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/contracts/common"
+	"github.com/make-os/kit/logic/proposals"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	crypto2 "github.com/make-os/kit/util/crypto"
+	"github.com/pkg/errors"
+)
+
+// Contract implements core.ProposalContract. It is a system contract that
+// creates a proposal to rename a repository and rewire the namespace
+// domains that target it.
+type Contract struct {
+	core.Keepers
+	tx          *txns.TxRepoProposalRename
+	chainHeight uint64
+	contracts   *[]core.SystemContract
+}
+
+// NewContract creates a new instance of Contract
+func NewContract(contracts *[]core.SystemContract) *Contract {
+	return &Contract{contracts: contracts}
+}
+
+func (c *Contract) CanExec(typ types.TxCode) bool {
+	return typ == txns.TxTypeRepoProposalRename
+}
+
+// Init initialize the contract
+func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight uint64) core.SystemContract {
+	c.Keepers = keepers
+	c.tx = tx.(*txns.TxRepoProposalRename)
+	c.chainHeight = curChainHeight
+	return c
+}
+
+// Exec executes the contract
+func (c *Contract) Exec() error {
+
+	// Get the repo
+	repoKeeper := c.RepoKeeper()
+	repo := repoKeeper.Get(c.tx.RepoName)
+
+	// Create a proposal
+	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
+	proposal := proposals.MakeProposal(spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
+	proposal.Action = txns.TxTypeRepoProposalRename
+	proposal.ActionData = map[string]util.Bytes{
+		constants.ActionDataKeyNewName:    util.ToBytes(c.tx.NewName),
+		constants.ActionDataKeyNamespaces: util.ToBytes(c.tx.Namespaces),
+	}
+
+	// Deduct network fee + proposal fee from sender
+	totalFee := c.tx.Fee.Decimal().Add(c.tx.Value.Decimal())
+	common.DebitAccount(c, spk, totalFee, c.chainHeight)
+
+	// Attempt to apply the proposal action.
+	// If applied, the repository is moved to its new name.
+	args := &proposals.ApplyProposalArgs{
+		Keepers:     c,
+		RepoName:    c.tx.RepoName,
+		ProposalID:  c.tx.ID,
+		Proposal:    proposal,
+		Repo:        repo,
+		ChainHeight: c.chainHeight,
+		Contracts:   *c.contracts,
+	}
+	applied, err := proposals.MaybeApplyProposal(args)
+	if err != nil {
+		return errors.Wrap(err, common.ErrFailedToApplyProposal)
+	} else if applied {
+		return proposals.PersistRepo(args, applied)
+	}
+
+	// Index the proposal against its end height so it can be tracked
+	// and finalized at that height.
+	if err = repoKeeper.IndexProposalEnd(c.tx.RepoName, proposal.ID, proposal.EndAt.UInt64()); err != nil {
+		return errors.Wrap(err, common.ErrFailedToIndexProposal)
+	}
+
+	repoKeeper.Update(c.tx.RepoName, repo)
+	return nil
+}
+
+// Apply applies the proposal by recording the current name of the
+// repository as a previous name and pointing the domains and ownership
+// of the given namespaces to the new name.
+//
+// The repository cannot be moved here since the caller persists the
+// repo after applying a proposal; Instead, the repository is moved
+// by proposals.PersistRepo.
+//
+// The new name may have been taken since the proposal was created;
+// If a repository now has the name or the name redirects to another
+// repository, the repository is not renamed and
+// core.ErrProposalActionFailed is returned.
+func (c *Contract) Apply(args *core.ProposalApplyArgs) error {
+
+	// Get the action data
+	ad := args.Proposal.GetActionData()
+	var newName string
+	if err := util.ToObject(ad[constants.ActionDataKeyNewName], &newName); err != nil {
+		return err
+	}
+
+	repoKeeper := args.Keepers.RepoKeeper()
+	if !repoKeeper.GetNoPopulate(newName).IsEmpty() {
+		return errors.Wrap(core.ErrProposalActionFailed, "a repository with the new name already exists")
+	}
+	if target := repoKeeper.GetRedirect(newName); target != "" && target != args.RepoName {
+		return errors.Wrap(core.ErrProposalActionFailed, "the new name redirects to another repository")
+	}
+	var namespaces []string
+	if err := util.ToObject(ad[constants.ActionDataKeyNamespaces], &namespaces); err != nil {
+		return err
+	}
+
+	args.Repo.PrevNames = append(args.Repo.PrevNames, &state.RepoPrevName{
+		Name:   args.RepoName,
+		Height: util.UInt64(args.ChainHeight + 1),
+	})
+
+	// Rewire the namespaces. The namespaces may have changed since the
+	// proposal was created, so only domains still targeting the repo
+	// are updated.
+	nsKeeper := args.Keepers.NamespaceKeeper()
+	oldTarget, newTarget := "r/"+args.RepoName, "r/"+newName
+	for _, name := range namespaces {
+		nsKey := crypto2.MakeNamespaceHash(name)
+		ns := nsKeeper.Get(nsKey)
+		if ns.IsNil() {
+			continue
+		}
+		for domain, target := range ns.Domains {
+			if target == oldTarget {
+				ns.Domains[domain] = newTarget
+			}
+		}
+		if ns.Owner == args.RepoName {
+			ns.Owner = newName
+		}
+		nsKeeper.Update(nsKey, ns)
+	}
+
+	return nil
+}
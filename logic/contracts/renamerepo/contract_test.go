@@ -0,0 +1,262 @@
+package renamerepo_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	logic2 "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/contracts"
+	"github.com/make-os/kit/logic/contracts/renamerepo"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	crypto2 "github.com/make-os/kit/util/crypto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+func TestRenameRepo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RenameRepo Suite")
+}
+
+var _ = Describe("Contract", func() {
+	var appDB storagetypes.Engine
+	var stateTreeDB tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var logic *logic2.Logic
+	var ctrl *gomock.Controller
+	var sender = ed25519.NewKeyFromIntSeed(1)
+	var key2 = ed25519.NewKeyFromIntSeed(2)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateTreeDB = testutil.GetDB()
+		logic = logic2.New(appDB, stateTreeDB, cfg)
+		err := logic.SysKeeper().SaveBlockInfo(&state.BlockInfo{Height: 1})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(appDB.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CanExec", func() {
+		It("should return true when able to execute tx type", func() {
+			ct := renamerepo.NewContract(nil)
+			Expect(ct.CanExec(txns.TxTypeRepoProposalRename)).To(BeTrue())
+			Expect(ct.CanExec(txns.TxTypeHostTicket)).To(BeFalse())
+		})
+	})
+
+	Describe(".Exec", func() {
+		var err error
+		var repoUpd *state.Repository
+		var repoName = "repo"
+		var newName = "repo2"
+		var propID = "1"
+
+		BeforeEach(func() {
+			logic.AccountKeeper().Update(sender.Addr(), &state.Account{
+				Balance:             "10",
+				Stakes:              state.BareAccountStakes(),
+				DelegatorCommission: 10,
+			})
+			repoUpd = state.BareRepository()
+			repoUpd.Config = state.DefaultRepoConfig
+			repoUpd.Config.Gov.Voter = pointer.ToInt(int(state.VoterOwner))
+		})
+
+		When("sender is the only owner", func() {
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = renamerepo.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalRename{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: "1", RepoName: repoName},
+					NewName:          newName,
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the repo was moved to the new name", func() {
+				Expect(logic.RepoKeeper().Get(repoName).IsEmpty()).To(BeTrue())
+				repo := logic.RepoKeeper().Get(newName)
+				Expect(repo.IsEmpty()).To(BeFalse())
+				Expect(repo.Proposals.Get(propID).Outcome).To(Equal(state.ProposalOutcomeAccepted))
+				Expect(repo.PrevNames).To(HaveLen(1))
+				Expect(repo.PrevNames[0].Name).To(Equal(repoName))
+				Expect(repo.PrevNames[0].Height.UInt64()).To(Equal(uint64(1)))
+			})
+
+			Specify("that the old name redirects to the new name", func() {
+				Expect(logic.RepoKeeper().GetRedirect(repoName)).To(Equal(newName))
+			})
+
+			Specify("that network fee + proposal fee was deducted", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("7.5"))
+			})
+		})
+
+		When("sender is not the only owner", func() {
+			curHeight := uint64(0)
+
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				repoUpd.AddOwner(key2.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = renamerepo.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalRename{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: "1", RepoName: repoName},
+					NewName:          newName,
+				}, curHeight).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is not finalized and the repo was not moved", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals.Get(propID).IsFinalized()).To(BeFalse())
+				Expect(logic.RepoKeeper().Get(newName).IsEmpty()).To(BeTrue())
+				Expect(logic.RepoKeeper().GetRedirect(repoName)).To(BeEmpty())
+			})
+
+			Specify("that the proposal was indexed against its end height", func() {
+				res := logic.RepoKeeper().GetProposalsEndingAt(util.PtrStrToUInt64(repoUpd.Config.Gov.PropDuration) + curHeight + 1)
+				Expect(res).To(HaveLen(1))
+			})
+
+			When("the proposal is accepted when it ends", func() {
+				BeforeEach(func() {
+					repo := logic.RepoKeeper().Get(repoName)
+					repo.Proposals.Get(propID).Yes = 2
+					logic.RepoKeeper().Update(repoName, repo)
+					endAt := repo.Proposals.Get(propID).EndAt.UInt64()
+					err = logic.ApplyProposals(&state.BlockInfo{Height: util.Int64(endAt)})
+					Expect(err).To(BeNil())
+				})
+
+				Specify("that the repo was moved to the new name", func() {
+					Expect(logic.RepoKeeper().Get(repoName).IsEmpty()).To(BeTrue())
+					repo := logic.RepoKeeper().Get(newName)
+					Expect(repo.Proposals.Get(propID).IsAccepted()).To(BeTrue())
+				})
+			})
+
+			When("a repository took the new name before the proposal ended", func() {
+				BeforeEach(func() {
+					other := state.BareRepository()
+					other.Description = "other"
+					logic.RepoKeeper().Update(newName, other)
+
+					repo := logic.RepoKeeper().Get(repoName)
+					repo.Proposals.Get(propID).Yes = 2
+					logic.RepoKeeper().Update(repoName, repo)
+					endAt := repo.Proposals.Get(propID).EndAt.UInt64()
+					err = logic.ApplyProposals(&state.BlockInfo{Height: util.Int64(endAt)})
+					Expect(err).To(BeNil())
+				})
+
+				Specify("that the repo was not moved and the proposal failed", func() {
+					repo := logic.RepoKeeper().Get(repoName)
+					Expect(repo.IsEmpty()).To(BeFalse())
+					Expect(repo.PrevNames).To(BeEmpty())
+					Expect(repo.Proposals.Get(propID).Outcome).To(Equal(state.ProposalOutcomeFailed))
+					Expect(logic.RepoKeeper().GetRedirect(repoName)).To(BeEmpty())
+				})
+
+				Specify("that the other repo was not replaced", func() {
+					Expect(logic.RepoKeeper().Get(newName).Description).To(Equal("other"))
+				})
+			})
+		})
+	})
+
+	Describe(".Apply", func() {
+		var repoUpd *state.Repository
+		var nsKey = crypto2.MakeNamespaceHash("ns1")
+
+		apply := func() error {
+			return renamerepo.NewContract(nil).Apply(&core.ProposalApplyArgs{
+				RepoName: "repo",
+				Proposal: &state.RepoProposal{ActionData: map[string]util.Bytes{
+					constants.ActionDataKeyNewName:    util.ToBytes("repo2"),
+					constants.ActionDataKeyNamespaces: util.ToBytes([]string{"ns1", "unknown"}),
+				}},
+				Repo:        repoUpd,
+				Keepers:     logic,
+				ChainHeight: 10,
+			})
+		}
+
+		BeforeEach(func() {
+			repoUpd = state.BareRepository()
+			logic.NamespaceKeeper().Update(nsKey, &state.Namespace{
+				Owner:   "repo",
+				Domains: map[string]string{"app": "r/repo", "other": "r/repo3"},
+			})
+		})
+
+		It("should record the old name of the repo", func() {
+			Expect(apply()).To(BeNil())
+			Expect(repoUpd.PrevNames).To(HaveLen(1))
+			Expect(repoUpd.PrevNames[0].Name).To(Equal("repo"))
+			Expect(repoUpd.PrevNames[0].Height.UInt64()).To(Equal(uint64(11)))
+		})
+
+		It("should rewire namespace domains targeting the repo", func() {
+			Expect(apply()).To(BeNil())
+			ns := logic.NamespaceKeeper().Get(nsKey)
+			Expect(ns.Domains["app"]).To(Equal("r/repo2"))
+			Expect(ns.Domains["other"]).To(Equal("r/repo3"))
+		})
+
+		It("should transfer namespace ownership to the new name", func() {
+			Expect(apply()).To(BeNil())
+			ns := logic.NamespaceKeeper().Get(nsKey)
+			Expect(ns.Owner).To(Equal("repo2"))
+		})
+
+		It("should return ErrProposalActionFailed and change nothing when a repo has the new name", func() {
+			other := state.BareRepository()
+			other.Description = "other"
+			logic.RepoKeeper().Update("repo2", other)
+			err = apply()
+			Expect(errors.Cause(err)).To(Equal(core.ErrProposalActionFailed))
+			Expect(repoUpd.PrevNames).To(BeEmpty())
+			ns := logic.NamespaceKeeper().Get(nsKey)
+			Expect(ns.Domains["app"]).To(Equal("r/repo"))
+			Expect(ns.Owner).To(Equal("repo"))
+		})
+
+		It("should return ErrProposalActionFailed when the new name redirects to another repo", func() {
+			Expect(logic.RepoKeeper().SetRedirect("repo2", "repo3", 1000)).To(BeNil())
+			err = apply()
+			Expect(errors.Cause(err)).To(Equal(core.ErrProposalActionFailed))
+			Expect(repoUpd.PrevNames).To(BeEmpty())
+		})
+
+		It("should succeed when the new name redirects to the repo", func() {
+			Expect(logic.RepoKeeper().SetRedirect("repo2", "repo", 1000)).To(BeNil())
+			Expect(apply()).To(BeNil())
+			Expect(repoUpd.PrevNames).To(HaveLen(1))
+		})
+	})
+})
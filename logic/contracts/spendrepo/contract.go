@@ -0,0 +1,124 @@
+package spendrepo
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/contracts/common"
+	"github.com/make-os/kit/logic/proposals"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/identifier"
+	"github.com/pkg/errors"
+)
+
+// Contract implements core.ProposalContract. It is a system contract that
+// creates a proposal to transfer coins from a repository's balance to an address.
+type Contract struct {
+	core.Keepers
+	tx          *txns.TxRepoProposalSpend
+	chainHeight uint64
+	contracts   *[]core.SystemContract
+}
+
+// NewContract creates a new instance of Contract
+func NewContract(contracts *[]core.SystemContract) *Contract {
+	return &Contract{contracts: contracts}
+}
+
+func (c *Contract) CanExec(typ types.TxCode) bool {
+	return typ == txns.TxTypeRepoProposalSpend
+}
+
+// Init initialize the contract
+func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight uint64) core.SystemContract {
+	c.Keepers = keepers
+	c.tx = tx.(*txns.TxRepoProposalSpend)
+	c.chainHeight = curChainHeight
+	return c
+}
+
+// Exec executes the contract
+func (c *Contract) Exec() error {
+
+	// Get the repo
+	repoKeeper := c.RepoKeeper()
+	repo := repoKeeper.Get(c.tx.RepoName)
+
+	// Create a proposal
+	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
+	proposal := proposals.MakeProposal(spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
+	proposal.Action = txns.TxTypeRepoProposalSpend
+	proposal.ActionData = map[string]util.Bytes{
+		constants.ActionDataKeyRecipient: util.ToBytes(c.tx.To),
+		constants.ActionDataKeyAmount:    util.ToBytes(c.tx.Amount),
+	}
+
+	// Deduct network fee + proposal fee from sender
+	totalFee := c.tx.Fee.Decimal().Add(c.tx.Value.Decimal())
+	common.DebitAccount(c, spk, totalFee, c.chainHeight)
+
+	// Attempt to apply the proposal action
+	applied, err := proposals.MaybeApplyProposal(&proposals.ApplyProposalArgs{
+		Keepers:     c,
+		RepoName:    c.tx.RepoName,
+		ProposalID:  c.tx.ID,
+		Proposal:    proposal,
+		Repo:        repo,
+		ChainHeight: c.chainHeight,
+		Contracts:   *c.contracts,
+	})
+	if err != nil {
+		return errors.Wrap(err, common.ErrFailedToApplyProposal)
+	} else if applied {
+		goto update
+	}
+
+	// Index the proposal against its end height so it can be tracked
+	// and finalized at that height.
+	if err = repoKeeper.IndexProposalEnd(c.tx.RepoName, proposal.ID, proposal.EndAt.UInt64()); err != nil {
+		return errors.Wrap(err, common.ErrFailedToIndexProposal)
+	}
+
+update:
+	repoKeeper.Update(c.tx.RepoName, repo)
+	return nil
+}
+
+// Apply applies the proposal by moving the amount from the repo's
+// balance to the recipient's account.
+//
+// The repo balance may have changed since the proposal was created;
+// If it can no longer cover the amount, no transfer is made and
+// core.ErrProposalActionFailed is returned.
+func (c *Contract) Apply(args *core.ProposalApplyArgs) error {
+
+	// Get the action data
+	ad := args.Proposal.GetActionData()
+	var to string
+	if err := util.ToObject(ad[constants.ActionDataKeyRecipient], &to); err != nil {
+		return err
+	}
+	var amount util.String
+	if err := util.ToObject(ad[constants.ActionDataKeyAmount], &amount); err != nil {
+		return err
+	}
+
+	repoBal := args.Repo.Balance.Decimal()
+	if repoBal.LessThan(amount.Decimal()) {
+		return errors.Wrap(core.ErrProposalActionFailed, "insufficient repo balance")
+	}
+
+	// Debit the repo. The caller is responsible for persisting the repo.
+	args.Repo.SetBalance(repoBal.Sub(amount.Decimal()).String())
+
+	// Credit the recipient
+	acctKeeper := args.Keepers.AccountKeeper()
+	recipient := acctKeeper.Get(identifier.Address(to))
+	recipient.Balance = util.String(recipient.Balance.Decimal().Add(amount.Decimal()).String())
+	recipient.Clean(args.ChainHeight)
+	acctKeeper.Update(identifier.Address(to), recipient)
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package spendrepo_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	logic2 "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/contracts"
+	"github.com/make-os/kit/logic/contracts/spendrepo"
+	"github.com/make-os/kit/params"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+func TestSpendRepo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SpendRepo Suite")
+}
+
+var _ = Describe("Contract", func() {
+	var appDB storagetypes.Engine
+	var stateTreeDB tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var logic *logic2.Logic
+	var ctrl *gomock.Controller
+	var sender = ed25519.NewKeyFromIntSeed(1)
+	var key2 = ed25519.NewKeyFromIntSeed(2)
+	var recipient = ed25519.NewKeyFromIntSeed(3)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateTreeDB = testutil.GetDB()
+		logic = logic2.New(appDB, stateTreeDB, cfg)
+		err := logic.SysKeeper().SaveBlockInfo(&state.BlockInfo{Height: 1})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(appDB.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CanExec", func() {
+		It("should return true when able to execute tx type", func() {
+			ct := spendrepo.NewContract(nil)
+			Expect(ct.CanExec(txns.TxTypeRepoProposalSpend)).To(BeTrue())
+			Expect(ct.CanExec(txns.TxTypeHostTicket)).To(BeFalse())
+		})
+	})
+
+	Describe(".Exec", func() {
+		var err error
+		var repoUpd *state.Repository
+		var repoName = "repo"
+		var propID = "1"
+		var proposalFee = util.String("1")
+
+		BeforeEach(func() {
+			logic.AccountKeeper().Update(sender.Addr(), &state.Account{
+				Balance:             "10",
+				Stakes:              state.BareAccountStakes(),
+				DelegatorCommission: 10,
+			})
+			repoUpd = state.BareRepository()
+			repoUpd.Balance = "100"
+			repoUpd.Config = state.DefaultRepoConfig
+			repoUpd.Config.Gov.Voter = pointer.ToInt(int(state.VoterOwner))
+		})
+
+		When("sender is the only owner", func() {
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = spendrepo.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalSpend{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: proposalFee, RepoName: repoName},
+					To:               recipient.Addr().String(),
+					Amount:           "30",
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is finalized and self accepted", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals).To(HaveLen(1))
+				Expect(repo.Proposals.Get(propID).IsFinalized()).To(BeTrue())
+				Expect(repo.Proposals.Get(propID).Outcome).To(Equal(state.ProposalOutcomeAccepted))
+			})
+
+			Specify("that the amount was moved from the repo to the recipient", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				repoCut := decimal.NewFromFloat(params.TargetRepoProposalFeeSplit).Mul(proposalFee.Decimal())
+				Expect(repo.Balance.Decimal().Equal(decimal.NewFromFloat(70).Add(repoCut))).To(BeTrue())
+				acct := logic.AccountKeeper().Get(recipient.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("30"))
+			})
+
+			Specify("that network fee + proposal fee was deducted", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("7.5"))
+			})
+		})
+
+		When("sender is the only owner and the repo balance does not cover the amount", func() {
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = spendrepo.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalSpend{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: proposalFee, RepoName: repoName},
+					To:               recipient.Addr().String(),
+					Amount:           "300",
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is finalized with a failed outcome", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals.Get(propID).IsFinalized()).To(BeTrue())
+				Expect(repo.Proposals.Get(propID).Outcome).To(Equal(state.ProposalOutcomeFailed))
+			})
+
+			Specify("that no coin was moved from the repo to the recipient", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				repoCut := decimal.NewFromFloat(params.TargetRepoProposalFeeSplit).Mul(proposalFee.Decimal())
+				Expect(repo.Balance.Decimal().Equal(decimal.NewFromFloat(100).Add(repoCut))).To(BeTrue())
+				acct := logic.AccountKeeper().Get(recipient.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("0"))
+			})
+		})
+
+		When("sender is not the only owner", func() {
+			curHeight := uint64(0)
+
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				repoUpd.AddOwner(key2.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = spendrepo.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalSpend{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: proposalFee, RepoName: repoName},
+					To:               recipient.Addr().String(),
+					Amount:           "30",
+				}, curHeight).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is not finalized", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals).To(HaveLen(1))
+				Expect(repo.Proposals.Get(propID).IsFinalized()).To(BeFalse())
+			})
+
+			Specify("that the repo balance and recipient account are unchanged", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Balance.String()).To(Equal("100"))
+				acct := logic.AccountKeeper().Get(recipient.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("0"))
+			})
+
+			Specify("that the proposal was indexed against its end height", func() {
+				res := logic.RepoKeeper().GetProposalsEndingAt(util.PtrStrToUInt64(repoUpd.Config.Gov.PropDuration) + curHeight + 1)
+				Expect(res).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe(".Apply", func() {
+		var repoUpd *state.Repository
+		var proposal *state.RepoProposal
+
+		BeforeEach(func() {
+			repoUpd = state.BareRepository()
+			repoUpd.Config = state.DefaultRepoConfig
+			proposal = &state.RepoProposal{ActionData: map[string]util.Bytes{
+				constants.ActionDataKeyRecipient: util.ToBytes(recipient.Addr().String()),
+				constants.ActionDataKeyAmount:    util.ToBytes(util.String("40")),
+			}}
+		})
+
+		When("the repo balance covers the amount", func() {
+			BeforeEach(func() {
+				repoUpd.Balance = "100"
+				err = spendrepo.NewContract(nil).Apply(&core.ProposalApplyArgs{
+					Proposal:    proposal,
+					Repo:        repoUpd,
+					Keepers:     logic,
+					ChainHeight: 0,
+				})
+			})
+
+			It("should return no err", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should debit the repo and credit the recipient", func() {
+				Expect(repoUpd.Balance.String()).To(Equal("60"))
+				acct := logic.AccountKeeper().Get(recipient.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("40"))
+			})
+		})
+
+		When("the repo balance no longer covers the amount", func() {
+			BeforeEach(func() {
+				repoUpd.Balance = "10"
+				err = spendrepo.NewContract(nil).Apply(&core.ProposalApplyArgs{
+					Proposal:    proposal,
+					Repo:        repoUpd,
+					Keepers:     logic,
+					ChainHeight: 0,
+				})
+			})
+
+			It("should return ErrProposalActionFailed", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Cause(err)).To(Equal(core.ErrProposalActionFailed))
+			})
+
+			It("should not transfer any coin", func() {
+				Expect(repoUpd.Balance.String()).To(Equal("10"))
+				acct := logic.AccountKeeper().Get(recipient.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("0"))
+			})
+		})
+	})
+})
@@ -0,0 +1,137 @@
+package updatenamespace
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/contracts/common"
+	"github.com/make-os/kit/logic/proposals"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	crypto2 "github.com/make-os/kit/util/crypto"
+	"github.com/pkg/errors"
+)
+
+// Contract implements core.ProposalContract. It is a system contract that
+// creates a proposal to update the domains and contributors of a namespace
+// owned by a repository. This allows the owners of the repository to govern
+// the namespace using the repository's governance config.
+type Contract struct {
+	core.Keepers
+	tx          *txns.TxRepoProposalUpdateNamespace
+	chainHeight uint64
+	contracts   *[]core.SystemContract
+}
+
+// NewContract creates a new instance of Contract
+func NewContract(contracts *[]core.SystemContract) *Contract {
+	return &Contract{contracts: contracts}
+}
+
+func (c *Contract) CanExec(typ types.TxCode) bool {
+	return typ == txns.TxTypeRepoProposalUpdateNamespace
+}
+
+// Init initialize the contract
+func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight uint64) core.SystemContract {
+	c.Keepers = keepers
+	c.tx = tx.(*txns.TxRepoProposalUpdateNamespace)
+	c.chainHeight = curChainHeight
+	return c
+}
+
+// Exec executes the contract
+func (c *Contract) Exec() error {
+
+	// Get the repo
+	repoKeeper := c.RepoKeeper()
+	repo := repoKeeper.Get(c.tx.RepoName)
+
+	// Create a proposal
+	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
+	proposal := proposals.MakeProposal(spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
+	proposal.Action = txns.TxTypeRepoProposalUpdateNamespace
+	proposal.ActionData = map[string]util.Bytes{
+		constants.ActionDataKeyNamespace: util.ToBytes(c.tx.Namespace),
+		constants.ActionDataKeyDomains:   util.ToBytes(c.tx.Domains),
+		constants.ActionDataKeyIDs:       util.ToBytes(c.tx.RemoveContributors),
+	}
+
+	// Deduct network fee + proposal fee from sender
+	totalFee := c.tx.Fee.Decimal().Add(c.tx.Value.Decimal())
+	common.DebitAccount(c, spk, totalFee, c.chainHeight)
+
+	// Attempt to apply the proposal action
+	applied, err := proposals.MaybeApplyProposal(&proposals.ApplyProposalArgs{
+		Keepers:     c,
+		RepoName:    c.tx.RepoName,
+		ProposalID:  c.tx.ID,
+		Proposal:    proposal,
+		Repo:        repo,
+		ChainHeight: c.chainHeight,
+		Contracts:   *c.contracts,
+	})
+	if err != nil {
+		return errors.Wrap(err, common.ErrFailedToApplyProposal)
+	} else if applied {
+		goto update
+	}
+
+	// Index the proposal against its end height so it can be tracked
+	// and finalized at that height.
+	if err = repoKeeper.IndexProposalEnd(c.tx.RepoName, proposal.ID, proposal.EndAt.UInt64()); err != nil {
+		return errors.Wrap(err, common.ErrFailedToIndexProposal)
+	}
+
+update:
+	repoKeeper.Update(c.tx.RepoName, repo)
+	return nil
+}
+
+// Apply applies the proposal by updating the domains of the namespace and
+// removing the given contributors from it. A domain with an empty target
+// is removed.
+//
+// The namespace may have changed owner since the proposal was created
+// (e.g. it expired and was registered by someone else); If the repository
+// no longer owns the namespace, the namespace is not updated.
+func (c *Contract) Apply(args *core.ProposalApplyArgs) error {
+
+	// Get the action data
+	ad := args.Proposal.GetActionData()
+	var name string
+	if err := util.ToObject(ad[constants.ActionDataKeyNamespace], &name); err != nil {
+		return err
+	}
+	var domains map[string]string
+	if err := util.ToObject(ad[constants.ActionDataKeyDomains], &domains); err != nil {
+		return err
+	}
+	var removeContributors []string
+	if err := util.ToObject(ad[constants.ActionDataKeyIDs], &removeContributors); err != nil {
+		return err
+	}
+
+	nsKeeper := args.Keepers.NamespaceKeeper()
+	nsKey := crypto2.MakeNamespaceHash(name)
+	ns := nsKeeper.Get(nsKey)
+	if ns.Owner != args.RepoName {
+		return nil
+	}
+
+	for domain, target := range domains {
+		if target == "" {
+			delete(ns.Domains, domain)
+			continue
+		}
+		ns.Domains[domain] = target
+	}
+
+	for _, pushKeyID := range removeContributors {
+		delete(ns.Contributors, pushKeyID)
+	}
+
+	nsKeeper.Update(nsKey, ns)
+	return nil
+}
@@ -0,0 +1,210 @@
+package updatenamespace_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	logic2 "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/contracts"
+	"github.com/make-os/kit/logic/contracts/updatenamespace"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	crypto2 "github.com/make-os/kit/util/crypto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+func TestUpdateNamespace(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "UpdateNamespace Suite")
+}
+
+var _ = Describe("Contract", func() {
+	var appDB storagetypes.Engine
+	var stateTreeDB tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var logic *logic2.Logic
+	var ctrl *gomock.Controller
+	var sender = ed25519.NewKeyFromIntSeed(1)
+	var key2 = ed25519.NewKeyFromIntSeed(2)
+	var nsKey = crypto2.MakeNamespaceHash("ns1")
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateTreeDB = testutil.GetDB()
+		logic = logic2.New(appDB, stateTreeDB, cfg)
+		err := logic.SysKeeper().SaveBlockInfo(&state.BlockInfo{Height: 1})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(appDB.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CanExec", func() {
+		It("should return true when able to execute tx type", func() {
+			ct := updatenamespace.NewContract(nil)
+			Expect(ct.CanExec(txns.TxTypeRepoProposalUpdateNamespace)).To(BeTrue())
+			Expect(ct.CanExec(txns.TxTypeNamespaceDomainUpdate)).To(BeFalse())
+		})
+	})
+
+	Describe(".Exec", func() {
+		var err error
+		var repoUpd *state.Repository
+		var repoName = "repo"
+		var propID = "1"
+
+		BeforeEach(func() {
+			logic.AccountKeeper().Update(sender.Addr(), &state.Account{
+				Balance:             "10",
+				Stakes:              state.BareAccountStakes(),
+				DelegatorCommission: 10,
+			})
+			repoUpd = state.BareRepository()
+			repoUpd.Config = state.DefaultRepoConfig
+			repoUpd.Config.Gov.Voter = pointer.ToInt(int(state.VoterOwner))
+			logic.NamespaceKeeper().Update(nsKey, &state.Namespace{
+				Owner:   repoName,
+				Domains: map[string]string{"app": "r/repo"},
+			})
+		})
+
+		When("sender is the only owner", func() {
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = updatenamespace.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalUpdateNamespace{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: "1", RepoName: repoName},
+					Namespace:        "ns1",
+					Domains:          map[string]string{"web": "r/repo2"},
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is finalized and self accepted", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals).To(HaveLen(1))
+				Expect(repo.Proposals.Get(propID).Outcome).To(Equal(state.ProposalOutcomeAccepted))
+			})
+
+			Specify("that the namespace was updated", func() {
+				ns := logic.NamespaceKeeper().Get(nsKey)
+				Expect(ns.Domains).To(Equal(state.NamespaceDomains{"app": "r/repo", "web": "r/repo2"}))
+			})
+
+			Specify("that network fee + proposal fee was deducted", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr(), 0)
+				Expect(acct.Balance.String()).To(Equal("7.5"))
+			})
+		})
+
+		When("sender is not the only owner", func() {
+			curHeight := uint64(0)
+
+			BeforeEach(func() {
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				repoUpd.AddOwner(key2.Addr().String(), &state.RepoOwner{})
+				logic.RepoKeeper().Update(repoName, repoUpd)
+				err = updatenamespace.NewContract(&contracts.SystemContracts).Init(logic, &txns.TxRepoProposalUpdateNamespace{
+					TxCommon:         &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					TxProposalCommon: &txns.TxProposalCommon{ID: propID, Value: "1", RepoName: repoName},
+					Namespace:        "ns1",
+					Domains:          map[string]string{"web": "r/repo2"},
+				}, curHeight).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that the proposal is not finalized and the namespace was not updated", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals.Get(propID).IsFinalized()).To(BeFalse())
+				ns := logic.NamespaceKeeper().Get(nsKey)
+				Expect(ns.Domains).To(Equal(state.NamespaceDomains{"app": "r/repo"}))
+			})
+
+			Specify("that the proposal was indexed against its end height", func() {
+				res := logic.RepoKeeper().GetProposalsEndingAt(util.PtrStrToUInt64(repoUpd.Config.Gov.PropDuration) + curHeight + 1)
+				Expect(res).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe(".Apply", func() {
+		var proposal *state.RepoProposal
+
+		BeforeEach(func() {
+			proposal = &state.RepoProposal{ActionData: map[string]util.Bytes{
+				constants.ActionDataKeyNamespace: util.ToBytes("ns1"),
+				constants.ActionDataKeyDomains:   util.ToBytes(map[string]string{"app": "", "web": "r/repo2"}),
+				constants.ActionDataKeyIDs:       util.ToBytes([]string{"pk1"}),
+			}}
+		})
+
+		When("the namespace is owned by the repo", func() {
+			BeforeEach(func() {
+				logic.NamespaceKeeper().Update(nsKey, &state.Namespace{
+					Owner:        "repo",
+					Domains:      map[string]string{"app": "r/repo", "docs": "r/repo3"},
+					Contributors: map[string]*state.BaseContributor{"pk1": {}, "pk2": {}},
+				})
+				err = updatenamespace.NewContract(nil).Apply(&core.ProposalApplyArgs{
+					RepoName: "repo",
+					Proposal: proposal,
+					Repo:     state.BareRepository(),
+					Keepers:  logic,
+				})
+				Expect(err).To(BeNil())
+			})
+
+			It("should add, update and remove domains", func() {
+				ns := logic.NamespaceKeeper().Get(nsKey)
+				Expect(ns.Domains).To(Equal(state.NamespaceDomains{"docs": "r/repo3", "web": "r/repo2"}))
+			})
+
+			It("should remove the given contributors", func() {
+				ns := logic.NamespaceKeeper().Get(nsKey)
+				Expect(ns.Contributors).To(HaveLen(1))
+				Expect(ns.Contributors).To(HaveKey("pk2"))
+			})
+		})
+
+		When("the namespace is no longer owned by the repo", func() {
+			BeforeEach(func() {
+				logic.NamespaceKeeper().Update(nsKey, &state.Namespace{
+					Owner:   "addr",
+					Domains: map[string]string{"app": "r/repo"},
+				})
+				err = updatenamespace.NewContract(nil).Apply(&core.ProposalApplyArgs{
+					RepoName: "repo",
+					Proposal: proposal,
+					Repo:     state.BareRepository(),
+					Keepers:  logic,
+				})
+				Expect(err).To(BeNil())
+			})
+
+			It("should not update the namespace", func() {
+				ns := logic.NamespaceKeeper().Get(nsKey)
+				Expect(ns.Domains).To(Equal(state.NamespaceDomains{"app": "r/repo"}))
+			})
+		})
+	})
+})
@@ -62,6 +62,8 @@ func (c *Contract) Exec() error {
 	// Attempt to apply the proposal action
 	applied, err := common2.MaybeApplyProposal(&common2.ApplyProposalArgs{
 		Keepers:     c,
+		RepoName:    c.tx.RepoName,
+		ProposalID:  c.tx.ID,
 		Proposal:    proposal,
 		Repo:        repo,
 		ChainHeight: c.chainHeight,
@@ -1,6 +1,8 @@
 package voteproposal
 
 import (
+	"math"
+
 	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/logic/contracts/common"
 	"github.com/make-os/kit/types"
@@ -39,19 +41,36 @@ func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight ui
 // Exec executes the contract
 func (c *Contract) Exec() error {
 
-	var err error
 	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
 
 	// Get the repo
 	repoKeeper := c.RepoKeeper()
 	repo := repoKeeper.Get(c.tx.RepoName)
 	prop := repo.Proposals.Get(c.tx.ProposalID)
+	senderAddr := spk.Addr().String()
+	isVetoOwnersVoter := *prop.Config.Voter == *state.VoterNetStakersAndVetoOwner.Ptr()
+	voterOwnerObj := repo.Owners.Get(senderAddr)
+
+	// If the sender has voted before, remove their previous vote from
+	// the tally so that the new vote replaces it.
+	prevVote, prevPower, hasVoted, err := repoKeeper.GetProposalVote(c.tx.RepoName, c.tx.ProposalID, senderAddr)
+	if err != nil {
+		return errors.Wrap(err, "failed to get previous vote of sender")
+	}
+	if hasVoted {
+		prop.AddVote(prevVote, -prevPower)
+		if prevVote == state.ProposalVoteNoWithVeto && isVetoOwnersVoter &&
+			voterOwnerObj != nil && voterOwnerObj.Veto && prop.NoWithVetoByOwners > 0 {
+			prop.NoWithVetoByOwners--
+		}
+	}
 
 	increments := float64(0)
 
 	// When proposers are the owners, and tally method is ProposalTallyMethodIdentity
-	// each proposer will have 1 voting power.
-	if *prop.Config.Voter == *state.VoterOwner.Ptr() &&
+	// each proposer will have 1 voting power. Non-owners (e.g vote delegates)
+	// have no voting power of their own.
+	if *prop.Config.Voter == *state.VoterOwner.Ptr() && voterOwnerObj != nil &&
 		*prop.Config.PropTallyMethod == *state.ProposalTallyMethodIdentity.Ptr() {
 		increments = 1
 	}
@@ -59,12 +78,32 @@ func (c *Contract) Exec() error {
 	// When proposers are the owners, and tally method is ProposalTallyMethodCoinWeighted
 	// each proposer will use the value of the voter's spendable account balance
 	// as their voting power.
-	if *prop.Config.Voter == *state.VoterOwner.Ptr() &&
+	if *prop.Config.Voter == *state.VoterOwner.Ptr() && voterOwnerObj != nil &&
 		*prop.Config.PropTallyMethod == *state.ProposalTallyMethodCoinWeighted.Ptr() {
 		senderAcct := c.AccountKeeper().Get(spk.Addr())
 		increments = senderAcct.GetAvailableBalance(c.chainHeight).Float()
 	}
 
+	// When proposers are the owners, and tally method is ProposalTallyMethodQuadraticCoinWeighted
+	// each proposer will use the square root of the voter's spendable account
+	// balance as their voting power.
+	if *prop.Config.Voter == *state.VoterOwner.Ptr() && voterOwnerObj != nil &&
+		*prop.Config.PropTallyMethod == *state.ProposalTallyMethodQuadraticCoinWeighted.Ptr() {
+		senderAcct := c.AccountKeeper().Get(spk.Addr())
+		increments = math.Sqrt(senderAcct.GetAvailableBalance(c.chainHeight).Float())
+	}
+
+	// For quadratic network staked-weighted votes, use the square root of the
+	// total value of coins directly staked by the voter as their vote power
+	if *prop.Config.PropTallyMethod == *state.ProposalTallyMethodQuadraticNetStake.Ptr() {
+		stake, err := c.GetTicketManager().
+			ValueOfNonDelegatedTickets(c.tx.SenderPubKey.ToBytes32(), prop.PowerAge.UInt64())
+		if err != nil {
+			return errors.Wrap(err, "failed to get value of non-delegated tickets of sender")
+		}
+		increments = math.Sqrt(stake)
+	}
+
 	// For network staked-weighted votes, use the total value of coins directly
 	// staked by the voter as their vote power
 	if *prop.Config.PropTallyMethod == *state.ProposalTallyMethodNetStakeNonDelegated.Ptr() {
@@ -104,7 +143,7 @@ func (c *Contract) Exec() error {
 			proposerPK := ticket.ProposerPubKey
 
 			// Count the ticket if it is not delegated or the delegator is also the voter
-			if ticket.Delegator == "" || (ticket.Delegator == spk.Addr().String() &&
+			if ticket.Delegator == "" || (ticket.Delegator == senderAddr &&
 				proposerPK.Equal(c.tx.SenderPubKey.ToBytes32())) {
 				sumValue = sumValue.Add(ticket.Value.Decimal())
 				continue
@@ -113,8 +152,8 @@ func (c *Contract) Exec() error {
 			// For tickets not delegated by the voter, determine whether the
 			// delegator has used their ticket to vote on this same proposal.
 			// If yes, we will not count it.
-			if ticket.Delegator != spk.Addr().String() {
-				_, voted, err := repoKeeper.GetProposalVote(c.tx.RepoName, c.tx.ProposalID, ticket.Delegator)
+			if ticket.Delegator != senderAddr {
+				_, _, voted, err := repoKeeper.GetProposalVote(c.tx.RepoName, c.tx.ProposalID, ticket.Delegator)
 				if err != nil {
 					return errors.Wrap(err, "failed to check ticket's delegator vote status")
 				}
@@ -126,38 +165,28 @@ func (c *Contract) Exec() error {
 
 			// For tickets delegated by the voter to a different user,
 			// determine if ticket proposer has voted in this same proposal.
-			// If yes, deduct the vote and apply to the delegator's choice vote option
-			if ticket.Delegator == spk.Addr().String() {
+			// If yes, deduct the vote and apply to the delegator's choice vote option.
+			// If the voter has voted before, the deduction was already made.
+			if ticket.Delegator == senderAddr {
 				proposerAddr := ed25519.MustPubKeyFromBytes(proposerPK.Bytes()).Addr().String()
-				vote, voted, err := repoKeeper.GetProposalVote(c.tx.RepoName, c.tx.ProposalID, proposerAddr)
+				vote, power, voted, err := repoKeeper.GetProposalVote(c.tx.RepoName, c.tx.ProposalID, proposerAddr)
 				if err != nil {
 					return errors.Wrap(err, "failed to check ticket's proposer vote status")
 				}
-				if !voted {
+				if !voted || hasVoted {
 					sumValue = sumValue.Add(ticket.Value.Decimal())
 					continue
 				}
 
-				switch vote {
-				case state.ProposalVoteYes:
-					newYes := decimal.NewFromFloat(prop.Yes)
-					newYes = newYes.Sub(ticket.Value.Decimal())
-					prop.Yes, _ = newYes.Float64()
-
-				case state.ProposalVoteNo:
-					newNo := decimal.NewFromFloat(prop.No)
-					newNo = newNo.Sub(ticket.Value.Decimal())
-					prop.Yes, _ = newNo.Float64()
-
-				case state.ProposalVoteNoWithVeto:
-					newNoWithVeto := decimal.NewFromFloat(prop.NoWithVeto)
-					newNoWithVeto = newNoWithVeto.Sub(ticket.Value.Decimal())
-					prop.NoWithVeto, _ = newNoWithVeto.Float64()
-
-				case state.ProposalVoteAbstain:
-					newAbstain := decimal.NewFromFloat(prop.Abstain)
-					newAbstain = newAbstain.Sub(ticket.Value.Decimal())
-					prop.Abstain, _ = newAbstain.Float64()
+				ticketValue, _ := ticket.Value.Decimal().Float64()
+				prop.AddVote(vote, -ticketValue)
+
+				// Reduce the indexed power of the proposer so that a change
+				// of vote by the proposer removes only what remains of it.
+				newPower, _ := decimal.NewFromFloat(power).Sub(ticket.Value.Decimal()).Float64()
+				if err = repoKeeper.IndexProposalVote(c.tx.RepoName, c.tx.ProposalID,
+					proposerAddr, vote, newPower); err != nil {
+					return errors.Wrap(err, "failed to update ticket's proposer vote")
 				}
 
 				sumValue = sumValue.Add(ticket.Value.Decimal())
@@ -167,23 +196,19 @@ func (c *Contract) Exec() error {
 		increments, _ = sumValue.Float64()
 	}
 
-	switch c.tx.Vote {
-	case state.ProposalVoteYes:
-		prop.Yes += increments
-	case state.ProposalVoteNo:
-		prop.No += increments
-	case state.ProposalVoteAbstain:
-		prop.Abstain += increments
-	case state.ProposalVoteNoWithVeto:
-		prop.NoWithVeto += increments
-
-		// Also, if the proposer type for the proposal is stakeholders and veto
-		// owners and voter is an owner, increment NoWithVetoByOwners by 1
-		voterOwnerObj := repo.Owners.Get(spk.Addr().String())
-		isStakeholderAndVetoOwnerProposer := *prop.Config.Voter == *state.VoterNetStakersAndVetoOwner.Ptr()
-		if isStakeholderAndVetoOwnerProposer && voterOwnerObj != nil && voterOwnerObj.Veto {
-			prop.NoWithVetoByOwners = 1
-		}
+	prop.AddVote(c.tx.Vote, increments)
+
+	// Also, if the proposer type for the proposal is stakeholders and veto
+	// owners and voter is an owner, increment NoWithVetoByOwners by 1
+	if c.tx.Vote == state.ProposalVoteNoWithVeto && isVetoOwnersVoter &&
+		voterOwnerObj != nil && voterOwnerObj.Veto {
+		prop.NoWithVetoByOwners++
+	}
+
+	// Index the vote and the power counted for it
+	if err = repoKeeper.IndexProposalVote(c.tx.RepoName, c.tx.ProposalID,
+		senderAddr, c.tx.Vote, increments); err != nil {
+		return errors.Wrap(err, "failed to index vote")
 	}
 
 	// Update the repo
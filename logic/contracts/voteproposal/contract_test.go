@@ -1,6 +1,8 @@
 package voteproposal_test
 
 import (
+	"math"
+
 	"os"
 	"testing"
 
@@ -103,7 +105,69 @@ var _ = Describe("Contract", func() {
 				Expect(repo.Proposals.Get(propID).Yes).To(Equal(float64(2)))
 			})
 
+			It("should index the vote and its power", func() {
+				vote, power, found, err := logic.RepoKeeper().GetProposalVote(repoName, propID, sender.Addr().String())
+				Expect(err).To(BeNil())
+				Expect(found).To(BeTrue())
+				Expect(vote).To(Equal(state.ProposalVoteYes))
+				Expect(power).To(Equal(float64(1)))
+			})
+
+			When("the voter changes their vote to 'No'", func() {
+				BeforeEach(func() {
+					err = voteproposal.NewContract().Init(logic, &txns.TxRepoProposalVote{
+						TxCommon:   &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+						RepoName:   repoName,
+						ProposalID: propID,
+						Vote:       state.ProposalVoteNo,
+					}, 0).Exec()
+					Expect(err).To(BeNil())
+				})
+
+				It("should remove the previous vote and count the new vote", func() {
+					repo := logic.RepoKeeper().Get(repoName)
+					Expect(repo.Proposals.Get(propID).Yes).To(Equal(float64(1)))
+					Expect(repo.Proposals.Get(propID).No).To(Equal(float64(1)))
+				})
+
+				It("should re-index the vote", func() {
+					vote, power, found, err := logic.RepoKeeper().GetProposalVote(repoName, propID, sender.Addr().String())
+					Expect(err).To(BeNil())
+					Expect(found).To(BeTrue())
+					Expect(vote).To(Equal(state.ProposalVoteNo))
+					Expect(power).To(Equal(float64(1)))
+				})
+			})
+
+			When("the voter is not an owner but a vote delegate of an owner", func() {
+				BeforeEach(func() {
+					repo := logic.RepoKeeper().Get(repoName)
+					repo.Owners.Get(sender.Addr().String()).Delegate = key2.Addr().String()
+					logic.RepoKeeper().Update(repoName, repo)
+
+					err = voteproposal.NewContract().Init(logic, &txns.TxRepoProposalVote{
+						TxCommon:   &txns.TxCommon{SenderPubKey: key2.PubKey().ToPublicKey(), Fee: "1.5"},
+						RepoName:   repoName,
+						ProposalID: propID,
+						Vote:       state.ProposalVoteNo,
+					}, 0).Exec()
+					Expect(err).To(BeNil())
+				})
+
+				It("should index the vote with no power of its own", func() {
+					repo := logic.RepoKeeper().Get(repoName)
+					Expect(repo.Proposals.Get(propID).No).To(Equal(float64(0)))
+					vote, power, found, err := logic.RepoKeeper().GetProposalVote(repoName, propID, key2.Addr().String())
+					Expect(err).To(BeNil())
+					Expect(found).To(BeTrue())
+					Expect(vote).To(Equal(state.ProposalVoteNo))
+					Expect(power).To(Equal(float64(0)))
+				})
+			})
+
 			Context("with two votes; vote 1 = NoWithVeto, vote 2=Yes", func() {
+				propID := "proposal_id_2"
+
 				BeforeEach(func() {
 					repoUpd := state.BareRepository()
 					repoUpd.Config = state.DefaultRepoConfig
@@ -168,6 +232,62 @@ var _ = Describe("Contract", func() {
 			})
 		})
 
+		When("proposal tally method is ProposalTallyMethodQuadraticCoinWeighted", func() {
+			BeforeEach(func() {
+				repoUpd.Config.Gov.Voter = pointer.ToInt(int(state.VoterOwner))
+				repoUpd.Config.Gov.PropTallyMethod = pointer.ToInt(int(state.ProposalTallyMethodQuadraticCoinWeighted))
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				proposal := &state.RepoProposal{
+					Config: repoUpd.Config.Gov,
+					Yes:    1,
+				}
+				repoUpd.Proposals.Add(propID, proposal)
+				logic.RepoKeeper().Update(repoName, repoUpd)
+
+				err = voteproposal.NewContract().Init(logic, &txns.TxRepoProposalVote{
+					TxCommon:   &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					RepoName:   repoName,
+					ProposalID: propID,
+					Vote:       state.ProposalVoteYes,
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			It("should increment proposal.Yes by the square root of 10", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals.Get(propID).Yes).To(Equal(1 + math.Sqrt(10)))
+			})
+		})
+
+		When("proposal tally method is ProposalTallyMethodQuadraticNetStake and the voter's non-delegated ticket value=100", func() {
+			BeforeEach(func() {
+				repoUpd.Config.Gov.PropTallyMethod = pointer.ToInt(int(state.ProposalTallyMethodQuadraticNetStake))
+				repoUpd.AddOwner(sender.Addr().String(), &state.RepoOwner{})
+				proposal := &state.RepoProposal{
+					Config: repoUpd.Config.Gov,
+					Yes:    0,
+				}
+				repoUpd.Proposals.Add(propID, proposal)
+				logic.RepoKeeper().Update(repoName, repoUpd)
+
+				mockTickMgr.EXPECT().ValueOfNonDelegatedTickets(sender.PubKey().MustBytes32(), uint64(0)).Return(float64(100), nil)
+				logic.SetTicketManager(mockTickMgr)
+
+				err = voteproposal.NewContract().Init(logic, &txns.TxRepoProposalVote{
+					TxCommon:   &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+					RepoName:   repoName,
+					ProposalID: propID,
+					Vote:       state.ProposalVoteYes,
+				}, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			It("should increment proposal.Yes by 10", func() {
+				repo := logic.RepoKeeper().Get(repoName)
+				Expect(repo.Proposals.Get(propID).Yes).To(Equal(float64(10)))
+			})
+		})
+
 		When("proposal tally method is ProposalTallyMethodNetStakeOfProposer and the voter's non-delegated ticket value=100", func() {
 			BeforeEach(func() {
 				repoUpd.Config.Gov.PropTallyMethod = pointer.ToInt(int(state.ProposalTallyMethodNetStakeNonDelegated))
@@ -415,7 +535,7 @@ var _ = Describe("Contract", func() {
 				logic.SetTicketManager(mockTickMgr)
 
 				logic.RepoKeeper().IndexProposalVote(repoName, propID,
-					key2.Addr().String(), state.ProposalVoteYes)
+					key2.Addr().String(), state.ProposalVoteYes, 20)
 
 				err = voteproposal.NewContract().Init(logic, &txns.TxRepoProposalVote{
 					TxCommon:   &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
@@ -501,7 +621,7 @@ var _ = Describe("Contract", func() {
 				logic.SetTicketManager(mockTickMgr)
 
 				logic.RepoKeeper().IndexProposalVote(repoName, propID,
-					key2.Addr().String(), state.ProposalVoteYes)
+					key2.Addr().String(), state.ProposalVoteYes, 100)
 
 				err = voteproposal.NewContract().Init(logic, &txns.TxRepoProposalVote{
 					TxCommon:   &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
@@ -521,6 +641,38 @@ var _ = Describe("Contract", func() {
 				repo := logic.RepoKeeper().Get(repoName)
 				Expect(repo.Proposals.Get(propID).Yes).To(Equal(float64(80)))
 			})
+
+			Specify("that the indexed vote power of the proposer is now 80", func() {
+				vote, power, found, err := logic.RepoKeeper().GetProposalVote(repoName, propID, key2.Addr().String())
+				Expect(err).To(BeNil())
+				Expect(found).To(BeTrue())
+				Expect(vote).To(Equal(state.ProposalVoteYes))
+				Expect(power).To(Equal(float64(80)))
+			})
+
+			When("the voter changes their vote to 'Abstain'", func() {
+				BeforeEach(func() {
+					mockTickMgr.EXPECT().GetUnExpiredTickets(sender.PubKey().
+						MustBytes32(), uint64(0)).Return([]*tickettypes.Ticket{
+						{Value: "10"},
+						{Value: "20", ProposerPubKey: key2.PubKey().MustBytes32(), Delegator: sender.Addr().String()},
+					}, nil)
+					err = voteproposal.NewContract().Init(logic, &txns.TxRepoProposalVote{
+						TxCommon:   &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey(), Fee: "1.5"},
+						RepoName:   repoName,
+						ProposalID: propID,
+						Vote:       state.ProposalVoteAbstain,
+					}, 0).Exec()
+					Expect(err).To(BeNil())
+				})
+
+				It("should move the voter's power to 'Abstain' without deducting the proposer's vote again", func() {
+					repo := logic.RepoKeeper().Get(repoName)
+					Expect(repo.Proposals.Get(propID).No).To(Equal(float64(0)))
+					Expect(repo.Proposals.Get(propID).Abstain).To(Equal(float64(30)))
+					Expect(repo.Proposals.Get(propID).Yes).To(Equal(float64(80)))
+				})
+			})
 		})
 
 		When("proposal tally method is ProposalTallyMethodNetStake and "+
@@ -0,0 +1,70 @@
+package withdrawproposal
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/contracts/common"
+	"github.com/make-os/kit/logic/proposals"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/pkg/errors"
+)
+
+// Contract implements core.SystemContract. It is a system contract for
+// withdrawing a proposal by its creator.
+//
+// Proposals cannot be amended. To correct a proposal, its creator
+// withdraws it and creates a new one.
+type Contract struct {
+	core.Keepers
+	tx          *txns.TxRepoProposalWithdraw
+	chainHeight uint64
+}
+
+// NewContract creates a new instance of Contract
+func NewContract() *Contract {
+	return &Contract{}
+}
+
+func (c *Contract) CanExec(typ types.TxCode) bool {
+	return typ == txns.TxTypeRepoProposalWithdraw
+}
+
+// Init initialize the contract
+func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight uint64) core.SystemContract {
+	c.Keepers = keepers
+	c.tx = tx.(*txns.TxRepoProposalWithdraw)
+	c.chainHeight = curChainHeight
+	return c
+}
+
+// Exec executes the contract
+func (c *Contract) Exec() error {
+	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
+
+	// Get the repo and proposal
+	repoKeeper := c.RepoKeeper()
+	repo := repoKeeper.Get(c.tx.RepoName)
+	prop := repo.Proposals.Get(c.tx.ProposalID)
+
+	// Deduct network fee from sender
+	common.DebitAccount(c, spk, c.tx.Fee.Decimal(), c.chainHeight)
+
+	// Finalize the proposal. A finalized proposal is
+	// ignored when its end height is reached.
+	prop.SetOutcome(state.ProposalOutcomeWithdrawn)
+
+	// Refund or distribute the deposited fees
+	if err := proposals.MaybeProcessProposalFee(state.ProposalOutcomeWithdrawn, c, prop, repo); err != nil {
+		return errors.Wrap(err, "failed to process proposal fee")
+	}
+
+	if err := repoKeeper.MarkProposalAsClosed(c.tx.RepoName, c.tx.ProposalID, state.ProposalOutcomeWithdrawn); err != nil {
+		return err
+	}
+
+	repoKeeper.Update(c.tx.RepoName, repo)
+
+	return nil
+}
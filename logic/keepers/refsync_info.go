@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/make-os/kit/pkgs/tree"
+	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/storage"
 	"github.com/make-os/kit/storage/common"
 	storagetypes "github.com/make-os/kit/storage/types"
@@ -178,3 +179,26 @@ func (t *RepoSyncInfoKeeper) GetRefLastSyncHeight(repo, ref string) (uint64, err
 	}
 	return util.DecodeNumber(rec.Value), nil
 }
+
+// AddCrossReferences indexes commits and post comments of a repository by the post or commit they mention.
+func (t *RepoSyncInfoKeeper) AddCrossReferences(repo string, refs ...*plumbing.CrossReference) error {
+	for _, ref := range refs {
+		rec := common.NewFromKeyValue(MakeRepoCrossReferenceKey(repo, ref.Target, ref.Hash), util.ToBytes(ref))
+		if err := t.db.Put(rec); err != nil {
+			return errors.Wrap(err, "failed to add cross reference")
+		}
+	}
+	return nil
+}
+
+// GetCrossReferences returns the commits and post comments of a repository that mention the given target.
+func (t *RepoSyncInfoKeeper) GetCrossReferences(repo, target string) (res []*plumbing.CrossReference) {
+	res = []*plumbing.CrossReference{}
+	t.db.NewTx(true, true).Iterate(MakeQueryRepoCrossReferencesKey(repo, target), false, func(r *common.Record) bool {
+		var ref plumbing.CrossReference
+		_ = r.Scan(&ref)
+		res = append(res, &ref)
+		return false
+	})
+	return
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/pkgs/tree"
+	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/storage"
 	storagetypes "github.com/make-os/kit/storage/types"
 	"github.com/make-os/kit/testutil"
@@ -242,4 +243,47 @@ var _ = Describe("Tracklist", func() {
 			Expect(height).To(Equal(uint64(10)))
 		})
 	})
+
+	Describe(".AddCrossReferences", func() {
+		It("should index cross references by target", func() {
+			err := keeper.AddCrossReferences("repo1",
+				&plumbing.CrossReference{Target: "refs/heads/issues/1", Type: plumbing.CrossRefSourceCommit, Source: "refs/heads/master", Hash: "hash1", Closes: true},
+				&plumbing.CrossReference{Target: "refs/heads/issues/10", Type: plumbing.CrossRefSourceIssue, Source: "refs/heads/issues/2", Hash: "hash2"},
+			)
+			Expect(err).To(BeNil())
+			rec, err := appDB.Get(MakeRepoCrossReferenceKey("repo1", "refs/heads/issues/1", "hash1"))
+			Expect(err).To(BeNil())
+			Expect(rec).ToNot(BeNil())
+		})
+	})
+
+	Describe(".GetCrossReferences", func() {
+		BeforeEach(func() {
+			err := keeper.AddCrossReferences("repo1",
+				&plumbing.CrossReference{Target: "refs/heads/issues/1", Type: plumbing.CrossRefSourceCommit, Source: "refs/heads/master", Hash: "hash1", Closes: true},
+				&plumbing.CrossReference{Target: "refs/heads/issues/1", Type: plumbing.CrossRefSourceIssue, Source: "refs/heads/issues/2", Hash: "hash2"},
+				&plumbing.CrossReference{Target: "refs/heads/issues/10", Type: plumbing.CrossRefSourceIssue, Source: "refs/heads/issues/2", Hash: "hash3"},
+			)
+			Expect(err).To(BeNil())
+			err = keeper.AddCrossReferences("repo2",
+				&plumbing.CrossReference{Target: "refs/heads/issues/1", Type: plumbing.CrossRefSourceCommit, Source: "refs/heads/master", Hash: "hash4"},
+			)
+			Expect(err).To(BeNil())
+		})
+
+		It("should return only cross references of the repo and target", func() {
+			res := keeper.GetCrossReferences("repo1", "refs/heads/issues/1")
+			Expect(res).To(HaveLen(2))
+			var closes = map[string]bool{}
+			for _, ref := range res {
+				closes[ref.Hash] = ref.Closes
+			}
+			Expect(closes).To(Equal(map[string]bool{"hash1": true, "hash2": false}))
+		})
+
+		It("should return empty result when target has no cross references", func() {
+			res := keeper.GetCrossReferences("repo1", "refs/heads/issues/3")
+			Expect(res).To(BeEmpty())
+		})
+	})
 })
@@ -3,6 +3,7 @@ package keepers
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/make-os/kit/storage"
 	"github.com/make-os/kit/storage/common"
@@ -38,7 +39,7 @@ func (rk *RepoKeeper) Get(name string, blockNum ...uint64) *state.Repository {
 			prop.Config = repo.Config.Gov
 			return nil
 		}
-		propParent := rk.GetNoPopulate(name, prop.Height.UInt64())
+		propParent := rk.GetNoPopulate(repo.NameAt(name, prop.Height.UInt64()), prop.Height.UInt64())
 		if propParent.IsEmpty() {
 			return fmt.Errorf("failed to get repo version of proposal (%s)", id)
 		}
@@ -90,10 +91,97 @@ func (rk *RepoKeeper) Update(name string, upd *state.Repository) {
 	rk.state.Set(MakeRepoKey(name), upd.Bytes())
 }
 
+// Remove implements RepoKeeper
+func (rk *RepoKeeper) Remove(name string) {
+	rk.state.Remove(MakeRepoKey(name))
+}
+
+// SetRedirect implements RepoKeeper
+func (rk *RepoKeeper) SetRedirect(name, target string, untilHeight uint64) error {
+	key := MakeRepoRedirectKey(name)
+	val := fmt.Sprintf("%s:%d", target, untilHeight)
+	rec := common.NewFromKeyValue(key, []byte(val))
+	if err := rk.db.Put(rec); err != nil {
+		return errors.Wrap(err, "failed to set repo redirect")
+	}
+	return nil
+}
+
+// GetRedirect implements RepoKeeper
+func (rk *RepoKeeper) GetRedirect(name string) string {
+	rec, err := rk.db.Get(MakeRepoRedirectKey(name))
+	if err != nil {
+		return ""
+	}
+
+	// The value is formatted as <target>:<untilHeight>.
+	parts := strings.SplitN(string(rec.Value), ":", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	untilHeight, _ := strconv.ParseUint(parts[1], 10, 64)
+	if uint64(rk.state.Version()) >= untilHeight {
+		return ""
+	}
+
+	return parts[0]
+}
+
+// MoveProposalIndexes implements RepoKeeper
+func (rk *RepoKeeper) MoveProposalIndexes(name, newName string, proposals state.RepoProposals) error {
+	for _, tag := range []string{TagRepoPropVote, TagClosedProp} {
+
+		// Collect the records first; The new records
+		// must not be written while iterating.
+		var recs []*common.Record
+		prefix := common.MakePrefix([]byte(tag), []byte(name), []byte(""))
+		rk.db.Iterate(prefix, true, func(rec *common.Record) bool {
+			recs = append(recs, rec)
+			return false
+		})
+
+		for _, rec := range recs {
+			parts := common.SplitPrefix(rec.GetKey())
+			parts[1] = []byte(newName)
+			if err := rk.db.Put(common.NewFromKeyValue(common.MakePrefix(parts...), rec.Value)); err != nil {
+				return errors.Wrap(err, "failed to move proposal index")
+			}
+			if err := rk.db.Del(rec.GetKey()); err != nil {
+				return errors.Wrap(err, "failed to delete proposal index")
+			}
+		}
+	}
+
+	// Move the end and execution height indexes of the unfinalized and
+	// queued proposals so they are finalized or executed only once, under
+	// the new name.
+	return proposals.ForEach(func(prop *state.RepoProposal, id string) error {
+		var key, newKey []byte
+		switch {
+		case prop.IsQueued():
+			key = MakeRepoProposalExecIndexKey(name, id, prop.ExecAt.UInt64())
+			newKey = MakeRepoProposalExecIndexKey(newName, id, prop.ExecAt.UInt64())
+		case !prop.IsFinalized():
+			key = MakeRepoProposalEndIndexKey(name, id, prop.EndAt.UInt64())
+			newKey = MakeRepoProposalEndIndexKey(newName, id, prop.EndAt.UInt64())
+		default:
+			return nil
+		}
+		if err := rk.db.Put(common.NewFromKeyValue(newKey, []byte("0"))); err != nil {
+			return errors.Wrap(err, "failed to move proposal index")
+		}
+		if err := rk.db.Del(key); err != nil {
+			return errors.Wrap(err, "failed to delete proposal index")
+		}
+		return nil
+	})
+}
+
 // IndexProposalVote implements RepoKeeper
-func (rk *RepoKeeper) IndexProposalVote(name, propID, voterAddr string, vote int) error {
+func (rk *RepoKeeper) IndexProposalVote(name, propID, voterAddr string, vote int, power float64) error {
 	key := MakeRepoProposalVoteKey(name, propID, voterAddr)
-	rec := common.NewFromKeyValue(key, []byte(fmt.Sprintf("%d", vote)))
+	val := fmt.Sprintf("%d:%s", vote, strconv.FormatFloat(power, 'f', -1, 64))
+	rec := common.NewFromKeyValue(key, []byte(val))
 	if err := rk.db.Put(rec); err != nil {
 		return errors.Wrap(err, "failed to index proposal vote")
 	}
@@ -104,20 +192,55 @@ func (rk *RepoKeeper) IndexProposalVote(name, propID, voterAddr string, vote int
 // GetProposalVote implements RepoKeeper
 func (rk *RepoKeeper) GetProposalVote(
 	name, propID,
-	voterAddr string) (vote int, found bool, err error) {
+	voterAddr string) (vote int, power float64, found bool, err error) {
 
 	key := MakeRepoProposalVoteKey(name, propID, voterAddr)
 	rec, err := rk.db.Get(key)
 	if err != nil {
 		if err != storage.ErrRecordNotFound {
-			return 0, false, err
+			return 0, 0, false, err
 		}
-		return 0, false, nil
+		return 0, 0, false, nil
 	}
 
-	vote, _ = strconv.Atoi(string(rec.Value))
+	// The value is formatted as <vote>:<power>.
+	// Votes indexed without a power have no power part.
+	parts := strings.SplitN(string(rec.Value), ":", 2)
+	vote, _ = strconv.Atoi(parts[0])
+	if len(parts) == 2 {
+		power, _ = strconv.ParseFloat(parts[1], 64)
+	}
+
+	return vote, power, true, nil
+}
 
-	return vote, true, nil
+// GetProposalVotes implements RepoKeeper
+func (rk *RepoKeeper) GetProposalVotes(name, propID string) ([]*core.ProposalVote, error) {
+	var votes []*core.ProposalVote
+	prefix := common.MakePrefix([]byte(TagRepoPropVote), []byte(name), []byte(propID), []byte(""))
+	rk.db.NewTx(true, true).Iterate(prefix, true, func(rec *common.Record) bool {
+		parts := common.SplitPrefix(rec.GetKey())
+		vote := &core.ProposalVote{Voter: string(parts[len(parts)-1])}
+		valParts := strings.SplitN(string(rec.Value), ":", 2)
+		vote.Vote, _ = strconv.Atoi(valParts[0])
+		if len(valParts) == 2 {
+			vote.Power, _ = strconv.ParseFloat(valParts[1], 64)
+		}
+		votes = append(votes, vote)
+		return false
+	})
+	return votes, nil
+}
+
+// HasProposalVotes implements RepoKeeper
+func (rk *RepoKeeper) HasProposalVotes(name, propID string) bool {
+	var found bool
+	prefix := common.MakePrefix([]byte(TagRepoPropVote), []byte(name), []byte(propID), []byte(""))
+	rk.db.NewTx(true, true).Iterate(prefix, true, func(rec *common.Record) bool {
+		found = true
+		return true
+	})
+	return found
 }
 
 // IndexProposalEnd implements RepoKeeper
@@ -146,10 +269,36 @@ func (rk *RepoKeeper) GetProposalsEndingAt(height uint64) []*core.EndingProposal
 	return res
 }
 
+// IndexProposalExec implements RepoKeeper
+func (rk *RepoKeeper) IndexProposalExec(name, propID string, execHeight uint64) error {
+	key := MakeRepoProposalExecIndexKey(name, propID, execHeight)
+	rec := common.NewFromKeyValue(key, []byte("0"))
+	if err := rk.db.Put(rec); err != nil {
+		return errors.Wrap(err, "failed to index proposal execution")
+	}
+	return nil
+}
+
+// GetProposalsExecutingAt implements RepoKeeper
+func (rk *RepoKeeper) GetProposalsExecutingAt(height uint64) []*core.EndingProposals {
+	key := MakeQueryKeyRepoProposalAtExecHeight(height)
+	var res []*core.EndingProposals
+	rk.db.NewTx(true, true).Iterate(key, true, func(rec *common.Record) bool {
+		prefixes := common.SplitPrefix(rec.GetKey())
+		res = append(res, &core.EndingProposals{
+			RepoName:   string(prefixes[2]),
+			ProposalID: string(prefixes[3]),
+			EndHeight:  height,
+		})
+		return false
+	})
+	return res
+}
+
 // MarkProposalAsClosed implements RepoKeeper
-func (rk *RepoKeeper) MarkProposalAsClosed(name, propID string) error {
+func (rk *RepoKeeper) MarkProposalAsClosed(name, propID string, outcome state.ProposalOutcome) error {
 	key := MakeClosedProposalKey(name, propID)
-	rec := common.NewFromKeyValue(key, []byte("0"))
+	rec := common.NewFromKeyValue(key, []byte(strconv.Itoa(int(outcome))))
 	if err := rk.db.Put(rec); err != nil {
 		return errors.Wrap(err, "failed to mark proposal as closed")
 	}
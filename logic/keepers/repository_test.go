@@ -2,6 +2,7 @@ package keepers
 
 import (
 	"os"
+	"strconv"
 
 	"github.com/AlekSi/pointer"
 	crypto2 "github.com/make-os/kit/crypto/ed25519"
@@ -10,7 +11,9 @@ import (
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/pkgs/tree"
 	"github.com/make-os/kit/storage"
+	"github.com/make-os/kit/storage/common"
 	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/core"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	tmdb "github.com/tendermint/tm-db"
@@ -119,6 +122,32 @@ var _ = Describe("RepoKeeper", func() {
 				Expect(repo.Proposals.Get("1").Config).To(Equal(repo.Config.Gov))
 			})
 		})
+
+		When("repo was renamed after a proposal was introduced at height/stateVersion=1", func() {
+			testRepo := state2.BareRepository()
+			repoAtVersion1 := state2.BareRepository()
+
+			BeforeEach(func() {
+				repoAtVersion1.Config.Gov.PropFee = pointer.ToString("100000")
+				state.Set(MakeRepoKey("repo1"), repoAtVersion1.Bytes())
+				_, _, err := state.SaveVersion()
+				Expect(err).To(BeNil())
+
+				testRepo.Proposals.Add("1", &state2.RepoProposal{Height: 1})
+				testRepo.AddOwner("owner", &state2.RepoOwner{})
+				testRepo.PrevNames = []*state2.RepoPrevName{{Name: "repo1", Height: 2}}
+				state.Remove(MakeRepoKey("repo1"))
+				state.Set(MakeRepoKey("repo2"), testRepo.Bytes())
+				_, _, err = state.SaveVersion()
+				Expect(err).To(BeNil())
+			})
+
+			It("should set proposal config to the config of the repo under its previous name", func() {
+				repo := rk.Get("repo2", 0)
+				Expect(repo.Proposals).To(HaveLen(1))
+				Expect(repo.Proposals.Get("1").Config).To(Equal(repoAtVersion1.Config.Gov))
+			})
+		})
 	})
 
 	Describe(".Update", func() {
@@ -137,34 +166,158 @@ var _ = Describe("RepoKeeper", func() {
 		})
 	})
 
+	Describe(".Remove", func() {
+		It("should remove repo object", func() {
+			key := "repo1"
+			repo := rk.Get(key)
+			repo.AddOwner("owner", &state2.RepoOwner{})
+			rk.Update(key, repo)
+			Expect(rk.Get(key).IsEmpty()).To(BeFalse())
+
+			rk.Remove(key)
+			Expect(rk.Get(key).IsEmpty()).To(BeTrue())
+		})
+	})
+
+	Describe(".SetRedirect", func() {
+		It("should save the redirect", func() {
+			err := rk.SetRedirect("repo1", "repo2", 100)
+			Expect(err).To(BeNil())
+			rec, err := appDB.Get(MakeRepoRedirectKey("repo1"))
+			Expect(err).To(BeNil())
+			Expect(rec.Value).To(Equal([]byte("repo2:100")))
+		})
+	})
+
+	Describe(".GetRedirect", func() {
+		It("should return empty string if no redirect exist", func() {
+			Expect(rk.GetRedirect("repo1")).To(BeEmpty())
+		})
+
+		It("should return the target if the redirect has not expired", func() {
+			err := rk.SetRedirect("repo1", "repo2", 100)
+			Expect(err).To(BeNil())
+			Expect(rk.GetRedirect("repo1")).To(Equal("repo2"))
+		})
+
+		It("should return empty string if the redirect has expired", func() {
+			err := rk.SetRedirect("repo1", "repo2", 1)
+			Expect(err).To(BeNil())
+			_, _, err = state.SaveVersion()
+			Expect(err).To(BeNil())
+			Expect(rk.GetRedirect("repo1")).To(BeEmpty())
+		})
+	})
+
+	Describe(".MoveProposalIndexes", func() {
+		BeforeEach(func() {
+			Expect(rk.IndexProposalVote("repo1", "prop1", "addr", 1, 2.5)).To(BeNil())
+			Expect(rk.IndexProposalVote("repo10", "prop1", "addr", 0, 1)).To(BeNil())
+			Expect(rk.MarkProposalAsClosed("repo1", "prop2", state2.ProposalOutcomeAccepted)).To(BeNil())
+			Expect(rk.IndexProposalEnd("repo1", "prop3", 100)).To(BeNil())
+			Expect(rk.IndexProposalExec("repo1", "prop4", 200)).To(BeNil())
+			proposals := state2.RepoProposals{
+				"prop2": {Outcome: state2.ProposalOutcomeAccepted, EndAt: 100},
+				"prop3": {EndAt: 100},
+				"prop4": {Outcome: state2.ProposalOutcomeQueued, EndAt: 100, ExecAt: 200},
+			}
+			Expect(rk.MoveProposalIndexes("repo1", "repo2", proposals)).To(BeNil())
+		})
+
+		It("should move the end height index of unfinalized proposals to the new name", func() {
+			res := rk.GetProposalsEndingAt(100)
+			Expect(res).To(ContainElement(&core.EndingProposals{RepoName: "repo2", ProposalID: "prop3", EndHeight: 100}))
+			Expect(res).ToNot(ContainElement(&core.EndingProposals{RepoName: "repo1", ProposalID: "prop3", EndHeight: 100}))
+			Expect(res).ToNot(ContainElement(&core.EndingProposals{RepoName: "repo2", ProposalID: "prop2", EndHeight: 100}))
+		})
+
+		It("should move the execution height index of queued proposals to the new name", func() {
+			res := rk.GetProposalsExecutingAt(200)
+			Expect(res).To(Equal([]*core.EndingProposals{{RepoName: "repo2", ProposalID: "prop4", EndHeight: 200}}))
+		})
+
+		It("should re-index votes under the new name", func() {
+			vote, power, found, err := rk.GetProposalVote("repo2", "prop1", "addr")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(vote).To(Equal(1))
+			Expect(power).To(Equal(2.5))
+			_, _, found, err = rk.GetProposalVote("repo1", "prop1", "addr")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeFalse())
+		})
+
+		It("should not move the votes of a repo whose name starts with the old name", func() {
+			_, _, found, err := rk.GetProposalVote("repo10", "prop1", "addr")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+		})
+
+		It("should re-index closed proposals under the new name", func() {
+			closed, err := rk.IsProposalClosed("repo2", "prop2")
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeTrue())
+			closed, err = rk.IsProposalClosed("repo1", "prop2")
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeFalse())
+		})
+	})
+
 	Describe(".IndexProposalVote", func() {
 		It("should save repo proposal vote", func() {
-			err := rk.IndexProposalVote("repo1", "prop1", "addr", 1)
+			err := rk.IndexProposalVote("repo1", "prop1", "addr", 1, 2.5)
 			Expect(err).To(BeNil())
 
 			key := MakeRepoProposalVoteKey("repo1", "prop1", "addr")
 			rec, err := appDB.Get(key)
 			Expect(err).To(BeNil())
-			Expect(rec.Value).To(Equal([]byte("1")))
+			Expect(rec.Value).To(Equal([]byte("1:2.5")))
+		})
+
+		It("should replace an existing vote", func() {
+			err := rk.IndexProposalVote("repo1", "prop1", "addr", 1, 2.5)
+			Expect(err).To(BeNil())
+			err = rk.IndexProposalVote("repo1", "prop1", "addr", 0, 3)
+			Expect(err).To(BeNil())
+
+			key := MakeRepoProposalVoteKey("repo1", "prop1", "addr")
+			rec, err := appDB.Get(key)
+			Expect(err).To(BeNil())
+			Expect(rec.Value).To(Equal([]byte("0:3")))
 		})
 	})
 
 	Describe(".GetProposalVote", func() {
 		When("proposal vote was indexed", func() {
 			It("should get repo proposal vote and found=true", func() {
-				err := rk.IndexProposalVote("repo1", "prop1", "addr", 1)
+				err := rk.IndexProposalVote("repo1", "prop1", "addr", 1, 2.5)
 				Expect(err).To(BeNil())
 
-				vote, found, err := rk.GetProposalVote("repo1", "prop1", "addr")
+				vote, power, found, err := rk.GetProposalVote("repo1", "prop1", "addr")
 				Expect(err).To(BeNil())
 				Expect(vote).To(Equal(1))
+				Expect(power).To(Equal(2.5))
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		When("proposal vote was indexed without a power", func() {
+			It("should get repo proposal vote with zero power", func() {
+				key := MakeRepoProposalVoteKey("repo1", "prop1", "addr")
+				err := rk.db.Put(common.NewFromKeyValue(key, []byte("1")))
+				Expect(err).To(BeNil())
+
+				vote, power, found, err := rk.GetProposalVote("repo1", "prop1", "addr")
+				Expect(err).To(BeNil())
+				Expect(vote).To(Equal(1))
+				Expect(power).To(Equal(float64(0)))
 				Expect(found).To(BeTrue())
 			})
 		})
 
 		When("proposal vote was not indexed", func() {
 			It("should not get repo proposal vote and found=false", func() {
-				vote, found, err := rk.GetProposalVote("repo1", "prop1", "addr")
+				vote, _, found, err := rk.GetProposalVote("repo1", "prop1", "addr")
 				Expect(err).To(BeNil())
 				Expect(vote).To(Equal(0))
 				Expect(found).To(BeFalse())
@@ -172,6 +325,40 @@ var _ = Describe("RepoKeeper", func() {
 		})
 	})
 
+	Describe(".GetProposalVotes", func() {
+		It("should return the votes of the proposal only", func() {
+			Expect(rk.IndexProposalVote("repo1", "prop1", "addr2", 0, 3)).To(BeNil())
+			Expect(rk.IndexProposalVote("repo1", "prop1", "addr1", 1, 2.5)).To(BeNil())
+			Expect(rk.IndexProposalVote("repo1", "prop10", "addr3", 1, 1)).To(BeNil())
+			Expect(rk.IndexProposalVote("repo10", "prop1", "addr4", 1, 1)).To(BeNil())
+
+			votes, err := rk.GetProposalVotes("repo1", "prop1")
+			Expect(err).To(BeNil())
+			Expect(votes).To(HaveLen(2))
+			Expect(votes[0]).To(Equal(&core.ProposalVote{Voter: "addr1", Vote: 1, Power: 2.5}))
+			Expect(votes[1]).To(Equal(&core.ProposalVote{Voter: "addr2", Vote: 0, Power: 3}))
+		})
+
+		It("should return empty result when the proposal has no votes", func() {
+			votes, err := rk.GetProposalVotes("repo1", "prop1")
+			Expect(err).To(BeNil())
+			Expect(votes).To(BeEmpty())
+		})
+	})
+
+	Describe(".HasProposalVotes", func() {
+		It("should return true when a vote with no voting power was indexed", func() {
+			Expect(rk.IndexProposalVote("repo1", "prop1", "addr1", 1, 0)).To(BeNil())
+			Expect(rk.HasProposalVotes("repo1", "prop1")).To(BeTrue())
+		})
+
+		It("should return false when only other proposals have votes", func() {
+			Expect(rk.IndexProposalVote("repo1", "prop10", "addr1", 1, 1)).To(BeNil())
+			Expect(rk.IndexProposalVote("repo10", "prop1", "addr1", 1, 1)).To(BeNil())
+			Expect(rk.HasProposalVotes("repo1", "prop1")).To(BeFalse())
+		})
+	})
+
 	Describe(".IndexProposalEnd", func() {
 		It("should save repo proposal by end height", func() {
 			err := rk.IndexProposalEnd("repo1", "prop1", 100)
@@ -216,15 +403,54 @@ var _ = Describe("RepoKeeper", func() {
 		})
 	})
 
+	Describe(".IndexProposalExec", func() {
+		It("should save repo proposal by execution height", func() {
+			err := rk.IndexProposalExec("repo1", "prop1", 100)
+			Expect(err).To(BeNil())
+
+			key := MakeRepoProposalExecIndexKey("repo1", "prop1", 100)
+			rec, err := appDB.Get(key)
+			Expect(err).To(BeNil())
+			Expect(rec.Value).To(Equal([]byte("0")))
+		})
+	})
+
+	Describe(".GetProposalsExecutingAt", func() {
+		It("should return only proposals queued for execution at the given height", func() {
+			err := rk.IndexProposalExec("repo1", "prop1", 100)
+			Expect(err).To(BeNil())
+			err = rk.IndexProposalExec("repo2", "prop2", 101)
+			Expect(err).To(BeNil())
+			err = rk.IndexProposalEnd("repo3", "prop3", 100)
+			Expect(err).To(BeNil())
+
+			res := rk.GetProposalsExecutingAt(100)
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].RepoName).To(Equal("repo1"))
+			Expect(res[0].ProposalID).To(Equal("prop1"))
+			Expect(res[0].EndHeight).To(Equal(uint64(100)))
+		})
+	})
+
 	Describe(".MarkProposalAsClosed", func() {
 		It("should add mark", func() {
-			err := rk.MarkProposalAsClosed("repo1", "prop1")
+			err := rk.MarkProposalAsClosed("repo1", "prop1", state2.ProposalOutcomeAccepted)
 			Expect(err).To(BeNil())
 
 			key := MakeClosedProposalKey("repo1", "prop1")
 			rec, err := appDB.Get(key)
 			Expect(err).To(BeNil())
-			Expect(rec.Value).To(Equal([]byte("0")))
+			Expect(rec.Value).To(Equal([]byte("1")))
+		})
+
+		It("should record a withdrawn outcome", func() {
+			err := rk.MarkProposalAsClosed("repo1", "prop1", state2.ProposalOutcomeWithdrawn)
+			Expect(err).To(BeNil())
+
+			key := MakeClosedProposalKey("repo1", "prop1")
+			rec, err := appDB.Get(key)
+			Expect(err).To(BeNil())
+			Expect(rec.Value).To(Equal([]byte(strconv.Itoa(int(state2.ProposalOutcomeWithdrawn)))))
 		})
 	})
 
@@ -239,7 +465,7 @@ var _ = Describe("RepoKeeper", func() {
 
 		When("a proposal is marked closed", func() {
 			It("should return true and nil error", func() {
-				err := rk.MarkProposalAsClosed("repo1", "prop1")
+				err := rk.MarkProposalAsClosed("repo1", "prop1", state2.ProposalOutcomeAccepted)
 				Expect(err).To(BeNil())
 				closed, err := rk.IsProposalClosed("repo1", "prop1")
 				Expect(err).To(BeNil())
@@ -12,6 +12,8 @@ const (
 	TagRepo                    = "r"
 	TagRepoPropVote            = "rpv"
 	TagRepoPropEndIndex        = "rei"
+	TagRepoPropExecIndex       = "rxi"
+	TagRepoRedirect            = "rrd"
 	TagNS                      = "ns"
 	TagClosedProp              = "cp"
 	TagBlockInfo               = "b"
@@ -21,6 +23,7 @@ const (
 	TagAnnouncementScheduleKey = "ak"
 	TagRepoRefLastSyncHeight   = "rrh"
 	TagAddressRepoPairKey      = "ar"
+	TagRepoCrossReference      = "rxr"
 )
 
 // MakeRepoRefLastSyncHeightKey creates a key for storing a repo's reference last successful synchronized height.
@@ -28,6 +31,17 @@ func MakeRepoRefLastSyncHeightKey(repo, reference string) []byte {
 	return common.MakePrefix([]byte(TagRepoRefLastSyncHeight), []byte(repo), []byte(reference))
 }
 
+// MakeRepoCrossReferenceKey creates a key for storing a commit or post comment that mentions a target
+func MakeRepoCrossReferenceKey(repo, target, hash string) []byte {
+	return common.MakeKey([]byte(hash), []byte(TagRepoCrossReference), []byte(repo), []byte(target))
+}
+
+// MakeQueryRepoCrossReferencesKey creates a key for querying commits and post comments that mention a target
+func MakeQueryRepoCrossReferencesKey(repo, target string) []byte {
+	prefix := common.MakePrefix([]byte(TagRepoCrossReference), []byte(repo), []byte(target))
+	return append(prefix, []byte(common.KeyPrefixSeparator)...)
+}
+
 // MakeTrackedRepoKey creates a key for accessing a tracked repo.
 func MakeTrackedRepoKey(name string) []byte {
 	return common.MakePrefix([]byte(TagTrackedRepo), []byte(name))
@@ -82,6 +96,24 @@ func MakeQueryKeyRepoProposalAtEndHeight(endHeight uint64) []byte {
 	return common.MakePrefix([]byte(TagRepoPropEndIndex), util.EncodeNumber(endHeight))
 }
 
+// MakeRepoProposalExecIndexKey creates a key that maps a queued repo
+// proposal to its execution height
+func MakeRepoProposalExecIndexKey(repoName, proposalID string, execHeight uint64) []byte {
+	return common.MakePrefix([]byte(TagRepoPropExecIndex), util.EncodeNumber(execHeight),
+		[]byte(repoName), []byte(proposalID))
+}
+
+// MakeQueryKeyRepoProposalAtExecHeight creates a key for finding queued
+// repo proposals to be executed at the given height
+func MakeQueryKeyRepoProposalAtExecHeight(execHeight uint64) []byte {
+	return common.MakePrefix([]byte(TagRepoPropExecIndex), util.EncodeNumber(execHeight))
+}
+
+// MakeRepoRedirectKey creates a key for storing the redirect of a repository name
+func MakeRepoRedirectKey(name string) []byte {
+	return common.MakePrefix([]byte(TagRepoRedirect), []byte(name))
+}
+
 // MakeClosedProposalKey creates a key for marking a proposal as "closed"
 func MakeClosedProposalKey(name, propID string) []byte {
 	return common.MakePrefix([]byte(TagClosedProp), []byte(name), []byte(propID))
@@ -2,7 +2,6 @@ package logic
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/logic/contracts"
@@ -339,22 +338,74 @@ func (l *Logic) ApplyProposals(block *state.BlockInfo) error {
 
 	endingProps := repoKeeper.GetProposalsEndingAt(nextChainHeight)
 	for _, ep := range endingProps {
-		repo := repoKeeper.Get(ep.RepoName)
-		if repo.IsEmpty() {
-			return fmt.Errorf("repo not found") // should never happen
+
+		// Skip proposals of repositories that have been deleted
+		name, repo := getRepoOrRedirect(repoKeeper, ep.RepoName)
+		proposal := repo.Proposals.Get(ep.ProposalID)
+		if repo.IsEmpty() || proposal == nil {
+			continue
+		}
+
+		args := &proposals.ApplyProposalArgs{
+			Keepers:     l,
+			RepoName:    name,
+			ProposalID:  ep.ProposalID,
+			Proposal:    proposal,
+			Repo:        repo,
+			ChainHeight: nextChainHeight - 1,
+			Contracts:   contracts.SystemContracts,
+		}
+		applied, err := proposals.MaybeApplyProposal(args)
+		if err != nil {
+			return err
+		}
+		if err = proposals.PersistRepo(args, applied); err != nil {
+			return err
 		}
-		_, err := proposals.MaybeApplyProposal(&proposals.ApplyProposalArgs{
+	}
+
+	// Apply queued proposals whose execution delay ends at the given block.
+	queuedProps := repoKeeper.GetProposalsExecutingAt(nextChainHeight)
+	for _, qp := range queuedProps {
+
+		// Skip proposals of repositories that have been deleted
+		name, repo := getRepoOrRedirect(repoKeeper, qp.RepoName)
+		proposal := repo.Proposals.Get(qp.ProposalID)
+		if repo.IsEmpty() || proposal == nil {
+			continue
+		}
+
+		args := &proposals.ApplyProposalArgs{
 			Keepers:     l,
-			Proposal:    repo.Proposals.Get(ep.ProposalID),
+			RepoName:    name,
+			ProposalID:  qp.ProposalID,
+			Proposal:    proposal,
 			Repo:        repo,
 			ChainHeight: nextChainHeight - 1,
 			Contracts:   contracts.SystemContracts,
-		})
+		}
+		applied, err := proposals.MaybeExecuteQueuedProposal(args)
 		if err != nil {
 			return err
 		}
-		repoKeeper.Update(ep.RepoName, repo)
+		if err = proposals.PersistRepo(args, applied); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// getRepoOrRedirect returns a repository and the name it was found by.
+// If no repository exist with the given name, the repository the name
+// redirects to is returned. This allows proposals indexed under the old
+// name of a repository renamed in the current block to still be processed.
+func getRepoOrRedirect(repoKeeper core.RepoKeeper, name string) (string, *state.Repository) {
+	repo := repoKeeper.Get(name)
+	if repo.IsEmpty() {
+		if target := repoKeeper.GetRedirect(name); target != "" {
+			return target, repoKeeper.Get(target)
+		}
+	}
+	return name, repo
+}
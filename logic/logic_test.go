@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/identifier"
 	tmdb "github.com/tendermint/tm-db"
@@ -117,4 +119,38 @@ var _ = Describe("Logic", func() {
 			})
 		})
 	})
+
+	Describe(".ApplyProposals", func() {
+		When("an ending proposal belongs to a repo that no longer exists", func() {
+			It("should skip the proposal and return no error", func() {
+				Expect(logic.RepoKeeper().IndexProposalEnd("repo1", "1", 10)).To(BeNil())
+				err = logic.ApplyProposals(&state.BlockInfo{Height: 10})
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("an ending repo deletion proposal is applied", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Config = state.MakeDefaultRepoConfig()
+				repo.AddOwner("addr1", &state.RepoOwner{})
+				repo.Proposals.Add("1", &state.RepoProposal{
+					Action:  txns.TxTypeRepoProposalDelete,
+					Creator: "addr1",
+					EndAt:   10,
+				})
+				logic.RepoKeeper().Update("repo1", repo)
+				Expect(logic.RepoKeeper().IndexProposalEnd("repo1", "1", 10)).To(BeNil())
+				err = logic.ApplyProposals(&state.BlockInfo{Height: 10})
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should remove the repo", func() {
+				Expect(logic.RepoKeeper().Get("repo1").IsEmpty()).To(BeTrue())
+			})
+		})
+	})
 })
@@ -3,14 +3,19 @@ package proposals
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/AlekSi/pointer"
 	"github.com/make-os/kit/params"
 	tickettypes "github.com/make-os/kit/ticket/types"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/identifier"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cast"
 	"github.com/thoas/go-funk"
@@ -81,10 +86,15 @@ func GetProposalOutcome(tickmgr tickettypes.TicketManager, prop state.Proposal,
 	}
 
 	// When proposers include only network stakeholders, the total power is the total
-	// value of mature and active tickets on the network.
+	// value of mature and active tickets on the network. For quadratic tally,
+	// it is the sum of the square root of each stakeholder's non-delegated stake.
 	if prop.GetVoterType() == state.VoterNetStakers ||
 		prop.GetVoterType() == state.VoterNetStakersAndVetoOwner {
-		totalPower, err = tickmgr.ValueOfAllTickets(prop.GetPowerAge())
+		if prop.GetTallyMethod() == state.ProposalTallyMethodQuadraticNetStake {
+			totalPower, err = tickmgr.QuadraticValueOfNonDelegatedTickets(prop.GetPowerAge())
+		} else {
+			totalPower, err = tickmgr.ValueOfAllTickets(prop.GetPowerAge())
+		}
 		if err != nil {
 			panic(err)
 		}
@@ -156,7 +166,11 @@ func refundProposalFees(keepers core.Keepers, proposal state.Proposal) error {
 	return nil
 }
 
-// MaybeProcessProposalFee determines and execute proposal fee refund or distribution
+// MaybeProcessProposalFee determines and execute proposal fee refund or distribution.
+//
+// A withdrawn proposal is treated like a rejected one; its fees are refunded
+// by every refund type that refunds fees of rejected proposals. A queued
+// proposal cancelled by veto owners is treated like a vetoed one.
 func MaybeProcessProposalFee(
 	outcome state.ProposalOutcome,
 	keepers core.Keepers,
@@ -172,7 +186,11 @@ func MaybeProcessProposalFee(
 		}
 
 	case state.ProposalFeeRefundOnAcceptReject:
-		expected := []state.ProposalOutcome{state.ProposalOutcomeAccepted, state.ProposalOutcomeRejected}
+		expected := []state.ProposalOutcome{
+			state.ProposalOutcomeAccepted,
+			state.ProposalOutcomeRejected,
+			state.ProposalOutcomeWithdrawn,
+		}
 		if funk.Contains(expected, outcome) {
 			return refundProposalFees(keepers, proposal)
 		}
@@ -183,6 +201,8 @@ func MaybeProcessProposalFee(
 			state.ProposalOutcomeRejected,
 			state.ProposalOutcomeRejectedWithVeto,
 			state.ProposalOutcomeRejectedWithVetoByOwners,
+			state.ProposalOutcomeWithdrawn,
+			state.ProposalOutcomeCancelled,
 		}
 		if funk.Contains(expected, outcome) {
 			return refundProposalFees(keepers, proposal)
@@ -208,6 +228,7 @@ func MaybeProcessProposalFee(
 			state.ProposalOutcomeBelowThreshold,
 			state.ProposalOutcomeAccepted,
 			state.ProposalOutcomeRejected,
+			state.ProposalOutcomeWithdrawn,
 		}
 		if funk.Contains(expected, outcome) {
 			return refundProposalFees(keepers, proposal)
@@ -220,6 +241,8 @@ func MaybeProcessProposalFee(
 			state.ProposalOutcomeRejected,
 			state.ProposalOutcomeRejectedWithVeto,
 			state.ProposalOutcomeRejectedWithVetoByOwners,
+			state.ProposalOutcomeWithdrawn,
+			state.ProposalOutcomeCancelled,
 		}
 		if funk.Contains(expected, outcome) {
 			return refundProposalFees(keepers, proposal)
@@ -244,12 +267,88 @@ dist: // Distribute to repo and helm accounts
 
 type ApplyProposalArgs struct {
 	Keepers     core.Keepers
+	RepoName    string
+	ProposalID  string
 	Proposal    state.Proposal
 	Repo        *state.Repository
 	ChainHeight uint64
 	Contracts   []core.SystemContract
 }
 
+// DelayableActions are the proposal actions whose application can be
+// delayed by the propExecDelay governance setting
+var DelayableActions = []types.TxCode{
+	txns.TxTypeRepoProposalUpdate,
+	txns.TxTypeRepoProposalUpsertOwner,
+	txns.TxTypeRepoProposalArchive,
+	txns.TxTypeRepoProposalDelete,
+	txns.TxTypeRepoProposalRename,
+	txns.TxTypeRepoProposalUpdateNamespace,
+}
+
+// ApplyDelegatedVotes adds the voting power of repo owners who delegated
+// their vote and did not vote directly to the vote choice of their delegate.
+// It only applies to owner-voted proposals tallied by identity or (quadratic)
+// coin weight.
+func ApplyDelegatedVotes(args *ApplyProposalArgs) error {
+	prop := args.Proposal
+	tallyMethod := prop.GetTallyMethod()
+	if prop.GetVoterType() != state.VoterOwner || (tallyMethod != state.ProposalTallyMethodIdentity &&
+		tallyMethod != state.ProposalTallyMethodCoinWeighted &&
+		tallyMethod != state.ProposalTallyMethodQuadraticCoinWeighted) {
+		return nil
+	}
+
+	// Iterate owners in a fixed order so that the tally is deterministic
+	var addresses []string
+	args.Repo.Owners.ForEach(func(o *state.RepoOwner, addr string) {
+		addresses = append(addresses, addr)
+	})
+	sort.Strings(addresses)
+
+	repoKeeper := args.Keepers.RepoKeeper()
+	maxJoinHeight := prop.GetPowerAge()
+	for _, addr := range addresses {
+		owner := args.Repo.Owners.Get(addr)
+		if owner.Delegate == "" || (maxJoinHeight > 0 && maxJoinHeight < owner.JoinedAt.UInt64()) {
+			continue
+		}
+
+		// Skip owners who voted directly
+		_, _, voted, err := repoKeeper.GetProposalVote(args.RepoName, args.ProposalID, addr)
+		if err != nil {
+			return errors.Wrap(err, "failed to get owner vote")
+		} else if voted {
+			continue
+		}
+
+		vote, _, voted, err := repoKeeper.GetProposalVote(args.RepoName, args.ProposalID, owner.Delegate)
+		if err != nil {
+			return errors.Wrap(err, "failed to get delegate vote")
+		} else if !voted {
+			continue
+		}
+
+		// An owner without veto right cannot veto through a delegate
+		if vote == state.ProposalVoteNoWithVeto && !owner.Veto {
+			vote = state.ProposalVoteNo
+		}
+
+		power := float64(1)
+		if tallyMethod == state.ProposalTallyMethodCoinWeighted {
+			acct := args.Keepers.AccountKeeper().Get(identifier.Address(addr))
+			power = acct.GetAvailableBalance(args.ChainHeight).Float()
+		} else if tallyMethod == state.ProposalTallyMethodQuadraticCoinWeighted {
+			acct := args.Keepers.AccountKeeper().Get(identifier.Address(addr))
+			power = math.Sqrt(acct.GetAvailableBalance(args.ChainHeight).Float())
+		}
+
+		prop.AddVote(vote, power)
+	}
+
+	return nil
+}
+
 // MaybeApplyProposal attempts to apply the action of a proposal
 func MaybeApplyProposal(args *ApplyProposalArgs) (bool, error) {
 
@@ -276,10 +375,9 @@ func MaybeApplyProposal(args *ApplyProposalArgs) (bool, error) {
 	// who is also the creator of the proposal, instantly apply the args.Proposal.
 	isOwnersOnlyProposal := args.Proposal.GetVoterType() == state.VoterOwner
 	if isOwnersOnlyProposal && len(args.Repo.Owners) == 1 && args.Repo.Owners.Has(args.Proposal.GetCreator()) {
-		outcome = state.ProposalOutcomeAccepted
-		args.Proposal.SetOutcome(outcome)
+		args.Proposal.SetOutcome(state.ProposalOutcomeAccepted)
 		args.Proposal.IncrAccept()
-		goto apply
+		return acceptProposal(args)
 	}
 
 	// Don't apply the proposal if the proposal end height is in the future.
@@ -287,8 +385,14 @@ func MaybeApplyProposal(args *ApplyProposalArgs) (bool, error) {
 		return false, nil
 	}
 
-	// Here, the proposal has come to its end. We need to determine if the
-	// outcome was an acceptance, if not we return false.
+	// Here, the proposal has come to its end. Count the votes of owners
+	// that delegated their voting power before determining the outcome.
+	if err = ApplyDelegatedVotes(args); err != nil {
+		return false, err
+	}
+
+	// We need to determine if the outcome was an
+	// acceptance, if not we return false.
 	outcome = DetermineProposalOutcome(args.Keepers, args.Proposal, args.Repo, args.ChainHeight)
 	args.Proposal.SetOutcome(outcome)
 	if outcome != state.ProposalOutcomeAccepted {
@@ -296,20 +400,68 @@ func MaybeApplyProposal(args *ApplyProposalArgs) (bool, error) {
 		return false, err
 	}
 
-apply:
+	return acceptProposal(args)
+}
+
+// acceptProposal applies an accepted proposal.
+//
+// When an execution delay is set and the proposal action can be delayed,
+// the proposal is queued to be applied after the delay instead. Until
+// then, veto owners can cancel it.
+func acceptProposal(args *ApplyProposalArgs) (bool, error) {
+	if delay := args.Proposal.GetExecDelay(); delay > 0 && funk.Contains(DelayableActions, args.Proposal.GetAction()) {
+		execAt := args.ChainHeight + 1 + delay
+		args.Proposal.SetOutcome(state.ProposalOutcomeQueued)
+		args.Proposal.SetExecAt(execAt)
+		if err := args.Keepers.RepoKeeper().IndexProposalExec(args.RepoName, args.ProposalID, execAt); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	return applyProposal(args, state.ProposalOutcomeAccepted)
+}
+
+// MaybeExecuteQueuedProposal applies the action of a queued
+// proposal if its execution height has been reached
+func MaybeExecuteQueuedProposal(args *ApplyProposalArgs) (bool, error) {
 
+	// Do nothing if the proposal is not queued (e.g it was cancelled)
+	// or its execution height is in the future.
+	if !args.Proposal.IsQueued() || args.Proposal.GetExecAt() > args.ChainHeight+1 {
+		return false, nil
+	}
+
+	args.Proposal.SetOutcome(state.ProposalOutcomeAccepted)
+	return applyProposal(args, state.ProposalOutcomeAccepted)
+}
+
+// applyProposal executes the action of an accepted proposal and
+// processes the proposal fee based on the outcome.
+//
+// If the action can no longer be executed, the proposal outcome is set to
+// failed and false is returned. The fee is processed as for an accepted
+// proposal since the proposal was accepted by its voters.
+func applyProposal(args *ApplyProposalArgs, outcome state.ProposalOutcome) (bool, error) {
+	var err error
+	applied := true
 	for _, contract := range args.Contracts {
 		if !contract.CanExec(args.Proposal.GetAction()) {
 			continue
 		}
 		err = contract.(core.ProposalContract).Apply(&core.ProposalApplyArgs{
+			RepoName:    args.RepoName,
 			Proposal:    args.Proposal,
 			Repo:        args.Repo,
 			Keepers:     args.Keepers,
 			ChainHeight: args.ChainHeight,
 		})
 		if err != nil {
-			return false, err
+			if errors.Cause(err) != core.ErrProposalActionFailed {
+				return false, err
+			}
+			args.Proposal.SetOutcome(state.ProposalOutcomeFailed)
+			applied = false
 		}
 		break
 	}
@@ -318,5 +470,63 @@ apply:
 		return false, err
 	}
 
-	return true, nil
+	return applied, nil
+}
+
+// PersistRepo stores a repository after one of its proposals was processed.
+//
+// If the proposal is an applied delete proposal, the repository is removed.
+// If the proposal is an applied rename proposal, the repository is moved to
+// its new name and the old name redirects to the new name for the number
+// of blocks set in the repository's redirectDur governance setting.
+func PersistRepo(args *ApplyProposalArgs, applied bool) error {
+	repoKeeper := args.Keepers.RepoKeeper()
+
+	if !applied {
+		repoKeeper.Update(args.RepoName, args.Repo)
+		return nil
+	}
+
+	switch args.Proposal.GetAction() {
+	case txns.TxTypeRepoProposalDelete:
+		repoKeeper.Remove(args.RepoName)
+		return nil
+	case txns.TxTypeRepoProposalRename:
+		var newName string
+		if err := util.ToObject(args.Proposal.GetActionData()[constants.ActionDataKeyNewName], &newName); err != nil {
+			return err
+		}
+		return moveRepo(args, newName)
+	default:
+		repoKeeper.Update(args.RepoName, args.Repo)
+		return nil
+	}
+}
+
+// moveRepo moves a repository and its proposal indexes to a new name
+// and makes the old name redirect to the new name.
+func moveRepo(args *ApplyProposalArgs, newName string) error {
+	repoKeeper := args.Keepers.RepoKeeper()
+	repoKeeper.Remove(args.RepoName)
+	repoKeeper.Update(newName, args.Repo)
+
+	redirectDur := params.RepoRedirectDur
+	if args.Repo.Config.Gov.RedirectDur != nil {
+		redirectDur = util.PtrStrToUInt64(args.Repo.Config.Gov.RedirectDur)
+	}
+
+	redirectUntil := args.ChainHeight + 1 + redirectDur
+	if err := repoKeeper.SetRedirect(args.RepoName, newName, redirectUntil); err != nil {
+		return err
+	}
+
+	// When the repository takes back a name that still redirects to it,
+	// expire the redirect since the name is in use again.
+	if repoKeeper.GetRedirect(newName) != "" {
+		if err := repoKeeper.SetRedirect(newName, "", 0); err != nil {
+			return err
+		}
+	}
+
+	return repoKeeper.MoveProposalIndexes(args.RepoName, newName, args.Repo.Proposals)
 }
@@ -1,6 +1,7 @@
 package proposals_test
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/AlekSi/pointer"
@@ -8,10 +9,13 @@ import (
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
 	logic2 "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/keepers"
 	"github.com/make-os/kit/logic/proposals"
 	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/params"
 	storagetypes "github.com/make-os/kit/storage/types"
 	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
@@ -134,6 +138,91 @@ var _ = Describe("ProposalHandler", func() {
 		})
 	})
 
+	Describe(".ApplyDelegatedVotes", func() {
+		var proposal *state.RepoProposal
+		var args *proposals.ApplyProposalArgs
+
+		BeforeEach(func() {
+			proposal = &state.RepoProposal{
+				Config: state.MakeDefaultRepoConfig().Gov,
+			}
+			proposal.Config.Voter = state.VoterOwner.Ptr()
+			proposal.Config.PropTallyMethod = state.ProposalTallyMethodIdentity.Ptr()
+			repo.Owners.Get("addr1").Delegate = "addr2"
+			repo.Owners.Get("addr3").Delegate = "addr2"
+			repo.Owners.Get("addr4").Delegate = "delegate1"
+			repo.Owners.Get("addr5").Delegate = "addr6"
+			args = &proposals.ApplyProposalArgs{Keepers: logic, RepoName: "repo1", ProposalID: "1",
+				Proposal: proposal, Repo: repo}
+		})
+
+		When("tally method is ProposalTallyMethodIdentity", func() {
+			BeforeEach(func() {
+				rk := logic.RepoKeeper()
+				Expect(rk.IndexProposalVote("repo1", "1", "addr2", state.ProposalVoteYes, 1)).To(BeNil())
+				Expect(rk.IndexProposalVote("repo1", "1", "addr3", state.ProposalVoteNo, 1)).To(BeNil())
+				Expect(rk.IndexProposalVote("repo1", "1", "delegate1", state.ProposalVoteNoWithVeto, 0)).To(BeNil())
+				proposal.Yes = 1
+				proposal.No = 1
+				err = proposals.ApplyDelegatedVotes(args)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+
+			It("should add 1 vote per owner to the choice of their delegate if they did not vote", func() {
+				Expect(proposal.Yes).To(Equal(float64(2)))
+			})
+
+			It("should count 'NoWithVeto' as 'No' for owners without veto right", func() {
+				Expect(proposal.NoWithVeto).To(Equal(float64(0)))
+				Expect(proposal.No).To(Equal(float64(2)))
+			})
+		})
+
+		When("tally method is ProposalTallyMethodCoinWeighted", func() {
+			BeforeEach(func() {
+				proposal.Config.PropTallyMethod = state.ProposalTallyMethodCoinWeighted.Ptr()
+				logic.AccountKeeper().Update("addr1", &state.Account{Balance: "10", Stakes: state.BareAccountStakes()})
+				Expect(logic.RepoKeeper().IndexProposalVote("repo1", "1", "addr2", state.ProposalVoteYes, 0)).To(BeNil())
+				err = proposals.ApplyDelegatedVotes(args)
+			})
+
+			It("should add the delegating owner's available balance to the choice of their delegate", func() {
+				Expect(err).To(BeNil())
+				Expect(proposal.Yes).To(Equal(float64(10)))
+			})
+		})
+
+		When("tally method is ProposalTallyMethodQuadraticCoinWeighted", func() {
+			BeforeEach(func() {
+				proposal.Config.PropTallyMethod = state.ProposalTallyMethodQuadraticCoinWeighted.Ptr()
+				logic.AccountKeeper().Update("addr1", &state.Account{Balance: "16", Stakes: state.BareAccountStakes()})
+				Expect(logic.RepoKeeper().IndexProposalVote("repo1", "1", "addr2", state.ProposalVoteYes, 0)).To(BeNil())
+				err = proposals.ApplyDelegatedVotes(args)
+			})
+
+			It("should add the square root of the delegating owner's available balance to the choice of their delegate", func() {
+				Expect(err).To(BeNil())
+				Expect(proposal.Yes).To(Equal(float64(4)))
+			})
+		})
+
+		When("voters are not the repo owners", func() {
+			BeforeEach(func() {
+				proposal.Config.Voter = state.VoterNetStakers.Ptr()
+				Expect(logic.RepoKeeper().IndexProposalVote("repo1", "1", "addr2", state.ProposalVoteYes, 1)).To(BeNil())
+				err = proposals.ApplyDelegatedVotes(args)
+			})
+
+			It("should not add delegated votes", func() {
+				Expect(err).To(BeNil())
+				Expect(proposal.Yes).To(Equal(float64(0)))
+			})
+		})
+	})
+
 	Describe(".common.MaybeApplyProposal", func() {
 		When("the proposal has already been finalized", func() {
 			It("should return false", func() {
@@ -198,6 +287,62 @@ var _ = Describe("ProposalHandler", func() {
 				Expect(applied).To(BeTrue())
 				Expect(proposal.Outcome).To(Equal(state.ProposalOutcomeAccepted))
 			})
+
+			It("should queue the proposal when an execution delay is set", func() {
+				proposal.Config.PropExecDelay = pointer.ToString("5")
+				args := &proposals.ApplyProposalArgs{Keepers: logic, RepoName: "repo1", ProposalID: "1",
+					Proposal: proposal, Repo: repo, ChainHeight: 10}
+				applied, err := proposals.MaybeApplyProposal(args)
+				Expect(err).To(BeNil())
+				Expect(applied).To(BeFalse())
+				Expect(proposal.Outcome).To(Equal(state.ProposalOutcomeQueued))
+				Expect(proposal.ExecAt).To(Equal(util.UInt64(16)))
+				res := logic.RepoKeeper().GetProposalsExecutingAt(16)
+				Expect(res).To(HaveLen(1))
+				Expect(res[0].ProposalID).To(Equal("1"))
+			})
+		})
+
+		When("the proposal is accepted and an execution delay is set", func() {
+			var proposal *state.RepoProposal
+			var repo *state.Repository
+
+			BeforeEach(func() {
+				proposal = &state.RepoProposal{Config: state.MakeDefaultRepoConfig().Gov}
+				proposal.Config.Voter = state.VoterOwner.Ptr()
+				proposal.Config.PropExecDelay = pointer.ToString("5")
+				proposal.Creator = key.Addr().String()
+				proposal.Action = txns.TxTypeRepoProposalUpsertOwner
+				proposal.EndAt = 11
+				proposal.Yes = 2
+				repo = state.BareRepository()
+				repo.AddOwner(key.Addr().String(), &state.RepoOwner{})
+				repo.AddOwner("addr2", &state.RepoOwner{})
+			})
+
+			It("should queue the proposal and index its execution height", func() {
+				args := &proposals.ApplyProposalArgs{Keepers: logic, RepoName: "repo1", ProposalID: "1",
+					Proposal: proposal, Repo: repo, ChainHeight: 10}
+				applied, err := proposals.MaybeApplyProposal(args)
+				Expect(err).To(BeNil())
+				Expect(applied).To(BeFalse())
+				Expect(proposal.Outcome).To(Equal(state.ProposalOutcomeQueued))
+				Expect(proposal.ExecAt).To(Equal(util.UInt64(16)))
+				res := logic.RepoKeeper().GetProposalsExecutingAt(16)
+				Expect(res).To(HaveLen(1))
+				Expect(res[0].RepoName).To(Equal("repo1"))
+				Expect(res[0].ProposalID).To(Equal("1"))
+			})
+
+			It("should not queue the proposal when its action cannot be delayed", func() {
+				proposal.Action = txns.TxTypeRepoProposalSpend
+				args := &proposals.ApplyProposalArgs{Keepers: logic, RepoName: "repo1", ProposalID: "1",
+					Proposal: proposal, Repo: repo, ChainHeight: 10}
+				applied, err := proposals.MaybeApplyProposal(args)
+				Expect(err).To(BeNil())
+				Expect(applied).To(BeTrue())
+				Expect(proposal.Outcome).To(Equal(state.ProposalOutcomeAccepted))
+			})
 		})
 
 		When("proposal's end height is a future height", func() {
@@ -297,6 +442,129 @@ var _ = Describe("ProposalHandler", func() {
 		})
 	})
 
+	Describe(".MaybeExecuteQueuedProposal", func() {
+		var proposal *state.RepoProposal
+		var repo *state.Repository
+
+		BeforeEach(func() {
+			proposal = &state.RepoProposal{Config: state.MakeDefaultRepoConfig().Gov}
+			proposal.Outcome = state.ProposalOutcomeQueued
+			proposal.ExecAt = 16
+			repo = state.BareRepository()
+		})
+
+		It("should return false when the execution height is in the future", func() {
+			args := &proposals.ApplyProposalArgs{Keepers: logic, Proposal: proposal, Repo: repo, ChainHeight: 14}
+			applied, err := proposals.MaybeExecuteQueuedProposal(args)
+			Expect(err).To(BeNil())
+			Expect(applied).To(BeFalse())
+			Expect(proposal.Outcome).To(Equal(state.ProposalOutcomeQueued))
+		})
+
+		It("should return false when the proposal was cancelled", func() {
+			proposal.Outcome = state.ProposalOutcomeCancelled
+			args := &proposals.ApplyProposalArgs{Keepers: logic, Proposal: proposal, Repo: repo, ChainHeight: 15}
+			applied, err := proposals.MaybeExecuteQueuedProposal(args)
+			Expect(err).To(BeNil())
+			Expect(applied).To(BeFalse())
+			Expect(proposal.Outcome).To(Equal(state.ProposalOutcomeCancelled))
+		})
+
+		It("should apply the proposal when the execution height is reached", func() {
+			args := &proposals.ApplyProposalArgs{Keepers: logic, Proposal: proposal, Repo: repo, ChainHeight: 15}
+			applied, err := proposals.MaybeExecuteQueuedProposal(args)
+			Expect(err).To(BeNil())
+			Expect(applied).To(BeTrue())
+			Expect(proposal.Outcome).To(Equal(state.ProposalOutcomeAccepted))
+		})
+	})
+
+	Describe(".PersistRepo", func() {
+		var args *proposals.ApplyProposalArgs
+
+		BeforeEach(func() {
+			args = &proposals.ApplyProposalArgs{
+				Keepers:     logic,
+				RepoName:    "repo1",
+				Repo:        repo,
+				ChainHeight: 10,
+			}
+			logic.RepoKeeper().Update("repo1", repo)
+		})
+
+		It("should update the repo when the proposal was not applied", func() {
+			args.Proposal = &state.RepoProposal{Action: txns.TxTypeRepoProposalDelete}
+			repo.Description = "updated"
+			Expect(proposals.PersistRepo(args, false)).To(BeNil())
+			Expect(logic.RepoKeeper().Get("repo1").Description).To(Equal("updated"))
+		})
+
+		It("should remove the repo when the proposal is an applied delete proposal", func() {
+			args.Proposal = &state.RepoProposal{Action: txns.TxTypeRepoProposalDelete}
+			Expect(proposals.PersistRepo(args, true)).To(BeNil())
+			Expect(logic.RepoKeeper().Get("repo1").IsEmpty()).To(BeTrue())
+		})
+
+		When("an applied rename proposal", func() {
+			BeforeEach(func() {
+				repo.Proposals.Add("2", &state.RepoProposal{EndAt: 20})
+				repo.Proposals.Add("3", &state.RepoProposal{Outcome: state.ProposalOutcomeQueued, ExecAt: 30})
+				err := logic.RepoKeeper().IndexProposalVote("repo1", "2", "addr1", state.ProposalVoteYes, 1)
+				Expect(err).To(BeNil())
+				args.Proposal = &state.RepoProposal{
+					Action:     txns.TxTypeRepoProposalRename,
+					ActionData: map[string]util.Bytes{constants.ActionDataKeyNewName: util.ToBytes("repo2")},
+				}
+			})
+
+			JustBeforeEach(func() {
+				Expect(proposals.PersistRepo(args, true)).To(BeNil())
+			})
+
+			It("should move the repo to the new name", func() {
+				Expect(logic.RepoKeeper().Get("repo1").IsEmpty()).To(BeTrue())
+				Expect(logic.RepoKeeper().Get("repo2").IsEmpty()).To(BeFalse())
+			})
+
+			It("should redirect the old name to the new name", func() {
+				Expect(logic.RepoKeeper().GetRedirect("repo1")).To(Equal("repo2"))
+			})
+
+			It("should redirect the old name for the default redirect duration", func() {
+				rec, err := appDB.Get(keepers.MakeRepoRedirectKey("repo1"))
+				Expect(err).To(BeNil())
+				Expect(string(rec.Value)).To(Equal(fmt.Sprintf("repo2:%d", 11+params.RepoRedirectDur)))
+			})
+
+			When("the repo sets a redirect duration", func() {
+				BeforeEach(func() {
+					repo.Config.Gov.RedirectDur = pointer.ToString("5")
+				})
+
+				It("should redirect the old name for the configured duration", func() {
+					rec, err := appDB.Get(keepers.MakeRepoRedirectKey("repo1"))
+					Expect(err).To(BeNil())
+					Expect(string(rec.Value)).To(Equal("repo2:16"))
+				})
+			})
+
+			It("should move proposal votes to the new name", func() {
+				_, _, found, err := logic.RepoKeeper().GetProposalVote("repo2", "2", "addr1")
+				Expect(err).To(BeNil())
+				Expect(found).To(BeTrue())
+			})
+
+			It("should re-index unfinalized and queued proposals under the new name", func() {
+				ending := logic.RepoKeeper().GetProposalsEndingAt(20)
+				Expect(ending).To(HaveLen(1))
+				Expect(ending[0].RepoName).To(Equal("repo2"))
+				queued := logic.RepoKeeper().GetProposalsExecutingAt(30)
+				Expect(queued).To(HaveLen(1))
+				Expect(queued[0].RepoName).To(Equal("repo2"))
+			})
+		})
+	})
+
 	Describe(".GetProposalOutcome", func() {
 		When("proposer type is ProposerNetStakeholders", func() {
 			var proposal *state.RepoProposal
@@ -323,6 +591,32 @@ var _ = Describe("ProposalHandler", func() {
 			})
 		})
 
+		When("proposer type is ProposerNetStakeholders and tally method is ProposalTallyMethodQuadraticNetStake", func() {
+			var proposal *state.RepoProposal
+
+			BeforeEach(func() {
+				proposal = &state.RepoProposal{
+					Config: state.MakeDefaultRepoConfig().Gov,
+				}
+				proposal.Config.Voter = state.VoterNetStakers.Ptr()
+				proposal.Config.PropTallyMethod = state.ProposalTallyMethodQuadraticNetStake.Ptr()
+				proposal.Creator = key.Addr().String()
+				proposal.Config.PropQuorum = pointer.ToString("40")
+				proposal.Config.PropThreshold = pointer.ToString("51")
+				proposal.Yes = 10
+				proposal.No = 5
+
+				mockTickMgr := mocks.NewMockTicketManager(ctrl)
+				mockTickMgr.EXPECT().QuadraticValueOfNonDelegatedTickets(uint64(0)).Return(float64(30), nil)
+				logic.SetTicketManager(mockTickMgr)
+			})
+
+			It("should use the quadratic value of tickets as the total power and return outcome=ProposalOutcomeAccepted", func() {
+				out := proposals.GetProposalOutcome(logic.GetTicketManager(), proposal, repo)
+				Expect(out).To(Equal(state.ProposalOutcomeAccepted))
+			})
+		})
+
 		When("proposer type is ProposerOwner", func() {
 			When("quorum is not reached", func() {
 				var proposal *state.RepoProposal
@@ -70,6 +70,21 @@ func (mr *MockStreamerMockRecorder) GetCommitWithAncestors(ctx, args interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitWithAncestors", reflect.TypeOf((*MockStreamer)(nil).GetCommitWithAncestors), ctx, args)
 }
 
+// GetLFSObject mocks base method.
+func (m *MockStreamer) GetLFSObject(ctx context.Context, repo string, oid []byte) (io.ReadSeekerCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLFSObject", ctx, repo, oid)
+	ret0, _ := ret[0].(io.ReadSeekerCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLFSObject indicates an expected call of GetLFSObject.
+func (mr *MockStreamerMockRecorder) GetLFSObject(ctx, repo, oid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLFSObject", reflect.TypeOf((*MockStreamer)(nil).GetLFSObject), ctx, repo, oid)
+}
+
 // GetProviders mocks base method.
 func (m *MockStreamer) GetProviders(ctx context.Context, repoName string, objectHash []byte) ([]peer.AddrInfo, error) {
 	m.ctrl.T.Helper()
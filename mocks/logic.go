@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	config "github.com/make-os/kit/config"
 	tree "github.com/make-os/kit/pkgs/tree"
+	plumbing "github.com/make-os/kit/remote/plumbing"
 	types "github.com/make-os/kit/storage/types"
 	types0 "github.com/make-os/kit/ticket/types"
 	types1 "github.com/make-os/kit/types"
@@ -362,6 +363,39 @@ func (m *MockRepoSyncInfoKeeper) EXPECT() *MockRepoSyncInfoKeeperMockRecorder {
 	return m.recorder
 }
 
+// AddCrossReferences mocks base method.
+func (m *MockRepoSyncInfoKeeper) AddCrossReferences(repo string, refs ...*plumbing.CrossReference) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{repo}
+	for _, a := range refs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddCrossReferences", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCrossReferences indicates an expected call of AddCrossReferences.
+func (mr *MockRepoSyncInfoKeeperMockRecorder) AddCrossReferences(repo interface{}, refs ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{repo}, refs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCrossReferences", reflect.TypeOf((*MockRepoSyncInfoKeeper)(nil).AddCrossReferences), varargs...)
+}
+
+// GetCrossReferences mocks base method.
+func (m *MockRepoSyncInfoKeeper) GetCrossReferences(repo, target string) []*plumbing.CrossReference {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCrossReferences", repo, target)
+	ret0, _ := ret[0].([]*plumbing.CrossReference)
+	return ret0
+}
+
+// GetCrossReferences indicates an expected call of GetCrossReferences.
+func (mr *MockRepoSyncInfoKeeperMockRecorder) GetCrossReferences(repo, target interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCrossReferences", reflect.TypeOf((*MockRepoSyncInfoKeeper)(nil).GetCrossReferences), repo, target)
+}
+
 // GetRefLastSyncHeight mocks base method.
 func (m *MockRepoSyncInfoKeeper) GetRefLastSyncHeight(repo, ref string) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -514,13 +548,14 @@ func (mr *MockRepoKeeperMockRecorder) GetNoPopulate(name interface{}, blockNum .
 }
 
 // GetProposalVote mocks base method.
-func (m *MockRepoKeeper) GetProposalVote(name, propID, voterAddr string) (int, bool, error) {
+func (m *MockRepoKeeper) GetProposalVote(name, propID, voterAddr string) (int, float64, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetProposalVote", name, propID, voterAddr)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(bool)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // GetProposalVote indicates an expected call of GetProposalVote.
@@ -529,6 +564,21 @@ func (mr *MockRepoKeeperMockRecorder) GetProposalVote(name, propID, voterAddr in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposalVote", reflect.TypeOf((*MockRepoKeeper)(nil).GetProposalVote), name, propID, voterAddr)
 }
 
+// GetProposalVotes mocks base method.
+func (m *MockRepoKeeper) GetProposalVotes(name, propID string) ([]*core.ProposalVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposalVotes", name, propID)
+	ret0, _ := ret[0].([]*core.ProposalVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposalVotes indicates an expected call of GetProposalVotes.
+func (mr *MockRepoKeeperMockRecorder) GetProposalVotes(name, propID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposalVotes", reflect.TypeOf((*MockRepoKeeper)(nil).GetProposalVotes), name, propID)
+}
+
 // GetProposalsEndingAt mocks base method.
 func (m *MockRepoKeeper) GetProposalsEndingAt(height uint64) []*core.EndingProposals {
 	m.ctrl.T.Helper()
@@ -543,6 +593,34 @@ func (mr *MockRepoKeeperMockRecorder) GetProposalsEndingAt(height interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposalsEndingAt", reflect.TypeOf((*MockRepoKeeper)(nil).GetProposalsEndingAt), height)
 }
 
+// GetProposalsExecutingAt mocks base method.
+func (m *MockRepoKeeper) GetProposalsExecutingAt(height uint64) []*core.EndingProposals {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposalsExecutingAt", height)
+	ret0, _ := ret[0].([]*core.EndingProposals)
+	return ret0
+}
+
+// GetProposalsExecutingAt indicates an expected call of GetProposalsExecutingAt.
+func (mr *MockRepoKeeperMockRecorder) GetProposalsExecutingAt(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposalsExecutingAt", reflect.TypeOf((*MockRepoKeeper)(nil).GetProposalsExecutingAt), height)
+}
+
+// GetRedirect mocks base method.
+func (m *MockRepoKeeper) GetRedirect(name string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirect", name)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRedirect indicates an expected call of GetRedirect.
+func (mr *MockRepoKeeperMockRecorder) GetRedirect(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirect", reflect.TypeOf((*MockRepoKeeper)(nil).GetRedirect), name)
+}
+
 // GetReposCreatedByAddress mocks base method.
 func (m *MockRepoKeeper) GetReposCreatedByAddress(address []byte) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -558,6 +636,20 @@ func (mr *MockRepoKeeperMockRecorder) GetReposCreatedByAddress(address interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReposCreatedByAddress", reflect.TypeOf((*MockRepoKeeper)(nil).GetReposCreatedByAddress), address)
 }
 
+// HasProposalVotes mocks base method.
+func (m *MockRepoKeeper) HasProposalVotes(name, propID string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasProposalVotes", name, propID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasProposalVotes indicates an expected call of HasProposalVotes.
+func (mr *MockRepoKeeperMockRecorder) HasProposalVotes(name, propID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasProposalVotes", reflect.TypeOf((*MockRepoKeeper)(nil).HasProposalVotes), name, propID)
+}
+
 // IndexProposalEnd mocks base method.
 func (m *MockRepoKeeper) IndexProposalEnd(name, propID string, endHeight uint64) error {
 	m.ctrl.T.Helper()
@@ -572,18 +664,32 @@ func (mr *MockRepoKeeperMockRecorder) IndexProposalEnd(name, propID, endHeight i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexProposalEnd", reflect.TypeOf((*MockRepoKeeper)(nil).IndexProposalEnd), name, propID, endHeight)
 }
 
+// IndexProposalExec mocks base method.
+func (m *MockRepoKeeper) IndexProposalExec(name, propID string, execHeight uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexProposalExec", name, propID, execHeight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexProposalExec indicates an expected call of IndexProposalExec.
+func (mr *MockRepoKeeperMockRecorder) IndexProposalExec(name, propID, execHeight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexProposalExec", reflect.TypeOf((*MockRepoKeeper)(nil).IndexProposalExec), name, propID, execHeight)
+}
+
 // IndexProposalVote mocks base method.
-func (m *MockRepoKeeper) IndexProposalVote(name, propID, voterAddr string, vote int) error {
+func (m *MockRepoKeeper) IndexProposalVote(name, propID, voterAddr string, vote int, power float64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IndexProposalVote", name, propID, voterAddr, vote)
+	ret := m.ctrl.Call(m, "IndexProposalVote", name, propID, voterAddr, vote, power)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // IndexProposalVote indicates an expected call of IndexProposalVote.
-func (mr *MockRepoKeeperMockRecorder) IndexProposalVote(name, propID, voterAddr, vote interface{}) *gomock.Call {
+func (mr *MockRepoKeeperMockRecorder) IndexProposalVote(name, propID, voterAddr, vote, power interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexProposalVote", reflect.TypeOf((*MockRepoKeeper)(nil).IndexProposalVote), name, propID, voterAddr, vote)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexProposalVote", reflect.TypeOf((*MockRepoKeeper)(nil).IndexProposalVote), name, propID, voterAddr, vote, power)
 }
 
 // IndexRepoCreatedByAddress mocks base method.
@@ -616,17 +722,57 @@ func (mr *MockRepoKeeperMockRecorder) IsProposalClosed(name, propID interface{})
 }
 
 // MarkProposalAsClosed mocks base method.
-func (m *MockRepoKeeper) MarkProposalAsClosed(name, propID string) error {
+func (m *MockRepoKeeper) MarkProposalAsClosed(name, propID string, outcome state.ProposalOutcome) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkProposalAsClosed", name, propID)
+	ret := m.ctrl.Call(m, "MarkProposalAsClosed", name, propID, outcome)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkProposalAsClosed indicates an expected call of MarkProposalAsClosed.
-func (mr *MockRepoKeeperMockRecorder) MarkProposalAsClosed(name, propID interface{}) *gomock.Call {
+func (mr *MockRepoKeeperMockRecorder) MarkProposalAsClosed(name, propID, outcome interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProposalAsClosed", reflect.TypeOf((*MockRepoKeeper)(nil).MarkProposalAsClosed), name, propID, outcome)
+}
+
+// MoveProposalIndexes mocks base method.
+func (m *MockRepoKeeper) MoveProposalIndexes(name, newName string, proposals state.RepoProposals) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveProposalIndexes", name, newName, proposals)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveProposalIndexes indicates an expected call of MoveProposalIndexes.
+func (mr *MockRepoKeeperMockRecorder) MoveProposalIndexes(name, newName, proposals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveProposalIndexes", reflect.TypeOf((*MockRepoKeeper)(nil).MoveProposalIndexes), name, newName, proposals)
+}
+
+// Remove mocks base method.
+func (m *MockRepoKeeper) Remove(name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", name)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRepoKeeperMockRecorder) Remove(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRepoKeeper)(nil).Remove), name)
+}
+
+// SetRedirect mocks base method.
+func (m *MockRepoKeeper) SetRedirect(name, target string, untilHeight uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRedirect", name, target, untilHeight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRedirect indicates an expected call of SetRedirect.
+func (mr *MockRepoKeeperMockRecorder) SetRedirect(name, target, untilHeight interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProposalAsClosed", reflect.TypeOf((*MockRepoKeeper)(nil).MarkProposalAsClosed), name, propID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRedirect", reflect.TypeOf((*MockRepoKeeper)(nil).SetRedirect), name, target, untilHeight)
 }
 
 // Update mocks base method.
@@ -10,6 +10,7 @@ import (
 	prompt "github.com/c-bata/go-prompt"
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/make-os/kit/modules/types"
+	core "github.com/make-os/kit/types/core"
 	util "github.com/make-os/kit/util"
 	otto "github.com/robertkrimen/otto"
 )
@@ -1163,6 +1164,34 @@ func (mr *MockRepoModuleMockRecorder) AddContributor(params interface{}, options
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContributor", reflect.TypeOf((*MockRepoModule)(nil).AddContributor), varargs...)
 }
 
+// Archive mocks base method.
+func (m *MockRepoModule) Archive(params map[string]interface{}) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", params)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockRepoModuleMockRecorder) Archive(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockRepoModule)(nil).Archive), params)
+}
+
+// CheckPolicy mocks base method.
+func (m *MockRepoModule) CheckPolicy(params map[string]interface{}) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPolicy", params)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// CheckPolicy indicates an expected call of CheckPolicy.
+func (mr *MockRepoModuleMockRecorder) CheckPolicy(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPolicy", reflect.TypeOf((*MockRepoModule)(nil).CheckPolicy), params)
+}
+
 // CloseIssue mocks base method.
 func (m *MockRepoModule) CloseIssue(name, reference string) util.Map {
 	m.ctrl.T.Helper()
@@ -1191,6 +1220,20 @@ func (mr *MockRepoModuleMockRecorder) CloseMergeRequest(name, reference interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseMergeRequest", reflect.TypeOf((*MockRepoModule)(nil).CloseMergeRequest), name, reference)
 }
 
+// CloseMilestone mocks base method.
+func (m *MockRepoModule) CloseMilestone(name, reference string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseMilestone", name, reference)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// CloseMilestone indicates an expected call of CloseMilestone.
+func (mr *MockRepoModuleMockRecorder) CloseMilestone(name, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseMilestone", reflect.TypeOf((*MockRepoModule)(nil).CloseMilestone), name, reference)
+}
+
 // ConfigureVM mocks base method.
 func (m *MockRepoModule) ConfigureVM(vm *otto.Otto) prompt.Completer {
 	m.ctrl.T.Helper()
@@ -1219,6 +1262,25 @@ func (mr *MockRepoModuleMockRecorder) CountCommits(name, branch interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCommits", reflect.TypeOf((*MockRepoModule)(nil).CountCommits), name, branch)
 }
 
+// CancelProposal mocks base method.
+func (m *MockRepoModule) CancelProposal(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelProposal", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// CancelProposal indicates an expected call of CancelProposal.
+func (mr *MockRepoModuleMockRecorder) CancelProposal(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelProposal", reflect.TypeOf((*MockRepoModule)(nil).CancelProposal), varargs...)
+}
+
 // Create mocks base method.
 func (m *MockRepoModule) Create(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -1266,6 +1328,39 @@ func (mr *MockRepoModuleMockRecorder) CreateMergeRequest(name, params interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMergeRequest", reflect.TypeOf((*MockRepoModule)(nil).CreateMergeRequest), name, params)
 }
 
+// CreateMilestone mocks base method.
+func (m *MockRepoModule) CreateMilestone(name string, params map[string]interface{}) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMilestone", name, params)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// CreateMilestone indicates an expected call of CreateMilestone.
+func (mr *MockRepoModuleMockRecorder) CreateMilestone(name, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMilestone", reflect.TypeOf((*MockRepoModule)(nil).CreateMilestone), name, params)
+}
+
+// DelegateVote mocks base method.
+func (m *MockRepoModule) DelegateVote(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DelegateVote", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// DelegateVote indicates an expected call of DelegateVote.
+func (mr *MockRepoModuleMockRecorder) DelegateVote(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelegateVote", reflect.TypeOf((*MockRepoModule)(nil).DelegateVote), varargs...)
+}
+
 // DepositProposalFee mocks base method.
 func (m *MockRepoModule) DepositProposalFee(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -1398,6 +1493,34 @@ func (mr *MockRepoModuleMockRecorder) GetParentsAndCommitDiff(name, commitHash i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentsAndCommitDiff", reflect.TypeOf((*MockRepoModule)(nil).GetParentsAndCommitDiff), name, commitHash)
 }
 
+// GetProposal mocks base method.
+func (m *MockRepoModule) GetProposal(params map[string]interface{}) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposal", params)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetProposal indicates an expected call of GetProposal.
+func (mr *MockRepoModuleMockRecorder) GetProposal(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposal", reflect.TypeOf((*MockRepoModule)(nil).GetProposal), params)
+}
+
+// GetReferencedBy mocks base method.
+func (m *MockRepoModule) GetReferencedBy(name, target string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferencedBy", name, target)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetReferencedBy indicates an expected call of GetReferencedBy.
+func (mr *MockRepoModuleMockRecorder) GetReferencedBy(name, target interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferencedBy", reflect.TypeOf((*MockRepoModule)(nil).GetReferencedBy), name, target)
+}
+
 // GetReposCreatedByAddress mocks base method.
 func (m *MockRepoModule) GetReposCreatedByAddress(address string) []string {
 	m.ctrl.T.Helper()
@@ -1426,32 +1549,98 @@ func (mr *MockRepoModuleMockRecorder) GetTracked() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracked", reflect.TypeOf((*MockRepoModule)(nil).GetTracked))
 }
 
+// IsIssueClosed mocks base method.
+func (m *MockRepoModule) IsIssueClosed(name, reference string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIssueClosed", name, reference)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsIssueClosed indicates an expected call of IsIssueClosed.
+func (mr *MockRepoModuleMockRecorder) IsIssueClosed(name, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIssueClosed", reflect.TypeOf((*MockRepoModule)(nil).IsIssueClosed), name, reference)
+}
+
+// ListIssueTemplates mocks base method.
+func (m *MockRepoModule) ListIssueTemplates(name string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssueTemplates", name)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ListIssueTemplates indicates an expected call of ListIssueTemplates.
+func (mr *MockRepoModuleMockRecorder) ListIssueTemplates(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssueTemplates", reflect.TypeOf((*MockRepoModule)(nil).ListIssueTemplates), name)
+}
+
 // ListIssues mocks base method.
-func (m *MockRepoModule) ListIssues(name string) []util.Map {
+func (m *MockRepoModule) ListIssues(name string, query ...map[string]interface{}) []util.Map {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListIssues", name)
+	varargs := []interface{}{name}
+	for _, a := range query {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListIssues", varargs...)
 	ret0, _ := ret[0].([]util.Map)
 	return ret0
 }
 
 // ListIssues indicates an expected call of ListIssues.
-func (mr *MockRepoModuleMockRecorder) ListIssues(name interface{}) *gomock.Call {
+func (mr *MockRepoModuleMockRecorder) ListIssues(name interface{}, query ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssues", reflect.TypeOf((*MockRepoModule)(nil).ListIssues), name)
+	varargs := append([]interface{}{name}, query...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssues", reflect.TypeOf((*MockRepoModule)(nil).ListIssues), varargs...)
+}
+
+// ListMergeRequestTemplates mocks base method.
+func (m *MockRepoModule) ListMergeRequestTemplates(name string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMergeRequestTemplates", name)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ListMergeRequestTemplates indicates an expected call of ListMergeRequestTemplates.
+func (mr *MockRepoModuleMockRecorder) ListMergeRequestTemplates(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMergeRequestTemplates", reflect.TypeOf((*MockRepoModule)(nil).ListMergeRequestTemplates), name)
 }
 
 // ListMergeRequests mocks base method.
-func (m *MockRepoModule) ListMergeRequests(name string) []util.Map {
+func (m *MockRepoModule) ListMergeRequests(name string, query ...map[string]interface{}) []util.Map {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMergeRequests", name)
+	varargs := []interface{}{name}
+	for _, a := range query {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMergeRequests", varargs...)
 	ret0, _ := ret[0].([]util.Map)
 	return ret0
 }
 
 // ListMergeRequests indicates an expected call of ListMergeRequests.
-func (mr *MockRepoModuleMockRecorder) ListMergeRequests(name interface{}) *gomock.Call {
+func (mr *MockRepoModuleMockRecorder) ListMergeRequests(name interface{}, query ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMergeRequests", reflect.TypeOf((*MockRepoModule)(nil).ListMergeRequests), name)
+	varargs := append([]interface{}{name}, query...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMergeRequests", reflect.TypeOf((*MockRepoModule)(nil).ListMergeRequests), varargs...)
+}
+
+// ListMilestones mocks base method.
+func (m *MockRepoModule) ListMilestones(name string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMilestones", name)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ListMilestones indicates an expected call of ListMilestones.
+func (mr *MockRepoModuleMockRecorder) ListMilestones(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMilestones", reflect.TypeOf((*MockRepoModule)(nil).ListMilestones), name)
 }
 
 // ListPath mocks base method.
@@ -1473,6 +1662,115 @@ func (mr *MockRepoModuleMockRecorder) ListPath(name, path interface{}, revision
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPath", reflect.TypeOf((*MockRepoModule)(nil).ListPath), varargs...)
 }
 
+// ListProposals mocks base method.
+func (m *MockRepoModule) ListProposals(params map[string]interface{}) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProposals", params)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ListProposals indicates an expected call of ListProposals.
+func (mr *MockRepoModuleMockRecorder) ListProposals(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProposals", reflect.TypeOf((*MockRepoModule)(nil).ListProposals), params)
+}
+
+// ProposeArchive mocks base method.
+func (m *MockRepoModule) ProposeArchive(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProposeArchive", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ProposeArchive indicates an expected call of ProposeArchive.
+func (mr *MockRepoModuleMockRecorder) ProposeArchive(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeArchive", reflect.TypeOf((*MockRepoModule)(nil).ProposeArchive), varargs...)
+}
+
+// ProposeDelete mocks base method.
+func (m *MockRepoModule) ProposeDelete(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProposeDelete", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ProposeDelete indicates an expected call of ProposeDelete.
+func (mr *MockRepoModuleMockRecorder) ProposeDelete(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeDelete", reflect.TypeOf((*MockRepoModule)(nil).ProposeDelete), varargs...)
+}
+
+// ProposeNamespaceUpdate mocks base method.
+func (m *MockRepoModule) ProposeNamespaceUpdate(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProposeNamespaceUpdate", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ProposeNamespaceUpdate indicates an expected call of ProposeNamespaceUpdate.
+func (mr *MockRepoModuleMockRecorder) ProposeNamespaceUpdate(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeNamespaceUpdate", reflect.TypeOf((*MockRepoModule)(nil).ProposeNamespaceUpdate), varargs...)
+}
+
+// ProposeRename mocks base method.
+func (m *MockRepoModule) ProposeRename(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProposeRename", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ProposeRename indicates an expected call of ProposeRename.
+func (mr *MockRepoModuleMockRecorder) ProposeRename(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeRename", reflect.TypeOf((*MockRepoModule)(nil).ProposeRename), varargs...)
+}
+
+// ProposeSpend mocks base method.
+func (m *MockRepoModule) ProposeSpend(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProposeSpend", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ProposeSpend indicates an expected call of ProposeSpend.
+func (mr *MockRepoModuleMockRecorder) ProposeSpend(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeSpend", reflect.TypeOf((*MockRepoModule)(nil).ProposeSpend), varargs...)
+}
+
 // Push mocks base method.
 func (m *MockRepoModule) Push(params map[string]interface{}, privateKeyOrPushToken string) string {
 	m.ctrl.T.Helper()
@@ -1487,6 +1785,20 @@ func (mr *MockRepoModuleMockRecorder) Push(params, privateKeyOrPushToken interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockRepoModule)(nil).Push), params, privateKeyOrPushToken)
 }
 
+// ReadArchive mocks base method.
+func (m *MockRepoModule) ReadArchive(params map[string]interface{}) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadArchive", params)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ReadArchive indicates an expected call of ReadArchive.
+func (mr *MockRepoModuleMockRecorder) ReadArchive(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadArchive", reflect.TypeOf((*MockRepoModule)(nil).ReadArchive), params)
+}
+
 // ReadFile mocks base method.
 func (m *MockRepoModule) ReadFile(name, filePath string, revision ...string) string {
 	m.ctrl.T.Helper()
@@ -1553,6 +1865,20 @@ func (mr *MockRepoModuleMockRecorder) ReadMergeRequest(name, reference interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMergeRequest", reflect.TypeOf((*MockRepoModule)(nil).ReadMergeRequest), name, reference)
 }
 
+// ReadMilestone mocks base method.
+func (m *MockRepoModule) ReadMilestone(name, reference string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMilestone", name, reference)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ReadMilestone indicates an expected call of ReadMilestone.
+func (mr *MockRepoModuleMockRecorder) ReadMilestone(name, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMilestone", reflect.TypeOf((*MockRepoModule)(nil).ReadMilestone), name, reference)
+}
+
 // ReopenIssue mocks base method.
 func (m *MockRepoModule) ReopenIssue(name, reference string) util.Map {
 	m.ctrl.T.Helper()
@@ -1667,6 +1993,39 @@ func (mr *MockRepoModuleMockRecorder) Vote(params interface{}, options ...interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockRepoModule)(nil).Vote), varargs...)
 }
 
+// WatchProposals mocks base method.
+func (m *MockRepoModule) WatchProposals(names []string, handler func(*core.ProposalEvent)) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchProposals", names, handler)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// WatchProposals indicates an expected call of WatchProposals.
+func (mr *MockRepoModuleMockRecorder) WatchProposals(names, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchProposals", reflect.TypeOf((*MockRepoModule)(nil).WatchProposals), names, handler)
+}
+
+// WithdrawProposal mocks base method.
+func (m *MockRepoModule) WithdrawProposal(params map[string]interface{}, options ...interface{}) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{params}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithdrawProposal", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// WithdrawProposal indicates an expected call of WithdrawProposal.
+func (mr *MockRepoModuleMockRecorder) WithdrawProposal(params interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{params}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawProposal", reflect.TypeOf((*MockRepoModule)(nil).WithdrawProposal), varargs...)
+}
+
 // MockNamespaceModule is a mock of NamespaceModule interface.
 type MockNamespaceModule struct {
 	ctrl     *gomock.Controller
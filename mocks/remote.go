@@ -149,6 +149,20 @@ func (mr *MockRemoteServerMockRecorder) Cfg() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cfg", reflect.TypeOf((*MockRemoteServer)(nil).Cfg))
 }
 
+// CheckLFSObjects mocks base method.
+func (m *MockRemoteServer) CheckLFSObjects(note types.PushNote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLFSObjects", note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckLFSObjects indicates an expected call of CheckLFSObjects.
+func (mr *MockRemoteServerMockRecorder) CheckLFSObjects(note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLFSObjects", reflect.TypeOf((*MockRemoteServer)(nil).CheckLFSObjects), note)
+}
+
 // CheckNote mocks base method.
 func (m *MockRemoteServer) CheckNote(note types.PushNote) error {
 	m.ctrl.T.Helper()
@@ -163,6 +177,20 @@ func (mr *MockRemoteServerMockRecorder) CheckNote(note interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckNote", reflect.TypeOf((*MockRemoteServer)(nil).CheckNote), note)
 }
 
+// DeleteRepository mocks base method.
+func (m *MockRemoteServer) DeleteRepository(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRepository", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRepository indicates an expected call of DeleteRepository.
+func (mr *MockRemoteServerMockRecorder) DeleteRepository(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepository", reflect.TypeOf((*MockRemoteServer)(nil).DeleteRepository), name)
+}
+
 // GetDHT mocks base method.
 func (m *MockRemoteServer) GetDHT() dht.DHT {
 	m.ctrl.T.Helper()
@@ -352,6 +380,20 @@ func (mr *MockRemoteServerMockRecorder) Log() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockRemoteServer)(nil).Log))
 }
 
+// RenameRepository mocks base method.
+func (m *MockRemoteServer) RenameRepository(name, newName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameRepository", name, newName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameRepository indicates an expected call of RenameRepository.
+func (mr *MockRemoteServerMockRecorder) RenameRepository(name, newName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameRepository", reflect.TypeOf((*MockRemoteServer)(nil).RenameRepository), name, newName)
+}
+
 // Shutdown mocks base method.
 func (m *MockRemoteServer) Shutdown(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -1291,17 +1291,17 @@ func (mr *MockLocalRepoMockRecorder) IsClean() *gomock.Call {
 }
 
 // IsContributor mocks base method.
-func (m *MockLocalRepo) IsContributor(arg0 string) bool {
+func (m *MockLocalRepo) IsContributor(arg0 string, arg1 uint64) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsContributor", arg0)
+	ret := m.ctrl.Call(m, "IsContributor", arg0, arg1)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
 // IsContributor indicates an expected call of IsContributor.
-func (mr *MockLocalRepoMockRecorder) IsContributor(arg0 interface{}) *gomock.Call {
+func (mr *MockLocalRepoMockRecorder) IsContributor(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsContributor", reflect.TypeOf((*MockLocalRepo)(nil).IsContributor), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsContributor", reflect.TypeOf((*MockLocalRepo)(nil).IsContributor), arg0, arg1)
 }
 
 // ListPath mocks base method.
@@ -11,6 +11,7 @@ import (
 	rpc "github.com/make-os/kit/rpc"
 	types "github.com/make-os/kit/rpc/types"
 	api "github.com/make-os/kit/types/api"
+	core "github.com/make-os/kit/types/core"
 	util "github.com/make-os/kit/util"
 )
 
@@ -551,6 +552,36 @@ func (mr *MockRepoMockRecorder) AddContributors(body interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContributors", reflect.TypeOf((*MockRepo)(nil).AddContributors), body)
 }
 
+// Archive mocks base method.
+func (m *MockRepo) Archive(body *api.BodyRepoArchive) (*api.ResultRepoArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", body)
+	ret0, _ := ret[0].(*api.ResultRepoArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockRepoMockRecorder) Archive(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockRepo)(nil).Archive), body)
+}
+
+// CheckPolicy mocks base method.
+func (m *MockRepo) CheckPolicy(body *api.BodyRepoCheckPolicy) (*api.ResultCheckPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPolicy", body)
+	ret0, _ := ret[0].(*api.ResultCheckPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPolicy indicates an expected call of CheckPolicy.
+func (mr *MockRepoMockRecorder) CheckPolicy(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPolicy", reflect.TypeOf((*MockRepo)(nil).CheckPolicy), body)
+}
+
 // Create mocks base method.
 func (m *MockRepo) Create(body *api.BodyCreateRepo) (*api.ResultCreateRepo, error) {
 	m.ctrl.T.Helper()
@@ -586,6 +617,66 @@ func (mr *MockRepoMockRecorder) Get(name interface{}, opts ...interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepo)(nil).Get), varargs...)
 }
 
+// GetProposal mocks base method.
+func (m *MockRepo) GetProposal(body *api.BodyRepoGetProposal) (*api.ResultRepoProposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposal", body)
+	ret0, _ := ret[0].(*api.ResultRepoProposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposal indicates an expected call of GetProposal.
+func (mr *MockRepoMockRecorder) GetProposal(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposal", reflect.TypeOf((*MockRepo)(nil).GetProposal), body)
+}
+
+// GetReferencedBy mocks base method.
+func (m *MockRepo) GetReferencedBy(body *api.BodyRepoGetReferencedBy) (*api.ResultRepoReferencedBy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferencedBy", body)
+	ret0, _ := ret[0].(*api.ResultRepoReferencedBy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferencedBy indicates an expected call of GetReferencedBy.
+func (mr *MockRepoMockRecorder) GetReferencedBy(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferencedBy", reflect.TypeOf((*MockRepo)(nil).GetReferencedBy), body)
+}
+
+// ListProposals mocks base method.
+func (m *MockRepo) ListProposals(body *api.BodyRepoListProposals) (*api.ResultRepoProposals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProposals", body)
+	ret0, _ := ret[0].(*api.ResultRepoProposals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProposals indicates an expected call of ListProposals.
+func (mr *MockRepoMockRecorder) ListProposals(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProposals", reflect.TypeOf((*MockRepo)(nil).ListProposals), body)
+}
+
+// ProposeSpend mocks base method.
+func (m *MockRepo) ProposeSpend(body *api.BodyRepoProposeSpend) (*api.ResultHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeSpend", body)
+	ret0, _ := ret[0].(*api.ResultHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposeSpend indicates an expected call of ProposeSpend.
+func (mr *MockRepoMockRecorder) ProposeSpend(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeSpend", reflect.TypeOf((*MockRepo)(nil).ProposeSpend), body)
+}
+
 // VoteProposal mocks base method.
 func (m *MockRepo) VoteProposal(body *api.BodyRepoVote) (*api.ResultHash, error) {
 	m.ctrl.T.Helper()
@@ -601,6 +692,20 @@ func (mr *MockRepoMockRecorder) VoteProposal(body interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteProposal", reflect.TypeOf((*MockRepo)(nil).VoteProposal), body)
 }
 
+// WatchProposals mocks base method.
+func (m *MockRepo) WatchProposals(names []string, handler func(*core.ProposalEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchProposals", names, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchProposals indicates an expected call of WatchProposals.
+func (mr *MockRepoMockRecorder) WatchProposals(names, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchProposals", reflect.TypeOf((*MockRepo)(nil).WatchProposals), names, handler)
+}
+
 // MockRPC is a mock of RPC interface.
 type MockRPC struct {
 	ctrl     *gomock.Controller
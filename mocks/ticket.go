@@ -159,6 +159,21 @@ func (mr *MockTicketManagerMockRecorder) Index(tx, blockHeight, txIndex interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockTicketManager)(nil).Index), tx, blockHeight, txIndex)
 }
 
+// QuadraticValueOfNonDelegatedTickets mocks base method.
+func (m *MockTicketManager) QuadraticValueOfNonDelegatedTickets(maturityHeight uint64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuadraticValueOfNonDelegatedTickets", maturityHeight)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuadraticValueOfNonDelegatedTickets indicates an expected call of QuadraticValueOfNonDelegatedTickets.
+func (mr *MockTicketManagerMockRecorder) QuadraticValueOfNonDelegatedTickets(maturityHeight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuadraticValueOfNonDelegatedTickets", reflect.TypeOf((*MockTicketManager)(nil).QuadraticValueOfNonDelegatedTickets), maturityHeight)
+}
+
 // Query mocks base method.
 func (m *MockTicketManager) Query(qf func(*types.Ticket) bool, queryOpt ...interface{}) []*types.Ticket {
 	m.ctrl.T.Helper()
@@ -27,6 +27,7 @@ const (
 	StatusCodeRepoNotFound          = "repo_not_found"
 	StatusCodeIssueNotFound         = "issue_not_found"
 	StatusCodeMergeRequestNotFound  = "merge_request_not_found"
+	StatusCodeMilestoneNotFound     = "milestone_not_found"
 	StatusCodePathNotFound          = "path_not_found"
 	StatusCodePathNotAFile          = "path_not_file"
 	StatusCodeBranchNotFound        = "branch_not_found"
@@ -36,6 +37,9 @@ const (
 	StatusCodeInvalidReferenceName  = "invalid_reference_name"
 	StatusCodeInvalidPrivateKey     = "invalid_private_key"
 	StatusCodePushFailure           = "push_failure"
+	StatusCodeReferenceNotFound     = "reference_not_found"
+	StatusCodeArchiveTooLarge       = "archive_too_large"
+	StatusCodeTooManyRequests       = "too_many_requests"
 )
 
 var se = errors2.ReqErr
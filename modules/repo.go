@@ -1,8 +1,14 @@
 package modules
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/AlekSi/pointer"
@@ -13,11 +19,13 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/make-os/kit/cmd/issuecmd"
 	"github.com/make-os/kit/cmd/mergecmd"
+	"github.com/make-os/kit/cmd/milestonecmd"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
 	modtypes "github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/node/services"
 	pl "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/policy"
 	"github.com/make-os/kit/remote/repo"
 	remotetypes "github.com/make-os/kit/remote/types"
 	rpctypes "github.com/make-os/kit/rpc/types"
@@ -25,6 +33,7 @@ import (
 	"github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/crypto"
@@ -34,6 +43,7 @@ import (
 	"github.com/robertkrimen/otto"
 	"github.com/spf13/cast"
 	"github.com/stretchr/objx"
+	"github.com/thoas/go-funk"
 )
 
 // RepoModule provides repository functionalities to JS environment
@@ -54,6 +64,11 @@ type RepoModule struct {
 	MergeRequestList   mergecmd.MergeRequestListCmdFunc
 	IssueRead          issuecmd.IssueReadCmdFunc
 	MergeRequestRead   mergecmd.MergeRequestReadCmdFunc
+	MilestoneCreate    milestonecmd.MilestoneCreateCmdFunc
+	MilestoneClose     milestonecmd.MilestoneCloseCmdFunc
+	MilestoneList      milestonecmd.MilestoneListCmdFunc
+	MilestoneRead      milestonecmd.MilestoneReadCmdFunc
+	TemplatesGetter    pl.PostTemplatesGetter
 }
 
 // NewAttachableRepoModule creates an instance of RepoModule suitable in attach mode
@@ -79,6 +94,11 @@ func NewRepoModule(service services.Service, repoSrv core.RemoteServer, logic co
 		MergeRequestList:   mergecmd.MergeRequestListCmd,
 		IssueRead:          issuecmd.IssueReadCmd,
 		MergeRequestRead:   mergecmd.MergeRequestReadCmd,
+		MilestoneCreate:    milestonecmd.MilestoneCreateCmd,
+		MilestoneClose:     milestonecmd.MilestoneCloseCmd,
+		MilestoneList:      milestonecmd.MilestoneListCmd,
+		MilestoneRead:      milestonecmd.MilestoneReadCmd,
+		TemplatesGetter:    pl.GetPostTemplates,
 	}
 }
 
@@ -89,18 +109,31 @@ func (m *RepoModule) methods() []*modtypes.VMMember {
 		{Name: "get", Value: m.Get, Description: "Get and return a repository"},
 		{Name: "update", Value: m.Update, Description: "Update a repository"},
 		{Name: "upsertOwner", Value: m.UpsertOwner, Description: "Create a proposal to add or update a repository owner"},
+		{Name: "proposeSpend", Value: m.ProposeSpend, Description: "Create a proposal to spend from a repository's balance"},
+		{Name: "proposeArchive", Value: m.ProposeArchive, Description: "Create a proposal to archive a repository"},
+		{Name: "proposeDelete", Value: m.ProposeDelete, Description: "Create a proposal to permanently delete a repository"},
+		{Name: "proposeRename", Value: m.ProposeRename, Description: "Create a proposal to rename a repository"},
+		{Name: "proposeNamespaceUpdate", Value: m.ProposeNamespaceUpdate, Description: "Create a proposal to update a namespace owned by a repository"},
 		{Name: "vote", Value: m.Vote, Description: "Vote for or against a proposal"},
 		{Name: "depositPropFee", Value: m.DepositProposalFee, Description: "Deposit fees into a proposal"},
+		{Name: "withdrawProposal", Value: m.WithdrawProposal, Description: "Withdraw a proposal"},
+		{Name: "delegateVote", Value: m.DelegateVote, Description: "Delegate proposal voting power to another address"},
+		{Name: "cancelProposal", Value: m.CancelProposal, Description: "Cancel a queued proposal"},
+		{Name: "listProposals", Value: m.ListProposals, Description: "List and filter the proposals of a repository"},
+		{Name: "getProposal", Value: m.GetProposal, Description: "Get a proposal and the votes cast on it"},
 		{Name: "addContributor", Value: m.AddContributor, Description: "Register one or more push keys as contributors"},
 		{Name: "track", Value: m.Track, Description: "Track one or more repositories"},
 		{Name: "untrack", Value: m.UnTrack, Description: "Untrack one or more repositories"},
 		{Name: "tracked", Value: m.GetTracked, Description: "Get a list of tracked repositories"},
 		{Name: "listByCreator", Value: m.GetReposCreatedByAddress, Description: "List repositories created by an address"},
+		{Name: "checkPolicy", Value: m.CheckPolicy, Description: "Simulate a policy check and get its evaluation trace"},
 
 		// Repository read and write methods.
 		{Name: "ls", Value: m.ListPath, Description: "List files and directories of a repository"},
 		{Name: "readFileLines", Value: m.ReadFileLines, Description: "Get the lines of a file in a repository"},
 		{Name: "readFile", Value: m.ReadFile, Description: "Get the string content of a file in a repository"},
+		{Name: "readArchive", Value: m.ReadArchive, Description: "Get the base64-encoded archive of a repository reference"},
+		{Name: "archive", Value: m.Archive, Description: "Download an archive of a repository reference to disk"},
 		{Name: "getBranches", Value: m.GetBranches, Description: "Get a list of branches in a repository"},
 		{Name: "getLatestCommit", Value: m.GetLatestBranchCommit, Description: "Get the latest commit of a branch in a repository"},
 		{Name: "getCommits", Value: m.GetCommits, Description: "Get a list of commits in a branch of a repository"},
@@ -113,11 +146,19 @@ func (m *RepoModule) methods() []*modtypes.VMMember {
 		{Name: "reopenIssue", Value: m.ReopenIssue, Description: "Reopen an issue"},
 		{Name: "listIssues", Value: m.ListIssues, Description: "List all issues"},
 		{Name: "readIssue", Value: m.ReadIssue, Description: "Read an issue"},
+		{Name: "getReferencedBy", Value: m.GetReferencedBy, Description: "Get commits and posts that reference a post or commit"},
+		{Name: "isIssueClosed", Value: m.IsIssueClosed, Description: "Check whether an issue is closed"},
+		{Name: "listIssueTemplates", Value: m.ListIssueTemplates, Description: "List the issue templates of a repository"},
 		{Name: "createMergeRequest", Value: m.CreateMergeRequest, Description: "Create, add comment or edit a merge request"},
 		{Name: "closeMergeRequest", Value: m.CloseMergeRequest, Description: "Close a merge request"},
 		{Name: "reopenMergeRequest", Value: m.ReopenMergeRequest, Description: "Reopen a merge request"},
 		{Name: "listMergeRequests", Value: m.ListMergeRequests, Description: "List all merge requests"},
 		{Name: "readMergeRequest", Value: m.ReadMergeRequest, Description: "Read a merge request"},
+		{Name: "listMergeRequestTemplates", Value: m.ListMergeRequestTemplates, Description: "List the merge request templates of a repository"},
+		{Name: "createMilestone", Value: m.CreateMilestone, Description: "Create, add comment or update a milestone"},
+		{Name: "closeMilestone", Value: m.CloseMilestone, Description: "Close a milestone"},
+		{Name: "listMilestones", Value: m.ListMilestones, Description: "List all milestones and their progress"},
+		{Name: "readMilestone", Value: m.ReadMilestone, Description: "Read a milestone and its progress"},
 		{Name: "push", Value: m.Push, Description: "Sign and push a commit, tag or note in a temporary worktree"},
 	}
 }
@@ -242,6 +283,64 @@ func (m *RepoModule) UpsertOwner(params map[string]interface{}, options ...inter
 	}
 }
 
+// ProposeSpend creates a proposal to transfer coins from a repository's balance to an address
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - id <string>: A unique proposal id
+//  - to <string>: The address of the recipient
+//  - amount <number|string>: The amount of coins to transfer
+//  - value <number|string>: The proposal fee to pay
+//  - nonce <number|string>: The senders next account nonce
+//  - fee <number|string>: The transaction fee to pay
+//  - timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) ProposeSpend(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalSpend()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	if retPayload {
+		return tx.ToMap()
+	}
+
+	if m.IsAttached() {
+		resp, err := m.Client.Repo().ProposeSpend(&api.BodyRepoProposeSpend{
+			RepoName:   tx.RepoName,
+			ProposalID: tx.ID,
+			To:         tx.To,
+			Amount:     cast.ToFloat64(tx.Amount.String()),
+			Value:      cast.ToFloat64(tx.Value.String()),
+			Nonce:      tx.Nonce,
+			Fee:        cast.ToFloat64(tx.Fee.String()),
+			SigningKey: ed25519.NewKeyFromPrivKey(signingKey),
+		})
+		if err != nil {
+			panic(err)
+		}
+		return util.ToMap(resp)
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
 // Vote sends a TxTypeRepoCreate transaction to create a git repository
 //
 // params <map>
@@ -407,6 +506,491 @@ func (m *RepoModule) Update(params map[string]interface{}, options ...interface{
 	}
 }
 
+// WithdrawProposal creates a transaction to withdraw a proposal.
+// Only the creator of the proposal can withdraw it.
+//
+// params <map>
+//  - params.name <string>: The name of the repository
+//  - params.id <string>: The ID of the proposal to withdraw
+//  - params.nonce <number|string>: The senders next account nonce
+//  - params.fee <number|string>: The transaction fee to pay
+//  - params.timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) WithdrawProposal(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalWithdraw()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
+// ProposeArchive creates a proposal to archive a repository, making it read-only
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - id <string>: A unique proposal id
+//  - value <number|string>: The proposal fee to pay
+//  - nonce <number|string>: The senders next account nonce
+//  - fee <number|string>: The transaction fee to pay
+//  - timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) ProposeArchive(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalArchive()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
+// ProposeDelete creates a proposal to permanently delete a repository
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - id <string>: A unique proposal id
+//  - value <number|string>: The proposal fee to pay
+//  - nonce <number|string>: The senders next account nonce
+//  - fee <number|string>: The transaction fee to pay
+//  - timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) ProposeDelete(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalDelete()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
+// ProposeRename creates a proposal to rename a repository.
+// The old name redirects to the new name for a number of blocks.
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - id <string>: A unique proposal id
+//  - newName <string>: The new name of the repository
+//  - [namespaces] <[]string>: Namespaces whose domains targeting the repository should target the new name
+//  - value <number|string>: The proposal fee to pay
+//  - nonce <number|string>: The senders next account nonce
+//  - fee <number|string>: The transaction fee to pay
+//  - timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) ProposeRename(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalRename()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
+// ProposeNamespaceUpdate creates a proposal to update the domains and
+// contributors of a namespace owned by a repository.
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - id <string>: A unique proposal id
+//  - namespace <string>: The name of the namespace
+//  - [domains] <map[string]string>: The domains to add or update. A domain with an empty target is removed.
+//  - [removeContributors] <[]string>: The push key IDs of contributors to remove from the namespace
+//  - value <number|string>: The proposal fee to pay
+//  - nonce <number|string>: The senders next account nonce
+//  - fee <number|string>: The transaction fee to pay
+//  - timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) ProposeNamespaceUpdate(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalUpdateNamespace()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
+// CancelProposal creates a transaction to cancel an accepted proposal
+// that is queued for execution. Only owners with veto right can cancel it.
+//
+// params <map>
+//  - params.name <string>: The name of the repository
+//  - params.id <string>: The ID of the proposal to cancel
+//  - params.nonce <number|string>: The senders next account nonce
+//  - params.fee <number|string>: The transaction fee to pay
+//  - params.timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) CancelProposal(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalCancel()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
+// WatchProposals passes lifecycle events of proposals of the given
+// repositories to the handler as they are emitted by the node. If no
+// repository is given, events of all repositories are passed.
+// It returns a function that stops the watch.
+func (m *RepoModule) WatchProposals(names []string, handler func(evt *core.ProposalEvent)) (stop func()) {
+	bus := m.logic.Config().G().Bus
+	ch := bus.On(core.EvtProposal)
+	go func() {
+		for evt := range ch {
+			pe := evt.Args[0].(*core.ProposalEvent)
+			if len(names) == 0 || funk.ContainsString(names, pe.RepoName) {
+				handler(pe)
+			}
+		}
+	}()
+	return func() { bus.Off(core.EvtProposal, ch) }
+}
+
+// ListProposals returns the proposals of a repository, newest first.
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - status <string>: Only include proposals with this status (deposit, voting or closed)
+//  - action <number>: Only include proposals of this action type
+//  - creator <string>: Only include proposals created by this address
+//  - offset <number>: The number of matched proposals to skip
+//  - limit <number>: The maximum number of proposals to return. 0 means all.
+//
+// RETURN object <map>
+//  - proposals <[]map>: The matched proposals
+//  - total <number>: The number of proposals matched before pagination
+func (m *RepoModule) ListProposals(params map[string]interface{}) util.Map {
+	o := objx.New(params)
+	name := o.Get("name").Str()
+	status := o.Get("status").Str()
+	creator := o.Get("creator").Str()
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if status != "" && !state.IsValidProposalStatus(status) {
+		panic(se(400, StatusCodeInvalidParam, "status", "unknown proposal status"))
+	}
+	action, err := cast.ToIntE(o.Get("action").Inter())
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "action", "unexpected type"))
+	}
+	offset, limit := decodePageParams(o)
+
+	if m.IsAttached() {
+		resp, err := m.Client.Repo().ListProposals(&api.BodyRepoListProposals{
+			RepoName: name,
+			Status:   status,
+			Action:   types.TxCode(action),
+			Creator:  creator,
+			Offset:   offset,
+			Limit:    limit,
+		})
+		if err != nil {
+			panic(err)
+		}
+		return util.ToMap(resp)
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	bi, err := m.logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	var res = &api.ResultRepoProposals{Proposals: []*api.ResultRepoProposal{}}
+	for id, prop := range repoState.Proposals {
+		if action != 0 && prop.Action != types.TxCode(action) {
+			continue
+		}
+		if creator != "" && prop.Creator != creator {
+			continue
+		}
+		result := m.makeProposalResult(name, id, prop, uint64(bi.Height))
+		if status != "" && result.Status != status {
+			continue
+		}
+		res.Proposals = append(res.Proposals, result)
+	}
+
+	sort.Slice(res.Proposals, func(i, j int) bool {
+		a, b := res.Proposals[i], res.Proposals[j]
+		if a.Height != b.Height {
+			return a.Height > b.Height
+		}
+		return a.ID < b.ID
+	})
+
+	res.Total = len(res.Proposals)
+	start, end := pageBounds(res.Total, offset, limit)
+	res.Proposals = res.Proposals[start:end]
+
+	return util.ToMap(res)
+}
+
+// GetProposal returns a repository proposal and the votes cast on it,
+// ordered by the address of the voters.
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - id <string>: The ID of the proposal
+//  - offset <number>: The number of votes to skip
+//  - limit <number>: The maximum number of votes to return. 0 means all.
+//
+// RETURN object <map>
+//  - id <string>: The ID of the proposal
+//  - status <string>: The status of the proposal (deposit, voting or closed)
+//  - votes <[]map>: The votes (voter, vote and power) cast on the proposal
+//  - totalVotes <number>: The number of votes cast on the proposal
+//  - ...: The fields of the proposal
+func (m *RepoModule) GetProposal(params map[string]interface{}) util.Map {
+	o := objx.New(params)
+	name := o.Get("name").Str()
+	id := o.Get("id").Str()
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if id == "" {
+		panic(se(400, StatusCodeInvalidParam, "id", "proposal id is required"))
+	}
+	offset, limit := decodePageParams(o)
+
+	if m.IsAttached() {
+		resp, err := m.Client.Repo().GetProposal(&api.BodyRepoGetProposal{
+			RepoName:   name,
+			ProposalID: id,
+			Offset:     offset,
+			Limit:      limit,
+		})
+		if err != nil {
+			panic(err)
+		}
+		return util.ToMap(resp)
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	prop := repoState.Proposals.Get(id)
+	if prop == nil {
+		panic(se(404, StatusCodeInvalidParam, "id", "proposal not found"))
+	}
+
+	bi, err := m.logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	votes, err := m.logic.RepoKeeper().GetProposalVotes(name, id)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	res := m.makeProposalResult(name, id, prop, uint64(bi.Height))
+	res.TotalVotes = len(votes)
+	start, end := pageBounds(len(votes), offset, limit)
+	res.Votes = votes[start:end]
+
+	return util.ToMap(res)
+}
+
+// makeProposalResult creates the result object of a proposal. A proposal
+// marked as closed in the closed proposal index is always reported as closed.
+func (m *RepoModule) makeProposalResult(name, id string, prop *state.RepoProposal, height uint64) *api.ResultRepoProposal {
+	res := &api.ResultRepoProposal{RepoProposal: *prop, ID: id, Status: prop.GetStatus(height)}
+	if res.Status != state.ProposalStatusClosed {
+		if closed, _ := m.logic.RepoKeeper().IsProposalClosed(name, id); closed {
+			res.Status = state.ProposalStatusClosed
+		}
+	}
+	return res
+}
+
+// decodePageParams returns the offset and limit pagination parameters
+func decodePageParams(o objx.Map) (offset, limit int) {
+	offset, err := cast.ToIntE(o.Get("offset").Inter())
+	if err != nil || offset < 0 {
+		panic(se(400, StatusCodeInvalidParam, "offset", "offset must be a non-negative number"))
+	}
+	limit, err = cast.ToIntE(o.Get("limit").Inter())
+	if err != nil || limit < 0 {
+		panic(se(400, StatusCodeInvalidParam, "limit", "limit must be a non-negative number"))
+	}
+	return offset, limit
+}
+
+// pageBounds returns the start and end indexes of a page of a list with the
+// given size. A zero limit selects all items after the offset.
+func pageBounds(size, offset, limit int) (start, end int) {
+	start, end = offset, size
+	if start > size {
+		start = size
+	}
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	return start, end
+}
+
+// DelegateVote creates a transaction to delegate the proposal voting power
+// of a repository owner to another address. The delegate's vote choice is
+// counted for the owner on proposals the owner did not vote on.
+//
+// params <map>
+//  - params.name <string>: The name of the repository
+//  - params.delegate <string>: The address of the delegate; empty to revoke
+//  - params.nonce <number|string>: The senders next account nonce
+//  - params.fee <number|string>: The transaction fee to pay
+//  - params.timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) DelegateVote(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoVoteDelegate()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
+
 // DepositProposalFee creates a transaction to deposit a fee to a proposal
 //
 // params <map>
@@ -546,6 +1130,99 @@ func (m *RepoModule) GetReposCreatedByAddress(address string) []string {
 	return repos
 }
 
+// CheckPolicy simulates the authorization check performed when a push key
+// attempts an action on a repository reference and returns the evaluation trace.
+//
+// params <map>
+//  - name <string>: The name of the repository
+//  - namespace <string>: The name of the namespace the repository is accessed through (optional)
+//  - pushKeyID <string>: The push key ID of the pusher
+//  - reference <string>: The target reference (e.g refs/heads/master)
+//  - action <string>: The action to check (default: write)
+//
+// RETURN object <map>
+//  - reference <string>: The target reference
+//  - rootDir <string>: The root directory of the target reference
+//  - action <string>: The checked action
+//  - pushKeyID <string>: The push key ID of the pusher
+//  - isContributor <bool>: Indicates whether the push key is a contributor
+//  - isRefCreator <bool>: Indicates whether the push key created the reference
+//  - steps <[]map>: The policy lookups performed, in evaluation order
+//  - allowed <bool>: The final verdict
+func (m *RepoModule) CheckPolicy(params map[string]interface{}) util.Map {
+	o := objx.New(params)
+	name := o.Get("name").Str()
+	namespaceName := o.Get("namespace").Str()
+	pushKeyID := o.Get("pushKeyID").Str()
+	reference := o.Get("reference").Str()
+	action := o.Get("action").Str()
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if pushKeyID == "" {
+		panic(se(400, StatusCodeInvalidParam, "pushKeyID", "push key ID is required"))
+	}
+	if !crypto.IsValidPushAddr(pushKeyID) {
+		panic(se(400, StatusCodeInvalidParam, "pushKeyID", "push key ID is not valid"))
+	}
+	if reference == "" {
+		panic(se(400, StatusCodeInvalidParam, "reference", "reference is required"))
+	}
+	if action == "" {
+		action = policy.PolicyActionWrite
+	}
+
+	if m.IsAttached() {
+		resp, err := m.Client.Repo().CheckPolicy(&api.BodyRepoCheckPolicy{
+			RepoName:  name,
+			Namespace: namespaceName,
+			PushKeyID: pushKeyID,
+			Reference: reference,
+			Action:    action,
+		})
+		if err != nil {
+			panic(err)
+		}
+		return util.ToMap(resp)
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	var namespace *state.Namespace
+	if namespaceName != "" {
+		namespace = m.logic.NamespaceKeeper().Get(crypto.MakeNamespaceHash(namespaceName))
+		if namespace.IsNil() {
+			panic(se(404, StatusCodeInvalidParam, "namespace", "namespace not found"))
+		}
+	}
+
+	bi, err := m.logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	isContributor := state.IsContributor(pushKeyID, repoState, namespace, uint64(bi.Height))
+	refState := repoState.References.Get(reference)
+	isRefCreator := !refState.IsNil() && refState.Creator.String() == pushKeyID
+
+	groups := policy.MakePusherPolicyGroups(pushKeyID, repoState, namespace, uint64(bi.Height))
+	enforcer := policy.GetPolicyEnforcer(groups)
+	trace, err := policy.TraceCheckPolicy(enforcer, reference, isRefCreator, pushKeyID, isContributor, action)
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "reference", err.Error()))
+	}
+
+	res := util.ToMap(trace)
+	res["pushKeyID"] = pushKeyID
+	res["isContributor"] = isContributor
+	res["isRefCreator"] = isRefCreator
+	return res
+}
+
 // ListPath returns a list of entries in a repository's path
 //  - name: The name of the target repository.
 //  - path: The file or directory path to list
@@ -666,18 +1343,167 @@ func (m *RepoModule) ReadFile(name, filePath string, revision ...string) string
 		rev = revision[0]
 	}
 
-	str, err := r.GetFile(rev, filePath)
+	str, err := r.GetFile(rev, filePath)
+	if err != nil {
+		if err == repo.ErrPathNotFound {
+			panic(se(404, StatusCodePathNotFound, "file", err.Error()))
+		}
+		if err == repo.ErrPathNotAFile {
+			panic(se(400, StatusCodePathNotAFile, "file", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "file", err.Error()))
+	}
+
+	return str
+}
+
+// getArchive returns an archive of the tree of a repository reference.
+// Archives are created on first request and cached by tree hash.
+func (m *RepoModule) getArchive(name, ref, format string) *pl.Archive {
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	archive, err := pl.GetArchive(r, ref, format)
+	if err != nil {
+		if err == pl.ErrRefNotFound {
+			panic(se(404, StatusCodeReferenceNotFound, "ref", err.Error()))
+		}
+		if err == pl.ErrArchiveCreationLimit {
+			panic(se(429, StatusCodeTooManyRequests, "", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return archive
+}
+
+// parseArchiveParams validates the parameters of an archive request
+// and returns the repository name, reference and archive format.
+func parseArchiveParams(o objx.Map) (name, ref, format string) {
+	name = o.Get("name").Str()
+	ref = o.Get("ref").Str("HEAD")
+	format = o.Get("format").Str(pl.ArchiveFormatTarGz)
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if format == "" {
+		format = pl.ArchiveFormatTarGz
+	}
+	if !pl.IsValidArchiveFormat(format) {
+		panic(se(400, StatusCodeInvalidParam, "format", pl.ErrUnknownArchiveFormat.Error()))
+	}
+	return
+}
+
+// ReadArchive returns the base64-encoded archive of the tree of a repository reference.
+// Archives larger than plumbing.MaxInlineArchiveSize are not returned; They must be
+// downloaded from the /<namespace>/<repo>/archive/<ref>.<format> endpoint of the
+// remote server.
+//
+// params <map>
+//  - name <string>: The name of the target repository.
+//  - [ref] <string>: A branch, tag, reference name or commit hash (default: HEAD).
+//  - [format] <string>: The archive format; "tar.gz" or "zip" (default: tar.gz).
+//
+// RETURNS object <map>
+//  - treeHash <string>: The hash of the archived tree.
+//  - format <string>: The archive format.
+//  - size <number>: The size of the archive.
+//  - content <string>: The base64-encoded content of the archive.
+func (m *RepoModule) ReadArchive(params map[string]interface{}) util.Map {
+	name, ref, format := parseArchiveParams(objx.New(params))
+	archive := m.getArchive(name, ref, format)
+	if archive.Size > pl.MaxInlineArchiveSize {
+		msg := fmt.Sprintf("%s (%d bytes); download it from the remote server at /r/%s/archive/%s.%s",
+			pl.ErrArchiveTooLargeToInline, archive.Size, name, ref, format)
+		panic(se(413, StatusCodeArchiveTooLarge, "", msg))
+	}
+
+	content, err := ioutil.ReadFile(archive.Path)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.ToMap(&api.ResultRepoArchive{
+		TreeHash: archive.TreeHash,
+		Format:   archive.Format,
+		Size:     archive.Size,
+		Content:  base64.StdEncoding.EncodeToString(content),
+	})
+}
+
+// Archive writes an archive of the tree of a repository reference to disk.
+//
+// params <map>
+//  - name <string>: The name of the target repository.
+//  - [ref] <string>: A branch, tag, reference name or commit hash (default: HEAD).
+//  - [format] <string>: The archive format; "tar.gz" or "zip" (default: tar.gz).
+//  - [out] <string>: The output file or directory (default: <name>-<ref>.<format>)
+//
+// RETURNS object <map>
+//  - path <string>: The path of the written archive.
+//  - treeHash <string>: The hash of the archived tree.
+//  - format <string>: The archive format.
+//  - size <number>: The size of the archive.
+func (m *RepoModule) Archive(params map[string]interface{}) util.Map {
+	o := objx.New(params)
+	name, ref, format := parseArchiveParams(o)
+
+	out := o.Get("out").Str()
+	fileName := pl.MakeArchiveName(name, ref, format)
+	if out == "" {
+		out = fileName
+	} else if info, err := os.Stat(out); err == nil && info.IsDir() {
+		out = filepath.Join(out, fileName)
+	}
+
+	var result = &api.ResultRepoArchive{}
+	var content io.Reader
+	if m.IsAttached() {
+		resp, err := m.Client.Repo().Archive(&api.BodyRepoArchive{RepoName: name, Ref: ref, Format: format})
+		if err != nil {
+			panic(err)
+		}
+		bz, err := base64.StdEncoding.DecodeString(resp.Content)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", "malformed archive content"))
+		}
+		result, content = resp, bytes.NewReader(bz)
+	} else {
+		archive := m.getArchive(name, ref, format)
+		f, err := os.Open(archive.Path)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		defer f.Close()
+		result.TreeHash, result.Format, result.Size, content = archive.TreeHash, archive.Format, archive.Size, f
+	}
+
+	f, err := os.Create(out)
 	if err != nil {
-		if err == repo.ErrPathNotFound {
-			panic(se(404, StatusCodePathNotFound, "file", err.Error()))
-		}
-		if err == repo.ErrPathNotAFile {
-			panic(se(400, StatusCodePathNotAFile, "file", err.Error()))
-		}
-		panic(se(500, StatusCodeServerErr, "file", err.Error()))
+		panic(se(400, StatusCodeInvalidParam, "out", err.Error()))
+	}
+	defer f.Close()
+	if _, err = io.Copy(f, content); err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return str
+	return util.Map{
+		"path":     out,
+		"treeHash": result.TreeHash,
+		"format":   result.Format,
+		"size":     result.Size,
+	}
 }
 
 // GetBranches returns the list of branches
@@ -916,10 +1742,13 @@ func (m *RepoModule) GetParentsAndCommitDiff(name string, commitHash string) uti
 //    - title: The title of the issue.
 //    - body: The body of the issue.
 //    - replyHash: The commit hash of a comment being replied to.
+//    - editHash: The commit hash of a comment whose body is replaced.
+//    - deleteHash: The commit hash of a comment to delete.
 //    - reactions: An array of unicode emojis.
 //    - labels: A list of labels.
 //    - assignees: A list of assignees.
 //    - close: Closes the issue status.
+//    - template: The name of an issue template to apply to a new issue.
 func (m *RepoModule) CreateIssue(name string, params map[string]interface{}) util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
@@ -963,10 +1792,14 @@ func (m *RepoModule) CreateIssue(name string, params map[string]interface{}) uti
 		Title:              o.Get("title").Str(),
 		Body:               o.Get("body").Str(),
 		ReplyHash:          o.Get("replyHash").Str(),
+		EditHash:           o.Get("editHash").Str(),
+		DeleteHash:         o.Get("deleteHash").Str(),
 		Reactions:          cast.ToStringSlice(o.Get("reactions").Inter()),
 		Labels:             cast.ToStringSlice(o.Get("labels").Inter()),
 		Assignees:          cast.ToStringSlice(o.Get("assignees").Inter()),
 		PostCommentCreator: pl.CreatePostCommit,
+		Template:           o.Get("template").Str(),
+		TemplatesGetter:    m.templatesGetter(r),
 	}
 	closeIssue := o.Get("close")
 	if !closeIssue.IsNil() {
@@ -996,6 +1829,7 @@ func (m *RepoModule) CreateIssue(name string, params map[string]interface{}) uti
 }
 
 // ReadIssue gets an issue.
+// Replies are nested under the comments they reply to.
 //  - name: The name of the repository.
 //  - reference: The full issue reference name.
 func (m *RepoModule) ReadIssue(name, reference string) []util.Map {
@@ -1012,8 +1846,10 @@ func (m *RepoModule) ReadIssue(name, reference string) []util.Map {
 	}
 
 	comments, err := m.IssueRead(r, &issuecmd.IssueReadArgs{
-		Reference:  reference,
-		PostGetter: pl.GetPosts,
+		Reference:             reference,
+		PostGetter:            pl.PostGetterWithCommitCloses(pl.GetPosts, m.crossReferencesGetter(name)),
+		CrossReferencesGetter: m.crossReferencesGetter(name),
+		Tree:                  true,
 	})
 	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
@@ -1022,6 +1858,64 @@ func (m *RepoModule) ReadIssue(name, reference string) []util.Map {
 	return util.StructSliceToMap(comments)
 }
 
+// GetReferencedBy returns the commits and post comments that reference a post or commit.
+//  - name: The name of the repository.
+//  - target: The full reference name of a post or the hash of a commit.
+func (m *RepoModule) GetReferencedBy(name, target string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	if target == "" {
+		panic(se(400, StatusCodeInvalidParam, "target", "target is required"))
+	}
+
+	return util.StructSliceToMap(m.logic.RepoSyncInfoKeeper().GetCrossReferences(name, target))
+}
+
+// IsIssueClosed checks whether an issue is closed by a comment or
+// by a commit on the default branch.
+//  - name: The name of the repository.
+//  - reference: The full issue reference name.
+func (m *RepoModule) IsIssueClosed(name, reference string) bool {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	getPosts := pl.PostGetterWithCommitCloses(pl.GetPosts, m.crossReferencesGetter(name))
+	issues, err := getPosts(r, func(ref plumbing.ReferenceName) bool {
+		return pl.IsIssueReference(ref.String()) && ref.String() == reference
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	} else if len(issues) == 0 {
+		panic(se(404, StatusCodeIssueNotFound, "reference", "issue not found"))
+	}
+
+	closed, err := issues[0].IsClosed()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return closed
+}
+
+// crossReferencesGetter returns a function that gets the indexed
+// commits and post comments of a repository that mention a target.
+func (m *RepoModule) crossReferencesGetter(name string) pl.CrossReferencesGetter {
+	return func(target string) ([]*pl.CrossReference, error) {
+		return m.logic.RepoSyncInfoKeeper().GetCrossReferences(name, target), nil
+	}
+}
+
 // CloseIssue closes an issue.
 //  - name: The name of the repository.
 //  - reference: The full issue reference name.
@@ -1140,7 +2034,14 @@ func (m *RepoModule) ReopenIssue(name, reference string) util.Map {
 
 // ListIssues returns a list of issues.
 //  - name: The name of the repository.
-func (m *RepoModule) ListIssues(name string) []util.Map {
+//  - [query]: Filter and sort options.
+//    - labels: Labels the issues must all have.
+//    - assignees: Push keys the issues must all be assigned to.
+//    - author: The name, email or push key ID of the issues' author.
+//    - state: The state of the issues (open or closed).
+//    - search: A text that must appear in the issues' title or comments.
+//    - sort: The sort order (newest, oldest or updated).
+func (m *RepoModule) ListIssues(name string, query ...map[string]interface{}) []util.Map {
 
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
@@ -1156,7 +2057,9 @@ func (m *RepoModule) ListIssues(name string) []util.Map {
 	}
 
 	issues, err := m.IssueList(r, &issuecmd.IssueListArgs{
-		PostGetter: pl.GetPosts,
+		Query:          decodePostQuery(query),
+		PostDataGetter: m.postDataGetter(r, name),
+		PostGetter:     pl.PostGetterWithCommitCloses(pl.GetPosts, m.crossReferencesGetter(name)),
 	})
 	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
@@ -1176,8 +2079,11 @@ func (m *RepoModule) ListIssues(name string) []util.Map {
 //    - title: The title of the issue.
 //    - body: The body of the issue.
 //    - replyHash: The commit hash of a comment being replied to.
+//    - editHash: The commit hash of a comment whose body is replaced.
+//    - deleteHash: The commit hash of a comment to delete.
 //    - reactions: An array of unicode emojis.
 //    - close: Closes the issue status.
+//    - template: The name of a merge request template to apply to a new merge request.
 func (m *RepoModule) CreateMergeRequest(name string, params map[string]interface{}) util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
@@ -1220,12 +2126,16 @@ func (m *RepoModule) CreateMergeRequest(name string, params map[string]interface
 		Title:              o.Get("title").Str(),
 		Body:               o.Get("body").Str(),
 		ReplyHash:          o.Get("replyHash").Str(),
+		EditHash:           o.Get("editHash").Str(),
+		DeleteHash:         o.Get("deleteHash").Str(),
 		Reactions:          cast.ToStringSlice(o.Get("reactions").Inter()),
 		Base:               o.Get("base").Str(),
 		BaseHash:           o.Get("baseHash").Str(),
 		Target:             o.Get("target").Str(),
 		TargetHash:         o.Get("targetHash").Str(),
 		PostCommentCreator: pl.CreatePostCommit,
+		Template:           o.Get("template").Str(),
+		TemplatesGetter:    m.templatesGetter(r),
 	}
 
 	closeIssue := o.Get("close")
@@ -1255,7 +2165,57 @@ func (m *RepoModule) CreateMergeRequest(name string, params map[string]interface
 	}
 }
 
-// ReadMergeRequest gets an issue.
+// ListIssueTemplates returns the issue templates found on the default branch of a repository.
+//  - name: The name of the repository.
+func (m *RepoModule) ListIssueTemplates(name string) []util.Map {
+	return m.listPostTemplates(name, pl.IssueBranchPrefix)
+}
+
+// ListMergeRequestTemplates returns the merge request templates found on the default branch of a repository.
+//  - name: The name of the repository.
+func (m *RepoModule) ListMergeRequestTemplates(name string) []util.Map {
+	return m.listPostTemplates(name, pl.MergeRequestBranchPrefix)
+}
+
+// listPostTemplates returns the templates of a post type found in a repository
+func (m *RepoModule) listPostTemplates(name, postType string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	templates, err := m.TemplatesGetter(r, postType)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to get templates").Error()))
+	}
+
+	var res = []util.Map{}
+	for _, tpl := range templates {
+		res = append(res, util.ToMap(tpl))
+	}
+
+	return res
+}
+
+// templatesGetter returns a post templates getter that reads templates from
+// the given repository. It is used in place of shallow clones of a post
+// reference which do not include the default branch.
+func (m *RepoModule) templatesGetter(r pl.LocalRepo) pl.PostTemplatesGetter {
+	return func(_ pl.LocalRepo, postType string) (pl.PostTemplates, error) {
+		return m.TemplatesGetter(r, postType)
+	}
+}
+
+// ReadMergeRequest gets a merge request.
+// Replies are nested under the comments they reply to.
 //  - name: The name of the repository.
 //  - reference: The full merge request reference name.
 func (m *RepoModule) ReadMergeRequest(name, reference string) []util.Map {
@@ -1274,6 +2234,7 @@ func (m *RepoModule) ReadMergeRequest(name, reference string) []util.Map {
 	comments, err := m.MergeRequestRead(r, &mergecmd.MergeRequestReadArgs{
 		Reference:  reference,
 		PostGetter: pl.GetPosts,
+		Tree:       true,
 	})
 	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
@@ -1400,7 +2361,12 @@ func (m *RepoModule) ReopenMergeRequest(name, reference string) util.Map {
 
 // ListMergeRequests returns a list of merge requests.
 //  - name: The name of the repository.
-func (m *RepoModule) ListMergeRequests(name string) []util.Map {
+//  - [query]: Filter and sort options.
+//    - author: The name, email or push key ID of the merge requests' author.
+//    - state: The state of the merge requests (open or closed).
+//    - search: A text that must appear in the merge requests' title or comments.
+//    - sort: The sort order (newest, oldest or updated).
+func (m *RepoModule) ListMergeRequests(name string, query ...map[string]interface{}) []util.Map {
 
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
@@ -1416,7 +2382,9 @@ func (m *RepoModule) ListMergeRequests(name string) []util.Map {
 	}
 
 	issues, err := m.MergeRequestList(r, &mergecmd.MergeRequestListArgs{
-		PostGetter: pl.GetPosts,
+		Query:          decodePostQuery(query),
+		PostDataGetter: m.postDataGetter(r, name),
+		PostGetter:     pl.GetPosts,
 	})
 	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
@@ -1425,6 +2393,44 @@ func (m *RepoModule) ListMergeRequests(name string) []util.Map {
 	return util.StructSliceToMap(issues)
 }
 
+// decodePostQuery decodes an optional post query parameter.
+// It returns nil if no query was provided.
+func decodePostQuery(query []map[string]interface{}) *pl.PostQuery {
+	if len(query) == 0 || len(query[0]) == 0 {
+		return nil
+	}
+	var q pl.PostQuery
+	if err := util.DecodeMap(query[0], &q); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "query", err.Error()))
+	}
+	if err := q.Validate(); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "query", err.Error()))
+	}
+	return &q
+}
+
+// postDataGetter returns a function that provides the labels, assignees and close
+// status of the posts of a repository from the network state. Closes by commits
+// on the default branch are applied to the close status.
+func (m *RepoModule) postDataGetter(r pl.LocalRepo, name string) pl.PostDataGetter {
+	var repoState *state.Repository
+	return func(reference string) *state.ReferenceData {
+		if repoState == nil {
+			repoState = m.logic.RepoKeeper().Get(name)
+		}
+		ref := repoState.References.Get(reference)
+		if ref.IsNil() || ref.Data == nil {
+			return nil
+		}
+		data := *ref.Data
+		xrefs := m.logic.RepoSyncInfoKeeper().GetCrossReferences(name, reference)
+		if closed, err := pl.ApplyCommitCloses(r, reference, data.Closed, xrefs); err == nil {
+			data.Closed = closed
+		}
+		return &data
+	}
+}
+
 // Push signs and pushes a reference in a temporary repository identified by ID.
 //   params <map>
 //     - id: The unique temporary manager ID of the target repository.
@@ -1540,3 +2546,207 @@ func (m *RepoModule) Push(params map[string]interface{}, privateKeyOrPushToken s
 	hash = strings.TrimSpace(stripansi.Strip(hash))
 	return hash
 }
+
+// CreateMilestone creates a milestone or adds a comment to an existing milestone.
+//  - name: The name of the repository.
+//  - params: Milestone parameters.
+//    - id: The new milestone ID or ID of an existing milestone to update.
+//    - title: The title of the milestone.
+//    - body: The description of the milestone or a comment.
+//    - due: The due date of the milestone (YYYY-MM-DD).
+//    - issues: A list of issue numbers to link (negative numbers unlink issues).
+//    - close: Closes the milestone status.
+func (m *RepoModule) CreateMilestone(name string, params map[string]interface{}) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	// Generate a new milestone ID
+	o := objx.New(params)
+	id := cast.ToInt(o.Get("id").Inter())
+	if id == 0 {
+		id, err = m.PostIDFinder(r, 1, pl.MilestoneBranchPrefix)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+	}
+
+	issues, err := cast.ToIntSliceE(o.Get("issues").Inter())
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params.issues", "expected a list of issue numbers"))
+	}
+
+	// Clone the repository and the milestone reference.
+	// Determine the full milestone reference name and check
+	// if it exists. If it does not reset to empty string.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: pl.MakeMilestoneReference(id)}
+	_, err = r.RefGet(cloneOpts.ReferenceName)
+	if err != nil {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := r.Clone(cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	// Create the milestone
+	args := &milestonecmd.MilestoneCreateArgs{
+		ID:                 id,
+		Title:              o.Get("title").Str(),
+		Body:               o.Get("body").Str(),
+		DueDate:            o.Get("due").Str(),
+		Issues:             issues,
+		NoBody:             true,
+		PostCommentCreator: pl.CreatePostCommit,
+	}
+	closeMilestone := o.Get("close")
+	if !closeMilestone.IsNil() {
+		args.Close = pointer.ToBool(closeMilestone.Bool())
+	}
+
+	res, err := m.MilestoneCreate(cloned, args)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+}
+
+// CloseMilestone closes a milestone.
+//  - name: The name of the repository.
+//  - reference: The full milestone reference name.
+func (m *RepoModule) CloseMilestone(name, reference string) util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	curRefHash, err := r.RefGet(reference)
+	if err != nil && err == pl.ErrRefNotFound {
+		panic(se(404, StatusCodeMilestoneNotFound, "reference", "milestone not found"))
+	}
+
+	// Clone the repository and the milestone reference.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
+	if curRefHash == "" {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := r.Clone(cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	res, err := m.MilestoneClose(cloned, &milestonecmd.MilestoneCloseArgs{
+		Reference:          reference,
+		PostCommentCreator: pl.CreatePostCommit,
+		ReadPostBody:       pl.ReadPostBody,
+	})
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+}
+
+// ListMilestones returns a list of milestones and their progress.
+//  - name: The name of the repository.
+func (m *RepoModule) ListMilestones(name string) []util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	milestones, err := m.MilestoneList(r, &milestonecmd.MilestoneListArgs{
+		PostGetter:      pl.GetPosts,
+		MilestoneGetter: pl.MilestoneGetterWithCommitCloses(m.crossReferencesGetter(name)),
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.StructSliceToMap(milestones)
+}
+
+// ReadMilestone gets a milestone and its progress.
+//  - name: The name of the repository.
+//  - reference: The full milestone reference name.
+func (m *RepoModule) ReadMilestone(name, reference string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	milestone, err := m.MilestoneRead(r, &milestonecmd.MilestoneReadArgs{
+		Reference:       reference,
+		PostGetter:      pl.GetPosts,
+		MilestoneGetter: pl.MilestoneGetterWithCommitCloses(m.crossReferencesGetter(name)),
+	})
+	if err != nil {
+		if err.Error() == "milestone not found" {
+			panic(se(404, StatusCodeMilestoneNotFound, "reference", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.ToMap(milestone)
+}
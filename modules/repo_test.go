@@ -2,7 +2,11 @@ package modules_test
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/go-git/go-git/v5"
 	config2 "github.com/go-git/go-git/v5/config"
@@ -10,6 +14,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/cmd/issuecmd"
 	"github.com/make-os/kit/cmd/mergecmd"
+	"github.com/make-os/kit/cmd/milestonecmd"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/mocks"
@@ -17,6 +22,7 @@ import (
 	"github.com/make-os/kit/modules"
 	"github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/remote/plumbing"
+	testutil2 "github.com/make-os/kit/remote/testutil"
 	remotetypes "github.com/make-os/kit/remote/types"
 	"github.com/make-os/kit/testutil"
 	"github.com/make-os/kit/types/api"
@@ -211,6 +217,55 @@ var _ = Describe("RepoModule", func() {
 		})
 	})
 
+	Describe(".ProposeSpend", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"to": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'to' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeSpend(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"name": "repo1", "to": "addr1", "amount": "10"}
+			res := m.ProposeSpend(params, key, true)
+			Expect(res["name"]).To(Equal("repo1"))
+			Expect(res["to"]).To(Equal("addr1"))
+			Expect(res["amount"]).To(Equal("10"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalSpend)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("to"),
+				HaveKey("amount"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"name": "repo1", "to": "addr1", "amount": "10"}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeSpend(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"name": "repo1", "to": "addr1", "amount": "10"}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.ProposeSpend(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
 	Describe(".Vote", func() {
 		It("should panic when unable to decode params", func() {
 			params := map[string]interface{}{"name": struct{}{}}
@@ -484,6 +539,535 @@ var _ = Describe("RepoModule", func() {
 		})
 	})
 
+	Describe(".WithdrawProposal", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.WithdrawProposal(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1}
+			res := m.WithdrawProposal(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalWithdraw)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.WithdrawProposal(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.WithdrawProposal(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".ProposeArchive", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeArchive(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1}
+			res := m.ProposeArchive(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalArchive)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeArchive(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.ProposeArchive(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".ProposeDelete", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeDelete(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1}
+			res := m.ProposeDelete(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalDelete)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeDelete(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.ProposeDelete(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".ProposeRename", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeRename(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1, "newName": "repo2", "namespaces": []string{"ns1"}}
+			res := m.ProposeRename(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res["newName"]).To(Equal("repo2"))
+			Expect(res["namespaces"]).To(Equal([]interface{}{"ns1"}))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalRename)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeRename(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.ProposeRename(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".ProposeNamespaceUpdate", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeNamespaceUpdate(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1, "namespace": "ns1", "domains": map[string]string{"app": "r/repo1"}}
+			res := m.ProposeNamespaceUpdate(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res["namespace"]).To(Equal("ns1"))
+			Expect(res["domains"]).To(Equal(map[string]interface{}{"app": "r/repo1"}))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalUpdateNamespace)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ProposeNamespaceUpdate(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.ProposeNamespaceUpdate(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".WatchProposals", func() {
+		It("should pass only events of the given repositories to the handler", func() {
+			events := make(chan *core.ProposalEvent, 2)
+			stop := m.WatchProposals([]string{"repo1"}, func(evt *core.ProposalEvent) { events <- evt })
+			defer stop()
+			<-cfg.G().Bus.Emit(core.EvtProposal, &core.ProposalEvent{RepoName: "repo2", ProposalID: "1"})
+			<-cfg.G().Bus.Emit(core.EvtProposal, &core.ProposalEvent{RepoName: "repo1", ProposalID: "2"})
+			evt := <-events
+			Expect(evt.RepoName).To(Equal("repo1"))
+			Expect(evt.ProposalID).To(Equal("2"))
+		})
+
+		It("should pass events of all repositories to the handler if no repository is given", func() {
+			events := make(chan *core.ProposalEvent, 2)
+			stop := m.WatchProposals(nil, func(evt *core.ProposalEvent) { events <- evt })
+			defer stop()
+			<-cfg.G().Bus.Emit(core.EvtProposal, &core.ProposalEvent{RepoName: "repo2", ProposalID: "1"})
+			evt := <-events
+			Expect(evt.RepoName).To(Equal("repo2"))
+		})
+	})
+
+	Describe(".ListProposals", func() {
+		var mockSysKeeper *mocks.MockSystemKeeper
+		var repo *state.Repository
+
+		getIDs := func(res util.Map) (ids []string) {
+			for _, p := range res["proposals"].([]interface{}) {
+				ids = append(ids, p.(map[string]interface{})["id"].(string))
+			}
+			return
+		}
+
+		BeforeEach(func() {
+			mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+			mockLogic.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+			repo = state.BareRepository()
+			repo.Balance = "100"
+			repo.Proposals.Add("1", &state.RepoProposal{Height: 1, Creator: "addr1", Action: txns.TxTypeRepoProposalSpend})
+			repo.Proposals.Add("2", &state.RepoProposal{Height: 2, Creator: "addr2", Action: txns.TxTypeRepoProposalUpdate, Outcome: state.ProposalOutcomeAccepted})
+			repo.Proposals.Add("3", &state.RepoProposal{Height: 3, Creator: "addr1", Action: txns.TxTypeRepoProposalSpend, FeeDepositEndAt: 20})
+		})
+
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListProposals(map[string]interface{}{})
+			})
+		})
+
+		It("should panic if status is unknown", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "unknown proposal status", Field: "status"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListProposals(map[string]interface{}{"name": "repo1", "status": "unknown"})
+			})
+		})
+
+		It("should panic if offset is negative", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "offset must be a non-negative number", Field: "offset"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListProposals(map[string]interface{}{"name": "repo1", "offset": -1})
+			})
+		})
+
+		It("should panic if in attach mode and RPC client method returns error", func() {
+			mockClient := mocks2.NewMockClient(ctrl)
+			mockRepoClient := mocks2.NewMockRepo(ctrl)
+			mockClient.EXPECT().Repo().Return(mockRepoClient)
+			m.Client = mockClient
+			mockRepoClient.EXPECT().ListProposals(&api.BodyRepoListProposals{
+				RepoName: "repo1",
+				Status:   state.ProposalStatusVoting,
+				Action:   txns.TxTypeRepoProposalSpend,
+				Limit:    10,
+			}).Return(nil, fmt.Errorf("error"))
+			assert.PanicsWithError(GinkgoT(), "error", func() {
+				m.ListProposals(map[string]interface{}{"name": "repo1", "status": "voting", "action": int(txns.TxTypeRepoProposalSpend), "limit": 10})
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListProposals(map[string]interface{}{"name": "repo1"})
+			})
+		})
+
+		When("repo exists", func() {
+			BeforeEach(func() {
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 10}, nil)
+			})
+
+			It("should return all proposals, newest first", func() {
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", gomock.Any()).Return(false, nil).Times(2)
+				res := m.ListProposals(map[string]interface{}{"name": "repo1"})
+				Expect(res["total"]).To(Equal(3))
+				Expect(getIDs(res)).To(Equal([]string{"3", "2", "1"}))
+				var statuses []string
+				for _, p := range res["proposals"].([]interface{}) {
+					statuses = append(statuses, p.(map[string]interface{})["status"].(string))
+				}
+				Expect(statuses).To(Equal([]string{state.ProposalStatusDeposit, state.ProposalStatusClosed, state.ProposalStatusVoting}))
+			})
+
+			It("should return proposals with the given status", func() {
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", gomock.Any()).Return(false, nil).Times(2)
+				res := m.ListProposals(map[string]interface{}{"name": "repo1", "status": "closed"})
+				Expect(res["total"]).To(Equal(1))
+				Expect(getIDs(res)).To(Equal([]string{"2"}))
+			})
+
+			It("should report a proposal marked as closed as closed", func() {
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", "1").Return(true, nil)
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", "3").Return(false, nil)
+				res := m.ListProposals(map[string]interface{}{"name": "repo1", "status": "closed"})
+				Expect(getIDs(res)).To(Equal([]string{"2", "1"}))
+			})
+
+			It("should return proposals with the given action and creator", func() {
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", gomock.Any()).Return(false, nil).Times(2)
+				res := m.ListProposals(map[string]interface{}{"name": "repo1", "action": int(txns.TxTypeRepoProposalSpend), "creator": "addr1"})
+				Expect(res["total"]).To(Equal(2))
+				Expect(getIDs(res)).To(Equal([]string{"3", "1"}))
+			})
+
+			It("should return a page of the matched proposals", func() {
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", gomock.Any()).Return(false, nil).Times(2)
+				res := m.ListProposals(map[string]interface{}{"name": "repo1", "offset": 1, "limit": 1})
+				Expect(res["total"]).To(Equal(3))
+				Expect(getIDs(res)).To(Equal([]string{"2"}))
+			})
+
+			It("should return no proposals if offset is beyond the matched proposals", func() {
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", gomock.Any()).Return(false, nil).Times(2)
+				res := m.ListProposals(map[string]interface{}{"name": "repo1", "offset": 5})
+				Expect(res["total"]).To(Equal(3))
+				Expect(getIDs(res)).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe(".GetProposal", func() {
+		var mockSysKeeper *mocks.MockSystemKeeper
+
+		BeforeEach(func() {
+			mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+			mockLogic.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+		})
+
+		It("should panic if proposal id was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "proposal id is required", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetProposal(map[string]interface{}{"name": "repo1"})
+			})
+		})
+
+		It("should panic if in attach mode and RPC client method returns error", func() {
+			mockClient := mocks2.NewMockClient(ctrl)
+			mockRepoClient := mocks2.NewMockRepo(ctrl)
+			mockClient.EXPECT().Repo().Return(mockRepoClient)
+			m.Client = mockClient
+			mockRepoClient.EXPECT().GetProposal(&api.BodyRepoGetProposal{RepoName: "repo1", ProposalID: "1"}).Return(nil, fmt.Errorf("error"))
+			assert.PanicsWithError(GinkgoT(), "error", func() {
+				m.GetProposal(map[string]interface{}{"name": "repo1", "id": "1"})
+			})
+		})
+
+		It("should panic if proposal does not exist", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "proposal not found", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetProposal(map[string]interface{}{"name": "repo1", "id": "1"})
+			})
+		})
+
+		It("should return the proposal and a page of its votes", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			repo.Proposals.Add("1", &state.RepoProposal{Height: 1, Creator: "addr1", Yes: 3})
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 10}, nil)
+			mockRepoKeeper.EXPECT().IsProposalClosed("repo1", "1").Return(false, nil)
+			mockRepoKeeper.EXPECT().GetProposalVotes("repo1", "1").Return([]*core.ProposalVote{
+				{Voter: "addr1", Vote: 1, Power: 1},
+				{Voter: "addr2", Vote: 1, Power: 2},
+				{Voter: "addr3", Vote: 0, Power: 3},
+			}, nil)
+			res := m.GetProposal(map[string]interface{}{"name": "repo1", "id": "1", "offset": 1, "limit": 1})
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res["status"]).To(Equal(state.ProposalStatusVoting))
+			Expect(res["creator"]).To(Equal("addr1"))
+			Expect(res["yes"]).To(Equal(float64(3)))
+			Expect(res["totalVotes"]).To(Equal(3))
+			Expect(res["votes"]).To(Equal([]interface{}{
+				map[string]interface{}{"voter": "addr2", "vote": 1, "power": float64(2)},
+			}))
+		})
+	})
+
+	Describe(".CancelProposal", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CancelProposal(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1}
+			res := m.CancelProposal(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalCancel)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CancelProposal(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.CancelProposal(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".DelegateVote", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"delegate": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'delegate' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.DelegateVote(params)
+			})
+		})
+
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"name": "repo1", "delegate": "addr1"}
+			res := m.DelegateVote(params, key, true)
+			Expect(res["delegate"]).To(Equal("addr1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoVoteDelegate)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("name"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"name": "repo1"}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.DelegateVote(params, "", false)
+			})
+		})
+
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"name": "repo1"}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.DelegateVote(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
 	Describe(".AddContributor", func() {
 		It("should panic when unable to decode params", func() {
 			params := map[string]interface{}{"id": struct{}{}}
@@ -583,26 +1167,113 @@ var _ = Describe("RepoModule", func() {
 			mockRepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(fmt.Errorf("error"))
 			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.UnTrack("repo1")
+				m.UnTrack("repo1")
+			})
+		})
+
+		It("should not panic if able to untrack repo", func() {
+			mockRepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(nil)
+			assert.NotPanics(GinkgoT(), func() {
+				m.UnTrack("repo1")
+			})
+		})
+	})
+
+	Describe(".Get", func() {
+		It("should panic if unable to untrack repo", func() {
+			tracked := map[string]*core.TrackedRepo{
+				"repo1": {UpdatedAt: 10},
+			}
+			mockRepoSyncInfoKeeper.EXPECT().Tracked().Return(tracked)
+			res := m.GetTracked()
+			Expect(res).To(Equal(util.Map(util.ToJSONMap(tracked))))
+		})
+	})
+
+	Describe(".CheckPolicy", func() {
+		var pushKeyID = ed25519.NewKeyFromIntSeed(1).PushAddr().String()
+		var mockSysKeeper *mocks.MockSystemKeeper
+
+		BeforeEach(func() {
+			mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+			mockLogic.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+		})
+
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckPolicy(map[string]interface{}{})
+			})
+		})
+
+		It("should panic if push key ID is not valid", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "push key ID is not valid", Field: "pushKeyID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckPolicy(map[string]interface{}{"name": "repo1", "pushKeyID": "invalid"})
+			})
+		})
+
+		It("should panic if in attach mode and RPC client method returns error", func() {
+			mockClient := mocks2.NewMockClient(ctrl)
+			mockRepoClient := mocks2.NewMockRepo(ctrl)
+			mockClient.EXPECT().Repo().Return(mockRepoClient)
+			m.Client = mockClient
+
+			mockRepoClient.EXPECT().CheckPolicy(&api.BodyRepoCheckPolicy{
+				RepoName:  "repo1",
+				PushKeyID: pushKeyID,
+				Reference: "refs/heads/master",
+				Action:    "write",
+			}).Return(nil, fmt.Errorf("error"))
+			assert.PanicsWithError(GinkgoT(), "error", func() {
+				m.CheckPolicy(map[string]interface{}{"name": "repo1", "pushKeyID": pushKeyID, "reference": "refs/heads/master"})
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckPolicy(map[string]interface{}{"name": "repo1", "pushKeyID": pushKeyID, "reference": "refs/heads/master"})
 			})
 		})
 
-		It("should not panic if able to untrack repo", func() {
-			mockRepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(nil)
-			assert.NotPanics(GinkgoT(), func() {
-				m.UnTrack("repo1")
+		It("should panic if reference is unknown", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 10}, nil)
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "unknown reference (refs/unknown/xyz)", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckPolicy(map[string]interface{}{"name": "repo1", "pushKeyID": pushKeyID, "reference": "refs/unknown/xyz"})
 			})
 		})
-	})
 
-	Describe(".Get", func() {
-		It("should panic if unable to untrack repo", func() {
-			tracked := map[string]*core.TrackedRepo{
-				"repo1": {UpdatedAt: 10},
-			}
-			mockRepoSyncInfoKeeper.EXPECT().Tracked().Return(tracked)
-			res := m.GetTracked()
-			Expect(res).To(Equal(util.Map(util.ToJSONMap(tracked))))
+		It("should return evaluation trace", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			repo.Config.Policies = append(repo.Config.Policies, &state.Policy{Subject: "contrib", Object: "refs/heads", Action: "write"})
+			repo.Contributors[pushKeyID] = &state.RepoContributor{}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 10}, nil)
+			res := m.CheckPolicy(map[string]interface{}{"name": "repo1", "pushKeyID": pushKeyID, "reference": "refs/heads/master"})
+			Expect(res["allowed"]).To(BeTrue())
+			Expect(res["isContributor"]).To(BeTrue())
+			Expect(res["isRefCreator"]).To(BeFalse())
+			Expect(res["rootDir"]).To(Equal("refs/heads"))
+			Expect(res["steps"]).To(HaveLen(12))
+		})
+
+		It("should ignore expired contributor policies", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			repo.Contributors[pushKeyID] = &state.RepoContributor{Policies: []*state.ContributorPolicy{
+				{Object: "refs/heads/master", Action: "write", EndHeight: 10},
+			}}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 10}, nil)
+			res := m.CheckPolicy(map[string]interface{}{"name": "repo1", "pushKeyID": pushKeyID, "reference": "refs/heads/master"})
+			Expect(res["allowed"]).To(BeFalse())
 		})
 	})
 
@@ -695,6 +1366,124 @@ var _ = Describe("RepoModule", func() {
 		})
 	})
 
+	Describe(".ReadArchive", func() {
+		BeforeEach(func() {
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			testutil2.AppendCommit(filepath.Join(cfg.GetRepoRoot(), "repo1"), "file.txt", "hello", "commit 1")
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(cfg.DataDir())).To(BeNil())
+		})
+
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadArchive(map[string]interface{}{})
+			})
+		})
+
+		It("should panic if format is unknown", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "unknown archive format", Field: "format"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadArchive(map[string]interface{}{"name": "repo1", "format": "rar"})
+			})
+		})
+
+		It("should panic if reference does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeReferenceNotFound, HttpCode: 404, Msg: "reference not found", Field: "ref"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadArchive(map[string]interface{}{"name": "repo1", "ref": "unknown"})
+			})
+		})
+
+		It("should return base64-encoded archive of HEAD by default", func() {
+			res := m.ReadArchive(map[string]interface{}{"name": "repo1"})
+			Expect(res["format"]).To(Equal(plumbing.ArchiveFormatTarGz))
+			Expect(res["treeHash"]).ToNot(BeEmpty())
+			content, err := base64.StdEncoding.DecodeString(res["content"].(string))
+			Expect(err).To(BeNil())
+			Expect(content[:2]).To(Equal([]byte{0x1f, 0x8b}))
+		})
+
+		It("should panic if the archive is larger than the max inline archive size", func() {
+			oldMax := plumbing.MaxInlineArchiveSize
+			plumbing.MaxInlineArchiveSize = 1
+			defer func() { plumbing.MaxInlineArchiveSize = oldMax }()
+			defer func() {
+				rcv := recover()
+				Expect(rcv).ToNot(BeNil())
+				reqErr := rcv.(*errors.ReqError)
+				Expect(reqErr.HttpCode).To(Equal(413))
+				Expect(reqErr.Code).To(Equal(modules.StatusCodeArchiveTooLarge))
+				Expect(reqErr.Msg).To(ContainSubstring("download it from the remote server at /r/repo1/archive/master.zip"))
+			}()
+			m.ReadArchive(map[string]interface{}{"name": "repo1", "ref": "master", "format": "zip"})
+		})
+
+		It("should panic if the max number of concurrent archives is reached", func() {
+			oldMax := plumbing.MaxConcurrentArchives
+			plumbing.MaxConcurrentArchives = 0
+			defer func() { plumbing.MaxConcurrentArchives = oldMax }()
+			err := &errors.ReqError{Code: modules.StatusCodeTooManyRequests, HttpCode: 429, Msg: plumbing.ErrArchiveCreationLimit.Error()}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadArchive(map[string]interface{}{"name": "repo1"})
+			})
+		})
+	})
+
+	Describe(".Archive", func() {
+		var out string
+
+		BeforeEach(func() {
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			testutil2.AppendCommit(filepath.Join(cfg.GetRepoRoot(), "repo1"), "file.txt", "hello", "commit 1")
+			out = filepath.Join(cfg.DataDir(), "out")
+			Expect(os.MkdirAll(out, 0700)).To(BeNil())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(cfg.DataDir())).To(BeNil())
+		})
+
+		It("should write archive into the output directory", func() {
+			res := m.Archive(map[string]interface{}{"name": "repo1", "ref": "master", "format": "zip", "out": out})
+			Expect(res["path"]).To(Equal(filepath.Join(out, "repo1-master.zip")))
+			content, err := ioutil.ReadFile(res["path"].(string))
+			Expect(err).To(BeNil())
+			Expect(int64(len(content))).To(Equal(res["size"]))
+			Expect(string(content[:2])).To(Equal("PK"))
+		})
+
+		It("should panic if in attach mode and RPC client method returns error", func() {
+			mockClient := mocks2.NewMockClient(ctrl)
+			mockRepoClient := mocks2.NewMockRepo(ctrl)
+			mockClient.EXPECT().Repo().Return(mockRepoClient)
+			m.Client = mockClient
+			mockRepoClient.EXPECT().Archive(&api.BodyRepoArchive{RepoName: "repo1", Ref: "HEAD", Format: "tar.gz"}).
+				Return(nil, fmt.Errorf("error"))
+			err := fmt.Errorf("error")
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Archive(map[string]interface{}{"name": "repo1", "out": out})
+			})
+		})
+
+		It("should write archive returned by RPC client in attach mode", func() {
+			mockClient := mocks2.NewMockClient(ctrl)
+			mockRepoClient := mocks2.NewMockRepo(ctrl)
+			mockClient.EXPECT().Repo().Return(mockRepoClient)
+			m.Client = mockClient
+			mockRepoClient.EXPECT().Archive(&api.BodyRepoArchive{RepoName: "repo1", Ref: "v1", Format: "zip"}).
+				Return(&api.ResultRepoArchive{TreeHash: "abc", Format: "zip", Size: 3, Content: "YWJj"}, nil)
+			path := filepath.Join(out, "archive.zip")
+			res := m.Archive(map[string]interface{}{"name": "repo1", "ref": "v1", "format": "zip", "out": path})
+			Expect(res).To(Equal(util.Map{"path": path, "treeHash": "abc", "format": "zip", "size": int64(3)}))
+			content, err := ioutil.ReadFile(path)
+			Expect(err).To(BeNil())
+			Expect(string(content)).To(Equal("abc"))
+		})
+	})
+
 	Describe(".GetBranches", func() {
 		It("should panic if repo name was not provided", func() {
 			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
@@ -1075,6 +1864,90 @@ index 0000000..3b0c2f1
 		})
 	})
 
+	Describe(".CreateIssue (template)", func() {
+		It("should pass the template name and read templates from the repository instead of the clone", func() {
+			issueRef := plumbing.MakeIssueReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(issueRef).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockCloneRepo.EXPECT().RefGet(issueRef).Return("hash123", nil)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+
+			m.TemplatesGetter = func(r plumbing.LocalRepo, postType string) (plumbing.PostTemplates, error) {
+				Expect(r).To(Equal(mockRepo))
+				Expect(postType).To(Equal(plumbing.IssueBranchPrefix))
+				return plumbing.PostTemplates{{Name: "bug"}}, nil
+			}
+
+			m.IssueCreate = func(r plumbing.LocalRepo, args *issuecmd.IssueCreateArgs) (*issuecmd.IssueCreateResult, error) {
+				Expect(args.Template).To(Equal("bug"))
+				templates, err := args.TemplatesGetter(r, plumbing.IssueBranchPrefix)
+				Expect(err).To(BeNil())
+				Expect(templates.Names()).To(Equal([]string{"bug"}))
+				return &issuecmd.IssueCreateResult{Reference: issueRef}, nil
+			}
+
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return("repoId_123")
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			assert.NotPanics(GinkgoT(), func() {
+				m.CreateIssue("repo3", map[string]interface{}{"id": 1, "template": "bug"})
+			})
+		})
+	})
+
+	Describe(".ListIssueTemplates", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListIssueTemplates("")
+			})
+		})
+
+		It("should panic when unable to get templates", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.TemplatesGetter = func(_ plumbing.LocalRepo, _ string) (plumbing.PostTemplates, error) {
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to get templates: error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListIssueTemplates("repo1")
+			})
+		})
+
+		It("should return templates", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.TemplatesGetter = func(_ plumbing.LocalRepo, postType string) (plumbing.PostTemplates, error) {
+				Expect(postType).To(Equal(plumbing.IssueBranchPrefix))
+				return plumbing.PostTemplates{{Name: "bug", Title: "Bug report", Labels: []string{"bug"}, Body: "steps"}}, nil
+			}
+			res := m.ListIssueTemplates("repo1")
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["name"]).To(Equal("bug"))
+			Expect(res[0]["title"]).To(Equal("Bug report"))
+			Expect(res[0]["labels"]).To(Equal([]string{"bug"}))
+			Expect(res[0]["body"]).To(Equal("steps"))
+		})
+	})
+
+	Describe(".ListMergeRequestTemplates", func() {
+		It("should return templates", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.TemplatesGetter = func(_ plumbing.LocalRepo, postType string) (plumbing.PostTemplates, error) {
+				Expect(postType).To(Equal(plumbing.MergeRequestBranchPrefix))
+				return plumbing.PostTemplates{}, nil
+			}
+			Expect(m.ListMergeRequestTemplates("repo1")).To(BeEmpty())
+		})
+	})
+
 	Describe(".CreateMergeRequest()", func() {
 		It("should panic when repo name was not provided", func() {
 			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
@@ -1361,6 +2234,78 @@ index 0000000..3b0c2f1
 				Expect(res[0]["author"]).To(Equal("a"))
 			})
 		})
+
+		It("should request comments as a tree and include nested replies", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.IssueRead = func(_ plumbing.LocalRepo, args *issuecmd.IssueReadArgs) (plumbing.Comments, error) {
+				Expect(args.Tree).To(BeTrue())
+				Expect(args.CrossReferencesGetter).ToNot(BeNil())
+				reply := &plumbing.Comment{Hash: "hash2", Body: &plumbing.PostBody{ReplyTo: "hash1"}}
+				return []*plumbing.Comment{
+					{Hash: "hash1", Body: &plumbing.PostBody{}, Replies: plumbing.Comments{reply}},
+				}, nil
+			}
+			res := m.ReadIssue("repo3", plumbing.MakeIssueReference(1))
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]).To(HaveKey("replies"))
+			replies := res[0]["replies"].([]interface{})
+			Expect(replies).To(HaveLen(1))
+			Expect(replies[0].(map[string]interface{})["hash"]).To(Equal("hash2"))
+		})
+	})
+
+	Describe(".IsIssueClosed", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.IsIssueClosed("", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.IsIssueClosed("unknown", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when the issue was not found", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().GetReferences().Return(nil, nil)
+			err := &errors.ReqError{Code: "issue_not_found", HttpCode: 404, Msg: "issue not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.IsIssueClosed("repo1", plumbing.MakeIssueReference(1))
+			})
+		})
+	})
+
+	Describe(".GetReferencedBy", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetReferencedBy("", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when target was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "target is required", Field: "target"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetReferencedBy("repo1", "")
+			})
+		})
+
+		It("should return indexed cross references of the target", func() {
+			target := plumbing.MakeIssueReference(1)
+			mockRepoSyncInfoKeeper.EXPECT().GetCrossReferences("repo1", target).Return([]*plumbing.CrossReference{
+				{Target: target, Type: plumbing.CrossRefSourceCommit, Source: plumbing.DefaultBranch, Hash: "hash1", Closes: true},
+			})
+			res := m.GetReferencedBy("repo1", target)
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["hash"]).To(Equal("hash1"))
+			Expect(res[0]["closes"]).To(BeTrue())
+		})
 	})
 
 	Describe(".ReopenIssue", func() {
@@ -1519,6 +2464,53 @@ index 0000000..3b0c2f1
 				Expect(res[0]["title"]).To(Equal("title"))
 			})
 		})
+
+		It("should panic when query is not valid", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "unknown state (pending); expected 'open' or 'closed'", Field: "query"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListIssues("repo1", map[string]interface{}{"state": "pending"})
+			})
+		})
+
+		It("should pass the query and a state-backed data getter to the issue lister", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			repoState := state.BareRepository()
+			repoState.References["refs/heads/issues/1"] = &state.Reference{Data: &state.ReferenceData{Labels: []string{"bug"}}}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repoState)
+			mockRepoSyncInfoKeeper.EXPECT().GetCrossReferences("repo1", "refs/heads/issues/1").Return(nil)
+			m.IssueList = func(_ plumbing.LocalRepo, args *issuecmd.IssueListArgs) (plumbing.Posts, error) {
+				Expect(args.Query).ToNot(BeNil())
+				Expect(args.Query.Labels).To(Equal([]string{"bug"}))
+				Expect(args.Query.State).To(Equal("open"))
+				Expect(args.PostDataGetter("refs/heads/issues/1").Labels).To(Equal([]string{"bug"}))
+				Expect(args.PostDataGetter("refs/heads/issues/2")).To(BeNil())
+				return plumbing.Posts{}, nil
+			}
+			res := m.ListIssues("repo1", map[string]interface{}{"labels": []interface{}{"bug"}, "state": "open"})
+			Expect(res).To(BeEmpty())
+		})
+
+		It("should apply closes by commits to the data of the issues", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			issueRef := plumbing.MakeIssueReference(1)
+			repoState := state.BareRepository()
+			repoState.References[issueRef] = &state.Reference{Data: &state.ReferenceData{Labels: []string{"bug"}}}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repoState)
+			mockRepoSyncInfoKeeper.EXPECT().GetCrossReferences("repo1", issueRef).Return([]*plumbing.CrossReference{
+				{Target: issueRef, Hash: "commit1", Closes: true, PostHash: "hash1"},
+			})
+			mockRepo.EXPECT().GetRefCommits(issueRef, true).Return([]string{"hash1"}, nil)
+			m.IssueList = func(_ plumbing.LocalRepo, args *issuecmd.IssueListArgs) (plumbing.Posts, error) {
+				Expect(args.PostDataGetter(issueRef).Closed).To(BeTrue())
+				Expect(repoState.References[issueRef].Data.Closed).To(BeFalse())
+				return plumbing.Posts{}, nil
+			}
+			m.ListIssues("repo1", map[string]interface{}{"state": "closed"})
+		})
 	})
 
 	Describe(".CloseMergeRequest()", func() {
@@ -1837,6 +2829,135 @@ index 0000000..3b0c2f1
 		})
 	})
 
+	Describe(".CreateMilestone", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMilestone("", map[string]interface{}{})
+			})
+		})
+
+		It("should panic when issues is not a list of numbers", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "expected a list of issue numbers", Field: "params.issues"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMilestone("repo1", map[string]interface{}{"id": 1, "issues": []interface{}{"abc"}})
+			})
+		})
+
+		It("should create the milestone and return the reference, hash and temp repo ID", func() {
+			ref := plumbing.MakeMilestoneReference(1)
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.PostIDFinder = func(_ plumbing.LocalRepo, _ int, postRefType string) (int, error) {
+				Expect(postRefType).To(Equal(plumbing.MilestoneBranchPrefix))
+				return 1, nil
+			}
+			mockRepo.EXPECT().RefGet(ref).Return("", plumbing.ErrRefNotFound)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(plumbing.CloneOptions{Depth: 1}).Return(mockCloneRepo, "", nil)
+			m.MilestoneCreate = func(r plumbing.LocalRepo, args *milestonecmd.MilestoneCreateArgs) (*milestonecmd.MilestoneCreateResult, error) {
+				Expect(args.ID).To(Equal(1))
+				Expect(args.Title).To(Equal("v1.0"))
+				Expect(args.DueDate).To(Equal("2020-10-01"))
+				Expect(args.Issues).To(Equal([]int{1, -2}))
+				Expect(*args.Close).To(BeTrue())
+				return &milestonecmd.MilestoneCreateResult{Reference: ref}, nil
+			}
+			mockCloneRepo.EXPECT().RefGet(ref).Return("hash_123", nil)
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return("repoId_123")
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			res := m.CreateMilestone("repo1", map[string]interface{}{
+				"title":  "v1.0",
+				"due":    "2020-10-01",
+				"issues": []interface{}{1, -2},
+				"close":  true,
+			})
+			Expect(res["reference"]).To(Equal(ref))
+			Expect(res["hash"]).To(Equal("hash_123"))
+			Expect(res["repoID"]).To(Equal("repoId_123"))
+		})
+	})
+
+	Describe(".CloseMilestone", func() {
+		It("should panic when milestone reference was not found", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeMilestoneReference(1)).Return("", plumbing.ErrRefNotFound)
+			err := &errors.ReqError{Code: "milestone_not_found", HttpCode: 404, Msg: "milestone not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMilestone("repo1", plumbing.MakeMilestoneReference(1))
+			})
+		})
+
+		It("should panic when unable to close milestone", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeMilestoneReference(1)).Return("hash", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().Delete()
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.MilestoneClose = func(r plumbing.LocalRepo, args *milestonecmd.MilestoneCloseArgs) (*milestonecmd.MilestoneCloseResult, error) {
+				return nil, fmt.Errorf("error here; cant close")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant close", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMilestone("repo1", plumbing.MakeMilestoneReference(1))
+			})
+		})
+	})
+
+	Describe(".ListMilestones", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListMilestones("")
+			})
+		})
+
+		It("should return milestones", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.MilestoneList = func(_ plumbing.LocalRepo, args *milestonecmd.MilestoneListArgs) ([]*plumbing.Milestone, error) {
+				Expect(args.MilestoneGetter).ToNot(BeNil())
+				return []*plumbing.Milestone{{Title: "v1.0", Progress: &plumbing.MilestoneProgress{Total: 2, Closed: 1, Percent: 50}}}, nil
+			}
+			res := m.ListMilestones("repo1")
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["title"]).To(Equal("v1.0"))
+			Expect(res[0]["progress"]).To(HaveKeyWithValue("percent", float64(50)))
+		})
+	})
+
+	Describe(".ReadMilestone", func() {
+		It("should panic when milestone was not found", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.MilestoneRead = func(_ plumbing.LocalRepo, _ *milestonecmd.MilestoneReadArgs) (*plumbing.Milestone, error) {
+				return nil, fmt.Errorf("milestone not found")
+			}
+			err := &errors.ReqError{Code: "milestone_not_found", HttpCode: 404, Msg: "milestone not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadMilestone("repo1", plumbing.MakeMilestoneReference(1))
+			})
+		})
+
+		It("should return the milestone", func() {
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			m.MilestoneRead = func(_ plumbing.LocalRepo, args *milestonecmd.MilestoneReadArgs) (*plumbing.Milestone, error) {
+				Expect(args.Reference).To(Equal(plumbing.MakeMilestoneReference(1)))
+				return &plumbing.Milestone{Title: "v1.0", Issues: []int{1}}, nil
+			}
+			res := m.ReadMilestone("repo1", plumbing.MakeMilestoneReference(1))
+			Expect(res["title"]).To(Equal("v1.0"))
+		})
+	})
+
 	Describe(".Push", func() {
 		It("should panic if id is not associated with a temporary repo", func() {
 			param := map[string]interface{}{"id": "repo_123"}
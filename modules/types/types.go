@@ -4,6 +4,7 @@ import (
 	"github.com/c-bata/go-prompt"
 	"github.com/fatih/structs"
 	"github.com/make-os/kit/rpc/types"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/util"
 	"github.com/robertkrimen/otto"
 )
@@ -148,7 +149,18 @@ type RepoModule interface {
 	Module
 	Create(params map[string]interface{}, options ...interface{}) util.Map
 	UpsertOwner(params map[string]interface{}, options ...interface{}) util.Map
+	ProposeSpend(params map[string]interface{}, options ...interface{}) util.Map
+	ProposeArchive(params map[string]interface{}, options ...interface{}) util.Map
+	ProposeDelete(params map[string]interface{}, options ...interface{}) util.Map
+	ProposeRename(params map[string]interface{}, options ...interface{}) util.Map
+	ProposeNamespaceUpdate(params map[string]interface{}, options ...interface{}) util.Map
+	WithdrawProposal(params map[string]interface{}, options ...interface{}) util.Map
+	DelegateVote(params map[string]interface{}, options ...interface{}) util.Map
+	CancelProposal(params map[string]interface{}, options ...interface{}) util.Map
 	Vote(params map[string]interface{}, options ...interface{}) util.Map
+	WatchProposals(names []string, handler func(evt *core.ProposalEvent)) (stop func())
+	ListProposals(params map[string]interface{}) util.Map
+	GetProposal(params map[string]interface{}) util.Map
 	Get(name string, opts ...GetOptions) util.Map
 	Update(params map[string]interface{}, options ...interface{}) util.Map
 	DepositProposalFee(params map[string]interface{}, options ...interface{}) util.Map
@@ -157,9 +169,12 @@ type RepoModule interface {
 	UnTrack(names string)
 	GetTracked() util.Map
 	GetReposCreatedByAddress(address string) []string
+	CheckPolicy(params map[string]interface{}) util.Map
 	ListPath(name, path string, revision ...string) []util.Map
 	ReadFileLines(name, filePath string, revision ...string) []string
 	ReadFile(name, filePath string, revision ...string) string
+	ReadArchive(params map[string]interface{}) util.Map
+	Archive(params map[string]interface{}) util.Map
 	GetBranches(name string) []string
 	GetLatestBranchCommit(name, branch string) util.Map
 	GetCommits(reference, branch string, limit ...int) []util.Map
@@ -169,14 +184,22 @@ type RepoModule interface {
 	GetParentsAndCommitDiff(name string, commitHash string) util.Map
 	CreateIssue(name string, params map[string]interface{}) util.Map
 	ReadIssue(name, reference string) []util.Map
+	GetReferencedBy(name, target string) []util.Map
+	IsIssueClosed(name, reference string) bool
+	ListIssueTemplates(name string) []util.Map
 	CloseIssue(name, reference string) util.Map
 	ReopenIssue(name, reference string) util.Map
-	ListIssues(name string) []util.Map
+	ListIssues(name string, query ...map[string]interface{}) []util.Map
 	CreateMergeRequest(name string, params map[string]interface{}) util.Map
 	ReadMergeRequest(name, reference string) []util.Map
+	ListMergeRequestTemplates(name string) []util.Map
 	CloseMergeRequest(name, reference string) util.Map
-	ListMergeRequests(name string) []util.Map
+	ListMergeRequests(name string, query ...map[string]interface{}) []util.Map
 	ReopenMergeRequest(name, reference string) util.Map
+	CreateMilestone(name string, params map[string]interface{}) util.Map
+	CloseMilestone(name, reference string) util.Map
+	ListMilestones(name string) []util.Map
+	ReadMilestone(name, reference string) util.Map
 	Push(params map[string]interface{}, privateKeyOrPushToken string) string
 }
 type NamespaceModule interface {
@@ -21,6 +21,7 @@ const (
 	ObjTypeAny int = iota
 	ObjTypeGit
 	ObjTypeRepoName
+	ObjTypeLFS
 )
 
 // ErrDelisted indicates that a task failed to be announced because it was delisted
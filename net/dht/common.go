@@ -17,6 +17,7 @@ const (
 	MsgTypeSend = "SEND"
 	MsgTypeNope = "NOPE"
 	MsgTypePack = "PACK"
+	MsgTypeBlob = "BLOB"
 )
 
 const (
@@ -49,11 +50,17 @@ func MakeSendMsg(repoName string, hash []byte) []byte {
 
 // ParseWantOrSendMsg parses a 'WANT/SEND' message
 func ParseWantOrSendMsg(msg []byte) (typ string, repoName string, hash []byte, err error) {
+	return ParseWantOrSendMsgWithHashLen(msg, 20)
+}
+
+// ParseWantOrSendMsgWithHashLen parses a 'WANT/SEND' message
+// whose hash is hashLen bytes long.
+func ParseWantOrSendMsgWithHashLen(msg []byte, hashLen int) (typ string, repoName string, hash []byte, err error) {
 	parts := bytes.SplitN(msg, []byte(" "), 3)
-	if len(parts) != 3 {
+	if len(parts) != 3 || len(parts[2]) < hashLen {
 		return "", "", nil, fmt.Errorf("malformed message")
 	}
-	return string(parts[0]), string(parts[1]), parts[2][:20], nil
+	return string(parts[0]), string(parts[1]), parts[2][:hashLen], nil
 }
 
 // ReadWantOrSendMsg reads WANT or SEND message from the reader
@@ -66,11 +73,28 @@ func ReadWantOrSendMsg(r io.Reader) (typ string, repoName string, hash []byte, e
 	return ParseWantOrSendMsg(buf)
 }
 
+// ReadWantOrSendMsgWithHashLen reads WANT or SEND message
+// whose hash is hashLen bytes long from the reader
+func ReadWantOrSendMsgWithHashLen(r io.Reader, hashLen int) (typ string, repoName string, hash []byte, err error) {
+	var buf = make([]byte, MsgTypeLen+identifier.MaxResourceNameLength+hashLen+2)
+	_, err = r.Read(buf)
+	if err != nil && err != io.EOF {
+		return "", "", nil, err
+	}
+	return ParseWantOrSendMsgWithHashLen(buf, hashLen)
+}
+
 // MakeHaveMsg creates a 'HAVE' message
 func MakeHaveMsg() []byte {
 	return []byte(MsgTypeHave)
 }
 
+// MakeBlobMsg creates a 'BLOB' message header.
+// The content of the blob is expected to follow the header.
+func MakeBlobMsg() []byte {
+	return []byte(MsgTypeBlob)
+}
+
 // MakeNopeMsg creates a 'NOPE' message
 func MakeNopeMsg() []byte {
 	return []byte(MsgTypeNope)
@@ -75,6 +75,30 @@ var _ = Describe("Common", func() {
 		})
 	})
 
+	Describe(".ReadWantOrSendMsgWithHashLen", func() {
+		It("should read SEND message with a 32 bytes hash correctly", func() {
+			hashBz := bytes.Repeat([]byte{1}, 32)
+			msg := MakeSendMsg("repo1", hashBz)
+			typ, repoName, hash, err := ReadWantOrSendMsgWithHashLen(bytes.NewReader(msg), 32)
+			Expect(err).To(BeNil())
+			Expect(typ).To(Equal("SEND"))
+			Expect(repoName).To(Equal("repo1"))
+			Expect(hash).To(Equal(hashBz))
+		})
+
+		It("should return error if hash is shorter than the expected length", func() {
+			_, _, _, err := ParseWantOrSendMsgWithHashLen(MakeSendMsg("repo1", []byte{1, 2}), 32)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("malformed message"))
+		})
+	})
+
+	Describe(".MakeBlobMsg", func() {
+		It("should make expected message", func() {
+			Expect(MakeBlobMsg()).To(Equal([]byte("BLOB")))
+		})
+	})
+
 	Describe(".MakeSendMsg", func() {
 		It("should return expected format", func() {
 			msg := MakeSendMsg("repo1", plumbing.HashToBytes("d9dbe0e59248c7f0505dd5d80ed470fb43f82521"))
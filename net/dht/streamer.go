@@ -17,6 +17,7 @@ type Streamer interface {
 	GetCommitWithAncestors(ctx context.Context, args GetAncestorArgs) (packfiles []io.ReadSeekerCloser, err error)
 	GetTaggedCommitWithAncestors(ctx context.Context, args GetAncestorArgs) (packfiles []io.ReadSeekerCloser, err error)
 	GetTag(ctx context.Context, repo string, hash []byte) (packfile io.ReadSeekerCloser, tag *object.Tag, err error)
+	GetLFSObject(ctx context.Context, repo string, oid []byte) (obj io.ReadSeekerCloser, err error)
 	OnRequest(s network.Stream) (success bool, err error)
 	GetProviders(ctx context.Context, repoName string, objectHash []byte) ([]peer.AddrInfo, error)
 }
@@ -37,6 +38,15 @@ type GetAncestorArgs struct {
 	// ExcludeEndCommit when true, indicates that the end commit should not be fetched.
 	ExcludeEndCommit bool
 
+	// Depth is the maximum number of commits to fetch along any ancestry
+	// path, counting from the start commit. Zero means no limit.
+	Depth int
+
+	// ShallowCB is called with the hash of a commit whose parents were
+	// not fetched because the depth limit was reached and which are
+	// not available locally.
+	ShallowCB func(hash string) error
+
 	// GitBinPath is the path to the git binary
 	GitBinPath string
 
@@ -14,6 +14,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/protocol"
 	dht2 "github.com/make-os/kit/net/dht"
 	"github.com/make-os/kit/pkgs/logger"
+	"github.com/make-os/kit/remote/lfs"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/util/io"
 	"github.com/pkg/errors"
@@ -71,6 +72,10 @@ type RequestArgs struct {
 	// Key is the requested object key
 	Key []byte
 
+	// ProtocolID is the protocol to request the object with.
+	// Defaults to ObjectStreamerProtocolID.
+	ProtocolID protocol.ID
+
 	// Log is the app logger
 	Log logger.Logger
 
@@ -84,6 +89,7 @@ type BasicObjectRequester struct {
 	providers             []peer.AddrInfo
 	repoName              string
 	key                   []byte
+	protocolID            protocol.ID
 	host                  host.Host
 	log                   logger.Logger
 	reposDir              string
@@ -97,14 +103,19 @@ type BasicObjectRequester struct {
 // NewBasicObjectRequester creates an instance of BasicObjectRequester
 func NewBasicObjectRequester(args RequestArgs) *BasicObjectRequester {
 	r := BasicObjectRequester{
-		lck:       &sync.Mutex{},
-		providers: args.Providers,
-		repoName:  args.RepoName,
-		key:       args.Key,
-		host:      args.Host,
-		log:       args.Log,
-		reposDir:  args.ReposDir,
-		tracker:   args.ProviderTracker,
+		lck:        &sync.Mutex{},
+		providers:  args.Providers,
+		repoName:   args.RepoName,
+		key:        args.Key,
+		protocolID: args.ProtocolID,
+		host:       args.Host,
+		log:        args.Log,
+		reposDir:   args.ReposDir,
+		tracker:    args.ProviderTracker,
+	}
+
+	if r.protocolID == "" {
+		r.protocolID = ObjectStreamerProtocolID
 	}
 
 	r.OnWantResponseHandler = r.OnWantResponse
@@ -164,7 +175,7 @@ func (r *BasicObjectRequester) DoWant(ctx context.Context) (err error) {
 
 		// Send 'WANT' message to provider
 		var s network.Stream
-		s, err = r.Write(ctx, prov, ObjectStreamerProtocolID, dht2.MakeWantMsg(r.repoName, r.key))
+		s, err = r.Write(ctx, prov, r.protocolID, dht2.MakeWantMsg(r.repoName, r.key))
 		if err != nil {
 			wg.Done()
 			r.log.Error("Unable to write `WANT` message to peer", "Peer", prov.ID.Pretty(), "Err", err)
@@ -290,7 +301,7 @@ func (r *BasicObjectRequester) OnWantResponse(s network.Stream) error {
 	return nil
 }
 
-// OnSendResponse handles incoming packfile data from remote peer.
+// OnSendResponse handles incoming packfile or blob data from remote peer.
 // If the remote peer responds with 'NOPE', it will be logged in the nope cache.
 func (r *BasicObjectRequester) OnSendResponse(s network.Stream) (io.ReadSeekerCloser, error) {
 	defer s.Reset()
@@ -329,6 +340,18 @@ func (r *BasicObjectRequester) OnSendResponse(s network.Stream) (io.ReadSeekerCl
 		}
 		return rdr, nil
 
+	case dht2.MsgTypeBlob:
+		r.log.Debug("BLOB<-: Blob received from provider",
+			"Repo", r.repoName, "Hash", hash, "Peer", remotePeer.Pretty())
+		if _, err := buf.Discard(dht2.MsgTypeLen); err != nil {
+			return nil, errors.Wrap(err, "failed to read blob header")
+		}
+		rdr, err := io.LimitedReadToTmpFile(buf, lfs.MaxObjectSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read blob data")
+		}
+		return rdr, nil
+
 	default:
 		return nil, ErrUnknownMsgType
 	}
@@ -178,6 +178,21 @@ var _ = Describe("BasicObjectRequester", func() {
 			Expect(err).To(BeNil())
 			Expect(called).To(BeTrue())
 		})
+
+		It("should send 'WANT' message using the protocol ID in the request arguments", func() {
+			ctx := context.Background()
+			prov := peer.AddrInfo{Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1")}}
+
+			mockPeerstore := mocks.NewMockPeerstore(ctrl)
+			mockPeerstore.EXPECT().AddAddr(prov.ID, prov.Addrs[0], peerstore.ProviderAddrTTL)
+			mockHost.EXPECT().Peerstore().Return(mockPeerstore)
+			mockHost.EXPECT().NewStream(ctx, prov.ID, streamer.LFSObjectProtocolID).Return(nil, fmt.Errorf("error"))
+
+			r := streamer.NewBasicObjectRequester(streamer.RequestArgs{Host: mockHost, Providers: []peer.AddrInfo{prov},
+				Log: log, ProtocolID: streamer.LFSObjectProtocolID})
+			err := r.DoWant(ctx)
+			Expect(err).ToNot(BeNil())
+		})
 	})
 
 	Describe(".Do", func() {
@@ -422,6 +437,21 @@ var _ = Describe("BasicObjectRequester", func() {
 			Expect(data).To(Equal([]byte(dht2.MsgTypePack)))
 		})
 
+		It("should return the blob content without the message type if msg type is 'BLOB'", func() {
+			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
+				msg := append(dht2.MakeBlobMsg(), []byte("content")...)
+				copy(p, msg)
+				return len(msg), io.EOF
+			})
+			r := streamer.NewBasicObjectRequester(reqArgs)
+			blob, err := r.OnSendResponse(mockStream)
+			Expect(err).To(BeNil())
+			Expect(blob).ToNot(BeNil())
+			data, err := ioutil.ReadAll(blob)
+			Expect(err).To(BeNil())
+			Expect(data).To(Equal([]byte("content")))
+		})
+
 		It("should return ErrUnknownMsgType if msg type is unknown", func() {
 			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
 				copy(p, "UNKNOWN")
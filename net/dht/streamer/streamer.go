@@ -4,7 +4,10 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	goio "io"
 	"path/filepath"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	dht3 "github.com/make-os/kit/net/dht"
 	"github.com/make-os/kit/net/dht/providertracker"
 	"github.com/make-os/kit/pkgs/logger"
+	"github.com/make-os/kit/remote/lfs"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/repo"
 	types2 "github.com/make-os/kit/types"
@@ -32,8 +36,12 @@ var (
 
 var (
 	ObjectStreamerProtocolID = protocol.ID("/object/1.0")
+	LFSObjectProtocolID      = protocol.ID("/lfs/1.0")
 )
 
+// lfsOidLen is the length of an LFS object ID in bytes
+const lfsOidLen = sha256.Size
+
 // BasicObjectStreamer implements Streamer. It provides a mechanism for
 // announcing or transferring repository objects to/from the DHT.
 type BasicObjectStreamer struct {
@@ -44,6 +52,9 @@ type BasicObjectStreamer struct {
 	tracker          dht3.ProviderTracker
 	OnWantHandler    WantSendHandler
 	OnSendHandler    WantSendHandler
+	OnLFSWantHandler WantSendHandler
+	OnLFSSendHandler WantSendHandler
+	LFSStoreGetter   func(repoPath string) lfs.Store
 	RepoGetter       repo.GetLocalRepoFunc
 	PackObject       plumbing.CommitPacker
 	MakeRequester    MakeObjectRequester
@@ -61,14 +72,19 @@ func NewStreamer(dht dht3.DHT, cfg *config.AppConfig) *BasicObjectStreamer {
 		RepoGetter:       repo.GetWithGitModule,
 		PackObject:       plumbing.PackObject,
 		PackObjectGetter: plumbing.GetObjectFromPack,
+		LFSStoreGetter:   lfs.GetStore,
 	}
 
 	// Hook concrete functions to function type fields
 	ce.OnWantHandler = ce.OnWantRequest
 	ce.OnSendHandler = ce.OnSendRequest
+	ce.OnLFSWantHandler = ce.OnLFSWantRequest
+	ce.OnLFSSendHandler = ce.OnLFSSendRequest
 	ce.MakeRequester = makeRequester
 
-	dht.Host().SetStreamHandler(ObjectStreamerProtocolID, ce.Handler)
+	host := dht.Host()
+	host.SetStreamHandler(ObjectStreamerProtocolID, ce.Handler)
+	host.SetStreamHandler(LFSObjectProtocolID, ce.LFSHandler)
 	return ce
 }
 
@@ -190,6 +206,8 @@ func (c *BasicObjectStreamer) GetCommit(
 // If ResultCB is set, packfiles will be passed to the callback and not returned.
 // If ResultCB returns an error, the method exits with that error. Use ErrExit to exit
 // with a nil error.
+// If Depth is set, parents of commits at the depth limit are not fetched; such commits
+// are passed to ShallowCB if any of their parents do not exist locally.
 func GetCommitWithAncestors(
 	ctx context.Context,
 	c dht3.Streamer,
@@ -213,6 +231,7 @@ func GetCommitWithAncestors(
 
 	// Maintain a wantlist containing commit objects to fetch, starting with the start commit.
 	var wantlist = [][]byte{args.StartHash}
+	var depths = map[string]int{plumbing.BytesToHex(args.StartHash): 1}
 	var fetched = map[string]struct{}{}
 	var endCommitSeen bool
 	for len(wantlist) > 0 {
@@ -276,6 +295,29 @@ func GetCommitWithAncestors(
 			continue
 		}
 
+		// If the depth limit has been reached, do not fetch the parents of the
+		// fetched commit. Report it as a shallow commit if any of its parents
+		// is not available locally.
+		depth := depths[targetHash]
+		if args.Depth > 0 && depth >= args.Depth {
+			if args.ShallowCB == nil {
+				continue
+			}
+			for _, parent := range fetchedCommit.ParentHashes {
+				if r.ObjectExist(parent.String()) {
+					continue
+				}
+				if err := args.ShallowCB(fetchedHash); err != nil {
+					if err == types2.ErrExit {
+						return packfiles, nil
+					}
+					return packfiles, err
+				}
+				break
+			}
+			continue
+		}
+
 		// At this point, the fetched commit is not the end commit.
 		// We need to add its parents that are currently unknown and un-fetched into the wantlist.
 		_, endCommitFetched := fetched[endCommitHash]
@@ -319,10 +361,11 @@ func GetCommitWithAncestors(
 				// end commit history, as such, we skip t
 				err := r.IsAncestor(parent.String(), endCommitHash)
 
-				// However, if we do not have the parent commit locally
-				// or it is not an ancestor of the end commit, it is okay
-				// to add it to the wantlist.
-				if err == plumb.ErrObjectNotFound || err == repo.ErrNotAnAncestor {
+				// However, if we do not have the parent commit locally,
+				// it is not an ancestor of the end commit or the local
+				// history is too shallow to tell, it is okay to add it
+				// to the wantlist.
+				if err == plumb.ErrObjectNotFound || err == repo.ErrNotAnAncestor || err == repo.ErrAncestryUnknown {
 					goto add
 				}
 
@@ -335,6 +378,9 @@ func GetCommitWithAncestors(
 
 		add:
 			if _, ok := fetched[parent.String()]; !ok {
+				if _, ok := depths[parent.String()]; !ok {
+					depths[parent.String()] = depth + 1
+				}
 				wantlist = append(wantlist, parent[:])
 			}
 		}
@@ -406,6 +452,76 @@ func (c *BasicObjectStreamer) GetTag(
 	return res.Pack, tag.(*object.Tag), nil
 }
 
+// GetLFSObject gets an LFS object by its object ID.
+//
+// The content received from a provider is verified against the object ID;
+// providers that send content that does not match are banned.
+// The returned reader is expected to be closed by the caller.
+func (c *BasicObjectStreamer) GetLFSObject(
+	ctx context.Context,
+	repoName string,
+	oid []byte) (io.ReadSeekerCloser, error) {
+
+	// Find providers of the object
+	providers, err := c.GetProviders(ctx, repoName, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove banned providers and providers that have recently
+	// sent NOPE as response to previous request for the key
+	providers = funk.Filter(providers, func(p peer.AddrInfo) bool {
+		return c.tracker.IsGood(p.ID) && !c.tracker.DidPeerSendNope(p.ID, oid)
+	}).([]peer.AddrInfo)
+
+	// Return immediate with error if no provider was found
+	if len(providers) == 0 {
+		return nil, ErrNoProviderFound
+	}
+
+	// Register the providers we can track its behaviour over time.
+	c.tracker.Register(providers...)
+
+	// Start request session
+	req := c.MakeRequester(RequestArgs{
+		Providers:       providers,
+		RepoName:        repoName,
+		Key:             oid,
+		ProtocolID:      LFSObjectProtocolID,
+		Host:            c.dht.Host(),
+		Log:             c.log,
+		ReposDir:        c.reposDir,
+		ProviderTracker: c.tracker,
+	})
+
+	// Do the request
+	res, err := req.Do(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+
+	// Ensure the content matches the object ID.
+	// If it does not, ban peer for sending a bad object.
+	hasher := sha256.New()
+	if _, err = goio.Copy(hasher, res.Pack); err != nil {
+		res.Pack.Close()
+		return nil, errors.Wrap(err, "failed to read object")
+	}
+	if !bytes.Equal(hasher.Sum(nil), oid) {
+		res.Pack.Close()
+		c.tracker.Ban(res.RemotePeer, 24*time.Hour)
+		return nil, fmt.Errorf("object content does not match object id")
+	}
+	if _, err = res.Pack.Seek(0, goio.SeekStart); err != nil {
+		res.Pack.Close()
+		return nil, err
+	}
+
+	c.log.Debug("New LFS object downloaded", "Oid", hex.EncodeToString(oid), "Repo", repoName)
+
+	return res.Pack, nil
+}
+
 // GetTaggedCommitWithAncestors gets the ancestors of the commit pointed by the given tag that
 // do not exist in the local repository.
 //
@@ -686,3 +802,119 @@ func (c *BasicObjectStreamer) OnSendRequest(repo string, hash []byte, s network.
 
 	return nil
 }
+
+// LFSHandler handles the lifecycle of the LFS object streaming protocol
+func (c *BasicObjectStreamer) LFSHandler(s network.Stream) {
+	for {
+		success, err := c.OnLFSRequest(s)
+		if err != nil {
+			return
+		}
+		if success {
+			break
+		}
+	}
+}
+
+// OnLFSRequest handles incoming LFS object requests
+func (c *BasicObjectStreamer) OnLFSRequest(s network.Stream) (bool, error) {
+
+	// Get request message
+	msgType, repoName, oid, err := dht3.ReadWantOrSendMsgWithHashLen(s, lfsOidLen)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read request")
+	}
+
+	switch msgType {
+
+	// Handle 'want' message
+	case dht3.MsgTypeWant:
+		err := c.OnLFSWantHandler(repoName, oid, s)
+		return false, err
+
+	// Handle 'send' message
+	case dht3.MsgTypeSend:
+		err := c.OnLFSSendHandler(repoName, oid, s)
+		return err == nil, err
+
+	default:
+		return false, ErrUnknownMsgType
+	}
+}
+
+// OnLFSWantRequest handles incoming "WANT" requests for LFS objects
+func (c *BasicObjectStreamer) OnLFSWantRequest(repo string, oid []byte, s network.Stream) error {
+
+	remotePeerID := s.Conn().RemotePeer().Pretty()
+	oidHex := hex.EncodeToString(oid)
+	c.log.Debug("WANT<-: Received request for LFS object", "Repo", repo, "Oid", oidHex,
+		"Peer", remotePeerID)
+
+	// Check if object exist in the repo's LFS store
+	if !c.LFSStoreGetter(filepath.Join(c.reposDir, repo)).Has(oidHex) {
+		if _, err := s.Write(dht3.MakeNopeMsg()); err != nil {
+			return errors.Wrap(err, "failed to write 'nope' message")
+		}
+		c.log.Debug("Requested LFS object does not exist in repo", "Repo", repo, "Oid", oidHex)
+		return dht3.ErrObjNotFound
+	}
+
+	// Respond with a 'have' message
+	if _, err := s.Write(dht3.MakeHaveMsg()); err != nil {
+		s.Reset()
+		c.log.Error("failed to Write 'have' message", "Err", err)
+		return err
+	}
+
+	c.log.Debug("WANT<-: Sent HAVE message", "Repo", repo, "Oid", oidHex, "Peer", remotePeerID)
+
+	return nil
+}
+
+// OnLFSSendRequest handles incoming "SEND" requests for LFS objects
+func (c *BasicObjectStreamer) OnLFSSendRequest(repo string, oid []byte, s network.Stream) error {
+
+	remotePeerID := s.Conn().RemotePeer().Pretty()
+	oidHex := hex.EncodeToString(oid)
+	c.log.Debug("SEND<-: Received request for LFS object", "Repo", repo, "Oid", oidHex,
+		"Peer", remotePeerID)
+
+	// Get the object
+	obj, err := c.LFSStoreGetter(filepath.Join(c.reposDir, repo)).Open(oidHex)
+	if err != nil {
+		if err != lfs.ErrObjectNotFound {
+			_ = s.Reset()
+			c.log.Error("failed local LFS object check", "Err", err)
+			return err
+		}
+
+		c.log.Debug("SEND<-: LFS object requested was not found", "Repo", repo, "Oid",
+			oidHex, "Peer", remotePeerID)
+
+		if _, err = s.Write(dht3.MakeNopeMsg()); err != nil {
+			_ = s.Reset()
+			return errors.Wrap(err, "failed to write 'nope' message")
+		}
+		_ = s.Reset()
+		return dht3.ErrObjNotFound
+	}
+	defer obj.Close()
+
+	// Write the object to the requester
+	w := bufio.NewWriter(s)
+	w.Write(dht3.MakeBlobMsg())
+	if _, err := w.ReadFrom(obj); err != nil {
+		_ = s.Reset()
+		c.log.Error("failed to Write LFS object", "Err", err)
+		return errors.Wrap(err, "Write LFS object error")
+	}
+	if err := w.Flush(); err != nil {
+		_ = s.Reset()
+		return errors.Wrap(err, "Write LFS object error")
+	}
+	s.Close()
+
+	c.log.Debug("->BLOB: Wrote LFS object to requester", "Oid", oidHex, "Peer", remotePeerID)
+
+	return nil
+}
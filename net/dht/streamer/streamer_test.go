@@ -3,10 +3,12 @@ package streamer_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	plumb "github.com/go-git/go-git/v5/plumbing"
@@ -18,6 +20,7 @@ import (
 	"github.com/make-os/kit/mocks"
 	dht2 "github.com/make-os/kit/net/dht"
 	"github.com/make-os/kit/net/dht/streamer"
+	"github.com/make-os/kit/remote/lfs"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/repo"
 	"github.com/make-os/kit/testutil"
@@ -58,6 +61,10 @@ var _ = Describe("BasicObjectStreamer", func() {
 	var cs *streamer.BasicObjectStreamer
 	var hash = plumb.NewHash("6fe5e981f7defdfb907c1237e2e8427696adafa7")
 	var parentHash = plumb.NewHash("7a561e23f4e81c61df1b0dc63a89ae9c8d5680cd")
+	var lfsContent = "large file content"
+	var lfsOidArr = sha256.Sum256([]byte(lfsContent))
+	var lfsOid = lfsOidArr[:]
+	var lfsStore lfs.Store
 
 	BeforeEach(func() {
 		cfg, err = testutil.SetTestCfg()
@@ -65,10 +72,11 @@ var _ = Describe("BasicObjectStreamer", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockHost = mocks.NewMockHost(ctrl)
 		mockDHT = mocks.NewMockDHT(ctrl)
+		lfsStore = lfs.NewBasicStore(filepath.Join(cfg.DataDir(), "lfs"))
 	})
 
 	BeforeEach(func() {
-		mockHost.EXPECT().SetStreamHandler(gomock.Any(), gomock.Any())
+		mockHost.EXPECT().SetStreamHandler(gomock.Any(), gomock.Any()).Times(2)
 		mockDHT.EXPECT().Host().Return(mockHost)
 		cs = streamer.NewStreamer(mockDHT, cfg)
 	})
@@ -80,8 +88,9 @@ var _ = Describe("BasicObjectStreamer", func() {
 	})
 
 	Describe(".NewStreamer", func() {
-		It("should register commit stream protocol handler", func() {
+		It("should register commit and LFS object stream protocol handlers", func() {
 			mockHost.EXPECT().SetStreamHandler(streamer.ObjectStreamerProtocolID, gomock.Any())
+			mockHost.EXPECT().SetStreamHandler(streamer.LFSObjectProtocolID, gomock.Any())
 			mockDHT.EXPECT().Host().Return(mockHost)
 			streamer.NewStreamer(mockDHT, cfg)
 		})
@@ -620,6 +629,158 @@ var _ = Describe("BasicObjectStreamer", func() {
 		})
 	})
 
+	Describe(".OnLFSRequest", func() {
+		It("should return ErrUnknownMsgType when message type is unknown", func() {
+			mockStream := mocks.NewMockStream(ctrl)
+			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
+				msg := []byte("unknown repo oid")
+				copy(p, msg)
+				return len(msg), nil
+			})
+			_, err := cs.OnLFSRequest(mockStream)
+			Expect(err).To(MatchError(streamer.ErrUnknownMsgType))
+		})
+
+		It("should call LFS 'Send' handler with the 32 bytes object ID when message is MsgTypeSend", func() {
+			msg := dht2.MakeSendMsg("repo1", lfsOid)
+			mockStream := mocks.NewMockStream(ctrl)
+			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
+				copy(p, msg)
+				return len(msg), nil
+			})
+			cs.OnLFSSendHandler = func(repo string, oid []byte, s network.Stream) error {
+				Expect(repo).To(Equal("repo1"))
+				Expect(oid).To(Equal(lfsOid))
+				return nil
+			}
+			success, err := cs.OnLFSRequest(mockStream)
+			Expect(err).To(BeNil())
+			Expect(success).To(BeTrue())
+		})
+	})
+
+	Describe(".OnLFSWantRequest", func() {
+		var mockStream *mocks.MockStream
+
+		BeforeEach(func() {
+			mockStream = mocks.NewMockStream(ctrl)
+			mockConn := mocks.NewMockConn(ctrl)
+			mockConn.EXPECT().RemotePeer().Return(peer.ID("peer-id"))
+			mockStream.EXPECT().Conn().Return(mockConn)
+			cs.LFSStoreGetter = func(string) lfs.Store { return lfsStore }
+		})
+
+		It("should write 'NOPE' message to stream and return ErrObjNotFound if object does not exist", func() {
+			mockStream.EXPECT().Write(dht2.MakeNopeMsg()).Return(0, nil)
+			err := cs.OnLFSWantRequest("repo1", lfsOid, mockStream)
+			Expect(err).To(Equal(dht2.ErrObjNotFound))
+		})
+
+		It("should write 'HAVE' message to stream if object exists", func() {
+			Expect(lfsStore.Put(plumbing.BytesToHex(lfsOid), -1, bytes.NewBufferString(lfsContent))).To(BeNil())
+			mockStream.EXPECT().Write(dht2.MakeHaveMsg()).Return(0, nil)
+			err := cs.OnLFSWantRequest("repo1", lfsOid, mockStream)
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe(".OnLFSSendRequest", func() {
+		var mockStream *mocks.MockStream
+
+		BeforeEach(func() {
+			mockStream = mocks.NewMockStream(ctrl)
+			mockConn := mocks.NewMockConn(ctrl)
+			mockConn.EXPECT().RemotePeer().Return(peer.ID("peer-id"))
+			mockStream.EXPECT().Conn().Return(mockConn)
+			cs.LFSStoreGetter = func(string) lfs.Store { return lfsStore }
+		})
+
+		It("should write 'NOPE' message to stream and return ErrObjNotFound if object does not exist", func() {
+			mockStream.EXPECT().Write(dht2.MakeNopeMsg()).Return(0, nil)
+			mockStream.EXPECT().Reset()
+			err := cs.OnLFSSendRequest("repo1", lfsOid, mockStream)
+			Expect(err).To(Equal(dht2.ErrObjNotFound))
+		})
+
+		It("should write 'BLOB' message followed by the object content if object exists", func() {
+			Expect(lfsStore.Put(plumbing.BytesToHex(lfsOid), -1, bytes.NewBufferString(lfsContent))).To(BeNil())
+			written := bytes.NewBuffer(nil)
+			mockStream.EXPECT().Write(gomock.Any()).DoAndReturn(written.Write)
+			mockStream.EXPECT().Close()
+			err := cs.OnLFSSendRequest("repo1", lfsOid, mockStream)
+			Expect(err).To(BeNil())
+			Expect(written.String()).To(Equal(dht2.MsgTypeBlob + lfsContent))
+		})
+	})
+
+	Describe(".GetLFSObject", func() {
+		var ctx = context.Background()
+		var repoName = "repo1"
+		var prov = peer.AddrInfo{ID: "id", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1")}}
+
+		It("should return ErrNoProviderFound when no provider is found", func() {
+			mockDHT.EXPECT().GetProviders(ctx, lfsOid).Return(nil, nil)
+			mockDHT.EXPECT().GetProviders(ctx, []byte(repoName)).Return(nil, nil)
+			_, err := cs.GetLFSObject(ctx, repoName, lfsOid)
+			Expect(err).To(Equal(streamer.ErrNoProviderFound))
+		})
+
+		It("should return error when request failed", func() {
+			mockDHT.EXPECT().Host().Return(mockHost)
+			mockDHT.EXPECT().GetProviders(ctx, lfsOid).Return([]peer.AddrInfo{prov}, nil)
+			mockDHT.EXPECT().GetProviders(ctx, []byte(repoName)).Return(nil, nil)
+			mockReq := mocks.NewMockObjectRequester(ctrl)
+			mockReq.EXPECT().Do(ctx).Return(nil, fmt.Errorf("request error"))
+			cs.MakeRequester = func(args streamer.RequestArgs) streamer.ObjectRequester {
+				Expect(args.ProtocolID).To(Equal(streamer.LFSObjectProtocolID))
+				return mockReq
+			}
+			_, err := cs.GetLFSObject(ctx, repoName, lfsOid)
+			Expect(err).To(MatchError("request failed: request error"))
+		})
+
+		It("should return error and ban provider when content does not match the object ID", func() {
+			mockDHT.EXPECT().Host().Return(mockHost)
+			mockDHT.EXPECT().GetProviders(ctx, lfsOid).Return([]peer.AddrInfo{prov}, nil)
+			mockDHT.EXPECT().GetProviders(ctx, []byte(repoName)).Return(nil, nil)
+			obj, _ := ioutil.TempFile(os.TempDir(), "")
+			obj.WriteString("bad content")
+			obj.Seek(0, 0)
+			mockReq := mocks.NewMockObjectRequester(ctrl)
+			mockReq.EXPECT().Do(ctx).Return(&streamer.PackResult{Pack: obj, RemotePeer: prov.ID}, nil)
+			cs.MakeRequester = func(args streamer.RequestArgs) streamer.ObjectRequester {
+				return mockReq
+			}
+			mockTracker := mocks.NewMockProviderTracker(ctrl)
+			cs.SetProviderTracker(mockTracker)
+			mockTracker.EXPECT().IsGood(prov.ID).Return(true)
+			mockTracker.EXPECT().DidPeerSendNope(prov.ID, lfsOid).Return(false)
+			mockTracker.EXPECT().Register(prov)
+			mockTracker.EXPECT().Ban(prov.ID, gomock.Any())
+			_, err := cs.GetLFSObject(ctx, repoName, lfsOid)
+			Expect(err).To(MatchError("object content does not match object id"))
+		})
+
+		It("should return the object on success", func() {
+			mockDHT.EXPECT().Host().Return(mockHost)
+			mockDHT.EXPECT().GetProviders(ctx, lfsOid).Return([]peer.AddrInfo{prov}, nil)
+			mockDHT.EXPECT().GetProviders(ctx, []byte(repoName)).Return(nil, nil)
+			obj, _ := ioutil.TempFile(os.TempDir(), "")
+			defer obj.Close()
+			obj.WriteString(lfsContent)
+			obj.Seek(0, 0)
+			mockReq := mocks.NewMockObjectRequester(ctrl)
+			mockReq.EXPECT().Do(ctx).Return(&streamer.PackResult{Pack: obj, RemotePeer: prov.ID}, nil)
+			cs.MakeRequester = func(args streamer.RequestArgs) streamer.ObjectRequester {
+				return mockReq
+			}
+			res, err := cs.GetLFSObject(ctx, repoName, lfsOid)
+			Expect(err).To(BeNil())
+			data, _ := ioutil.ReadAll(res)
+			Expect(string(data)).To(Equal(lfsContent))
+		})
+	})
+
 	Describe(".GetTaggedCommitWithAncestors", func() {
 		var ctx = context.Background()
 		var repoName = "repo1"
@@ -1293,6 +1454,70 @@ var _ = Describe("BasicObjectStreamer", func() {
 			})
 		})
 
+		When("Depth is set", func() {
+			var parentHash = plumb.NewHash("7a561e23f4e81c61df1b0dc63a89ae9c8d5680cd")
+			var grandParentHash = plumb.NewHash("ab8f7a1b2a0c6a0e5f6b3f7f4c2e6a1f0b9c8d7e")
+
+			It("should not fetch parents of commits at the depth limit and report them as shallow", func() {
+				cs := mocks.NewMockStreamer(ctrl)
+				mockRepo := mocks.NewMockLocalRepo(ctrl)
+				startCommit := &object.Commit{Hash: hash, ParentHashes: []plumb.Hash{parentHash}}
+				startCommitPackfile := &fakePackfile{"pack-1"}
+				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
+				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+
+				parentCommit := &object.Commit{Hash: parentHash, ParentHashes: []plumb.Hash{grandParentHash}}
+				parentCommitPackfile := &fakePackfile{"pack-2"}
+				mockRepo.EXPECT().CommitObject(parentHash).Return(nil, plumb.ErrObjectNotFound)
+				cs.EXPECT().GetCommit(ctx, repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
+				mockRepo.EXPECT().ObjectExist(grandParentHash.String()).Return(false)
+
+				var shallow []string
+				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
+					return mockRepo, nil
+				}, dht2.GetAncestorArgs{
+					StartHash: hash[:],
+					RepoName:  repoName,
+					Depth:     2,
+					ShallowCB: func(hash string) error {
+						shallow = append(shallow, hash)
+						return nil
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(packfiles).To(HaveLen(2))
+				Expect(packfiles[0]).To(Equal(startCommitPackfile))
+				Expect(packfiles[1]).To(Equal(parentCommitPackfile))
+				Expect(shallow).To(Equal([]string{parentHash.String()}))
+			})
+
+			It("should not report a commit at the depth limit as shallow if its parents exist locally", func() {
+				cs := mocks.NewMockStreamer(ctrl)
+				mockRepo := mocks.NewMockLocalRepo(ctrl)
+				startCommit := &object.Commit{Hash: hash, ParentHashes: []plumb.Hash{parentHash}}
+				startCommitPackfile := &fakePackfile{"pack-1"}
+				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
+				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				mockRepo.EXPECT().ObjectExist(parentHash.String()).Return(true)
+
+				var shallow []string
+				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
+					return mockRepo, nil
+				}, dht2.GetAncestorArgs{
+					StartHash: hash[:],
+					RepoName:  repoName,
+					Depth:     1,
+					ShallowCB: func(hash string) error {
+						shallow = append(shallow, hash)
+						return nil
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(packfiles).To(HaveLen(1))
+				Expect(shallow).To(BeEmpty())
+			})
+		})
+
 		Context("use callback to collect result, instead method returned result", func() {
 			var parentHash = plumbing.HashToBytes("7a561e23f4e81c61df1b0dc63a89ae9c8d5680cd")
 
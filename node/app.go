@@ -3,6 +3,7 @@ package node
 import (
 	"bytes"
 
+	"github.com/AlekSi/pointer"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/logic/contracts/mergerequest"
 	"github.com/make-os/kit/logic/keepers"
@@ -20,6 +21,7 @@ import (
 	"github.com/make-os/kit/validation"
 	"github.com/pkg/errors"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/thoas/go-funk"
 )
 
 type ticketInfo struct {
@@ -43,6 +45,20 @@ type newRepo struct {
 	creatorAddress []byte
 }
 
+type renamedRepo struct {
+	name    string
+	newName string
+}
+
+// dueProposal describes a proposal ending or due
+// for execution at the proposed block height
+type dueProposal struct {
+	repo    string
+	id      string
+	action  types.TxCode
+	outcome state.ProposalOutcome
+}
+
 // App implements tendermint ABCI interface to
 type App struct {
 	db                        storagetypes.Engine
@@ -60,9 +76,11 @@ type App struct {
 	unsavedValidators         []*core.Validator
 	heightToSaveNewValidators int64
 	okTxs                     []blockTx
-	repoPropTxs               []*txns.TxRepoProposalVote
 	newRepos                  []newRepo
+	deletedRepos              []string
+	renamedRepos              []renamedRepo
 	closedMergeProps          []*mergeProposalInfo
+	proposalEvents            []*core.ProposalEvent
 	curEpoch                  int64
 }
 
@@ -207,6 +225,8 @@ func (a *App) preExecChecks(tx types.BaseTx) *abcitypes.ResponseDeliverTx {
 
 // postExec initiates events based on specific, successfully processed transactions
 func (a *App) postExec(tx types.BaseTx, resp *abcitypes.ResponseDeliverTx) *abcitypes.ResponseDeliverTx {
+	a.collectProposalEvents(tx)
+
 	switch o := tx.(type) {
 	case *txns.TxTicketPurchase:
 		if o.Is(txns.TxTypeValidatorTicket) {
@@ -221,8 +241,15 @@ func (a *App) postExec(tx types.BaseTx, resp *abcitypes.ResponseDeliverTx) *abci
 	case *txns.TxRepoCreate:
 		a.newRepos = append(a.newRepos, newRepo{name: o.Name, creatorAddress: o.SenderPubKey.MustAddressRaw()})
 
-	case *txns.TxRepoProposalVote:
-		a.repoPropTxs = append(a.repoPropTxs, o)
+	case *txns.TxRepoProposalDelete:
+		if a.logic.RepoKeeper().GetNoPopulate(o.RepoName).IsEmpty() {
+			a.deletedRepos = append(a.deletedRepos, o.RepoName)
+		}
+
+	case *txns.TxRepoProposalRename:
+		if a.logic.RepoKeeper().GetNoPopulate(o.RepoName).IsEmpty() {
+			a.renamedRepos = append(a.renamedRepos, renamedRepo{name: o.RepoName, newName: o.NewName})
+		}
 
 	case *txns.TxPush:
 		for _, ref := range o.Note.GetPushedReferences() {
@@ -291,10 +318,30 @@ func (a *App) EndBlock(req abcitypes.RequestEndBlock) abcitypes.ResponseEndBlock
 		panic(errors.Wrap(err, "failed to update validators"))
 	}
 
+	// Get proposals to be applied in this block and their repos, so that the
+	// proposals finalized and the repos deleted or renamed by an applied
+	// proposal can be detected afterwards.
+	propRepos := a.getReposWithDueProposals()
+	dueProps := a.getDueProposals()
+
 	if err := a.logic.OnEndBlock(a.proposedBlock); err != nil {
 		panic(errors.Wrap(err, "logic.OnEndBlock"))
 	}
 
+	a.collectDueProposalEvents(dueProps)
+
+	for _, name := range propRepos {
+		repoKeeper := a.logic.RepoKeeper()
+		if !repoKeeper.GetNoPopulate(name).IsEmpty() {
+			continue
+		}
+		if newName := repoKeeper.GetRedirect(name); newName != "" {
+			a.renamedRepos = append(a.renamedRepos, renamedRepo{name: name, newName: newName})
+			continue
+		}
+		a.deletedRepos = append(a.deletedRepos, name)
+	}
+
 	if err := a.trackAndBroadcastEpochChange(); err != nil {
 		panic(errors.Wrap(err, "failed to track epoch change"))
 	}
@@ -335,9 +382,10 @@ func (a *App) Commit() abcitypes.ResponseCommit {
 	}
 
 	a.broadcastTx()
-	a.indexProposalVotes()
 	a.expireHostTickets()
 	a.createGitRepositories()
+	a.deleteGitRepositories()
+	a.renameGitRepositories()
 	a.indexRepoCreator()
 	a.markMergeProposalAsClosed()
 	a.updateDifficulty(a.proposedBlock)
@@ -347,6 +395,8 @@ func (a *App) Commit() abcitypes.ResponseCommit {
 		a.commitPanic(errors.Wrap(err, "failed to commit"))
 	}
 
+	a.broadcastProposalEvents()
+
 	return abcitypes.ResponseCommit{
 		Data: bi.AppHash,
 	}
@@ -366,9 +416,11 @@ func (a *App) reset() {
 	a.txIndex = 0
 	a.isCurrentBlockProposer = false
 	a.okTxs = []blockTx{}
-	a.repoPropTxs = []*txns.TxRepoProposalVote{}
 	a.newRepos = []newRepo{}
+	a.deletedRepos = []string{}
+	a.renamedRepos = []renamedRepo{}
 	a.closedMergeProps = []*mergeProposalInfo{}
+	a.proposalEvents = []*core.ProposalEvent{}
 
 	// Only reset heightToSaveNewValidators if the current height is
 	// same as it to avoid not triggering saving of new validators at the target height.
@@ -480,6 +532,199 @@ func (a *App) createGitRepositories() error {
 	return nil
 }
 
+// getReposWithDueProposals returns the names of existing repositories that
+// have proposals ending or due for execution at the proposed block height.
+func (a *App) getReposWithDueProposals() (names []string) {
+	repoKeeper := a.logic.RepoKeeper()
+	height := uint64(a.proposedBlock.Height)
+	props := append(repoKeeper.GetProposalsEndingAt(height), repoKeeper.GetProposalsExecutingAt(height)...)
+	for _, p := range props {
+		if funk.ContainsString(names, p.RepoName) || repoKeeper.GetNoPopulate(p.RepoName).IsEmpty() {
+			continue
+		}
+		names = append(names, p.RepoName)
+	}
+	return
+}
+
+// getDueProposals returns proposals ending or due for
+// execution at the proposed block height.
+func (a *App) getDueProposals() (props []*dueProposal) {
+	repoKeeper := a.logic.RepoKeeper()
+	height := uint64(a.proposedBlock.Height)
+	for _, p := range append(repoKeeper.GetProposalsEndingAt(height), repoKeeper.GetProposalsExecutingAt(height)...) {
+		if _, proposal := a.getProposal(p.RepoName, p.ProposalID); proposal != nil {
+			props = append(props, &dueProposal{
+				repo:    p.RepoName,
+				id:      p.ProposalID,
+				action:  proposal.Action,
+				outcome: proposal.Outcome,
+			})
+		}
+	}
+	return
+}
+
+// getProposal finds a proposal and returns it along with the name of its
+// repository. If the repository was renamed, the proposal is searched in the
+// repository the name redirects to. Returns nil if the proposal is not found.
+func (a *App) getProposal(repoName, id string) (string, *state.RepoProposal) {
+	repoKeeper := a.logic.RepoKeeper()
+	repo := repoKeeper.Get(repoName)
+	if repo.IsEmpty() {
+		if target := repoKeeper.GetRedirect(repoName); target != "" {
+			repoName, repo = target, repoKeeper.Get(target)
+		}
+	}
+	return repoName, repo.Proposals.Get(id)
+}
+
+// collectProposalEvents collects lifecycle events of
+// proposals created or acted on by a successful transaction.
+func (a *App) collectProposalEvents(tx types.BaseTx) {
+	var repoName, id string
+	evt := &core.ProposalEvent{Height: uint64(a.proposedBlock.Height)}
+	switch o := tx.(type) {
+	case *txns.TxRepoProposalVote:
+		repoName, id = o.RepoName, o.ProposalID
+		evt.Type, evt.Vote = core.ProposalEventVoted, pointer.ToInt(o.Vote)
+	case *txns.TxRepoProposalSendFee:
+		repoName, id = o.RepoName, o.ID
+		evt.Type, evt.Value = core.ProposalEventFeeDeposited, o.Value.String()
+	case *txns.TxRepoProposalWithdraw:
+		repoName, id = o.RepoName, o.ProposalID
+	case *txns.TxRepoProposalCancel:
+		repoName, id = o.RepoName, o.ProposalID
+	case types.ProposalTx:
+		repoName, id = o.GetProposalRepoName(), o.GetProposalID()
+		evt.Type, evt.Value = core.ProposalEventCreated, o.GetProposalValue().String()
+		evt.Action = tx.GetType()
+	default:
+		return
+	}
+
+	name, proposal := a.getProposal(repoName, id)
+	evt.RepoName, evt.ProposalID, evt.Actor = name, id, tx.GetFrom().String()
+	if proposal != nil {
+		evt.Action = proposal.Action
+	}
+	if evt.Type != "" {
+		a.proposalEvents = append(a.proposalEvents, evt)
+	}
+
+	if proposal == nil {
+		// A proposal that does not exist after its creation
+		// was applied and removed its repository.
+		if evt.Type == core.ProposalEventCreated {
+			a.addProposalEndEvents(repoName, id, evt.Action, state.ProposalOutcomeAccepted, false)
+		}
+		return
+	}
+
+	if proposal.IsFinalized() {
+		a.addProposalEndEvents(name, id, proposal.Action, proposal.Outcome, false)
+	}
+}
+
+// collectDueProposalEvents collects lifecycle events of due
+// proposals whose outcome was changed at the end of the block.
+func (a *App) collectDueProposalEvents(props []*dueProposal) {
+	for _, dp := range props {
+		wasQueued := dp.outcome == state.ProposalOutcomeQueued
+		name, proposal := a.getProposal(dp.repo, dp.id)
+		if proposal == nil {
+			if dp.action == txns.TxTypeRepoProposalDelete {
+				a.addProposalEndEvents(dp.repo, dp.id, dp.action, state.ProposalOutcomeAccepted, wasQueued)
+			}
+			continue
+		}
+		if proposal.Outcome != dp.outcome {
+			a.addProposalEndEvents(name, dp.id, proposal.Action, proposal.Outcome, wasQueued)
+		}
+	}
+}
+
+// addProposalEndEvents adds an ended event for a proposal and an applied
+// event if the proposal was accepted or a failed event if its action could
+// not be executed. If the proposal was queued, only the applied or failed
+// event is added since its ended event was already added.
+func (a *App) addProposalEndEvents(repoName, id string, action types.TxCode,
+	outcome state.ProposalOutcome, wasQueued bool) {
+	evt := core.ProposalEvent{RepoName: repoName, ProposalID: id, Action: action,
+		Height: uint64(a.proposedBlock.Height), Outcome: outcome}
+	if !wasQueued {
+		ended := evt
+		ended.Type = core.ProposalEventEnded
+		a.proposalEvents = append(a.proposalEvents, &ended)
+	}
+	switch outcome {
+	case state.ProposalOutcomeAccepted:
+		evt.Type = core.ProposalEventApplied
+		a.proposalEvents = append(a.proposalEvents, &evt)
+	case state.ProposalOutcomeFailed:
+		evt.Type = core.ProposalEventFailed
+		a.proposalEvents = append(a.proposalEvents, &evt)
+	}
+}
+
+// deleteGitRepositories removes the local data of deleted repositories
+// and stops tracking them.
+// If the node is in validator node, there is no local repository to remove.
+func (a *App) deleteGitRepositories() error {
+
+	if len(a.deletedRepos) == 0 {
+		return nil
+	}
+
+	if a.cfg.IsValidatorNode() {
+		return types.ErrSkipped
+	}
+
+	for _, name := range a.deletedRepos {
+		if a.logic.RepoSyncInfoKeeper().GetTracked(name) != nil {
+			if err := a.logic.RepoSyncInfoKeeper().UnTrack(name); err != nil {
+				a.commitPanic(errors.Wrap(err, "failed to untrack repository"))
+			}
+		}
+		if err := a.logic.GetRemoteServer().DeleteRepository(name); err != nil {
+			a.commitPanic(errors.Wrap(err, "failed to delete repository"))
+		}
+	}
+
+	return nil
+}
+
+// renameGitRepositories moves the local data of renamed repositories
+// to their new name and tracks them by their new name.
+// If the node is in validator node, there is no local repository to move.
+func (a *App) renameGitRepositories() error {
+
+	if len(a.renamedRepos) == 0 {
+		return nil
+	}
+
+	if a.cfg.IsValidatorNode() {
+		return types.ErrSkipped
+	}
+
+	syncInfoKeeper := a.logic.RepoSyncInfoKeeper()
+	for _, repo := range a.renamedRepos {
+		if tracked := syncInfoKeeper.GetTracked(repo.name); tracked != nil {
+			if err := syncInfoKeeper.UnTrack(repo.name); err != nil {
+				a.commitPanic(errors.Wrap(err, "failed to untrack repository"))
+			}
+			if err := syncInfoKeeper.Track(repo.newName, tracked.UpdatedAt.UInt64()); err != nil {
+				a.commitPanic(errors.Wrap(err, "failed to track repository"))
+			}
+		}
+		if err := a.logic.GetRemoteServer().RenameRepository(repo.name, repo.newName); err != nil {
+			a.commitPanic(errors.Wrap(err, "failed to rename repository"))
+		}
+	}
+
+	return nil
+}
+
 // indexRepoCreator indexes a new repo name with their creator.
 func (a *App) indexRepoCreator() {
 	for _, repo := range a.newRepos {
@@ -488,9 +733,11 @@ func (a *App) indexRepoCreator() {
 }
 
 // markMergeProposalAsClosed marks a merge proposal as closed.
+// Only accepted merge proposals can be merged, so they are closed as accepted.
 func (a *App) markMergeProposalAsClosed() {
 	for _, info := range a.closedMergeProps {
-		if err := a.logic.RepoKeeper().MarkProposalAsClosed(info.repo, info.proposalID); err != nil {
+		if err := a.logic.RepoKeeper().MarkProposalAsClosed(info.repo, info.proposalID,
+			state.ProposalOutcomeAccepted); err != nil {
 			a.commitPanic(errors.Wrap(err, "failed to mark merge proposal as closed"))
 		}
 	}
@@ -505,16 +752,6 @@ func (a *App) expireHostTickets() {
 	}
 }
 
-// indexProposalVotes indexes a vote for on a proposal
-func (a *App) indexProposalVotes() {
-	for _, v := range a.repoPropTxs {
-		if err := a.logic.RepoKeeper().IndexProposalVote(v.RepoName, v.ProposalID,
-			v.GetFrom().String(), v.Vote); err != nil {
-			a.commitPanic(errors.Wrap(err, "failed to index repository proposal vote"))
-		}
-	}
-}
-
 // broadcastTx selected transactions that may be need by other app processes
 func (a *App) broadcastTx() {
 	for _, btx := range a.okTxs {
@@ -524,6 +761,14 @@ func (a *App) broadcastTx() {
 	}
 }
 
+// broadcastProposalEvents emits the proposal lifecycle events
+// collected in the committed block
+func (a *App) broadcastProposalEvents() {
+	for _, evt := range a.proposalEvents {
+		a.cfg.G().Bus.Emit(core.EvtProposal, evt)
+	}
+}
+
 // trackAndBroadcastEpochChange tracks current epoch and will
 // broadcast EvtNewEpoch if there is a change in epoch
 func (a *App) trackAndBroadcastEpochChange() error {
@@ -479,20 +479,121 @@ var _ = Describe("App", func() {
 			BeforeEach(func() {
 				tx = txns.NewBareRepoProposalVote()
 				tx.RepoName = "repo1"
+				tx.ProposalID = "1"
+				tx.Vote = 1
 				tx.SetSenderPubKey(sender.PubKey().MustBytes())
+				repo := state.BareRepository()
+				repo.Balance = "10"
+				repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalSpend})
+				mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(repo)
 				resp := &abcitypes.ResponseDeliverTx{}
 				app.postExec(tx, resp)
 			})
 
-			It("should add repo name to new repo index", func() {
-				Expect(app.repoPropTxs).To(HaveLen(1))
-				Expect(app.repoPropTxs).To(ContainElement(tx))
-			})
-
 			It("should add tx to un-indexed cache", func() {
 				Expect(app.okTxs).To(HaveLen(1))
 				Expect(app.okTxs[0].tx).To(Equal(tx))
 			})
+
+			It("should add a voted proposal event", func() {
+				Expect(app.proposalEvents).To(HaveLen(1))
+				Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventVoted))
+				Expect(app.proposalEvents[0].RepoName).To(Equal("repo1"))
+				Expect(app.proposalEvents[0].ProposalID).To(Equal("1"))
+				Expect(app.proposalEvents[0].Action).To(Equal(txns.TxTypeRepoProposalSpend))
+				Expect(app.proposalEvents[0].Actor).To(Equal(sender.Addr().String()))
+				Expect(*app.proposalEvents[0].Vote).To(Equal(1))
+			})
+		})
+
+		When("tx is TxRepoProposalDelete and the repo was removed", func() {
+			var tx *txns.TxRepoProposalDelete
+
+			BeforeEach(func() {
+				tx = txns.NewBareRepoProposalDelete()
+				tx.RepoName = "repo1"
+				tx.ID = "1"
+				tx.SetSenderPubKey(sender.PubKey().MustBytes())
+				mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+				mockLogic.RepoKeeper.EXPECT().GetRedirect("repo1").Return("")
+				mockLogic.RepoKeeper.EXPECT().GetNoPopulate("repo1").Return(state.BareRepository())
+				resp := &abcitypes.ResponseDeliverTx{}
+				app.postExec(tx, resp)
+			})
+
+			It("should add repo name to deleted repo index", func() {
+				Expect(app.deletedRepos).To(Equal([]string{"repo1"}))
+			})
+
+			It("should add created, ended and applied proposal events", func() {
+				Expect(app.proposalEvents).To(HaveLen(3))
+				Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventCreated))
+				Expect(app.proposalEvents[0].Action).To(Equal(txns.TxTypeRepoProposalDelete))
+				Expect(app.proposalEvents[1].Type).To(Equal(core.ProposalEventEnded))
+				Expect(app.proposalEvents[1].Outcome).To(Equal(state.ProposalOutcomeAccepted))
+				Expect(app.proposalEvents[2].Type).To(Equal(core.ProposalEventApplied))
+			})
+		})
+
+		When("tx is TxRepoProposalDelete and the repo was not removed", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalDelete()
+				tx.RepoName = "repo1"
+				tx.ID = "1"
+				tx.SetSenderPubKey(sender.PubKey().MustBytes())
+				repo := state.BareRepository()
+				repo.Balance = "10"
+				repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalDelete})
+				mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(repo)
+				mockLogic.RepoKeeper.EXPECT().GetNoPopulate("repo1").Return(&state.Repository{Balance: "10"})
+				resp := &abcitypes.ResponseDeliverTx{}
+				app.postExec(tx, resp)
+			})
+
+			It("should not add repo name to deleted repo index", func() {
+				Expect(app.deletedRepos).To(BeEmpty())
+			})
+
+			It("should add only a created proposal event", func() {
+				Expect(app.proposalEvents).To(HaveLen(1))
+				Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventCreated))
+			})
+		})
+
+		When("tx is TxRepoProposalRename and the repo was moved", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalRename()
+				tx.RepoName = "repo1"
+				tx.NewName = "repo2"
+				tx.SetSenderPubKey(sender.PubKey().MustBytes())
+				mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+				mockLogic.RepoKeeper.EXPECT().GetRedirect("repo1").Return("repo2")
+				mockLogic.RepoKeeper.EXPECT().Get("repo2").Return(state.BareRepository())
+				mockLogic.RepoKeeper.EXPECT().GetNoPopulate("repo1").Return(state.BareRepository())
+				resp := &abcitypes.ResponseDeliverTx{}
+				app.postExec(tx, resp)
+			})
+
+			It("should add repo to renamed repo index", func() {
+				Expect(app.renamedRepos).To(Equal([]renamedRepo{{name: "repo1", newName: "repo2"}}))
+			})
+		})
+
+		When("tx is TxRepoProposalRename and the repo was not moved", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalRename()
+				tx.RepoName = "repo1"
+				tx.NewName = "repo2"
+				tx.SetSenderPubKey(sender.PubKey().MustBytes())
+				mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(&state.Repository{Balance: "10"})
+				mockLogic.RepoKeeper.EXPECT().GetNoPopulate("repo1").Return(&state.Repository{Balance: "10"})
+				resp := &abcitypes.ResponseDeliverTx{}
+				app.postExec(tx, resp)
+			})
+
+			It("should not add repo to renamed repo index", func() {
+				Expect(app.renamedRepos).To(BeEmpty())
+			})
 		})
 
 		When("tx is TxPush with a reference with merge proposal id", func() {
@@ -623,7 +724,7 @@ var _ = Describe("App", func() {
 
 			It("should attempt to mark the proposal as closed", func() {
 				mockLogic.RepoKeeper.EXPECT().
-					MarkProposalAsClosed(mergePropInfo.repo, mergePropInfo.proposalID).Return(nil)
+					MarkProposalAsClosed(mergePropInfo.repo, mergePropInfo.proposalID, state.ProposalOutcomeAccepted).Return(nil)
 				app.Commit()
 			})
 		})
@@ -677,6 +778,73 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe(".deleteGitRepositories", func() {
+		It("should return nil if no deleted repo", func() {
+			app.deletedRepos = []string{}
+			Expect(app.deleteGitRepositories()).To(BeNil())
+		})
+
+		It("should skip if node is in validator mode", func() {
+			cfg.Node.Validator = true
+			app.deletedRepos = []string{"repo1"}
+			Expect(app.deleteGitRepositories()).To(Equal(types.ErrSkipped))
+		})
+
+		It("should delete all repositories and untrack tracked ones", func() {
+			app.deletedRepos = []string{"repo1", "repo2"}
+			mockLogic.RepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(&core.TrackedRepo{})
+			mockLogic.RepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(nil)
+			mockLogic.RepoSyncInfoKeeper.EXPECT().GetTracked("repo2").Return(nil)
+			mockLogic.RemoteServer.EXPECT().DeleteRepository("repo1")
+			mockLogic.RemoteServer.EXPECT().DeleteRepository("repo2")
+			Expect(app.deleteGitRepositories()).To(BeNil())
+		})
+
+		It("should panic if unable to delete repository", func() {
+			app.deletedRepos = []string{"repo1"}
+			mockLogic.RepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(nil)
+			mockLogic.RemoteServer.EXPECT().DeleteRepository("repo1").Return(fmt.Errorf("error"))
+			mockLogic.AtomicLogic.EXPECT().Discard()
+			Expect(func() {
+				app.deleteGitRepositories()
+			}).To(Panic())
+		})
+	})
+
+	Describe(".renameGitRepositories", func() {
+		It("should return nil if no renamed repo", func() {
+			app.renamedRepos = []renamedRepo{}
+			Expect(app.renameGitRepositories()).To(BeNil())
+		})
+
+		It("should skip if node is in validator mode", func() {
+			cfg.Node.Validator = true
+			app.renamedRepos = []renamedRepo{{name: "repo1", newName: "repo2"}}
+			Expect(app.renameGitRepositories()).To(Equal(types.ErrSkipped))
+		})
+
+		It("should rename all repositories and track tracked ones by their new name", func() {
+			app.renamedRepos = []renamedRepo{{name: "repo1", newName: "repo2"}, {name: "repo3", newName: "repo4"}}
+			mockLogic.RepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(&core.TrackedRepo{UpdatedAt: 10})
+			mockLogic.RepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(nil)
+			mockLogic.RepoSyncInfoKeeper.EXPECT().Track("repo2", uint64(10)).Return(nil)
+			mockLogic.RepoSyncInfoKeeper.EXPECT().GetTracked("repo3").Return(nil)
+			mockLogic.RemoteServer.EXPECT().RenameRepository("repo1", "repo2")
+			mockLogic.RemoteServer.EXPECT().RenameRepository("repo3", "repo4")
+			Expect(app.renameGitRepositories()).To(BeNil())
+		})
+
+		It("should panic if unable to rename repository", func() {
+			app.renamedRepos = []renamedRepo{{name: "repo1", newName: "repo2"}}
+			mockLogic.RepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(nil)
+			mockLogic.RemoteServer.EXPECT().RenameRepository("repo1", "repo2").Return(fmt.Errorf("error"))
+			mockLogic.AtomicLogic.EXPECT().Discard()
+			Expect(func() {
+				app.renameGitRepositories()
+			}).To(Panic())
+		})
+	})
+
 	Describe(".broadcastTx", func() {
 		It("should broadcast push transaction", func() {
 			tx := txns.NewBareTxPush()
@@ -691,6 +859,85 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe(".collectDueProposalEvents", func() {
+		It("should add ended and applied events for an accepted proposal", func() {
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalSpend, Outcome: state.ProposalOutcomeAccepted})
+			mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(repo)
+			app.collectDueProposalEvents([]*dueProposal{{repo: "repo1", id: "1", action: txns.TxTypeRepoProposalSpend}})
+			Expect(app.proposalEvents).To(HaveLen(2))
+			Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventEnded))
+			Expect(app.proposalEvents[0].Outcome).To(Equal(state.ProposalOutcomeAccepted))
+			Expect(app.proposalEvents[1].Type).To(Equal(core.ProposalEventApplied))
+		})
+
+		It("should add only an applied event for an executed queued proposal", func() {
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalSpend, Outcome: state.ProposalOutcomeAccepted})
+			mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(repo)
+			app.collectDueProposalEvents([]*dueProposal{{repo: "repo1", id: "1", action: txns.TxTypeRepoProposalSpend,
+				outcome: state.ProposalOutcomeQueued}})
+			Expect(app.proposalEvents).To(HaveLen(1))
+			Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventApplied))
+		})
+
+		It("should add ended and failed events for an accepted proposal whose action failed", func() {
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalSpend, Outcome: state.ProposalOutcomeFailed})
+			mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(repo)
+			app.collectDueProposalEvents([]*dueProposal{{repo: "repo1", id: "1", action: txns.TxTypeRepoProposalSpend}})
+			Expect(app.proposalEvents).To(HaveLen(2))
+			Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventEnded))
+			Expect(app.proposalEvents[0].Outcome).To(Equal(state.ProposalOutcomeFailed))
+			Expect(app.proposalEvents[1].Type).To(Equal(core.ProposalEventFailed))
+		})
+
+		It("should add only an ended event for a rejected proposal", func() {
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalSpend, Outcome: state.ProposalOutcomeRejected})
+			mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(repo)
+			app.collectDueProposalEvents([]*dueProposal{{repo: "repo1", id: "1", action: txns.TxTypeRepoProposalSpend}})
+			Expect(app.proposalEvents).To(HaveLen(1))
+			Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventEnded))
+			Expect(app.proposalEvents[0].Outcome).To(Equal(state.ProposalOutcomeRejected))
+		})
+
+		It("should not add events for a proposal whose outcome did not change", func() {
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalSpend, Outcome: state.ProposalOutcomeQueued})
+			mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(repo)
+			app.collectDueProposalEvents([]*dueProposal{{repo: "repo1", id: "1", action: txns.TxTypeRepoProposalSpend,
+				outcome: state.ProposalOutcomeQueued}})
+			Expect(app.proposalEvents).To(BeEmpty())
+		})
+
+		It("should add ended and applied events for a delete proposal whose repo was removed", func() {
+			mockLogic.RepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			mockLogic.RepoKeeper.EXPECT().GetRedirect("repo1").Return("")
+			app.collectDueProposalEvents([]*dueProposal{{repo: "repo1", id: "1", action: txns.TxTypeRepoProposalDelete}})
+			Expect(app.proposalEvents).To(HaveLen(2))
+			Expect(app.proposalEvents[0].Type).To(Equal(core.ProposalEventEnded))
+			Expect(app.proposalEvents[1].Type).To(Equal(core.ProposalEventApplied))
+		})
+	})
+
+	Describe(".broadcastProposalEvents", func() {
+		It("should emit collected proposal events", func() {
+			evt := &core.ProposalEvent{Type: core.ProposalEventCreated, RepoName: "repo1", ProposalID: "1"}
+			app.proposalEvents = []*core.ProposalEvent{evt}
+			ch := cfg.G().Bus.Once(core.EvtProposal)
+			app.broadcastProposalEvents()
+			emitted := <-ch
+			Expect(emitted.Args).To(HaveLen(1))
+			Expect(emitted.Args[0]).To(Equal(evt))
+		})
+	})
+
 	Describe(".trackAndBroadcastEpochChange", func() {
 		It("should return error when unable to get current epoch", func() {
 			mockLogic.SysKeeper.EXPECT().GetCurrentEpoch().Return(int64(0), fmt.Errorf("error"))
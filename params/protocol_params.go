@@ -122,6 +122,10 @@ var (
 	// RepoProposalTTL is the number of blocks a repo proposal can remain active
 	RepoProposalTTL = uint64(10)
 
+	// RepoRedirectDur is the default number of blocks the old name of a
+	// renamed repository continues to redirect to its new name
+	RepoRedirectDur = uint64(100)
+
 	// DefaultRepoProposalQuorum is the minimum percentage of voters required to consider a proposal valid.
 	DefaultRepoProposalQuorum = float64(10)
 
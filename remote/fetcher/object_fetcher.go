@@ -74,6 +74,7 @@ type BasicObjectFetcher struct {
 	queue              chan *Task
 	onObjFetchedCb     func(string, io.ReadSeeker)
 	PackToRepoUnpacker plumbing.PackToRepoUnpacker
+	ShallowMarker      plumbing.ShallowCommitMarker
 }
 
 // NewFetcher creates an instance of BasicObjectFetcher
@@ -85,6 +86,7 @@ func NewFetcher(dht dht2.DHT, cfg *config.AppConfig) *BasicObjectFetcher {
 		queue:              make(chan *Task, 10000),
 		cfg:                cfg,
 		PackToRepoUnpacker: plumbing.UnpackPackfileToRepo,
+		ShallowMarker:      plumbing.MarkShallowCommit,
 	}
 }
 
@@ -161,6 +163,7 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 				StartHash:        plumbing.HashToBytes(ref.NewHash),
 				EndHash:          endHash,
 				ExcludeEndCommit: true,
+				Depth:            f.cfg.Repo.FetchDepth,
 				GitBinPath:       f.cfg.Node.GitBinPath,
 				ReposDir:         f.cfg.GetRepoRoot(),
 				ResultCB: func(packfile io2.ReadSeekerCloser, hash string) error {
@@ -175,6 +178,9 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 					packfile.Close()
 					return nil
 				},
+				ShallowCB: func(hash string) error {
+					return f.ShallowMarker(task.note.GetTargetRepo(), hash)
+				},
 			})
 			if err != nil {
 				f.log.Error("failed to fetch object(s) of reference",
@@ -221,6 +227,7 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 				StartHash:        plumbing.HashToBytes(ref.NewHash),
 				EndHash:          endHash,
 				ExcludeEndCommit: true,
+				Depth:            f.cfg.Repo.FetchDepth,
 				GitBinPath:       f.cfg.Node.GitBinPath,
 				ReposDir:         f.cfg.GetRepoRoot(),
 				ResultCB: func(packfile io2.ReadSeekerCloser, hash string) error {
@@ -234,6 +241,9 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 					}
 					return nil
 				},
+				ShallowCB: func(hash string) error {
+					return f.ShallowMarker(task.note.GetTargetRepo(), hash)
+				},
 			})
 			if err != nil {
 				f.log.Error("failed to fetch object(s) of reference",
@@ -186,6 +186,31 @@ var _ = Describe("ObjectFetcher", func() {
 				Expect(err).To(BeNil())
 				Expect(fetchedCalled).To(BeTrue())
 			})
+			It("should pass the configured fetch depth and mark shallow commits reported by the object streamer", func() {
+				note := &types.Note{
+					RepoName:   "repo1",
+					References: []*types.PushedReference{{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash}},
+				}
+
+				cfg.Repo.FetchDepth = 10
+				var shallow []string
+				f.ShallowMarker = func(repo plumbing.LocalRepo, hash string) error {
+					shallow = append(shallow, hash)
+					return nil
+				}
+
+				mockDHT.EXPECT().ObjectStreamer().Return(mockObjStreamer)
+				mockF := mockObjStreamer.EXPECT().GetCommitWithAncestors(gomock.Any(), gomock.Any())
+				mockF.DoAndReturn(func(ctx context.Context, args dht2.GetAncestorArgs) (packfiles []io.ReadSeekerCloser, err error) {
+					Expect(args.Depth).To(Equal(10))
+					return nil, args.ShallowCB(newHash)
+				})
+
+				task := fetcher.NewTask(note, func(err error) {})
+				err := f.Operation(task)
+				Expect(err).To(BeNil())
+				Expect(shallow).To(Equal([]string{newHash}))
+			})
 		})
 
 		When("pushed reference is a tag", func() {
@@ -0,0 +1,57 @@
+package lfs
+
+const (
+	// MediaType is the content type of LFS batch API requests and responses
+	MediaType = "application/vnd.git-lfs+json"
+
+	// TransferBasic is the name of the basic transfer adapter
+	TransferBasic = "basic"
+
+	// OperationDownload is the batch operation for downloading objects
+	OperationDownload = "download"
+
+	// OperationUpload is the batch operation for uploading objects
+	OperationUpload = "upload"
+
+	// MaxBatchObjects is the maximum number of objects in a batch request
+	MaxBatchObjects = 100
+)
+
+// BatchRequest describes a batch API request
+type BatchRequest struct {
+	Operation string    `json:"operation"`
+	Transfers []string  `json:"transfers,omitempty"`
+	Objects   []*Object `json:"objects"`
+}
+
+// BatchResponse describes a batch API response
+type BatchResponse struct {
+	Transfer string    `json:"transfer,omitempty"`
+	Objects  []*Object `json:"objects"`
+}
+
+// Object describes an object in a batch API request or response
+type Object struct {
+	Oid           string             `json:"oid"`
+	Size          int64              `json:"size"`
+	Authenticated bool               `json:"authenticated,omitempty"`
+	Actions       map[string]*Action `json:"actions,omitempty"`
+	Error         *ObjectError       `json:"error,omitempty"`
+}
+
+// Action describes how a client can transfer an object
+type Action struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+// ObjectError describes an error that occurred for a specific object
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse describes a batch API error response
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
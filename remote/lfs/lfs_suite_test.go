@@ -0,0 +1,13 @@
+package lfs_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLfs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Lfs Suite")
+}
@@ -0,0 +1,180 @@
+// Package lfs provides support for Git Large File Storage (LFS).
+// It implements pointer file parsing, a content-addressed object
+// store and the types of the LFS batch API.
+package lfs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	plumbing2 "github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PointerVersion is the version line of a v1 pointer file
+	PointerVersion = "https://git-lfs.github.com/spec/v1"
+
+	// MaxPointerSize is the maximum size of a pointer file
+	MaxPointerSize = 1024
+
+	// OidLength is the length of a hex-encoded object ID
+	OidLength = 64
+)
+
+var (
+	ErrNotPointer = fmt.Errorf("not a pointer file")
+)
+
+// Pointer describes a pointer file that stands in place
+// of an LFS object in a repository tree.
+type Pointer struct {
+	Oid  string
+	Size int64
+}
+
+// String returns the pointer file content
+func (p *Pointer) String() string {
+	return fmt.Sprintf("version %s\noid sha256:%s\nsize %d\n", PointerVersion, p.Oid, p.Size)
+}
+
+// IsValidOid checks whether oid is a hex-encoded SHA-256 hash
+func IsValidOid(oid string) bool {
+	if len(oid) != OidLength {
+		return false
+	}
+	_, err := hex.DecodeString(oid)
+	return err == nil
+}
+
+// ParsePointer parses a pointer file.
+// It returns ErrNotPointer if data is not a valid pointer file.
+func ParsePointer(data []byte) (*Pointer, error) {
+	if len(data) > MaxPointerSize {
+		return nil, ErrNotPointer
+	}
+
+	var ptr Pointer
+	var hasSize bool
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for i := 0; scanner.Scan(); i++ {
+		line := scanner.Text()
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 {
+			return nil, ErrNotPointer
+		}
+
+		// The first line must be the version
+		if i == 0 {
+			if parts[0] != "version" || parts[1] != PointerVersion {
+				return nil, ErrNotPointer
+			}
+			continue
+		}
+
+		switch parts[0] {
+		case "oid":
+			if !strings.HasPrefix(parts[1], "sha256:") {
+				return nil, ErrNotPointer
+			}
+			ptr.Oid = strings.TrimPrefix(parts[1], "sha256:")
+			if !IsValidOid(ptr.Oid) {
+				return nil, ErrNotPointer
+			}
+		case "size":
+			size, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil || size < 0 {
+				return nil, ErrNotPointer
+			}
+			ptr.Size, hasSize = size, true
+		}
+	}
+
+	if ptr.Oid == "" || !hasSize {
+		return nil, ErrNotPointer
+	}
+
+	return &ptr, nil
+}
+
+// GetPointers returns the pointer files introduced by the commits
+// between startHash and endHash (exclusive).
+// If endHash is a zero hash, the entire history of startHash is checked.
+func GetPointers(repo plumbing.LocalRepo, startHash, endHash string) ([]*Pointer, error) {
+	var pointers []*Pointer
+	seen := make(map[string]struct{})
+	err := plumbing.WalkBack(repo, startHash, endHash, func(hash string) error {
+		obj, err := repo.GetObject(hash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get object (%s)", hash)
+		}
+
+		blob, ok := obj.(*object.Blob)
+		if !ok || blob.Size > MaxPointerSize {
+			return nil
+		}
+
+		rdr, err := blob.Reader()
+		if err != nil {
+			return err
+		}
+		defer rdr.Close()
+		data, err := ioutil.ReadAll(rdr)
+		if err != nil {
+			return err
+		}
+
+		ptr, err := ParsePointer(data)
+		if err != nil {
+			return nil
+		}
+
+		if _, ok := seen[ptr.Oid]; !ok {
+			seen[ptr.Oid] = struct{}{}
+			pointers = append(pointers, ptr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pointers, nil
+}
+
+// GetRepoPointers returns the pointer files in the history of
+// the references of a repository, keyed by their object ID.
+func GetRepoPointers(repo plumbing.LocalRepo) (map[string]*Pointer, error) {
+	itr, err := repo.References()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get references")
+	}
+
+	var hashes []string
+	_ = itr.ForEach(func(ref *plumbing2.Reference) error {
+		if ref.Type() == plumbing2.HashReference {
+			hashes = append(hashes, ref.Hash().String())
+		}
+		return nil
+	})
+
+	pointers := make(map[string]*Pointer)
+	for _, hash := range hashes {
+		ptrs, err := GetPointers(repo, hash, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, ptr := range ptrs {
+			pointers[ptr.Oid] = ptr
+		}
+	}
+
+	return pointers, nil
+}
@@ -0,0 +1,150 @@
+package lfs_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/remote/lfs"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func makeOid(content string) string {
+	h := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(h[:])
+}
+
+var _ = Describe("Pointer", func() {
+	oid := makeOid("hello")
+
+	Describe(".ParsePointer", func() {
+		It("should parse a valid pointer file", func() {
+			ptr, err := lfs.ParsePointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 5\n"))
+			Expect(err).To(BeNil())
+			Expect(ptr.Oid).To(Equal(oid))
+			Expect(ptr.Size).To(Equal(int64(5)))
+		})
+
+		It("should return ErrNotPointer if version line is missing or unknown", func() {
+			_, err := lfs.ParsePointer([]byte("oid sha256:" + oid + "\nsize 5\n"))
+			Expect(err).To(Equal(lfs.ErrNotPointer))
+			_, err = lfs.ParsePointer([]byte("version https://example.com/v2\noid sha256:" + oid + "\nsize 5\n"))
+			Expect(err).To(Equal(lfs.ErrNotPointer))
+		})
+
+		It("should return ErrNotPointer if oid is invalid or size is missing", func() {
+			_, err := lfs.ParsePointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 5\n"))
+			Expect(err).To(Equal(lfs.ErrNotPointer))
+			_, err = lfs.ParsePointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n"))
+			Expect(err).To(Equal(lfs.ErrNotPointer))
+		})
+
+		It("should return ErrNotPointer if data is larger than the max pointer size", func() {
+			_, err := lfs.ParsePointer([]byte(strings.Repeat("a", lfs.MaxPointerSize+1)))
+			Expect(err).To(Equal(lfs.ErrNotPointer))
+		})
+
+		It("should parse the output of Pointer.String", func() {
+			ptr := &lfs.Pointer{Oid: oid, Size: 5}
+			res, err := lfs.ParsePointer([]byte(ptr.String()))
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(ptr))
+		})
+	})
+
+	Describe(".GetPointers", func() {
+		var err error
+		var cfg *config.AppConfig
+		var path string
+
+		BeforeEach(func() {
+			cfg, err = testutil.SetTestCfg()
+			Expect(err).To(BeNil())
+			repoName := util.RandString(5)
+			path = filepath.Join(cfg.GetRepoRoot(), repoName)
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+		})
+
+		AfterEach(func() {
+			err = os.RemoveAll(cfg.DataDir())
+			Expect(err).To(BeNil())
+		})
+
+		It("should return pointers introduced by the commits in the range", func() {
+			testutil2.AppendCommit(path, "file.txt", "hello", "commit 1")
+			start := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD")))
+			ptr1 := &lfs.Pointer{Oid: makeOid("obj1"), Size: 4}
+			testutil2.AppendCommit(path, "obj1.bin", ptr1.String(), "commit 2")
+			ptr2 := &lfs.Pointer{Oid: makeOid("obj2"), Size: 4}
+			testutil2.AppendCommit(path, "obj2.bin", ptr2.String(), "commit 3")
+			end := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD")))
+
+			r, err := repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+			Expect(err).To(BeNil())
+			pointers, err := lfs.GetPointers(r, end, start)
+			Expect(err).To(BeNil())
+			Expect(pointers).To(ConsistOf(ptr1, ptr2))
+		})
+
+		It("should return no pointers when the commits contain no pointer file", func() {
+			testutil2.AppendCommit(path, "file.txt", "hello", "commit 1")
+			end := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD")))
+			r, err := repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+			Expect(err).To(BeNil())
+			pointers, err := lfs.GetPointers(r, end, "")
+			Expect(err).To(BeNil())
+			Expect(pointers).To(BeEmpty())
+		})
+	})
+
+	Describe(".GetRepoPointers", func() {
+		var err error
+		var cfg *config.AppConfig
+		var path string
+
+		BeforeEach(func() {
+			cfg, err = testutil.SetTestCfg()
+			Expect(err).To(BeNil())
+			repoName := util.RandString(5)
+			path = filepath.Join(cfg.GetRepoRoot(), repoName)
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+		})
+
+		AfterEach(func() {
+			err = os.RemoveAll(cfg.DataDir())
+			Expect(err).To(BeNil())
+		})
+
+		It("should return pointers in the history of all references", func() {
+			ptr1 := &lfs.Pointer{Oid: makeOid("obj1"), Size: 4}
+			testutil2.AppendCommit(path, "obj1.bin", ptr1.String(), "commit 1")
+			testutil2.ExecGit(path, "checkout", "-b", "dev")
+			ptr2 := &lfs.Pointer{Oid: makeOid("obj2"), Size: 4}
+			testutil2.AppendCommit(path, "obj2.bin", ptr2.String(), "commit 2")
+
+			r, err := repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+			Expect(err).To(BeNil())
+			pointers, err := lfs.GetRepoPointers(r)
+			Expect(err).To(BeNil())
+			Expect(pointers).To(HaveLen(2))
+			Expect(pointers).To(HaveKeyWithValue(ptr1.Oid, ptr1))
+			Expect(pointers).To(HaveKeyWithValue(ptr2.Oid, ptr2))
+		})
+
+		It("should return no pointers when the repository has no references", func() {
+			r, err := repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+			Expect(err).To(BeNil())
+			pointers, err := lfs.GetRepoPointers(r)
+			Expect(err).To(BeNil())
+			Expect(pointers).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,162 @@
+package lfs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// MaxObjectSize is the maximum size of an LFS object
+var MaxObjectSize int64 = 5000000000
+
+var (
+	ErrInvalidOid      = fmt.Errorf("invalid object id")
+	ErrObjectNotFound  = fmt.Errorf("object not found")
+	ErrOidMismatch     = fmt.Errorf("object content does not match object id")
+	ErrSizeMismatch    = fmt.Errorf("object size does not match expected size")
+	storeDirName       = filepath.Join("lfs", "objects")
+	storeTmpDirName    = "tmp"
+	storeObjectDirPerm = os.FileMode(0700)
+)
+
+// Store describes a storage for LFS objects
+type Store interface {
+	// Has checks whether an object exist in the store
+	Has(oid string) bool
+
+	// Size returns the size of an object
+	Size(oid string) (int64, error)
+
+	// Open returns a reader for the content of an object.
+	// The caller is responsible for closing the returned reader.
+	Open(oid string) (*os.File, error)
+
+	// Put adds an object to the store.
+	// The content read from r must hash to oid; If size is not
+	// negative, the content must also be of the given size.
+	Put(oid string, size int64, r io.Reader) error
+}
+
+// GetStore returns the LFS object store of the repository at repoPath
+func GetStore(repoPath string) Store {
+	return NewBasicStore(filepath.Join(repoPath, storeDirName))
+}
+
+// BasicStore implements Store. It stores objects on disk in a
+// content-addressed layout: <dir>/<oid[0:2]>/<oid[2:4]>/<oid>
+type BasicStore struct {
+	dir string
+}
+
+// NewBasicStore creates an instance of BasicStore
+func NewBasicStore(dir string) *BasicStore {
+	return &BasicStore{dir: dir}
+}
+
+// path returns the path of an object
+func (s *BasicStore) path(oid string) string {
+	return filepath.Join(s.dir, oid[0:2], oid[2:4], oid)
+}
+
+// Has checks whether an object exist in the store
+func (s *BasicStore) Has(oid string) bool {
+	if !IsValidOid(oid) {
+		return false
+	}
+	_, err := os.Stat(s.path(oid))
+	return err == nil
+}
+
+// Size returns the size of an object
+func (s *BasicStore) Size(oid string) (int64, error) {
+	if !IsValidOid(oid) {
+		return 0, ErrInvalidOid
+	}
+	info, err := os.Stat(s.path(oid))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, ErrObjectNotFound
+		}
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// Open returns a reader for the content of an object.
+// The caller is responsible for closing the returned reader.
+func (s *BasicStore) Open(oid string) (*os.File, error) {
+	if !IsValidOid(oid) {
+		return nil, ErrInvalidOid
+	}
+	f, err := os.Open(s.path(oid))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// Put adds an object to the store.
+// The content read from r must hash to oid; If size is not
+// negative, the content must also be of the given size.
+// It is a no-op if the object already exists.
+func (s *BasicStore) Put(oid string, size int64, r io.Reader) error {
+	if !IsValidOid(oid) {
+		return ErrInvalidOid
+	}
+
+	if s.Has(oid) {
+		return nil
+	}
+
+	// Write the content to a temporary file first so that partially
+	// written or invalid content never becomes visible in the store.
+	tmpDir := filepath.Join(s.dir, storeTmpDirName)
+	if err := os.MkdirAll(tmpDir, storeObjectDirPerm); err != nil {
+		return errors.Wrap(err, "failed to create tmp directory")
+	}
+	tmp, err := ioutil.TempFile(tmpDir, oid)
+	if err != nil {
+		return errors.Wrap(err, "failed to create tmp file")
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	// When the size is known, read at most one byte more than
+	// the size; Enough to detect content larger than expected.
+	if size >= 0 {
+		r = io.LimitReader(r, size+1)
+	}
+
+	hasher := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, hasher), r)
+	if err != nil {
+		return errors.Wrap(err, "failed to write object")
+	}
+
+	if size >= 0 && n != size {
+		return ErrSizeMismatch
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != oid {
+		return ErrOidMismatch
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path(oid)), storeObjectDirPerm); err != nil {
+		return errors.Wrap(err, "failed to create object directory")
+	}
+
+	return os.Rename(tmp.Name(), s.path(oid))
+}
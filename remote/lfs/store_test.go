@@ -0,0 +1,89 @@
+package lfs_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/make-os/kit/remote/lfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// countingReader counts the bytes read from a reader
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+var _ = Describe("BasicStore", func() {
+	var dir string
+	var store *lfs.BasicStore
+	oid := makeOid("hello")
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir(os.TempDir(), "")
+		Expect(err).To(BeNil())
+		store = lfs.NewBasicStore(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(BeNil())
+	})
+
+	Describe(".Put", func() {
+		It("should add the object to the store", func() {
+			Expect(store.Put(oid, 5, bytes.NewBufferString("hello"))).To(BeNil())
+			Expect(store.Has(oid)).To(BeTrue())
+			size, err := store.Size(oid)
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(5)))
+
+			f, err := store.Open(oid)
+			Expect(err).To(BeNil())
+			defer f.Close()
+			content, _ := ioutil.ReadAll(f)
+			Expect(string(content)).To(Equal("hello"))
+		})
+
+		It("should return ErrOidMismatch if content does not hash to the object id", func() {
+			err := store.Put(oid, -1, bytes.NewBufferString("world"))
+			Expect(err).To(Equal(lfs.ErrOidMismatch))
+			Expect(store.Has(oid)).To(BeFalse())
+		})
+
+		It("should return ErrSizeMismatch if content size is not the expected size", func() {
+			err := store.Put(oid, 10, bytes.NewBufferString("hello"))
+			Expect(err).To(Equal(lfs.ErrSizeMismatch))
+			Expect(store.Has(oid)).To(BeFalse())
+		})
+
+		It("should stop reading content once it is larger than the expected size", func() {
+			r := &countingReader{r: bytes.NewBufferString("hello world")}
+			err := store.Put(oid, 2, r)
+			Expect(err).To(Equal(lfs.ErrSizeMismatch))
+			Expect(r.n).To(Equal(3))
+			Expect(store.Has(oid)).To(BeFalse())
+		})
+
+		It("should return ErrInvalidOid if object id is not valid", func() {
+			err := store.Put("abc", 5, bytes.NewBufferString("hello"))
+			Expect(err).To(Equal(lfs.ErrInvalidOid))
+		})
+	})
+
+	Describe(".Open", func() {
+		It("should return ErrObjectNotFound if object does not exist", func() {
+			_, err := store.Open(oid)
+			Expect(err).To(Equal(lfs.ErrObjectNotFound))
+		})
+	})
+})
@@ -0,0 +1,101 @@
+package plumbing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/mr-tron/base58"
+)
+
+// ApprovalNoteRef is the note reference where commit approvals are stored.
+// Each note entry is keyed by the approved commit hash and contains one
+// approval per line and per approved reference.
+const ApprovalNoteRef = "refs/notes/approvals"
+
+// Approval represents a push key's signed approval of a commit
+type Approval struct {
+	// PushKeyID is the ID of the push key that signed the approval
+	PushKeyID string
+
+	// Reference is the name of the reference the commit was approved for
+	Reference string
+
+	// Signature is the push key's signature of the approval message
+	Signature []byte
+}
+
+// String returns the approval as a note line: '<push key id> <reference> <base58 signature>'
+func (a *Approval) String() string {
+	return fmt.Sprintf("%s %s %s", a.PushKeyID, a.Reference, base58.Encode(a.Signature))
+}
+
+// MakeApprovalMsg returns the message a push key must sign to approve a commit.
+// The message includes the repository name and the target reference so that
+// an approval cannot be replayed on another repository or reference.
+func MakeApprovalMsg(repoName, reference, commitHash string) []byte {
+	return []byte(fmt.Sprintf("approve:%s:%s:%s", repoName, reference, commitHash))
+}
+
+// ParseApprovals parses the content of an approval note entry.
+// Malformed lines are ignored.
+func ParseApprovals(content string) (approvals []*Approval) {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		sig, err := base58.Decode(fields[2])
+		if err != nil || len(sig) == 0 {
+			continue
+		}
+		approvals = append(approvals, &Approval{PushKeyID: fields[0], Reference: fields[1], Signature: sig})
+	}
+	return
+}
+
+// GetApprovals returns the approvals recorded for a commit in the approval note.
+// It returns no approvals (and no error) if the note or the commit's entry does not exist.
+func GetApprovals(repo LocalRepo, commitHash string) ([]*Approval, error) {
+	if !plumbing.IsHash(commitHash) {
+		return nil, fmt.Errorf("invalid commit hash")
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(ApprovalNoteRef), true)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	noteCommit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := noteCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	// Git stores note entries either flat (<hash>) or, for large
+	// notes, fanned out by the first byte of the hash (<xx>/<rest>)
+	for _, path := range []string{commitHash, commitHash[:2] + "/" + commitHash[2:]} {
+		file, err := tree.File(path)
+		if err != nil {
+			if err == object.ErrFileNotFound || err == object.ErrDirectoryNotFound || err == object.ErrEntryNotFound {
+				continue
+			}
+			return nil, err
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return nil, err
+		}
+		return ParseApprovals(content), nil
+	}
+
+	return nil, nil
+}
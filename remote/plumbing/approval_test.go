@@ -0,0 +1,41 @@
+package plumbing_test
+
+import (
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/mr-tron/base58"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Approval", func() {
+	Describe(".ParseApprovals", func() {
+		It("should parse well-formed lines and skip malformed ones", func() {
+			sig := base58.Encode([]byte("signature"))
+			approvals := plumbing.ParseApprovals("pk1 refs/heads/master " + sig + "\nmalformed\npk2 " + sig +
+				"\npk3 refs/heads/dev " + sig + " extra\n\npk4 refs/heads/dev " + sig)
+			Expect(approvals).To(HaveLen(2))
+			Expect(approvals[0].PushKeyID).To(Equal("pk1"))
+			Expect(approvals[0].Reference).To(Equal("refs/heads/master"))
+			Expect(approvals[0].Signature).To(Equal([]byte("signature")))
+			Expect(approvals[1].PushKeyID).To(Equal("pk4"))
+			Expect(approvals[1].Reference).To(Equal("refs/heads/dev"))
+		})
+	})
+
+	Describe(".MakeApprovalMsg", func() {
+		It("should bind the approval to the repository, reference and commit", func() {
+			msg := plumbing.MakeApprovalMsg("repo1", "refs/heads/master", "a3a1bc4")
+			Expect(string(msg)).To(Equal("approve:repo1:refs/heads/master:a3a1bc4"))
+			Expect(msg).ToNot(Equal(plumbing.MakeApprovalMsg("repo2", "refs/heads/master", "a3a1bc4")))
+			Expect(msg).ToNot(Equal(plumbing.MakeApprovalMsg("repo1", "refs/heads/dev", "a3a1bc4")))
+		})
+	})
+
+	Describe("Approval.String", func() {
+		It("should return the approval note line", func() {
+			a := &plumbing.Approval{PushKeyID: "pk1", Reference: "refs/heads/master", Signature: []byte("signature")}
+			Expect(a.String()).To(Equal("pk1 refs/heads/master " + base58.Encode([]byte("signature"))))
+			Expect(plumbing.ParseApprovals(a.String())[0]).To(Equal(a))
+		})
+	})
+})
@@ -0,0 +1,359 @@
+package plumbing
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ArchiveFormatTarGz is the gzip-compressed tar archive format
+	ArchiveFormatTarGz = "tar.gz"
+
+	// ArchiveFormatZip is the zip archive format
+	ArchiveFormatZip = "zip"
+
+	// archiveDirName is the directory in a repository where archives are cached
+	archiveDirName = "archives"
+)
+
+var (
+	ErrUnknownArchiveFormat    = fmt.Errorf("unknown archive format")
+	ErrArchiveCreationLimit    = fmt.Errorf("too many archives are being created; try again later")
+	ErrArchiveTooLargeToInline = fmt.Errorf("archive is too large to be returned inline")
+)
+
+var (
+	// MaxArchiveCacheSize is the maximum total size of the archives
+	// cached in a repository. The least recently used archives are
+	// removed when the cache grows larger.
+	MaxArchiveCacheSize int64 = 1024 * 1024 * 500 // 500 MB
+
+	// MaxConcurrentArchives is the maximum number of
+	// archives that can be created at the same time
+	MaxConcurrentArchives = 4
+
+	// MaxInlineArchiveSize is the maximum size of an archive that
+	// can be returned inline (e.g base64-encoded in an RPC response).
+	// Larger archives must be downloaded from the remote server.
+	MaxInlineArchiveSize int64 = 1024 * 1024 * 10 // 10 MB
+
+	archivesInProgress    int
+	archivesInProgressMtx sync.Mutex
+)
+
+// Archive describes an archive of the tree of a commit
+type Archive struct {
+	Path     string
+	TreeHash string
+	Format   string
+	Size     int64
+}
+
+// IsValidArchiveFormat checks whether format is a supported archive format
+func IsValidArchiveFormat(format string) bool {
+	return format == ArchiveFormatTarGz || format == ArchiveFormatZip
+}
+
+// ResolveCommit returns the commit that ref points to.
+// ref can be a commit hash, a full reference name or the
+// short name of a branch or tag. Annotated tags are peeled.
+func ResolveCommit(repo LocalRepo, ref string) (*object.Commit, error) {
+	var hash plumbing.Hash
+	if plumbing.IsHash(ref) {
+		hash = plumbing.NewHash(ref)
+	} else {
+		var found bool
+		for _, name := range []string{ref, "refs/heads/" + ref, "refs/tags/" + ref} {
+			reference, err := repo.Reference(plumbing.ReferenceName(name), true)
+			if err != nil {
+				continue
+			}
+			hash, found = reference.Hash(), true
+			break
+		}
+		if !found {
+			return nil, ErrRefNotFound
+		}
+	}
+
+	obj, err := repo.GetObject(hash.String())
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			return nil, ErrRefNotFound
+		}
+		return nil, err
+	}
+
+	for {
+		switch o := obj.(type) {
+		case *object.Commit:
+			return o, nil
+		case *object.Tag:
+			if obj, err = o.Object(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrRefNotFound
+		}
+	}
+}
+
+// GetArchive returns an archive of the tree of the commit that ref points to.
+//
+// Archives are cached in the repository by tree hash and format, such that
+// references or commits sharing a tree share an archive. Entries of a cached
+// archive carry the committer time of the commit it was first created for.
+//
+// At most MaxConcurrentArchives archives are created at the same time;
+// ErrArchiveCreationLimit is returned when the limit has been reached.
+// The size of the cache is bounded by MaxArchiveCacheSize.
+func GetArchive(repo LocalRepo, ref, format string) (*Archive, error) {
+	if !IsValidArchiveFormat(format) {
+		return nil, ErrUnknownArchiveFormat
+	}
+
+	commit, err := ResolveCommit(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tree")
+	}
+
+	dir := filepath.Join(repo.GetPath(), archiveDirName)
+	archive := &Archive{
+		Path:     filepath.Join(dir, fmt.Sprintf("%s.%s", tree.Hash.String(), format)),
+		TreeHash: tree.Hash.String(),
+		Format:   format,
+	}
+
+	// Return the cached archive if it exists. Its modification
+	// time is updated to mark it as recently used.
+	if info, err := os.Stat(archive.Path); err == nil {
+		now := time.Now()
+		_ = os.Chtimes(archive.Path, now, now)
+		archive.Size = info.Size()
+		return archive, nil
+	}
+
+	if !acquireArchiveSlot() {
+		return nil, ErrArchiveCreationLimit
+	}
+	defer releaseArchiveSlot()
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create archive directory")
+	}
+
+	// Write the archive to a temporary file first so that
+	// incomplete archives are never served from the cache.
+	tmp, err := ioutil.TempFile(dir, tree.Hash.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tmp file")
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err = WriteArchive(tmp, tree, format, commit.Committer.When); err != nil {
+		return nil, errors.Wrap(err, "failed to write archive")
+	}
+
+	if err = tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	if err = os.Rename(tmp.Name(), archive.Path); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(archive.Path)
+	if err != nil {
+		return nil, err
+	}
+	archive.Size = info.Size()
+
+	if err = pruneArchiveCache(dir, archive.Path); err != nil {
+		return nil, errors.Wrap(err, "failed to prune archive cache")
+	}
+
+	return archive, nil
+}
+
+// acquireArchiveSlot reserves one of the MaxConcurrentArchives archive
+// creation slots. It returns false if all slots are in use.
+func acquireArchiveSlot() bool {
+	archivesInProgressMtx.Lock()
+	defer archivesInProgressMtx.Unlock()
+	if archivesInProgress >= MaxConcurrentArchives {
+		return false
+	}
+	archivesInProgress++
+	return true
+}
+
+// releaseArchiveSlot releases an archive creation slot
+func releaseArchiveSlot() {
+	archivesInProgressMtx.Lock()
+	archivesInProgress--
+	archivesInProgressMtx.Unlock()
+}
+
+// pruneArchiveCache removes the least recently used archives in dir
+// until the total size of the archives is at most MaxArchiveCacheSize.
+// The archive at keepPath is never removed.
+func pruneArchiveCache(dir, keepPath string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	var archives []os.FileInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !(strings.HasSuffix(name, "."+ArchiveFormatTarGz) ||
+			strings.HasSuffix(name, "."+ArchiveFormatZip)) {
+			continue
+		}
+		total += entry.Size()
+		archives = append(archives, entry)
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].ModTime().Before(archives[j].ModTime())
+	})
+
+	for _, entry := range archives {
+		if total <= MaxArchiveCacheSize {
+			break
+		}
+		path := filepath.Join(dir, entry.Name())
+		if path == keepPath {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= entry.Size()
+	}
+
+	return nil
+}
+
+// WriteArchive writes an archive of the files in tree to w.
+// modTime is used as the modification time of all entries.
+func WriteArchive(w io.Writer, tree *object.Tree, format string, modTime time.Time) error {
+	switch format {
+	case ArchiveFormatTarGz:
+		gw := gzip.NewWriter(w)
+		tw := tar.NewWriter(gw)
+		if err := writeTarEntries(tw, tree, modTime); err != nil {
+			return err
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		return gw.Close()
+
+	case ArchiveFormatZip:
+		zw := zip.NewWriter(w)
+		if err := writeZipEntries(zw, tree, modTime); err != nil {
+			return err
+		}
+		return zw.Close()
+
+	default:
+		return ErrUnknownArchiveFormat
+	}
+}
+
+// writeTarEntries writes the files in tree to a tar writer
+func writeTarEntries(tw *tar.Writer, tree *object.Tree, modTime time.Time) error {
+	return tree.Files().ForEach(func(f *object.File) error {
+		hdr := &tar.Header{
+			Name:    f.Name,
+			Mode:    fileModeToPerm(f.Mode),
+			ModTime: modTime,
+			Size:    f.Size,
+		}
+
+		if f.Mode == filemode.Symlink {
+			target, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeSymlink, target, 0
+			return tw.WriteHeader(hdr)
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		return copyFileContent(tw, f)
+	})
+}
+
+// writeZipEntries writes the files in tree to a zip writer
+func writeZipEntries(zw *zip.Writer, tree *object.Tree, modTime time.Time) error {
+	return tree.Files().ForEach(func(f *object.File) error {
+		hdr := &zip.FileHeader{
+			Name:     f.Name,
+			Method:   zip.Deflate,
+			Modified: modTime,
+		}
+
+		mode := os.FileMode(fileModeToPerm(f.Mode))
+		if f.Mode == filemode.Symlink {
+			mode |= os.ModeSymlink
+		}
+		hdr.SetMode(mode)
+
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		return copyFileContent(fw, f)
+	})
+}
+
+// copyFileContent copies the content of a file to w
+func copyFileContent(w io.Writer, f *object.File) error {
+	rdr, err := f.Reader()
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+	_, err = io.Copy(w, rdr)
+	return err
+}
+
+// fileModeToPerm returns the permission bits of a git file mode
+func fileModeToPerm(mode filemode.FileMode) int64 {
+	if mode == filemode.Executable || mode == filemode.Symlink {
+		return 0755
+	}
+	return 0644
+}
+
+// MakeArchiveName returns the file name of an archive of a repository reference
+func MakeArchiveName(repoName, ref, format string) string {
+	ref = strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/tags/")
+	return fmt.Sprintf("%s-%s.%s", repoName, strings.ReplaceAll(ref, "/", "-"), format)
+}
@@ -0,0 +1,203 @@
+package plumbing_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/make-os/kit/config"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archive", func() {
+	var err error
+	var cfg *config.AppConfig
+	var path string
+	var testRepo plumbing2.LocalRepo
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		repoName := util.RandString(5)
+		path = filepath.Join(cfg.GetRepoRoot(), repoName)
+		testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+		testutil2.AppendCommit(path, "file.txt", "hello", "commit 1")
+		testutil2.AppendDirAndCommitFile(path, "dir", "file2.txt", "world", "commit 2")
+		testRepo, err = repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	readTarGz := func(path string) map[string]string {
+		f, err := os.Open(path)
+		Expect(err).To(BeNil())
+		defer f.Close()
+		gr, err := gzip.NewReader(f)
+		Expect(err).To(BeNil())
+		tr := tar.NewReader(gr)
+		files := map[string]string{}
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				break
+			}
+			content, _ := ioutil.ReadAll(tr)
+			files[hdr.Name] = string(content)
+		}
+		return files
+	}
+
+	Describe(".ResolveCommit", func() {
+		It("should resolve a short branch name, a full reference name and a commit hash", func() {
+			head := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD")))
+			for _, ref := range []string{"master", "refs/heads/master", "HEAD", head} {
+				commit, err := plumbing2.ResolveCommit(testRepo, ref)
+				Expect(err).To(BeNil())
+				Expect(commit.Hash.String()).To(Equal(head))
+			}
+		})
+
+		It("should peel an annotated tag to its commit", func() {
+			testutil2.CreateCommitAndAnnotatedTag(path, "file.txt", "v1", "commit 3", "v1.0")
+			head := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD")))
+			commit, err := plumbing2.ResolveCommit(testRepo, "v1.0")
+			Expect(err).To(BeNil())
+			Expect(commit.Hash.String()).To(Equal(head))
+		})
+
+		It("should return ErrRefNotFound if reference is unknown", func() {
+			_, err := plumbing2.ResolveCommit(testRepo, "unknown")
+			Expect(err).To(Equal(plumbing2.ErrRefNotFound))
+			_, err = plumbing2.ResolveCommit(testRepo, strings.Repeat("a", 40))
+			Expect(err).To(Equal(plumbing2.ErrRefNotFound))
+		})
+	})
+
+	Describe(".GetArchive", func() {
+		It("should return error if format is unknown", func() {
+			_, err := plumbing2.GetArchive(testRepo, "master", "rar")
+			Expect(err).To(Equal(plumbing2.ErrUnknownArchiveFormat))
+		})
+
+		It("should create a tar.gz archive of the tree of the reference", func() {
+			archive, err := plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+			tree := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD^{tree}")))
+			Expect(archive.TreeHash).To(Equal(tree))
+			Expect(archive.Size).ToNot(BeZero())
+			Expect(readTarGz(archive.Path)).To(Equal(map[string]string{
+				"file.txt":      "hello",
+				"dir/file2.txt": "world",
+			}))
+		})
+
+		It("should create a zip archive of the tree of the reference", func() {
+			archive, err := plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatZip)
+			Expect(err).To(BeNil())
+			zr, err := zip.OpenReader(archive.Path)
+			Expect(err).To(BeNil())
+			defer zr.Close()
+			Expect(zr.File).To(HaveLen(2))
+			rdr, _ := zr.File[1].Open()
+			content, _ := ioutil.ReadAll(rdr)
+			Expect(zr.File[1].Name).To(Equal("file.txt"))
+			Expect(string(content)).To(Equal("hello"))
+		})
+
+		It("should return the cached archive of a reference with the same tree", func() {
+			archive, err := plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+			Expect(ioutil.WriteFile(archive.Path, []byte("cached"), 0600)).To(BeNil())
+
+			testutil2.ExecGit(path, "branch", "dev")
+			archive2, err := plumbing2.GetArchive(testRepo, "dev", plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+			Expect(archive2.Path).To(Equal(archive.Path))
+			Expect(archive2.Size).To(Equal(int64(len("cached"))))
+		})
+
+		It("should remove the least recently used archives when the cache is larger than the max cache size", func() {
+			oldMax := plumbing2.MaxArchiveCacheSize
+			defer func() { plumbing2.MaxArchiveCacheSize = oldMax }()
+
+			parent := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD~1")))
+			first, err := plumbing2.GetArchive(testRepo, parent, plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+			second, err := plumbing2.GetArchive(testRepo, parent, plumbing2.ArchiveFormatZip)
+			Expect(err).To(BeNil())
+			old := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(first.Path, old, old)).To(BeNil())
+			Expect(os.Chtimes(second.Path, old.Add(time.Minute), old.Add(time.Minute))).To(BeNil())
+
+			plumbing2.MaxArchiveCacheSize = second.Size + 1
+			third, err := plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+			Expect(third.Path).To(BeAnExistingFile())
+			Expect(first.Path).ToNot(BeAnExistingFile())
+			Expect(second.Path).ToNot(BeAnExistingFile())
+		})
+
+		It("should not remove a recently used archive before less recently used archives", func() {
+			oldMax := plumbing2.MaxArchiveCacheSize
+			defer func() { plumbing2.MaxArchiveCacheSize = oldMax }()
+
+			parent := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD~1")))
+			first, err := plumbing2.GetArchive(testRepo, parent, plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+			second, err := plumbing2.GetArchive(testRepo, parent, plumbing2.ArchiveFormatZip)
+			Expect(err).To(BeNil())
+			old := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(first.Path, old, old)).To(BeNil())
+			Expect(os.Chtimes(second.Path, old.Add(time.Minute), old.Add(time.Minute))).To(BeNil())
+
+			// Use the first archive so that it becomes the most recently used
+			_, err = plumbing2.GetArchive(testRepo, parent, plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+
+			plumbing2.MaxArchiveCacheSize = first.Size + 1
+			third, err := plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatZip)
+			Expect(err).To(BeNil())
+			Expect(third.Path).To(BeAnExistingFile())
+			Expect(second.Path).ToNot(BeAnExistingFile())
+		})
+
+		It("should return ErrArchiveCreationLimit when the max number of concurrent archives is reached", func() {
+			archive, err := plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+
+			oldMax := plumbing2.MaxConcurrentArchives
+			plumbing2.MaxConcurrentArchives = 0
+			defer func() { plumbing2.MaxConcurrentArchives = oldMax }()
+
+			_, err = plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatZip)
+			Expect(err).To(Equal(plumbing2.ErrArchiveCreationLimit))
+
+			// Cached archives are still returned
+			cached, err := plumbing2.GetArchive(testRepo, "master", plumbing2.ArchiveFormatTarGz)
+			Expect(err).To(BeNil())
+			Expect(cached.Path).To(Equal(archive.Path))
+		})
+	})
+
+	Describe(".MakeArchiveName", func() {
+		It("should return a file name without reference prefixes or slashes", func() {
+			Expect(plumbing2.MakeArchiveName("repo1", "refs/heads/feature/x", "zip")).To(Equal("repo1-feature-x.zip"))
+			Expect(plumbing2.MakeArchiveName("repo1", "v1.0", "tar.gz")).To(Equal("repo1-v1.0.tar.gz"))
+		})
+	})
+})
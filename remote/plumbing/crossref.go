@@ -0,0 +1,220 @@
+package plumbing
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+)
+
+const (
+	// CrossRefSourceCommit indicates that a mention was found in a commit message
+	CrossRefSourceCommit = "commit"
+
+	// CrossRefSourceIssue indicates that a mention was found in an issue comment
+	CrossRefSourceIssue = "issue"
+
+	// CrossRefSourceMergeRequest indicates that a mention was found in a merge request comment
+	CrossRefSourceMergeRequest = "merge_request"
+)
+
+var (
+	issueMentionRe        = regexp.MustCompile(`(?:^|[^\w&#!/])#([1-9][0-9]*)\b`)
+	mergeRequestMentionRe = regexp.MustCompile(`(?:^|[^\w&#!/])!([1-9][0-9]*)\b`)
+	commitMentionRe       = regexp.MustCompile(`\b[0-9a-f]{7,40}\b`)
+	closingIssueRe        = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#([1-9][0-9]*)\b`)
+)
+
+// Mention describes a post or commit mentioned in a text
+type Mention struct {
+
+	// Target is the full reference name of the mentioned post or
+	// the (possibly abbreviated) hash of the mentioned commit
+	Target string
+
+	// IsCommit indicates that the target is a commit hash
+	IsCommit bool
+
+	// Closes indicates that the mention is preceded by a closing
+	// keyword (e.g. closes #1, fixes #1, resolves #1)
+	Closes bool
+}
+
+// ParseMentions finds mentions of issues (#123), merge requests (!45)
+// and commits (abbreviated or full hash) in the given text.
+func ParseMentions(text string) (mentions []*Mention) {
+	var index = map[string]*Mention{}
+	var add = func(target string, isCommit bool) *Mention {
+		if m, ok := index[target]; ok {
+			return m
+		}
+		m := &Mention{Target: target, IsCommit: isCommit}
+		index[target] = m
+		mentions = append(mentions, m)
+		return m
+	}
+
+	for _, match := range issueMentionRe.FindAllStringSubmatch(text, -1) {
+		id, _ := strconv.Atoi(match[1])
+		add(MakeIssueReference(id), false)
+	}
+
+	for _, match := range mergeRequestMentionRe.FindAllStringSubmatch(text, -1) {
+		id, _ := strconv.Atoi(match[1])
+		add(MakeMergeRequestReference(id), false)
+	}
+
+	for _, hash := range commitMentionRe.FindAllString(text, -1) {
+		add(hash, true)
+	}
+
+	for _, match := range closingIssueRe.FindAllStringSubmatch(text, -1) {
+		id, _ := strconv.Atoi(match[1])
+		add(MakeIssueReference(id), false).Closes = true
+	}
+
+	return
+}
+
+// CrossReference describes a commit or post comment that mentions another post or commit
+type CrossReference struct {
+
+	// Target is the full reference name of the mentioned post or the hash of the mentioned commit
+	Target string `json:"target"`
+
+	// Type is the type of the source (commit, issue or merge_request)
+	Type string `json:"type"`
+
+	// Source is the reference where the mention was found
+	Source string `json:"source"`
+
+	// Hash is the hash of the commit containing the mention
+	Hash string `json:"hash"`
+
+	// Closes indicates that the source closes the target issue
+	Closes bool `json:"closes"`
+
+	// PostHash is the hash of the most recent comment of the target issue
+	// when the closing commit was synchronized. The close applies from it.
+	PostHash string `json:"postHash,omitempty"`
+}
+
+// CrossReferencesGetter describes a function for getting cross references that target a post or commit
+type CrossReferencesGetter func(target string) ([]*CrossReference, error)
+
+// GetCrossReferencesFunc describes GetCrossReferences function signature
+type GetCrossReferencesFunc func(repo LocalRepo, reference, oldHash, newHash string) ([]*CrossReference, error)
+
+// GetCrossReferences finds mentions in commits added to a reference between oldHash
+// (exclusive) and newHash. Mentions in post references are read from the comment body
+// while mentions in other references are read from the commit message.
+// Closing keywords are only honoured in commits that landed on the repository's
+// default branch.
+func GetCrossReferences(repo LocalRepo, reference, oldHash, newHash string) ([]*CrossReference, error) {
+
+	var srcType = CrossRefSourceCommit
+	switch {
+	case IsIssueReference(reference):
+		srcType = CrossRefSourceIssue
+	case IsMergeRequestReference(reference):
+		srcType = CrossRefSourceMergeRequest
+	case IsPostReference(reference):
+		return []*CrossReference{}, nil
+	}
+
+	var closable = srcType == CrossRefSourceCommit && reference == GetDefaultBranch(repo)
+	var revRange = newHash
+	if oldHash != "" && !IsZeroHash(oldHash) {
+		revRange = oldHash + ".." + newHash
+	}
+
+	hashes, err := repo.GetRefCommits(revRange, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get commits")
+	}
+
+	var res = []*CrossReference{}
+	for _, hash := range hashes {
+
+		var text string
+		if srcType == CrossRefSourceCommit {
+			commit, err := repo.CommitObject(plumbing.NewHash(hash))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get commit (%s)", hash)
+			}
+			text = commit.Message
+		} else {
+			body, _, err := repo.ReadPostBody(hash)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read post body (%s)", hash)
+			}
+			text = string(body.Content)
+		}
+
+		for _, mention := range ParseMentions(text) {
+			target := mention.Target
+
+			// Ignore mentioned hashes that are not known commits
+			if mention.IsCommit {
+				if target, err = repo.ExpandShortHash(target); err != nil {
+					continue
+				} else if _, err = repo.CommitObject(plumbing.NewHash(target)); err != nil {
+					continue
+				}
+			}
+
+			// Ignore self mentions
+			if target == reference || target == hash {
+				continue
+			}
+
+			res = append(res, &CrossReference{
+				Target: target,
+				Type:   srcType,
+				Source: reference,
+				Hash:   hash,
+				Closes: mention.Closes && closable,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// ApplyCommitCloses applies the closes of a post by commits on the default
+// branch to the close status derived from the post's comments. A close applies
+// from the comment that was the most recent when the closing commit was
+// synchronized; a later comment with a close directive (e.g. a re-open)
+// overrides it.
+func ApplyCommitCloses(repo LocalRepo, reference string, closed bool, xrefs []*CrossReference) (bool, error) {
+	var closedAt = map[string]struct{}{}
+	for _, xref := range xrefs {
+		if xref.Closes && xref.PostHash != "" && xref.Target == reference {
+			closedAt[xref.PostHash] = struct{}{}
+		}
+	}
+	if len(closedAt) == 0 {
+		return closed, nil
+	}
+
+	hashes, err := repo.GetRefCommits(reference, true)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get comments")
+	}
+
+	for _, hash := range hashes {
+		if _, ok := closedAt[hash]; ok {
+			return true, nil
+		}
+		body, _, err := repo.ReadPostBody(hash)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to read post body (%s)", hash)
+		}
+		if body.Close != nil {
+			return *body.Close, nil
+		}
+	}
+
+	return closed, nil
+}
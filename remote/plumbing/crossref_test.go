@@ -0,0 +1,212 @@
+package plumbing_test
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	plumb "github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/remote/plumbing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CrossReference", func() {
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockLocalRepo
+	var hash1 = "1111111111111111111111111111111111111111"
+	var hash2 = "2222222222222222222222222222222222222222"
+	var mentioned = "abcdef0123456789abcdef0123456789abcdef01"
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockLocalRepo(ctrl)
+	})
+
+	var mockHead = func(branch string) {
+		head := plumb.NewSymbolicReference(plumb.HEAD, plumb.ReferenceName(branch))
+		mockRepo.EXPECT().Reference(plumb.HEAD, false).Return(head, nil)
+	}
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe(".ParseMentions", func() {
+		It("should find issues, merge requests and commits", func() {
+			res := plumbing.ParseMentions("see #1 and !2, introduced in abcdef0")
+			Expect(res).To(HaveLen(3))
+			Expect(res[0]).To(Equal(&plumbing.Mention{Target: plumbing.MakeIssueReference(1)}))
+			Expect(res[1]).To(Equal(&plumbing.Mention{Target: plumbing.MakeMergeRequestReference(2)}))
+			Expect(res[2]).To(Equal(&plumbing.Mention{Target: "abcdef0", IsCommit: true}))
+		})
+
+		It("should mark issues preceded by a closing keyword", func() {
+			res := plumbing.ParseMentions("Fixes #1, closes: #2 and relates to #3")
+			Expect(res).To(HaveLen(3))
+			Expect(res[0].Closes).To(BeTrue())
+			Expect(res[1].Closes).To(BeTrue())
+			Expect(res[2].Closes).To(BeFalse())
+		})
+
+		It("should ignore duplicates, zero ids and anchors", func() {
+			res := plumbing.ParseMentions("#1 #1 #0 &#39; a#2 http://host/#3")
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Target).To(Equal(plumbing.MakeIssueReference(1)))
+		})
+
+		It("should return empty result when text has no mentions", func() {
+			Expect(plumbing.ParseMentions("nothing here")).To(BeEmpty())
+		})
+	})
+
+	Describe(".GetCrossReferences", func() {
+		It("should return empty result for milestone references", func() {
+			res, err := plumbing.GetCrossReferences(mockRepo, plumbing.MakeMilestoneReference(1), "", hash1)
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+		})
+
+		It("should return error when unable to get commits", func() {
+			mockHead(plumbing.DefaultBranch)
+			mockRepo.EXPECT().GetRefCommits(hash1+".."+hash2, false).Return(nil, fmt.Errorf("error"))
+			_, err := plumbing.GetCrossReferences(mockRepo, plumbing.DefaultBranch, hash1, hash2)
+			Expect(err).To(MatchError("failed to get commits: error"))
+		})
+
+		It("should return error when unable to get a commit", func() {
+			mockHead(plumbing.DefaultBranch)
+			mockRepo.EXPECT().GetRefCommits(hash2, false).Return([]string{hash2}, nil)
+			mockRepo.EXPECT().CommitObject(plumb.NewHash(hash2)).Return(nil, fmt.Errorf("error"))
+			_, err := plumbing.GetCrossReferences(mockRepo, plumbing.DefaultBranch, plumb.ZeroHash.String(), hash2)
+			Expect(err).To(MatchError(fmt.Sprintf("failed to get commit (%s): error", hash2)))
+		})
+
+		It("should return mentions in commit messages of the default branch", func() {
+			mockHead(plumbing.DefaultBranch)
+			mockRepo.EXPECT().GetRefCommits(hash2, false).Return([]string{hash2}, nil)
+			mockRepo.EXPECT().CommitObject(plumb.NewHash(hash2)).Return(&object.Commit{Message: "closes #1, reverts abcdef0 and 9999999"}, nil)
+			mockRepo.EXPECT().ExpandShortHash("abcdef0").Return(mentioned, nil)
+			mockRepo.EXPECT().CommitObject(plumb.NewHash(mentioned)).Return(&object.Commit{}, nil)
+			mockRepo.EXPECT().ExpandShortHash("9999999").Return("", fmt.Errorf("unknown"))
+			res, err := plumbing.GetCrossReferences(mockRepo, plumbing.DefaultBranch, "", hash2)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal([]*plumbing.CrossReference{
+				{Target: plumbing.MakeIssueReference(1), Type: plumbing.CrossRefSourceCommit, Source: plumbing.DefaultBranch, Hash: hash2, Closes: true},
+				{Target: mentioned, Type: plumbing.CrossRefSourceCommit, Source: plumbing.DefaultBranch, Hash: hash2},
+			}))
+		})
+
+		It("should not close issues from commits outside the default branch", func() {
+			mockHead(plumbing.DefaultBranch)
+			mockRepo.EXPECT().GetRefCommits(hash2, false).Return([]string{hash2}, nil)
+			mockRepo.EXPECT().CommitObject(plumb.NewHash(hash2)).Return(&object.Commit{Message: "fixes #1"}, nil)
+			res, err := plumbing.GetCrossReferences(mockRepo, "refs/heads/dev", "", hash2)
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Closes).To(BeFalse())
+		})
+
+		It("should close issues from commits on the configured default branch", func() {
+			mockHead("refs/heads/main")
+			mockRepo.EXPECT().GetRefCommits(hash2, false).Return([]string{hash2}, nil)
+			mockRepo.EXPECT().CommitObject(plumb.NewHash(hash2)).Return(&object.Commit{Message: "fixes #1"}, nil)
+			res, err := plumbing.GetCrossReferences(mockRepo, "refs/heads/main", "", hash2)
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Closes).To(BeTrue())
+		})
+
+		It("should not close issues from commits on master when the default branch is different", func() {
+			mockHead("refs/heads/main")
+			mockRepo.EXPECT().GetRefCommits(hash2, false).Return([]string{hash2}, nil)
+			mockRepo.EXPECT().CommitObject(plumb.NewHash(hash2)).Return(&object.Commit{Message: "fixes #1"}, nil)
+			res, err := plumbing.GetCrossReferences(mockRepo, plumbing.DefaultBranch, "", hash2)
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Closes).To(BeFalse())
+		})
+
+		It("should return mentions in post comments and ignore self mentions", func() {
+			issueRef := plumbing.MakeIssueReference(2)
+			body := plumbing.NewEmptyPostBody()
+			body.Content = []byte("fixes #1, duplicate of #2 and see !3")
+			mockRepo.EXPECT().GetRefCommits(hash1, false).Return([]string{hash1}, nil)
+			mockRepo.EXPECT().ReadPostBody(hash1).Return(body, nil, nil)
+			res, err := plumbing.GetCrossReferences(mockRepo, issueRef, "", hash1)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal([]*plumbing.CrossReference{
+				{Target: plumbing.MakeIssueReference(1), Type: plumbing.CrossRefSourceIssue, Source: issueRef, Hash: hash1},
+				{Target: plumbing.MakeMergeRequestReference(3), Type: plumbing.CrossRefSourceIssue, Source: issueRef, Hash: hash1},
+			}))
+		})
+
+		It("should return error when unable to read post body", func() {
+			mockRepo.EXPECT().GetRefCommits(hash1, false).Return([]string{hash1}, nil)
+			mockRepo.EXPECT().ReadPostBody(hash1).Return(nil, nil, fmt.Errorf("error"))
+			_, err := plumbing.GetCrossReferences(mockRepo, plumbing.MakeMergeRequestReference(1), "", hash1)
+			Expect(err).To(MatchError(fmt.Sprintf("failed to read post body (%s): error", hash1)))
+		})
+	})
+
+	Describe(".GetDefaultBranch", func() {
+		It("should return the branch HEAD points to", func() {
+			mockHead("refs/heads/main")
+			Expect(plumbing.GetDefaultBranch(mockRepo)).To(Equal("refs/heads/main"))
+		})
+
+		It("should return DefaultBranch when HEAD is missing", func() {
+			mockRepo.EXPECT().Reference(plumb.HEAD, false).Return(nil, plumb.ErrReferenceNotFound)
+			Expect(plumbing.GetDefaultBranch(mockRepo)).To(Equal(plumbing.DefaultBranch))
+		})
+	})
+
+	Describe(".ApplyCommitCloses", func() {
+		var issueRef = plumbing.MakeIssueReference(1)
+		var closer = &plumbing.CrossReference{Target: issueRef, Hash: mentioned, Closes: true, PostHash: hash1}
+
+		var body = func(close *bool) *plumbing.PostBody {
+			b := plumbing.NewEmptyPostBody()
+			b.Close = close
+			return b
+		}
+
+		It("should return the given status when no commit closed the post", func() {
+			mention := &plumbing.CrossReference{Target: issueRef, Hash: mentioned}
+			closed, err := plumbing.ApplyCommitCloses(mockRepo, issueRef, false, []*plumbing.CrossReference{mention})
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeFalse())
+		})
+
+		It("should return true when the post has no comment after the close", func() {
+			mockRepo.EXPECT().GetRefCommits(issueRef, true).Return([]string{hash1}, nil)
+			closed, err := plumbing.ApplyCommitCloses(mockRepo, issueRef, false, []*plumbing.CrossReference{closer})
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeTrue())
+		})
+
+		It("should return true when comments after the close have no close directive", func() {
+			mockRepo.EXPECT().GetRefCommits(issueRef, true).Return([]string{hash2, hash1}, nil)
+			mockRepo.EXPECT().ReadPostBody(hash2).Return(body(nil), nil, nil)
+			closed, err := plumbing.ApplyCommitCloses(mockRepo, issueRef, false, []*plumbing.CrossReference{closer})
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeTrue())
+		})
+
+		It("should return false when a comment after the close re-opens the post", func() {
+			mockRepo.EXPECT().GetRefCommits(issueRef, true).Return([]string{hash2, hash1}, nil)
+			mockRepo.EXPECT().ReadPostBody(hash2).Return(body(pointer.ToBool(false)), nil, nil)
+			closed, err := plumbing.ApplyCommitCloses(mockRepo, issueRef, true, []*plumbing.CrossReference{closer})
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeFalse())
+		})
+
+		It("should return error when unable to get the comments", func() {
+			mockRepo.EXPECT().GetRefCommits(issueRef, true).Return(nil, fmt.Errorf("error"))
+			_, err := plumbing.ApplyCommitCloses(mockRepo, issueRef, false, []*plumbing.CrossReference{closer})
+			Expect(err).To(MatchError("failed to get comments: error"))
+		})
+	})
+})
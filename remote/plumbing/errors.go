@@ -3,6 +3,7 @@ package plumbing
 import "fmt"
 
 var (
-	ErrRefNotFound = fmt.Errorf("reference not found")
-	ErrNoCommits   = fmt.Errorf("no commits")
+	ErrRefNotFound  = fmt.Errorf("reference not found")
+	ErrNoCommits    = fmt.Errorf("no commits")
+	ErrPathNotFound = fmt.Errorf("path not found")
 )
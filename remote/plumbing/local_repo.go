@@ -34,9 +34,9 @@ type LocalRepo interface {
 	// References returns an unsorted ReferenceIter for all references.
 	References() (storer.ReferenceIter, error)
 
-	// IsContributor checks whether a push key is a contributor to either
-	// the repository or its namespace
-	IsContributor(pushKeyID string) bool
+	// IsContributor checks whether a push key is an active contributor to
+	// either the repository or its namespace at the given block height
+	IsContributor(pushKeyID string, height uint64) bool
 
 	// GetGitConfigOption finds and returns git config option value
 	GetGitConfigOption(path string) string
@@ -0,0 +1,192 @@
+package plumbing
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pkg/errors"
+)
+
+// MilestoneDueDateFormat is the expected format of a milestone due date
+const MilestoneDueDateFormat = "2006-01-02"
+
+// Milestone describes the current state of a milestone post
+type Milestone struct {
+
+	// Name is the full reference name of the milestone
+	Name string `json:"name"`
+
+	// Title is the title of the milestone
+	Title string `json:"title"`
+
+	// Description is the content of the milestone's first comment
+	Description string `json:"description"`
+
+	// Author is the author of the milestone's first comment
+	Author string `json:"author"`
+
+	// Hash is the hash of the milestone's first comment
+	Hash string `json:"hash"`
+
+	// CreatedAt is the time the milestone was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DueDate is the most recently set due date (YYYY-MM-DD)
+	DueDate string `json:"dueDate"`
+
+	// Issues are the IDs of the linked issues in ascending order
+	Issues []int `json:"issues"`
+
+	// Closed indicates whether the milestone is closed
+	Closed bool `json:"closed"`
+
+	// Progress describes how many of the linked issues have been closed
+	Progress *MilestoneProgress `json:"progress"`
+}
+
+// MilestoneProgress describes the completion of a milestone
+type MilestoneProgress struct {
+
+	// Total is the number of linked issues that exist
+	Total int `json:"total"`
+
+	// Closed is the number of linked issues that are closed
+	Closed int `json:"closed"`
+
+	// Percent is the percentage of linked issues that are closed
+	Percent float64 `json:"percent"`
+
+	// Open are the IDs of linked issues that are open
+	Open []int `json:"open"`
+
+	// Missing are the IDs of linked issues that do not exist
+	Missing []int `json:"missing"`
+}
+
+// MilestoneGetter describes GetMilestone function signature
+type MilestoneGetter func(repo LocalRepo, post PostEntry) (*Milestone, error)
+
+// GetMilestone replays the comments of a milestone post from the oldest to
+// the newest to determine its due date and linked issues, then computes its
+// progress from the close status of the linked issues.
+func GetMilestone(repo LocalRepo, post PostEntry) (*Milestone, error) {
+	return getMilestone(repo, post, nil)
+}
+
+// MilestoneGetterWithCommitCloses returns a MilestoneGetter that applies
+// closes by commits on the default branch, as provided by getXRefs, to
+// the linked issues when computing the progress of a milestone.
+func MilestoneGetterWithCommitCloses(getXRefs CrossReferencesGetter) MilestoneGetter {
+	return func(repo LocalRepo, post PostEntry) (*Milestone, error) {
+		return getMilestone(repo, post, getXRefs)
+	}
+}
+
+func getMilestone(repo LocalRepo, post PostEntry, getXRefs CrossReferencesGetter) (*Milestone, error) {
+	comments, err := post.GetComments()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get comments")
+	}
+
+	ms := &Milestone{Name: post.GetName(), Title: post.GetTitle()}
+	if first := post.GetComment(); first != nil {
+		ms.Author, ms.Hash, ms.CreatedAt = first.Author, first.Hash, first.CreatedAt
+	}
+
+	// Use the first comment from the comment list as it includes edits
+	if n := len(comments); n > 0 && comments[n-1].Body != nil {
+		ms.Description = string(comments[n-1].Body.Content)
+	}
+
+	for i := len(comments) - 1; i >= 0; i-- {
+		body := comments[i].Body
+		if body == nil {
+			continue
+		}
+		if body.MilestoneFields != nil {
+			if body.DueDate != "" {
+				ms.DueDate = body.DueDate
+			}
+			ms.Issues = applyIssueLinks(ms.Issues, body.Issues)
+		}
+		if body.Close != nil {
+			ms.Closed = *body.Close
+		}
+	}
+
+	sort.Ints(ms.Issues)
+	if ms.Progress, err = GetMilestoneProgress(repo, ms.Issues, getXRefs); err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+// GetMilestoneProgress computes the progress of a milestone from
+// the close status of the issues linked to it. If getXRefs is set,
+// closes by commits on the default branch apply.
+func GetMilestoneProgress(repo LocalRepo, issues []int, getXRefs CrossReferencesGetter) (*MilestoneProgress, error) {
+	progress := &MilestoneProgress{Open: []int{}, Missing: []int{}}
+	for _, id := range issues {
+		hash, err := repo.RefGet(MakeIssueReference(id))
+		if err != nil {
+			if err == ErrRefNotFound {
+				progress.Missing = append(progress.Missing, id)
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get issue (%d)", id)
+		}
+
+		body, _, err := repo.ReadPostBody(hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read issue (%d)", id)
+		}
+
+		closed := pointer.GetBool(body.Close)
+		if getXRefs != nil {
+			xrefs, err := getXRefs(MakeIssueReference(id))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get cross references of issue (%d)", id)
+			}
+			if closed, err = ApplyCommitCloses(repo, MakeIssueReference(id), closed, xrefs); err != nil {
+				return nil, errors.Wrapf(err, "failed to check close status of issue (%d)", id)
+			}
+		}
+
+		progress.Total++
+		if closed {
+			progress.Closed++
+			continue
+		}
+		progress.Open = append(progress.Open, id)
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = math.Round(float64(progress.Closed)/float64(progress.Total)*10000) / 100
+	}
+
+	return progress, nil
+}
+
+// applyIssueLinks adds issue IDs to a list of linked issues;
+// negative IDs are removed from the list instead.
+func applyIssueLinks(issues, ids []int) []int {
+	for _, id := range ids {
+		idx := -1
+		for i, linked := range issues {
+			if linked == id || linked == -id {
+				idx = i
+				break
+			}
+		}
+		switch {
+		case id < 0 && idx > -1:
+			issues = append(issues[:idx], issues[idx+1:]...)
+		case id > 0 && idx == -1:
+			issues = append(issues, id)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,123 @@
+package plumbing_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/remote/plumbing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Milestone", func() {
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockLocalRepo
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockLocalRepo(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	var makeComment = func(content, due string, issues []int, cls *bool) *plumbing.Comment {
+		body := plumbing.NewEmptyPostBody()
+		body.Content = []byte(content)
+		body.DueDate = due
+		body.Issues = issues
+		body.Close = cls
+		return &plumbing.Comment{Body: body, Hash: "a1b2c3d4e5f6", CreatedAt: time.Now()}
+	}
+
+	Describe(".GetMilestoneProgress", func() {
+		It("should return error when unable to get an issue reference", func() {
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(1)).Return("", fmt.Errorf("error"))
+			_, err := plumbing.GetMilestoneProgress(mockRepo, []int{1}, nil)
+			Expect(err).To(MatchError("failed to get issue (1): error"))
+		})
+
+		It("should count closed, open and missing issues", func() {
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(1)).Return("hash1", nil)
+			mockRepo.EXPECT().ReadPostBody("hash1").Return(&plumbing.PostBody{Close: pointer.ToBool(true)}, nil, nil)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(2)).Return("hash2", nil)
+			mockRepo.EXPECT().ReadPostBody("hash2").Return(&plumbing.PostBody{}, nil, nil)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(3)).Return("hash3", nil)
+			mockRepo.EXPECT().ReadPostBody("hash3").Return(&plumbing.PostBody{Close: pointer.ToBool(false)}, nil, nil)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(4)).Return("", plumbing.ErrRefNotFound)
+			progress, err := plumbing.GetMilestoneProgress(mockRepo, []int{1, 2, 3, 4}, nil)
+			Expect(err).To(BeNil())
+			Expect(progress.Total).To(Equal(3))
+			Expect(progress.Closed).To(Equal(1))
+			Expect(progress.Percent).To(Equal(33.33))
+			Expect(progress.Open).To(Equal([]int{2, 3}))
+			Expect(progress.Missing).To(Equal([]int{4}))
+		})
+
+		It("should count issues closed by commits as closed", func() {
+			issueRef := plumbing.MakeIssueReference(1)
+			mockRepo.EXPECT().RefGet(issueRef).Return("hash1", nil)
+			mockRepo.EXPECT().ReadPostBody("hash1").Return(&plumbing.PostBody{}, nil, nil)
+			mockRepo.EXPECT().GetRefCommits(issueRef, true).Return([]string{"hash1"}, nil)
+			getXRefs := func(target string) ([]*plumbing.CrossReference, error) {
+				Expect(target).To(Equal(issueRef))
+				return []*plumbing.CrossReference{{Target: issueRef, Hash: "commit1", Closes: true, PostHash: "hash1"}}, nil
+			}
+			progress, err := plumbing.GetMilestoneProgress(mockRepo, []int{1}, getXRefs)
+			Expect(err).To(BeNil())
+			Expect(progress.Closed).To(Equal(1))
+			Expect(progress.Open).To(BeEmpty())
+		})
+
+		It("should return zero progress when no issue is linked", func() {
+			progress, err := plumbing.GetMilestoneProgress(mockRepo, nil, nil)
+			Expect(err).To(BeNil())
+			Expect(progress.Total).To(Equal(0))
+			Expect(progress.Percent).To(Equal(float64(0)))
+		})
+	})
+
+	Describe(".GetMilestone", func() {
+		It("should return error when unable to get comments", func() {
+			post := mocks.NewMockPostEntry(ctrl)
+			post.EXPECT().GetComments().Return(nil, fmt.Errorf("error"))
+			_, err := plumbing.GetMilestone(mockRepo, post)
+			Expect(err).To(MatchError("failed to get comments: error"))
+		})
+
+		It("should replay comments to get the due date, linked issues and close status", func() {
+			first := makeComment("the first release", "2020-10-01", []int{3, 1}, nil)
+			comments := plumbing.Comments{
+				makeComment("", "", nil, pointer.ToBool(true)),
+				makeComment("postponed", "2020-11-01", []int{-3, 2}, nil),
+				first,
+			}
+			post := mocks.NewMockPostEntry(ctrl)
+			post.EXPECT().GetName().Return(plumbing.MakeMilestoneReference(1))
+			post.EXPECT().GetTitle().Return("v1.0")
+			post.EXPECT().GetComment().Return(first)
+			post.EXPECT().GetComments().Return(comments, nil)
+
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(1)).Return("hash1", nil)
+			mockRepo.EXPECT().ReadPostBody("hash1").Return(&plumbing.PostBody{Close: pointer.ToBool(true)}, nil, nil)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(2)).Return("hash2", nil)
+			mockRepo.EXPECT().ReadPostBody("hash2").Return(&plumbing.PostBody{}, nil, nil)
+
+			ms, err := plumbing.GetMilestone(mockRepo, post)
+			Expect(err).To(BeNil())
+			Expect(ms.Name).To(Equal(plumbing.MakeMilestoneReference(1)))
+			Expect(ms.Title).To(Equal("v1.0"))
+			Expect(ms.Description).To(Equal("the first release"))
+			Expect(ms.DueDate).To(Equal("2020-11-01"))
+			Expect(ms.Issues).To(Equal([]int{1, 2}))
+			Expect(ms.Closed).To(BeTrue())
+			Expect(ms.Progress.Total).To(Equal(2))
+			Expect(ms.Progress.Closed).To(Equal(1))
+			Expect(ms.Progress.Percent).To(Equal(float64(50)))
+		})
+	})
+})
@@ -30,16 +30,62 @@ func (c *Comments) Reverse() {
 	}
 }
 
+// Tree arranges the comments into a tree where each reply is nested
+// under the comment it replied to. Comments replying to an unknown
+// comment are placed at the root. The order of comments is preserved.
+func (c Comments) Tree() Comments {
+	var index = make(map[string]*Comment, len(c))
+	for _, comment := range c {
+		comment.Replies = nil
+		index[comment.Hash] = comment
+	}
+
+	var roots Comments
+	for _, comment := range c {
+		if comment.Body != nil && comment.Body.ReplyTo != "" {
+			if parent, ok := index[comment.Body.ReplyTo]; ok && parent != comment {
+				parent.Replies = append(parent.Replies, comment)
+				continue
+			}
+		}
+		roots = append(roots, comment)
+	}
+
+	return roots
+}
+
+// Walk calls fn for each comment of a comment tree in depth-first order.
+// depth is 0 for root comments and increases by 1 for each reply level.
+func (c Comments) Walk(fn func(comment *Comment, depth int)) {
+	c.walk(fn, 0)
+}
+
+func (c Comments) walk(fn func(comment *Comment, depth int), depth int) {
+	for _, comment := range c {
+		fn(comment, depth)
+		comment.Replies.walk(fn, depth+1)
+	}
+}
+
 // Comment represent a reference post comment
 type Comment struct {
 	CreatedAt    time.Time             `json:"createdAt"`
 	Hash         string                `json:"hash"`
 	Author       string                `json:"author"`
 	AuthorEmail  string                `json:"authorEmail"`
+	PusherKeyID  string                `json:"pusherKeyID,omitempty"`
 	Body         *PostBody             `json:"body,omitempty"`
+	Edits        []string              `json:"edits,omitempty"`
+	Deleted      bool                  `json:"deleted,omitempty"`
+	Replies      Comments              `json:"replies,omitempty"`
 	GetReactions func() map[string]int `json:"-"`
 }
 
+// IsEdited checks whether the comment's content was superseded by an edit
+func (c *Comment) IsEdited() bool {
+	return len(c.Edits) > 0
+}
+
 // Post represents a reference post
 type Post struct {
 	Repo LocalRepo `json:"-"`
@@ -55,6 +101,9 @@ type Post struct {
 
 	// Comment is the first comment of the post.
 	Comment *Comment `json:"comment"`
+
+	// CrossReferencesGetter, if set, provides the commits that closed the post
+	CrossReferencesGetter CrossReferencesGetter `json:"-"`
 }
 
 func (p *Post) GetComment() *Comment {
@@ -109,7 +158,8 @@ func (p *Post) GetComments() (comments Comments, err error) {
 	}
 
 	var reactions = ReactionMap{}
-	var pusherKeyID string
+	var index = map[string]*Comment{}
+	var revisions []*commentRevision
 
 	// process each comment commit
 	for _, hash := range hashes {
@@ -119,12 +169,9 @@ func (p *Post) GetComments() (comments Comments, err error) {
 		}
 
 		// Get the pusher key from the signature header
-		if commit.PGPSignature != "" {
-			p, _ := pem.Decode([]byte(commit.PGPSignature))
-			if p == nil {
-				return nil, fmt.Errorf("unable to decode commit (%s) signature", hash)
-			}
-			pusherKeyID = p.Headers["pkID"]
+		pusherKeyID, err := GetCommitPusherKeyID(commit)
+		if err != nil {
+			return nil, err
 		}
 
 		// Expand reply hash
@@ -135,16 +182,40 @@ func (p *Post) GetComments() (comments Comments, err error) {
 			}
 		}
 
-		comments = append(comments, &Comment{
+		// Expand the hash of the comment being edited or deleted
+		if body.Edit != "" {
+			body.Edit, err = p.Repo.ExpandShortHash(body.Edit)
+			if err != nil {
+				return nil, errors.Wrapf(err, "commit (%s) edit hash could not be expanded", hash)
+			}
+		}
+		if body.Delete != "" {
+			body.Delete, err = p.Repo.ExpandShortHash(body.Delete)
+			if err != nil {
+				return nil, errors.Wrapf(err, "commit (%s) delete hash could not be expanded", hash)
+			}
+		}
+
+		// Edit and delete commits are not comments; they are
+		// applied to their target comments after all comments are read.
+		if body.Edit != "" || body.Delete != "" {
+			revisions = append(revisions, &commentRevision{body: body, hash: hash, pusherKeyID: pusherKeyID})
+			continue
+		}
+
+		comment := &Comment{
 			Body:        body,
 			Hash:        commit.Hash.String(),
 			CreatedAt:   commit.Committer.When,
 			Author:      commit.Author.Name,
 			AuthorEmail: commit.Author.Email,
+			PusherKeyID: pusherKeyID,
 			GetReactions: func() map[string]int {
 				return GetReactionsForComment(reactions, commit.Hash.String())
 			},
-		})
+		}
+		index[comment.Hash] = comment
+		comments = append(comments, comment)
 
 		// Compute and updates reactions if this comment replied with reaction(s)
 		if body.ReplyTo != "" && len(body.Reactions) > 0 {
@@ -152,11 +223,61 @@ func (p *Post) GetComments() (comments Comments, err error) {
 		}
 	}
 
+	// Apply the revisions from the oldest to the most recent
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revisions[i].apply(index)
+	}
+
 	return
 }
 
+// commentRevision is an edit or delete commit of a post
+type commentRevision struct {
+	body        *PostBody
+	hash        string
+	pusherKeyID string
+}
+
+// apply applies the revision to the indexed comment it targets.
+// The revision is ignored if the target is unknown, already deleted or was pushed by a
+// different push key.
+func (r *commentRevision) apply(index map[string]*Comment) {
+	target := r.body.Edit
+	if target == "" {
+		target = r.body.Delete
+	}
+
+	comment, ok := index[target]
+	if !ok || comment.Deleted || comment.PusherKeyID != r.pusherKeyID {
+		return
+	}
+
+	comment.Edits = append(comment.Edits, r.hash)
+	if r.body.Delete != "" {
+		comment.Deleted = true
+		comment.Body.Content = nil
+		return
+	}
+
+	comment.Body.Content = r.body.Content
+}
+
+// GetCommitPusherKeyID returns the push key ID stored in the 'pkID' header
+// of a commit's signature. It returns an empty string if the commit is unsigned.
+func GetCommitPusherKeyID(commit *object.Commit) (string, error) {
+	if commit.PGPSignature == "" {
+		return "", nil
+	}
+	p, _ := pem.Decode([]byte(commit.PGPSignature))
+	if p == nil {
+		return "", fmt.Errorf("unable to decode commit (%s) signature", commit.Hash.String())
+	}
+	return p.Headers["pkID"], nil
+}
+
 // IsClosed tells whether the post is closed by checking if the last
-// comment includes a "closed" directive.
+// comment includes a "closed" directive. If the post has a cross
+// references getter, closes by commits on the default branch apply.
 func (p *Post) IsClosed() (bool, error) {
 	ref, err := p.Repo.Reference(plumbing.ReferenceName(p.Name), false)
 	if err != nil {
@@ -168,11 +289,17 @@ func (p *Post) IsClosed() (bool, error) {
 		return false, err
 	}
 
-	if body.Close != nil {
-		return *body.Close, nil
+	closed := pointer.GetBool(body.Close)
+	if p.CrossReferencesGetter == nil {
+		return closed, nil
+	}
+
+	xrefs, err := p.CrossReferencesGetter(p.Name)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get cross references")
 	}
 
-	return false, nil
+	return ApplyCommitCloses(p.Repo, p.Name, closed, xrefs)
 }
 
 // GetReactionsForComment returns summed reactions of a comment.
@@ -250,6 +377,29 @@ func (p *Posts) SortByFirstPostCreationTimeDesc() {
 // PostGetter describes a function for finding posts
 type PostGetter func(targetRepo LocalRepo, filter func(ref plumbing.ReferenceName) bool) (posts Posts, err error)
 
+// PostGetterWithCommitCloses returns a PostGetter that applies closes by
+// commits on the default branch, as provided by getXRefs, to the posts
+// found by getter.
+func PostGetterWithCommitCloses(getter PostGetter, getXRefs CrossReferencesGetter) PostGetter {
+	return func(targetRepo LocalRepo, filter func(ref plumbing.ReferenceName) bool) (Posts, error) {
+		posts, err := getter(targetRepo, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range posts {
+			post, ok := entry.(*Post)
+			if !ok {
+				continue
+			}
+			post.CrossReferencesGetter = getXRefs
+			if post.Closed, err = post.IsClosed(); err != nil {
+				return nil, errors.Wrapf(err, "failed to check close status of %s", post.Name)
+			}
+		}
+		return posts, nil
+	}
+}
+
 // GetPosts returns references that conform to the post protocol
 // filter is used to check whether a reference is a post reference.
 // Returns a slice of posts
@@ -289,6 +439,11 @@ func GetPosts(targetRepo LocalRepo, filter func(ref plumbing.ReferenceName) bool
 			return nil, err
 		}
 
+		pusherKeyID, err := GetCommitPusherKeyID(commit)
+		if err != nil {
+			return nil, err
+		}
+
 		posts = append(posts, &Post{
 			Name:   ref.String(),
 			Title:  postBody.Title,
@@ -299,6 +454,7 @@ func GetPosts(targetRepo LocalRepo, filter func(ref plumbing.ReferenceName) bool
 				CreatedAt:   commit.Committer.When,
 				Author:      commit.Author.Name,
 				AuthorEmail: commit.Author.Email,
+				PusherKeyID: pusherKeyID,
 			},
 			Repo: targetRepo,
 		})
@@ -324,6 +480,9 @@ type PostBody struct {
 	// Merge Request Fields
 	*MergeRequestFields `yaml:",omitempty,inline" msgpack:",omitempty" json:"mergeRequestFields,omitempty"`
 
+	// Milestone Fields
+	*MilestoneFields `yaml:",omitempty,inline" msgpack:",omitempty" json:"milestoneFields,omitempty"`
+
 	// Content is the post's main content
 	Content []byte `yaml:"-" msgpack:"content,omitempty" json:"content"`
 
@@ -338,6 +497,12 @@ type PostBody struct {
 
 	// Close indicates that the post's thread should be closed.
 	Close *bool `yaml:"close,omitempty" msgpack:"close,omitempty" json:"close,omitempty"`
+
+	// Edit is the hash of a comment whose content is replaced by this comment's content
+	Edit string `yaml:"edit,omitempty" msgpack:"edit,omitempty" json:"edit,omitempty"`
+
+	// Delete is the hash of a comment that this comment retracts
+	Delete string `yaml:"delete,omitempty" msgpack:"delete,omitempty" json:"delete,omitempty"`
 }
 
 // NewEmptyPostBody returns a PostBody instance that is empty
@@ -345,6 +510,7 @@ func NewEmptyPostBody() *PostBody {
 	return &PostBody{
 		IssueFields:        &IssueFields{},
 		MergeRequestFields: &MergeRequestFields{},
+		MilestoneFields:    &MilestoneFields{},
 	}
 }
 
@@ -368,6 +534,9 @@ func (b *PostBody) IncludesAdminFields() bool {
 		len(b.TargetBranch) > 0 || len(b.TargetBranchHash) > 0) {
 		return true
 	}
+	if b.MilestoneFields != nil && (len(b.DueDate) > 0 || len(b.Issues) > 0) {
+		return true
+	}
 	return false
 }
 
@@ -381,6 +550,8 @@ func PostBodyFromContentFrontMatter(cfm *pageparser.ContentFrontMatter) *PostBod
 	b.Content = cfm.Content
 	b.Title = ob.Get("title").String()
 	b.ReplyTo = ob.Get("replyTo").String()
+	b.Edit = ob.Get("edit").String()
+	b.Delete = ob.Get("delete").String()
 	b.BaseBranch = ob.Get("base").String()
 	b.BaseBranchHash = ob.Get("baseHash").String()
 	b.TargetBranch = ob.Get("target").String()
@@ -402,6 +573,11 @@ func PostBodyFromContentFrontMatter(cfm *pageparser.ContentFrontMatter) *PostBod
 		b.Assignees = assignees
 	}
 
+	b.DueDate = ob.Get("due").String()
+	if ob.Has("issues") {
+		b.Issues = cast.ToIntSlice(ob.Get("issues").InterSlice())
+	}
+
 	return b
 }
 
@@ -434,6 +610,8 @@ func GetFreePostID(repo LocalRepo, startID int, postRefType string) (int, error)
 			ref = MakeIssueReference(startID)
 		case MergeRequestBranchPrefix:
 			ref = MakeMergeRequestReference(startID)
+		case MilestoneBranchPrefix:
+			ref = MakeMilestoneReference(startID)
 		default:
 			return 0, fmt.Errorf("unknown post reference type")
 		}
@@ -474,6 +652,10 @@ type CreatePostCommitArgs struct {
 
 	// GetFreePostID is used to find a free post ID
 	GetFreePostID GetFreePostIDFunc
+
+	// Template is an optional template whose defaults are
+	// applied to the body of a new post
+	Template *PostTemplate
 }
 
 // CreatePostCommit creates a new post reference or adds a comment commit to an existing one.
@@ -506,6 +688,8 @@ func CreatePostCommit(r LocalRepo, args *CreatePostCommitArgs) (isNew bool, refe
 			ref = MakeIssueReference(v)
 		case MergeRequestBranchPrefix:
 			ref = MakeMergeRequestReference(v)
+		case MilestoneBranchPrefix:
+			ref = MakeMilestoneReference(v)
 		default:
 			return false, "", fmt.Errorf("unknown post reference type")
 		}
@@ -524,6 +708,17 @@ func CreatePostCommit(r LocalRepo, args *CreatePostCommitArgs) (isNew bool, refe
 		return false, "", fmt.Errorf("can't add comment to a non-existing post")
 	}
 
+	// Apply the template's defaults if this is a new post
+	if hash == "" && args.Template != nil {
+		cfm, err := util.ParseContentFrontMatter(strings.NewReader(args.Body))
+		if err != nil {
+			return false, "", errors.Wrap(err, "failed to parse post body")
+		}
+		body := PostBodyFromContentFrontMatter(&cfm)
+		args.Template.Apply(args.Type, body)
+		args.Body = PostBodyToString(body)
+	}
+
 	// Create a post commit (pass the current reference hash as parent)
 	commitHash, err := r.CreateSingleFileCommit("body", args.Body, "", hash)
 	if err != nil {
@@ -0,0 +1,237 @@
+package plumbing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/make-os/kit/types/state"
+	"github.com/thoas/go-funk"
+)
+
+const (
+	// PostStateOpen selects posts that are not closed
+	PostStateOpen = "open"
+
+	// PostStateClosed selects posts that are closed
+	PostStateClosed = "closed"
+
+	// PostSortNewest sorts posts by their creation time in descending order
+	PostSortNewest = "newest"
+
+	// PostSortOldest sorts posts by their creation time in ascending order
+	PostSortOldest = "oldest"
+
+	// PostSortUpdated sorts posts by the time of their most recent comment in descending order
+	PostSortUpdated = "updated"
+)
+
+// PostQuery contains filters and sort options for finding posts.
+// A post must satisfy every filter that is set.
+type PostQuery struct {
+
+	// Labels are labels that a post must all have
+	Labels []string `json:"labels,omitempty" mapstructure:"labels"`
+
+	// Assignees are push keys that a post must all be assigned to
+	Assignees []string `json:"assignees,omitempty" mapstructure:"assignees"`
+
+	// Author is the name, email or push key ID of the post's author
+	Author string `json:"author,omitempty" mapstructure:"author"`
+
+	// State is the state of the post (open or closed)
+	State string `json:"state,omitempty" mapstructure:"state"`
+
+	// Search is a text that must appear in the title or any comment of the post (case-insensitive)
+	Search string `json:"search,omitempty" mapstructure:"search"`
+
+	// Sort is the order of the result (newest, oldest or updated). Default: newest
+	Sort string `json:"sort,omitempty" mapstructure:"sort"`
+}
+
+// Validate checks whether the query's state and sort options are valid
+func (q *PostQuery) Validate() error {
+	if q.State != "" && q.State != PostStateOpen && q.State != PostStateClosed {
+		return fmt.Errorf("unknown state (%s); expected 'open' or 'closed'", q.State)
+	}
+	if q.Sort != "" && !funk.ContainsString([]string{PostSortNewest, PostSortOldest, PostSortUpdated}, q.Sort) {
+		return fmt.Errorf("unknown sort option (%s); expected 'newest', 'oldest' or 'updated'", q.Sort)
+	}
+	return nil
+}
+
+// needsComments checks whether the query cannot be answered without reading the comments of a post
+func (q *PostQuery) needsComments() bool {
+	return q.Search != "" || q.Sort == PostSortUpdated
+}
+
+// PostDataGetter returns indexed reference data (labels, assignees and close
+// status) of a post reference. It returns nil if the reference is not indexed.
+type PostDataGetter func(reference string) *state.ReferenceData
+
+// queriedPost holds a post and the data collected about it while it is queried
+type queriedPost struct {
+	post     PostEntry
+	comments Comments
+	data     *state.ReferenceData
+}
+
+// updatedAt returns the creation time of the most recent comment of the post
+func (p *queriedPost) updatedAt() time.Time {
+	if len(p.comments) > 0 {
+		return p.comments[0].CreatedAt
+	}
+	return p.post.GetComment().CreatedAt
+}
+
+// QueryPosts returns the posts that match the query, sorted by the query's sort option.
+// getData, if set, provides the labels, assignees and close status of a post (e.g. from
+// the network state); when unset or when it has no data for a post, they are derived
+// from the post's comments.
+func QueryPosts(posts Posts, q *PostQuery, getData PostDataGetter) (Posts, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var matched []*queriedPost
+	for _, post := range posts {
+		qp := &queriedPost{post: post}
+
+		if q.Author != "" && !matchPostAuthor(post.GetComment(), q.Author) {
+			continue
+		}
+
+		var err error
+		if q.needsComments() {
+			if qp.comments, err = post.GetComments(); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(q.Labels) > 0 || len(q.Assignees) > 0 || q.State != "" {
+			if getData != nil {
+				qp.data = getData(post.GetName())
+			}
+			if qp.data == nil {
+				if qp.data, err = qp.deriveData(); err != nil {
+					return nil, err
+				}
+			}
+			if !qp.matchData(q) {
+				continue
+			}
+		}
+
+		if q.Search != "" && !qp.matchText(q.Search) {
+			continue
+		}
+
+		matched = append(matched, qp)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		switch q.Sort {
+		case PostSortOldest:
+			return a.post.GetComment().CreatedAt.Before(b.post.GetComment().CreatedAt)
+		case PostSortUpdated:
+			return a.updatedAt().After(b.updatedAt())
+		default:
+			return a.post.GetComment().CreatedAt.After(b.post.GetComment().CreatedAt)
+		}
+	})
+
+	var res = Posts{}
+	for _, qp := range matched {
+		res = append(res, qp.post)
+	}
+
+	return res, nil
+}
+
+// deriveData computes the labels, assignees and close status of
+// the post by replaying its comments from the oldest to the newest.
+func (p *queriedPost) deriveData() (*state.ReferenceData, error) {
+	if p.comments == nil {
+		comments, err := p.post.GetComments()
+		if err != nil {
+			return nil, err
+		}
+		p.comments = comments
+	}
+
+	data := &state.ReferenceData{}
+	for i := len(p.comments) - 1; i >= 0; i-- {
+		body := p.comments[i].Body
+		if body == nil {
+			continue
+		}
+		if body.IssueFields != nil {
+			data.Labels = applyFieldUpdates(data.Labels, body.Labels)
+			data.Assignees = applyFieldUpdates(data.Assignees, body.Assignees)
+		}
+		if body.Close != nil {
+			data.Closed = *body.Close
+		}
+	}
+
+	return data, nil
+}
+
+// matchData checks whether the post's labels, assignees and close status satisfy the query
+func (p *queriedPost) matchData(q *PostQuery) bool {
+	for _, label := range q.Labels {
+		if !funk.ContainsString(p.data.Labels, label) {
+			return false
+		}
+	}
+	for _, assignee := range q.Assignees {
+		if !funk.ContainsString(p.data.Assignees, assignee) {
+			return false
+		}
+	}
+	switch q.State {
+	case PostStateOpen:
+		return !p.data.Closed
+	case PostStateClosed:
+		return p.data.Closed
+	}
+	return true
+}
+
+// matchText checks whether text appears in the post's title or any of its comments
+func (p *queriedPost) matchText(text string) bool {
+	text = strings.ToLower(text)
+	if strings.Contains(strings.ToLower(p.post.GetTitle()), text) {
+		return true
+	}
+	for _, comment := range p.comments {
+		if comment.Body != nil && strings.Contains(strings.ToLower(string(comment.Body.Content)), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPostAuthor checks whether author is the name, email or push key ID of the comment's author
+func matchPostAuthor(comment *Comment, author string) bool {
+	return strings.EqualFold(comment.Author, author) ||
+		strings.EqualFold(comment.AuthorEmail, author) ||
+		(comment.PusherKeyID != "" && comment.PusherKeyID == author)
+}
+
+// applyFieldUpdates adds values to a list field; values prefixed
+// with '-' are removed from the field instead.
+func applyFieldUpdates(field, values []string) []string {
+	for _, val := range values {
+		if strings.HasPrefix(val, "-") {
+			field = funk.FilterString(field, func(v string) bool { return v != val[1:] })
+			continue
+		}
+		if !funk.ContainsString(field, val) {
+			field = append(field, val)
+		}
+	}
+	return field
+}
@@ -0,0 +1,179 @@
+package plumbing_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/types/state"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PostQuery", func() {
+	var ctrl *gomock.Controller
+	var now = time.Now()
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	// makePost creates a post whose comments are ordered from the newest to the oldest
+	var makePost = func(name, title string, comments ...*plumbing.Comment) *mocks.MockPostEntry {
+		post := mocks.NewMockPostEntry(ctrl)
+		post.EXPECT().GetName().Return(name).AnyTimes()
+		post.EXPECT().GetTitle().Return(title).AnyTimes()
+		post.EXPECT().GetComment().Return(comments[len(comments)-1]).AnyTimes()
+		post.EXPECT().GetComments().Return(plumbing.Comments(comments), nil).AnyTimes()
+		return post
+	}
+
+	var makeComment = func(author string, createdAt time.Time, content string, fields *plumbing.IssueFields, cls *bool) *plumbing.Comment {
+		body := plumbing.NewEmptyPostBody()
+		body.Content = []byte(content)
+		body.Close = cls
+		if fields != nil {
+			body.IssueFields = fields
+		}
+		return &plumbing.Comment{Author: author, AuthorEmail: author + "@email.com", CreatedAt: createdAt, Body: body}
+	}
+
+	var names = func(posts plumbing.Posts) (res []string) {
+		for _, p := range posts {
+			res = append(res, p.GetName())
+		}
+		return
+	}
+
+	Describe(".Validate", func() {
+		It("should return error when state is unknown", func() {
+			err := (&plumbing.PostQuery{State: "pending"}).Validate()
+			Expect(err).To(MatchError("unknown state (pending); expected 'open' or 'closed'"))
+		})
+
+		It("should return error when sort option is unknown", func() {
+			err := (&plumbing.PostQuery{Sort: "votes"}).Validate()
+			Expect(err).To(MatchError("unknown sort option (votes); expected 'newest', 'oldest' or 'updated'"))
+		})
+
+		It("should return nil when state and sort option are valid", func() {
+			Expect((&plumbing.PostQuery{State: "open", Sort: "updated"}).Validate()).To(BeNil())
+		})
+	})
+
+	Describe(".QueryPosts", func() {
+		var posts plumbing.Posts
+
+		BeforeEach(func() {
+			posts = plumbing.Posts{
+				makePost("refs/heads/issues/1", "Crash on startup",
+					makeComment("ben", now.Add(-1*time.Hour), "closing", &plumbing.IssueFields{Labels: []string{"-help"}}, pointer.ToBool(true)),
+					makeComment("ada", now.Add(-3*time.Hour), "the app crashes", &plumbing.IssueFields{Labels: []string{"bug", "help"}}, nil),
+				),
+				makePost("refs/heads/issues/2", "Add dark mode",
+					makeComment("ada", now.Add(-2*time.Hour), "please add a dark theme", &plumbing.IssueFields{Labels: []string{"feature"}, Assignees: []string{"pk1abc"}}, nil),
+				),
+				makePost("refs/heads/issues/3", "Typo in readme",
+					makeComment("cid", now.Add(-30*time.Minute), "fixed", nil, nil),
+					makeComment("cid", now.Add(-4*time.Hour), "there is a typo", &plumbing.IssueFields{Labels: []string{"bug"}}, nil),
+				),
+			}
+		})
+
+		It("should return error when query is not valid", func() {
+			_, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{State: "unknown"}, nil)
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should return all posts sorted by creation time in descending order when no filter is set", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/2", "refs/heads/issues/1", "refs/heads/issues/3"}))
+		})
+
+		It("should sort by creation time in ascending order when sort is 'oldest'", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{Sort: plumbing.PostSortOldest}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/3", "refs/heads/issues/1", "refs/heads/issues/2"}))
+		})
+
+		It("should sort by most recent comment time when sort is 'updated'", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{Sort: plumbing.PostSortUpdated}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/3", "refs/heads/issues/1", "refs/heads/issues/2"}))
+		})
+
+		It("should filter by labels derived from comments", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{Labels: []string{"bug"}}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/1", "refs/heads/issues/3"}))
+
+			res, err = plumbing.QueryPosts(posts, &plumbing.PostQuery{Labels: []string{"help"}}, nil)
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+		})
+
+		It("should filter by assignees", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{Assignees: []string{"pk1abc"}}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/2"}))
+		})
+
+		It("should filter by state", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{State: plumbing.PostStateClosed}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/1"}))
+
+			res, err = plumbing.QueryPosts(posts, &plumbing.PostQuery{State: plumbing.PostStateOpen}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/2", "refs/heads/issues/3"}))
+		})
+
+		It("should filter by author name or email", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{Author: "ADA"}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/2", "refs/heads/issues/1"}))
+
+			res, err = plumbing.QueryPosts(posts, &plumbing.PostQuery{Author: "cid@email.com"}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/3"}))
+		})
+
+		It("should search the title and comments of posts", func() {
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{Search: "DARK"}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/2"}))
+
+			res, err = plumbing.QueryPosts(posts, &plumbing.PostQuery{Search: "crashes"}, nil)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/1"}))
+		})
+
+		It("should use indexed data when the data getter has data for a post", func() {
+			getData := func(ref string) *state.ReferenceData {
+				if ref == "refs/heads/issues/2" {
+					return &state.ReferenceData{Labels: []string{"bug"}}
+				}
+				return nil
+			}
+			res, err := plumbing.QueryPosts(posts, &plumbing.PostQuery{Labels: []string{"bug"}}, getData)
+			Expect(err).To(BeNil())
+			Expect(names(res)).To(Equal([]string{"refs/heads/issues/2", "refs/heads/issues/1", "refs/heads/issues/3"}))
+		})
+
+		It("should return error when unable to get comments of a post", func() {
+			post := mocks.NewMockPostEntry(ctrl)
+			post.EXPECT().GetComment().Return(&plumbing.Comment{}).AnyTimes()
+			post.EXPECT().GetComments().Return(nil, fmt.Errorf("error"))
+			_, err := plumbing.QueryPosts(plumbing.Posts{post}, &plumbing.PostQuery{Search: "text"}, nil)
+			Expect(err).To(MatchError("error"))
+		})
+	})
+})
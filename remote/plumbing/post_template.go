@@ -0,0 +1,166 @@
+package plumbing
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/make-os/kit/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+	"github.com/stretchr/objx"
+)
+
+const (
+	// PostTemplateBranch is the branch from which post templates are read
+	PostTemplateBranch = DefaultBranch
+
+	// IssueTemplateDir is the directory where issue templates are stored
+	IssueTemplateDir = ".makeos/ISSUE_TEMPLATE"
+
+	// MergeRequestTemplateDir is the directory where merge request templates are stored
+	MergeRequestTemplateDir = ".makeos/MERGE_REQUEST_TEMPLATE"
+
+	// PostTemplateExt is the file extension of post templates
+	PostTemplateExt = ".md"
+)
+
+// PostTemplate describes a template used to pre-fill a new issue or merge request
+type PostTemplate struct {
+
+	// Name is the file name of the template without its extension
+	Name string `json:"name"`
+
+	// Title is the default title of the post
+	Title string `json:"title"`
+
+	// Labels are the default labels of the post (issues only)
+	Labels []string `json:"labels"`
+
+	// Assignees are the default assignees of the post (issues only)
+	Assignees []string `json:"assignees"`
+
+	// Body is the default content of the post
+	Body string `json:"body"`
+}
+
+// Apply sets the template's defaults on fields of the post body that are unset.
+// Labels and assignees are only applied to issues.
+func (t *PostTemplate) Apply(postType string, body *PostBody) {
+	if body.Title == "" {
+		body.Title = t.Title
+	}
+	if len(body.Content) == 0 {
+		body.Content = []byte(t.Body)
+	}
+	if postType != IssueBranchPrefix {
+		return
+	}
+	if body.IssueFields == nil {
+		body.IssueFields = &IssueFields{}
+	}
+	if body.Labels == nil && len(t.Labels) > 0 {
+		body.Labels = t.Labels
+	}
+	if body.Assignees == nil && len(t.Assignees) > 0 {
+		body.Assignees = t.Assignees
+	}
+}
+
+// PostTemplates is a collection of post templates
+type PostTemplates []*PostTemplate
+
+// Find returns the template with the given name or nil if not found
+func (t PostTemplates) Find(name string) *PostTemplate {
+	for _, tpl := range t {
+		if tpl.Name == name {
+			return tpl
+		}
+	}
+	return nil
+}
+
+// Names returns the names of the templates
+func (t PostTemplates) Names() (names []string) {
+	for _, tpl := range t {
+		names = append(names, tpl.Name)
+	}
+	return
+}
+
+// PostTemplatesGetter describes GetPostTemplates function signature
+type PostTemplatesGetter func(repo LocalRepo, postType string) (PostTemplates, error)
+
+// GetPostTemplates returns the templates of a post type found on the default branch.
+// It returns an empty list if the default branch or the template directory does not exist.
+func GetPostTemplates(repo LocalRepo, postType string) (PostTemplates, error) {
+	var dir string
+	switch postType {
+	case IssueBranchPrefix:
+		dir = IssueTemplateDir
+	case MergeRequestBranchPrefix:
+		dir = MergeRequestTemplateDir
+	default:
+		return nil, fmt.Errorf("unknown post reference type")
+	}
+
+	var templates = PostTemplates{}
+	if _, err := repo.RefGet(PostTemplateBranch); err != nil {
+		if err == ErrRefNotFound {
+			return templates, nil
+		}
+		return nil, errors.Wrap(err, "failed to get default branch")
+	}
+
+	entries, err := repo.ListPath(PostTemplateBranch, dir)
+	if err != nil {
+		if err == ErrPathNotFound {
+			return templates, nil
+		}
+		return nil, errors.Wrap(err, "failed to list templates")
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir || entry.IsBinary || filepath.Ext(entry.Name) != PostTemplateExt {
+			continue
+		}
+
+		content, err := repo.GetFile(PostTemplateBranch, path.Join(dir, entry.Name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read template (%s)", entry.Name)
+		}
+
+		tpl, err := ParsePostTemplate(strings.TrimSuffix(entry.Name, PostTemplateExt), content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse template (%s)", entry.Name)
+		}
+
+		templates = append(templates, tpl)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
+
+// ParsePostTemplate parses a template file. The front matter of the file
+// may include a title, labels and assignees; The content is the template's body.
+func ParsePostTemplate(name, content string) (*PostTemplate, error) {
+	cfm, err := util.ParseContentFrontMatter(strings.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	ob := objx.New(cfm.FrontMatter)
+	return &PostTemplate{
+		Name:      name,
+		Title:     ob.Get("title").String(),
+		Labels:    cast.ToStringSlice(ob.Get("labels").InterSlice()),
+		Assignees: cast.ToStringSlice(ob.Get("assignees").InterSlice()),
+		Body:      string(cfm.Content),
+	}, nil
+}
@@ -0,0 +1,134 @@
+package plumbing_test
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/remote/plumbing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PostTemplate", func() {
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockLocalRepo
+	var branch = plumbing.PostTemplateBranch
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockLocalRepo(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe(".ParsePostTemplate", func() {
+		It("should parse front matter defaults and body", func() {
+			content := "---\ntitle: Bug report\nlabels: [bug, help]\nassignees: [push1abc]\n---\nSteps to reproduce"
+			tpl, err := plumbing.ParsePostTemplate("bug", content)
+			Expect(err).To(BeNil())
+			Expect(tpl.Name).To(Equal("bug"))
+			Expect(tpl.Title).To(Equal("Bug report"))
+			Expect(tpl.Labels).To(Equal([]string{"bug", "help"}))
+			Expect(tpl.Assignees).To(Equal([]string{"push1abc"}))
+			Expect(tpl.Body).To(Equal("Steps to reproduce"))
+		})
+
+		It("should use the entire content as body when there is no front matter", func() {
+			tpl, err := plumbing.ParsePostTemplate("plain", "Describe the feature")
+			Expect(err).To(BeNil())
+			Expect(tpl.Title).To(BeEmpty())
+			Expect(tpl.Labels).To(BeEmpty())
+			Expect(tpl.Body).To(Equal("Describe the feature"))
+		})
+	})
+
+	Describe(".Apply", func() {
+		var tpl = &plumbing.PostTemplate{Title: "Bug report", Labels: []string{"bug"}, Assignees: []string{"push1abc"}, Body: "steps"}
+
+		It("should set unset fields of an issue", func() {
+			body := plumbing.NewEmptyPostBody()
+			tpl.Apply(plumbing.IssueBranchPrefix, body)
+			Expect(body.Title).To(Equal("Bug report"))
+			Expect(string(body.Content)).To(Equal("steps"))
+			Expect(body.Labels).To(Equal([]string{"bug"}))
+			Expect(body.Assignees).To(Equal([]string{"push1abc"}))
+		})
+
+		It("should not set labels and assignees of a merge request", func() {
+			body := plumbing.NewEmptyPostBody()
+			tpl.Apply(plumbing.MergeRequestBranchPrefix, body)
+			Expect(body.Title).To(Equal("Bug report"))
+			Expect(body.Labels).To(BeNil())
+			Expect(body.Assignees).To(BeNil())
+		})
+
+		It("should not override fields already set", func() {
+			body := plumbing.NewEmptyPostBody()
+			body.Title = "Crash"
+			body.Content = []byte("it crashed")
+			body.Labels = []string{}
+			tpl.Apply(plumbing.IssueBranchPrefix, body)
+			Expect(body.Title).To(Equal("Crash"))
+			Expect(string(body.Content)).To(Equal("it crashed"))
+			Expect(body.Labels).To(Equal([]string{}))
+			Expect(body.Assignees).To(Equal([]string{"push1abc"}))
+		})
+	})
+
+	Describe(".GetPostTemplates", func() {
+		It("should return error when post type is unknown", func() {
+			_, err := plumbing.GetPostTemplates(mockRepo, "unknown")
+			Expect(err).To(MatchError("unknown post reference type"))
+		})
+
+		It("should return empty result when the default branch does not exist", func() {
+			mockRepo.EXPECT().RefGet(branch).Return("", plumbing.ErrRefNotFound)
+			res, err := plumbing.GetPostTemplates(mockRepo, plumbing.IssueBranchPrefix)
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+		})
+
+		It("should return error when unable to get the default branch", func() {
+			mockRepo.EXPECT().RefGet(branch).Return("", fmt.Errorf("error"))
+			_, err := plumbing.GetPostTemplates(mockRepo, plumbing.IssueBranchPrefix)
+			Expect(err).To(MatchError("failed to get default branch: error"))
+		})
+
+		It("should return empty result when the template directory does not exist", func() {
+			mockRepo.EXPECT().RefGet(branch).Return("hash", nil)
+			mockRepo.EXPECT().ListPath(branch, plumbing.MergeRequestTemplateDir).Return(nil, plumbing.ErrPathNotFound)
+			res, err := plumbing.GetPostTemplates(mockRepo, plumbing.MergeRequestBranchPrefix)
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+		})
+
+		It("should return error when unable to read a template", func() {
+			mockRepo.EXPECT().RefGet(branch).Return("hash", nil)
+			mockRepo.EXPECT().ListPath(branch, plumbing.IssueTemplateDir).Return([]plumbing.ListPathValue{{Name: "bug.md"}}, nil)
+			mockRepo.EXPECT().GetFile(branch, plumbing.IssueTemplateDir+"/bug.md").Return("", fmt.Errorf("error"))
+			_, err := plumbing.GetPostTemplates(mockRepo, plumbing.IssueBranchPrefix)
+			Expect(err).To(MatchError("failed to read template (bug.md): error"))
+		})
+
+		It("should return markdown templates sorted by name and skip other entries", func() {
+			mockRepo.EXPECT().RefGet(branch).Return("hash", nil)
+			mockRepo.EXPECT().ListPath(branch, plumbing.IssueTemplateDir).Return([]plumbing.ListPathValue{
+				{Name: "feature.md"},
+				{Name: "bug.md"},
+				{Name: "config.yml"},
+				{Name: "drafts", IsDir: true},
+			}, nil)
+			mockRepo.EXPECT().GetFile(branch, plumbing.IssueTemplateDir+"/feature.md").Return("---\ntitle: Feature\n---\nDescribe it", nil)
+			mockRepo.EXPECT().GetFile(branch, plumbing.IssueTemplateDir+"/bug.md").Return("---\nlabels: [bug]\n---\nSteps", nil)
+			res, err := plumbing.GetPostTemplates(mockRepo, plumbing.IssueBranchPrefix)
+			Expect(err).To(BeNil())
+			Expect(res.Names()).To(Equal([]string{"bug", "feature"}))
+			Expect(res.Find("bug").Labels).To(Equal([]string{"bug"}))
+			Expect(res.Find("feature").Title).To(Equal("Feature"))
+			Expect(res.Find("unknown")).To(BeNil())
+		})
+	})
+})
@@ -1,13 +1,17 @@
 package plumbing_test
 
 import (
+	"bytes"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	plumbing2 "github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/gohugoio/hugo/parser/pageparser"
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
@@ -211,6 +215,58 @@ var _ = Describe("Post", func() {
 		})
 	})
 
+	Describe(".PostGetterWithCommitCloses", func() {
+		var issueRef = plumbing.MakeIssueReference(1)
+		var closedAt string
+		var getPosts plumbing.PostGetter
+
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
+			testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+			testutil2.AppendCommit(path, "body", "some text 1", "commit 1")
+			closedAt, err = testRepo.RefGet(issueRef)
+			Expect(err).To(BeNil())
+			getPosts = plumbing.PostGetterWithCommitCloses(plumbing.GetPosts, func(target string) ([]*plumbing.CrossReference, error) {
+				Expect(target).To(Equal(issueRef))
+				return []*plumbing.CrossReference{{Target: issueRef, Hash: "hash1", Closes: true, PostHash: closedAt}}, nil
+			})
+		})
+
+		var getIssue = func() *plumbing.Post {
+			posts, err := getPosts(testRepo, func(ref plumbing2.ReferenceName) bool { return ref.String() == issueRef })
+			Expect(err).To(BeNil())
+			Expect(posts).To(HaveLen(1))
+			return posts[0].(*plumbing.Post)
+		}
+
+		It("should close a post closed by a commit", func() {
+			post := getIssue()
+			Expect(post.Closed).To(BeTrue())
+			Expect(post.IsClosed()).To(BeTrue())
+		})
+
+		It("should keep the post closed when a later comment has no close directive", func() {
+			testutil2.AppendCommit(path, "body", "some text 2", "commit 2")
+			Expect(getIssue().Closed).To(BeTrue())
+		})
+
+		It("should re-open the post when a later comment re-opens it", func() {
+			Expect(ioutil.WriteFile(filepath.Join(path, "body"), []byte("---\nclose: false\n---\nreopen"), 0644)).To(BeNil())
+			testutil2.ExecGitCommit(path, "commit 2")
+			post := getIssue()
+			Expect(post.Closed).To(BeFalse())
+			Expect(post.IsClosed()).To(BeFalse())
+		})
+
+		It("should return error when unable to get cross references", func() {
+			getPosts = plumbing.PostGetterWithCommitCloses(plumbing.GetPosts, func(string) ([]*plumbing.CrossReference, error) {
+				return nil, fmt.Errorf("error")
+			})
+			_, err := getPosts(testRepo, func(ref plumbing2.ReferenceName) bool { return ref.String() == issueRef })
+			Expect(err).To(MatchError("failed to check close status of refs/heads/issues/1: failed to get cross references: error"))
+		})
+	})
+
 	Describe(".GetComments", func() {
 
 		It("should return error when unable to query comment commits", func() {
@@ -313,6 +369,134 @@ content`, "commit 2")
 		})
 	})
 
+	Describe(".GetComments (edits and deletions)", func() {
+		var commitBody = func(body, msg string) string {
+			Expect(ioutil.WriteFile(filepath.Join(path, "body"), []byte(body), 0644)).To(BeNil())
+			testutil2.ExecGitCommit(path, msg)
+			return testutil2.GetRecentCommitHash(path, "issues/1")
+		}
+
+		It("should replace the content of a comment targeted by an edit commit", func() {
+			testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+			targetHash := commitBody("---\ntitle: hello\n---\nthe content", "commit 1")
+			editHash := commitBody("---\nedit: "+targetHash[:7]+"\n---\nthe new content", "commit 2")
+			var post = &plumbing.Post{Repo: testRepo, Name: plumbing.MakeIssueReference(1)}
+			comments, err := post.GetComments()
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(1))
+			Expect(comments[0].Hash).To(Equal(targetHash))
+			Expect(string(comments[0].Body.Content)).To(Equal("the new content"))
+			Expect(comments[0].IsEdited()).To(BeTrue())
+			Expect(comments[0].Edits).To(Equal([]string{editHash}))
+		})
+
+		It("should mark a comment targeted by a delete commit as deleted", func() {
+			testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+			commitBody("---\ntitle: hello\n---\nthe content", "commit 1")
+			targetHash := commitBody("a comment", "commit 2")
+			commitBody("---\ndelete: "+targetHash+"\n---\n", "commit 3")
+			var post = &plumbing.Post{Repo: testRepo, Name: plumbing.MakeIssueReference(1)}
+			comments, err := post.GetComments()
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(2))
+			Expect(comments[0].Hash).To(Equal(targetHash))
+			Expect(comments[0].Deleted).To(BeTrue())
+			Expect(comments[0].Body.Content).To(BeEmpty())
+		})
+
+		It("should ignore an edit of a deleted comment", func() {
+			testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+			targetHash := commitBody("---\ntitle: hello\n---\nthe content", "commit 1")
+			commitBody("---\ndelete: "+targetHash+"\n---\n", "commit 2")
+			commitBody("---\nedit: "+targetHash+"\n---\nthe new content", "commit 3")
+			var post = &plumbing.Post{Repo: testRepo, Name: plumbing.MakeIssueReference(1)}
+			comments, err := post.GetComments()
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(1))
+			Expect(comments[0].Deleted).To(BeTrue())
+			Expect(comments[0].Edits).To(HaveLen(1))
+			Expect(comments[0].Body.Content).To(BeEmpty())
+		})
+
+		It("should return error when attempt to expand edit hash fails", func() {
+			testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+			commitBody("---\ntitle: hello\n---\nthe content", "commit 1")
+			commitBody("---\nedit: bad_short_hash\n---\ncontent", "commit 2")
+			var post = &plumbing.Post{Repo: testRepo, Name: plumbing.MakeIssueReference(1)}
+			_, err := post.GetComments()
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(MatchRegexp("commit (.*) edit hash could not be expanded"))
+		})
+	})
+
+	Describe(".GetCommitPusherKeyID", func() {
+		It("should return empty string when commit is not signed", func() {
+			pkID, err := plumbing.GetCommitPusherKeyID(&object.Commit{})
+			Expect(err).To(BeNil())
+			Expect(pkID).To(BeEmpty())
+		})
+
+		It("should return error when signature could not be decoded", func() {
+			_, err := plumbing.GetCommitPusherKeyID(&object.Commit{PGPSignature: "bad signature"})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(MatchRegexp("unable to decode commit (.*) signature"))
+		})
+
+		It("should return the push key ID in the signature header", func() {
+			sig := pem.EncodeToMemory(&pem.Block{Type: "PGP SIGNATURE", Headers: map[string]string{"pkID": "pk1abc"}, Bytes: []byte("sig")})
+			pkID, err := plumbing.GetCommitPusherKeyID(&object.Commit{PGPSignature: string(sig)})
+			Expect(err).To(BeNil())
+			Expect(pkID).To(Equal("pk1abc"))
+		})
+	})
+
+	Describe("Comments.Tree", func() {
+		It("should nest replies under the comments they reply to", func() {
+			comments := plumbing.Comments{
+				{Hash: "a", Body: &plumbing.PostBody{}},
+				{Hash: "b", Body: &plumbing.PostBody{ReplyTo: "a"}},
+				{Hash: "c", Body: &plumbing.PostBody{}},
+				{Hash: "d", Body: &plumbing.PostBody{ReplyTo: "b"}},
+				{Hash: "e", Body: &plumbing.PostBody{ReplyTo: "a"}},
+			}
+			tree := comments.Tree()
+			Expect(tree).To(HaveLen(2))
+			Expect(tree[0].Hash).To(Equal("a"))
+			Expect(tree[1].Hash).To(Equal("c"))
+			Expect(tree[0].Replies).To(HaveLen(2))
+			Expect(tree[0].Replies[0].Hash).To(Equal("b"))
+			Expect(tree[0].Replies[1].Hash).To(Equal("e"))
+			Expect(tree[0].Replies[0].Replies).To(HaveLen(1))
+			Expect(tree[0].Replies[0].Replies[0].Hash).To(Equal("d"))
+		})
+
+		It("should place a reply to an unknown comment at the root", func() {
+			comments := plumbing.Comments{
+				{Hash: "a", Body: &plumbing.PostBody{}},
+				{Hash: "b", Body: &plumbing.PostBody{ReplyTo: "x"}},
+			}
+			tree := comments.Tree()
+			Expect(tree).To(HaveLen(2))
+			Expect(tree[1].Hash).To(Equal("b"))
+		})
+	})
+
+	Describe("Comments.Walk", func() {
+		It("should visit comments in depth-first order", func() {
+			comments := plumbing.Comments{
+				{Hash: "a", Body: &plumbing.PostBody{}},
+				{Hash: "b", Body: &plumbing.PostBody{ReplyTo: "a"}},
+				{Hash: "c", Body: &plumbing.PostBody{}},
+				{Hash: "d", Body: &plumbing.PostBody{ReplyTo: "b"}},
+			}
+			var visited []string
+			comments.Tree().Walk(func(comment *plumbing.Comment, depth int) {
+				visited = append(visited, fmt.Sprintf("%s:%d", comment.Hash, depth))
+			})
+			Expect(visited).To(Equal([]string{"a:0", "b:1", "d:2", "c:0"}))
+		})
+	})
+
 	Describe(".GetCommentPreview", func() {
 		It("should return short version (with ellipsis) when content length is above 80", func() {
 			text := `Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.`
@@ -339,6 +523,8 @@ content`, "commit 2")
 					"labels":    []interface{}{"help"},
 					"assignees": []interface{}{"pk1abc"},
 					"close":     true,
+					"edit":      "abcde",
+					"delete":    "fghij",
 				},
 				FrontMatterFormat: "",
 			})
@@ -350,6 +536,22 @@ content`, "commit 2")
 			Expect(issue.Reactions).To(Equal([]string{"smile"}))
 			Expect(issue.Labels).To(Equal([]string{"help"}))
 			Expect(issue.Assignees).To(Equal([]string{"pk1abc"}))
+			Expect(issue.Edit).To(Equal("abcde"))
+			Expect(issue.Delete).To(Equal("fghij"))
+		})
+
+		It("should parse milestone fields from a post body string", func() {
+			str := plumbing.PostBodyToString(&plumbing.PostBody{
+				Title:           "v1.0",
+				Content:         []byte("content"),
+				MilestoneFields: &plumbing.MilestoneFields{DueDate: "2020-10-01", Issues: []int{1, -2}},
+			})
+			cfm, err := util.ParseContentFrontMatter(bytes.NewBufferString(str))
+			Expect(err).To(BeNil())
+			body := plumbing.PostBodyFromContentFrontMatter(&cfm)
+			Expect(body.DueDate).To(Equal("2020-10-01"))
+			Expect(body.Issues).To(Equal([]int{1, -2}))
+			Expect(body.IncludesAdminFields()).To(BeTrue())
 		})
 
 		It("case 2 - when close, labels, assignees are unset, it should be nil", func() {
@@ -721,6 +923,56 @@ content`, "commit 2")
 					Expect(err).To(BeNil())
 				})
 			})
+
+			When("a template is provided", func() {
+				var tpl = &plumbing.PostTemplate{Title: "Bug report", Labels: []string{"bug"}, Assignees: []string{"push1abc"}, Body: "steps"}
+
+				It("should apply the template's defaults to the body of a new post", func() {
+					refname := plumbing.MakeIssueReference(1)
+					issueHash := util.RandString(40)
+					expected := "---\nlabels: [bug]\nassignees: [push1abc]\ntitle: Bug report\n---\nsteps"
+					mockRepo.EXPECT().RefGet(refname).Return("", plumbing.ErrRefNotFound)
+					mockRepo.EXPECT().CreateSingleFileCommit("body", expected, "", "").Return(issueHash, nil)
+					mockRepo.EXPECT().RefUpdate(refname, issueHash).Return(nil)
+					mockRepo.EXPECT().Head().Return("refs/heads/master", nil)
+
+					args := &plumbing.CreatePostCommitArgs{Type: plumbing.IssueBranchPrefix, Force: true, ID: 1, Template: tpl}
+					isNew, _, err := plumbing.CreatePostCommit(mockRepo, args)
+					Expect(err).To(BeNil())
+					Expect(isNew).To(BeTrue())
+				})
+
+				It("should not override fields set in the body", func() {
+					refname := plumbing.MakeIssueReference(1)
+					issueHash := util.RandString(40)
+					body := plumbing.PostBodyToString(&plumbing.PostBody{Title: "Crash", Content: []byte("it crashed"),
+						IssueFields: &plumbing.IssueFields{Labels: []string{"urgent"}}})
+					expected := "---\nlabels: [urgent]\nassignees: [push1abc]\ntitle: Crash\n---\nit crashed"
+					mockRepo.EXPECT().RefGet(refname).Return("", plumbing.ErrRefNotFound)
+					mockRepo.EXPECT().CreateSingleFileCommit("body", expected, "", "").Return(issueHash, nil)
+					mockRepo.EXPECT().RefUpdate(refname, issueHash).Return(nil)
+					mockRepo.EXPECT().Head().Return("refs/heads/master", nil)
+
+					args := &plumbing.CreatePostCommitArgs{Type: plumbing.IssueBranchPrefix, Force: true, ID: 1, Body: body, Template: tpl}
+					_, _, err := plumbing.CreatePostCommit(mockRepo, args)
+					Expect(err).To(BeNil())
+				})
+
+				It("should not apply the template when the post already exists", func() {
+					refname := plumbing.MakeIssueReference(1)
+					hash := util.RandString(40)
+					issueHash := util.RandString(40)
+					mockRepo.EXPECT().RefGet(refname).Return(hash, nil)
+					mockRepo.EXPECT().CreateSingleFileCommit("body", "a comment", "", hash).Return(issueHash, nil)
+					mockRepo.EXPECT().RefUpdate(refname, issueHash).Return(nil)
+					mockRepo.EXPECT().Head().Return("refs/heads/master", nil)
+
+					args := &plumbing.CreatePostCommitArgs{Type: plumbing.IssueBranchPrefix, Force: true, ID: 1, Body: "a comment", Template: tpl}
+					isNew, _, err := plumbing.CreatePostCommit(mockRepo, args)
+					Expect(err).To(BeNil())
+					Expect(isNew).To(BeFalse())
+				})
+			})
 		})
 	})
 })
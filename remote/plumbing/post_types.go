@@ -25,3 +25,14 @@ type MergeRequestFields struct {
 	// TargetBranchHash is the hash of the source branch
 	TargetBranchHash string `yaml:"targetHash,omitempty" msgpack:"targetHash,omitempty" json:"targetBranchHash,omitempty"`
 }
+
+// MilestoneFields contains post body fields specific to milestone posts
+type MilestoneFields struct {
+
+	// DueDate is the date (YYYY-MM-DD) the milestone is expected to be completed
+	DueDate string `yaml:"due,omitempty" msgpack:"due,omitempty" json:"dueDate,omitempty"`
+
+	// Issues are the IDs of issues linked to the milestone.
+	// Negative IDs unlink previously linked issues.
+	Issues []int `yaml:"issues,flow,omitempty" msgpack:"issues,omitempty" json:"issues,omitempty"`
+}
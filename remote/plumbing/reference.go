@@ -11,8 +11,22 @@ import (
 var (
 	IssueBranchPrefix        = "issues"
 	MergeRequestBranchPrefix = "merges"
+	MilestoneBranchPrefix    = "milestones"
 )
 
+// DefaultBranch is the default branch of a repository
+const DefaultBranch = "refs/heads/master"
+
+// GetDefaultBranch returns the branch the HEAD of a repository points to.
+// It returns DefaultBranch if HEAD is missing or does not point to a branch.
+func GetDefaultBranch(repo LocalRepo) string {
+	ref, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil || ref.Type() != plumbing.SymbolicReference || !ref.Target().IsBranch() {
+		return DefaultBranch
+	}
+	return ref.Target().String()
+}
+
 // IsBranch checks whether a reference name indicates a branch
 func IsBranch(name string) bool {
 	return plumbing.ReferenceName(name).IsBranch()
@@ -25,7 +39,7 @@ func IsIssueReference(name string) bool {
 
 // IsPostReference checks whether a reference is a post reference
 func IsPostReference(name string) bool {
-	return IsMergeRequestReference(name) || IsIssueReference(name)
+	return IsMergeRequestReference(name) || IsIssueReference(name) || IsMilestoneReference(name)
 }
 
 // IsIssueReferencePath checks if the specified reference matches an issue reference path
@@ -39,9 +53,21 @@ func IsMergeRequestReference(name string) bool {
 	return regexp.MustCompile(fmt.Sprintf(re, MergeRequestBranchPrefix)).MatchString(name)
 }
 
+// IsMilestoneReference checks whether a branch is a milestone branch
+func IsMilestoneReference(name string) bool {
+	re := "^refs/heads/%s/[1-9]+([0-9]+)?$"
+	return regexp.MustCompile(fmt.Sprintf(re, MilestoneBranchPrefix)).MatchString(name)
+}
+
+// IsMilestoneReferencePath checks if the specified reference matches a milestone reference path
+func IsMilestoneReferencePath(name string) bool {
+	re := "^refs/heads/%s(/|$)?"
+	return regexp.MustCompile(fmt.Sprintf(re, MilestoneBranchPrefix)).MatchString(name)
+}
+
 // GetReferenceShortName returns the short name of a reference
 func GetReferenceShortName(name string) string {
-	if IsMergeRequestReference(name) || IsIssueReference(name) {
+	if IsPostReference(name) {
 		_, file := filepath.Split(name)
 		return file
 	}
@@ -89,3 +115,13 @@ func MakeMergeRequestReference(id interface{}) string {
 func MakeMergeRequestReferencePath() string {
 	return fmt.Sprintf("refs/heads/%s", MergeRequestBranchPrefix)
 }
+
+// MakeMilestoneReference creates a milestone reference
+func MakeMilestoneReference(id interface{}) string {
+	return fmt.Sprintf("refs/heads/%s/%v", MilestoneBranchPrefix, id)
+}
+
+// MakeMilestoneReferencePath returns the full milestone reference path
+func MakeMilestoneReferencePath() string {
+	return fmt.Sprintf("refs/heads/%s", MilestoneBranchPrefix)
+}
@@ -59,6 +59,31 @@ var _ = Describe("Common", func() {
 			Expect(plumbing.IsPostReference(fmt.Sprintf("refs/heads/%s/0001", plumbing.IssueBranchPrefix))).To(BeFalse())
 			Expect(plumbing.IsPostReference(fmt.Sprintf("refs/heads/%s/1", plumbing.MergeRequestBranchPrefix))).To(BeTrue())
 			Expect(plumbing.IsPostReference(fmt.Sprintf("refs/heads/%s/1", plumbing.IssueBranchPrefix))).To(BeTrue())
+			Expect(plumbing.IsPostReference(fmt.Sprintf("refs/heads/%s/1", plumbing.MilestoneBranchPrefix))).To(BeTrue())
+		})
+	})
+
+	Describe(".IsMilestoneReference", func() {
+		It("should return false if not a milestone branch name or true if otherwise", func() {
+			Expect(plumbing.IsMilestoneReference("refs/heads/abc")).To(BeFalse())
+			Expect(plumbing.IsMilestoneReference(fmt.Sprintf("refs/heads/%s/0001", plumbing.MilestoneBranchPrefix))).To(BeFalse())
+			Expect(plumbing.IsMilestoneReference(fmt.Sprintf("refs/heads/%s/1", plumbing.MilestoneBranchPrefix))).To(BeTrue())
+		})
+	})
+
+	Describe(".IsMilestoneReferencePath", func() {
+		It("should return true if string has milestone reference path or false if otherwise", func() {
+			Expect(plumbing.IsMilestoneReferencePath(fmt.Sprintf("refs/heads/%s/", plumbing.MilestoneBranchPrefix))).To(BeTrue())
+			Expect(plumbing.IsMilestoneReferencePath(fmt.Sprintf("refs/heads/%s", plumbing.MilestoneBranchPrefix))).To(BeTrue())
+			Expect(plumbing.IsMilestoneReferencePath("refs/heads/stuffs")).To(BeFalse())
+		})
+	})
+
+	Describe(".MakeMilestoneReference", func() {
+		It("should create a valid milestone reference", func() {
+			ref := plumbing.MakeMilestoneReference(1)
+			Expect(plumbing.IsMilestoneReference(ref)).To(BeTrue())
+			Expect(plumbing.MakeMilestoneReferencePath()).To(Equal("refs/heads/" + plumbing.MilestoneBranchPrefix))
 		})
 	})
 
@@ -148,6 +173,7 @@ var _ = Describe("Common", func() {
 			Expect(plumbing.GetReferenceShortName("refs/heads/main/master")).To(Equal("main/master"))
 			Expect(plumbing.GetReferenceShortName(plumbing.MakeIssueReference(1))).To(Equal("1"))
 			Expect(plumbing.GetReferenceShortName(plumbing.MakeMergeRequestReference(1))).To(Equal("1"))
+			Expect(plumbing.GetReferenceShortName(plumbing.MakeMilestoneReference(1))).To(Equal("1"))
 		})
 	})
 })
@@ -0,0 +1,27 @@
+package plumbing
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ShallowCommitMarker describes a function for marking a commit as shallow
+type ShallowCommitMarker func(repo LocalRepo, hash string) error
+
+// MarkShallowCommit records the given commit as a shallow commit of the
+// repository, indicating that its parents are not available locally.
+// It is a no-op if the commit is already marked as shallow.
+func MarkShallowCommit(repo LocalRepo, hash string) error {
+	shallows, err := repo.GetStorer().Shallow()
+	if err != nil {
+		return err
+	}
+
+	target := plumbing.NewHash(hash)
+	for _, h := range shallows {
+		if h == target {
+			return nil
+		}
+	}
+
+	return repo.GetStorer().SetShallow(append(shallows, target))
+}
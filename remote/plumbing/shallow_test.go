@@ -0,0 +1,59 @@
+package plumbing_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/make-os/kit/config"
+	pl "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shallow", func() {
+	var err error
+	var cfg *config.AppConfig
+	var testRepo pl.LocalRepo
+	var path string
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		repoName := util.RandString(5)
+		path = filepath.Join(cfg.GetRepoRoot(), repoName)
+		testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+		testRepo, err = repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".MarkShallowCommit", func() {
+		hash := "7a561e23f4e81c61df1b0dc63a89ae9c8d5680cd"
+		hash2 := "ab8f7a1b2a0c6a0e5f6b3f7f4c2e6a1f0b9c8d7e"
+
+		It("should add the commit to the shallow list of the repository", func() {
+			Expect(pl.MarkShallowCommit(testRepo, hash)).To(BeNil())
+			Expect(pl.MarkShallowCommit(testRepo, hash2)).To(BeNil())
+			shallows, err := testRepo.GetStorer().Shallow()
+			Expect(err).To(BeNil())
+			Expect(shallows).To(Equal([]plumbing.Hash{plumbing.NewHash(hash), plumbing.NewHash(hash2)}))
+		})
+
+		It("should not add the commit again if it is already marked as shallow", func() {
+			Expect(pl.MarkShallowCommit(testRepo, hash)).To(BeNil())
+			Expect(pl.MarkShallowCommit(testRepo, hash)).To(BeNil())
+			shallows, err := testRepo.GetStorer().Shallow()
+			Expect(err).To(BeNil())
+			Expect(shallows).To(HaveLen(1))
+		})
+	})
+})
@@ -6,16 +6,19 @@ import (
 )
 
 const (
-	PolicyActionWrite      = "write"
-	PolicyActionDelete     = "delete"
-	PolicyActionUpdate     = "update"
-	PolicyActionDenyDelete = "deny-delete"
+	PolicyActionWrite          = "write"
+	PolicyActionForceWrite     = "force-write" // Write that rewrites history (non-fast-forward)
+	PolicyActionDelete         = "delete"
+	PolicyActionUpdate         = "update"
+	PolicyActionDenyDelete     = "deny-delete"
+	PolicyActionDenyForceWrite = "deny-force-write"
 )
 
 // AddDefaultPolicies adds default repo-level policies
 func AddDefaultPolicies(config *state.RepoConfig) {
 	issueRefPath := plumbing.MakeIssueReferencePath()
 	mergeReqRefPath := plumbing.MakeMergeRequestReferencePath()
+	milestoneRefPath := plumbing.MakeMilestoneReferencePath()
 	config.Policies = append(
 		config.Policies,
 
@@ -24,9 +27,11 @@ func AddDefaultPolicies(config *state.RepoConfig) {
 		&state.Policy{Subject: "all", Object: mergeReqRefPath, Action: PolicyActionWrite},
 
 		// Contributors default branch policies
-		&state.Policy{Subject: "contrib", Object: "refs/heads", Action: PolicyActionWrite},             // can create branches
-		&state.Policy{Subject: "contrib", Object: "refs/heads/master", Action: PolicyActionDenyDelete}, // cannot delete master branch
-		&state.Policy{Subject: "contrib", Object: "refs/heads", Action: PolicyActionDelete},            // can delete any branches
+		&state.Policy{Subject: "contrib", Object: "refs/heads", Action: PolicyActionWrite},                 // can create branches
+		&state.Policy{Subject: "contrib", Object: "refs/heads/master", Action: PolicyActionDenyDelete},     // cannot delete master branch
+		&state.Policy{Subject: "contrib", Object: "refs/heads", Action: PolicyActionDelete},                // can delete any branches
+		&state.Policy{Subject: "contrib", Object: "^refs/heads/.+$", Action: PolicyActionForceWrite},       // can force-push to any branches
+		&state.Policy{Subject: "contrib", Object: "refs/heads/master", Action: PolicyActionDenyForceWrite}, // cannot force-push to master branch
 
 		// Contributor default tag policies
 		&state.Policy{Subject: "contrib", Object: "refs/tags", Action: PolicyActionWrite},   // can create tags
@@ -49,5 +54,13 @@ func AddDefaultPolicies(config *state.RepoConfig) {
 		// Contributor default merge request policies
 		&state.Policy{Subject: "contrib", Object: mergeReqRefPath, Action: PolicyActionUpdate}, // can update any merge requests
 		&state.Policy{Subject: "contrib", Object: mergeReqRefPath, Action: PolicyActionDelete}, // can delete any merge requests
+
+		// Contributor default milestone policies
+		&state.Policy{Subject: "contrib", Object: milestoneRefPath, Action: PolicyActionWrite},  // can create milestones
+		&state.Policy{Subject: "contrib", Object: milestoneRefPath, Action: PolicyActionUpdate}, // can update any milestone admin fields
+		&state.Policy{Subject: "contrib", Object: milestoneRefPath, Action: PolicyActionDelete}, // can delete any milestones
+
+		// Creator default milestone policies
+		&state.Policy{Subject: "creator", Object: milestoneRefPath, Action: PolicyActionUpdate}, // can update own milestone admin fields
 	)
 }
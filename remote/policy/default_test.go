@@ -0,0 +1,43 @@
+package policy_test
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/remote/policy"
+	"github.com/make-os/kit/types/state"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Default", func() {
+	Describe(".AddDefaultPolicies", func() {
+		var enforcer policy.EnforcerFunc
+		var pushKeyID = ed25519.NewKeyFromIntSeed(1).PushAddr().String()
+
+		BeforeEach(func() {
+			repoState := state.BareRepository()
+			policy.AddDefaultPolicies(repoState.Config)
+			enforcer = policy.GetPolicyEnforcer(policy.MakePusherPolicyGroups(pushKeyID, repoState, nil, 1))
+		})
+
+		It("should allow contributors to force-write non-master branches", func() {
+			err := policy.CheckPolicy(enforcer, "refs/heads/dev", false, pushKeyID, true, policy.PolicyActionForceWrite)
+			Expect(err).To(BeNil())
+		})
+
+		It("should deny contributors from force-writing the master branch", func() {
+			err := policy.CheckPolicy(enforcer, "refs/heads/master", false, pushKeyID, true, policy.PolicyActionForceWrite)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("reference (refs/heads/master): not authorized to perform 'force-write' action"))
+		})
+
+		It("should allow contributors to write the master branch", func() {
+			err := policy.CheckPolicy(enforcer, "refs/heads/master", false, pushKeyID, true, policy.PolicyActionWrite)
+			Expect(err).To(BeNil())
+		})
+
+		It("should deny non-contributors from force-writing any branch", func() {
+			err := policy.CheckPolicy(enforcer, "refs/heads/dev", false, pushKeyID, false, policy.PolicyActionForceWrite)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/make-os/kit/remote/plumbing"
+)
+
+// RegexPatternPrefix is the prefix that marks a policy object as a regular expression
+const RegexPatternPrefix = "^"
+
+// globChars are characters that indicate a policy object is a glob pattern
+const globChars = "*?["
+
+// IsRegexPattern checks whether a policy object is a regular expression pattern.
+// Regular expression objects start with '^' (e.g ^refs/heads/release-[0-9]+$).
+func IsRegexPattern(object string) bool {
+	return strings.HasPrefix(object, RegexPatternPrefix)
+}
+
+// IsGlobPattern checks whether a policy object is a glob pattern (e.g refs/heads/release/*).
+// Glob patterns follow the syntax of path.Match; '*' does not match the '/' separator.
+func IsGlobPattern(object string) bool {
+	return !IsRegexPattern(object) && strings.ContainsAny(object, globChars)
+}
+
+// IsPattern checks whether a policy object is a glob or regular expression pattern
+func IsPattern(object string) bool {
+	return IsRegexPattern(object) || IsGlobPattern(object)
+}
+
+// CheckPattern checks whether a pattern object is valid.
+// Both glob and regex patterns must target a path under 'refs/'.
+func CheckPattern(object string) error {
+	if IsRegexPattern(object) {
+		if !strings.HasPrefix(object, RegexPatternPrefix+"refs/") {
+			return fmt.Errorf("regex pattern must start with '^refs/'")
+		}
+		if _, err := regexp.Compile(object); err != nil {
+			return fmt.Errorf("invalid regex pattern: %s", err)
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(object, "refs/") {
+		return fmt.Errorf("glob pattern must start with 'refs/'")
+	}
+	if _, err := path.Match(object, ""); err != nil {
+		return fmt.Errorf("invalid glob pattern")
+	}
+	return nil
+}
+
+// MatchPattern checks whether a reference name matches a pattern object.
+// It returns false if the pattern is invalid or is not a pattern.
+func MatchPattern(pattern, reference string) bool {
+	if IsRegexPattern(pattern) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(reference)
+	}
+
+	if IsGlobPattern(pattern) {
+		matched, err := path.Match(pattern, reference)
+		return err == nil && matched
+	}
+
+	return false
+}
+
+// IsValidObject checks whether a policy object is a reference path,
+// a full reference name or a valid reference pattern.
+func IsValidObject(object string) bool {
+	if IsPattern(object) {
+		return CheckPattern(object) == nil
+	}
+	return plumbing.IsReference(object)
+}
@@ -0,0 +1,66 @@
+package policy_test
+
+import (
+	"github.com/make-os/kit/remote/policy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pattern", func() {
+	Describe(".IsPattern", func() {
+		It("should detect glob and regex patterns", func() {
+			Expect(policy.IsPattern("refs/heads/release/*")).To(BeTrue())
+			Expect(policy.IsPattern("refs/tags/v?")).To(BeTrue())
+			Expect(policy.IsPattern("^refs/tags/v[0-9]+$")).To(BeTrue())
+			Expect(policy.IsPattern("refs/heads/master")).To(BeFalse())
+		})
+	})
+
+	Describe(".CheckPattern", func() {
+		It("should return error when glob pattern is not under refs/", func() {
+			err := policy.CheckPattern("heads/*")
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("glob pattern must start with 'refs/'"))
+		})
+
+		It("should return error when glob pattern is malformed", func() {
+			err := policy.CheckPattern("refs/heads/[")
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("invalid glob pattern"))
+		})
+
+		It("should return error when regex pattern is not under refs/", func() {
+			err := policy.CheckPattern("^heads/.*")
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("regex pattern must start with '^refs/'"))
+		})
+
+		It("should return error when regex pattern is malformed", func() {
+			err := policy.CheckPattern("^refs/heads/(")
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("invalid regex pattern"))
+		})
+
+		It("should return nil when pattern is valid", func() {
+			Expect(policy.CheckPattern("refs/heads/release/*")).To(BeNil())
+			Expect(policy.CheckPattern("^refs/heads/release-[0-9]+$")).To(BeNil())
+		})
+	})
+
+	Describe(".MatchPattern", func() {
+		It("should match glob patterns segment by segment", func() {
+			Expect(policy.MatchPattern("refs/heads/release/*", "refs/heads/release/v1")).To(BeTrue())
+			Expect(policy.MatchPattern("refs/heads/release/*", "refs/heads/release/v1/fix")).To(BeFalse())
+			Expect(policy.MatchPattern("refs/tags/v*", "refs/tags/v1.0")).To(BeTrue())
+		})
+
+		It("should match regex patterns", func() {
+			Expect(policy.MatchPattern("^refs/heads/release-[0-9]+$", "refs/heads/release-10")).To(BeTrue())
+			Expect(policy.MatchPattern("^refs/heads/release-[0-9]+$", "refs/heads/release-x")).To(BeFalse())
+		})
+
+		It("should return false for non-patterns", func() {
+			Expect(policy.MatchPattern("refs/heads/master", "refs/heads/master")).To(BeFalse())
+		})
+	})
+})
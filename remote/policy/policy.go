@@ -31,16 +31,74 @@ func GetPolicyEnforcer(policyGroup [][]*state.Policy) EnforcerFunc {
 // action is the action requested by the user.
 type PolicyChecker func(enforcer EnforcerFunc, reference string, isRefCreator bool, pushKeyID string, isContributor bool, action string) error
 
+// TraceStep describes a single policy lookup performed by CheckPolicy
+type TraceStep struct {
+
+	// Subject is the policy subject that was checked (all, contrib, creator or a push key ID)
+	Subject string `json:"subject"`
+
+	// Object is the reference or reference root directory that was checked
+	Object string `json:"object"`
+
+	// Action is the action that was checked (e.g write or deny-write)
+	Action string `json:"action"`
+
+	// Matched indicates that a policy matched the subject, object and action
+	Matched bool `json:"matched"`
+
+	// Level is the level of the matched policy (-1 if no policy matched)
+	Level int `json:"level"`
+
+	// Allowed is the verdict of the matched policy
+	Allowed bool `json:"allowed"`
+
+	// Applied indicates that the matched policy took precedence over previously matched policies
+	Applied bool `json:"applied"`
+}
+
+// Trace describes the evaluation steps and verdict of a policy check
+type Trace struct {
+
+	// Reference is the target reference
+	Reference string `json:"reference"`
+
+	// RootDir is the root directory of the target reference
+	RootDir string `json:"rootDir"`
+
+	// Action is the requested action
+	Action string `json:"action"`
+
+	// Steps are the policy lookups performed, in evaluation order
+	Steps []*TraceStep `json:"steps"`
+
+	// Allowed is the final verdict
+	Allowed bool `json:"allowed"`
+}
+
 // CheckPolicy performs ACL checks to determine whether the given push key
 // is permitted to perform the given action on the reference subject.
 func CheckPolicy(enforcer EnforcerFunc, reference string, isRefCreator bool, pushKeyID string, isContributor bool, action string) error {
+	trace, err := TraceCheckPolicy(enforcer, reference, isRefCreator, pushKeyID, isContributor, action)
+	if err != nil {
+		return err
+	}
 
-	// Determine the reference root
+	if !trace.Allowed {
+		return fmt.Errorf("reference (%s): not authorized to perform '%s' action", reference, action)
+	}
+
+	return nil
+}
+
+// getReferenceRootDir returns the root directory a reference's policies are inherited from
+func getReferenceRootDir(reference string) (string, error) {
 	rootDir := "refs/"
 	if plumbing.IsIssueReference(reference) {
 		rootDir = plumbing.MakeIssueReferencePath()
 	} else if plumbing.IsMergeRequestReference(reference) {
 		rootDir = plumbing.MakeMergeRequestReferencePath()
+	} else if plumbing.IsMilestoneReference(reference) {
+		rootDir = plumbing.MakeMilestoneReferencePath()
 	} else if plumbing.IsBranch(reference) {
 		rootDir = rootDir + "heads"
 	} else if plumbing.IsTag(reference) {
@@ -51,49 +109,77 @@ func CheckPolicy(enforcer EnforcerFunc, reference string, isRefCreator bool, pus
 		if plumbing.IsReference(reference) {
 			rootDir = reference
 		} else {
-			return fmt.Errorf("unknown reference (%s)", reference)
+			return "", fmt.Errorf("unknown reference (%s)", reference)
 		}
 	}
+	return rootDir, nil
+}
+
+// TraceCheckPolicy evaluates the policies that apply to the given push key and reference
+// exactly as CheckPolicy does and returns every policy lookup performed alongside the
+// final verdict. It returns an error only when the reference is unknown.
+func TraceCheckPolicy(enforcer EnforcerFunc, reference string, isRefCreator bool, pushKeyID string, isContributor bool, action string) (*Trace, error) {
+
+	// Determine the reference root
+	rootDir, err := getReferenceRootDir(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &Trace{Reference: reference, RootDir: rootDir, Action: action}
 
 	var negativeAct = "deny-" + action
 	var allowed bool
 	var highestLvl = 999 // Set default to a random, high number greater than all levels
-	var res bool
-	var lvl int
+	var specificDeny bool // Indicates that the current verdict was decided by a reference-specific deny policy
+
+	// check queries the enforcer and records the lookup in the trace.
+	// canApply decides whether a matched policy of the given level takes precedence.
+	var check = func(subject, object, act string, canApply func(lvl int) bool) (bool, int, bool) {
+		res, lvl := enforcer(subject, object, act)
+		step := &TraceStep{Subject: subject, Object: object, Action: act, Level: lvl, Matched: lvl > -1}
+		if step.Matched {
+			step.Allowed = res
+			if act == negativeAct {
+				step.Allowed = !res
+			}
+			step.Applied = canApply(lvl)
+		}
+		trace.Steps = append(trace.Steps, step)
+		return step.Allowed, lvl, step.Applied
+	}
+
+	var refApplies = func(lvl int) bool { return lvl <= highestLvl }
+	var rootApplies = func(lvl int) bool { return lvl < highestLvl || lvl == highestLvl && !specificDeny }
 
 	// enforce attempts to check whether the specified subject is allowed
 	// or disallowed to perform the specified action on both the target
 	// reference and the root of the target reference.
+	//
+	// Precedence: policies of lower levels always win. At the same level,
+	// later checks override earlier ones, so a deny policy overrides an allow
+	// policy and a policy targeting the reference's root directory overrides
+	// a policy targeting the reference (literally or by a glob/regex pattern).
+	// The exception is a deny policy targeting the reference; it is not
+	// overridden by a policy targeting the root directory at the same level.
 	var enforce = func(subject string) {
 
 		// Skip to root directory check if target reference and root directory are the same.
-		if reference == rootDir {
-			goto root
-		}
-
-		// Check if the subject can or cannot perform the action on the reference
-		res, lvl = enforcer(subject, reference, action)
-		if lvl > -1 && lvl <= highestLvl {
-			allowed = res
-			highestLvl = lvl
-		}
-		res, lvl = enforcer(subject, reference, negativeAct)
-		if lvl > -1 && lvl <= highestLvl {
-			allowed = !res
-			highestLvl = lvl
+		if reference != rootDir {
+
+			// Check if the subject can or cannot perform the action on the reference
+			for _, act := range []string{action, negativeAct} {
+				if res, lvl, ok := check(subject, reference, act, refApplies); ok {
+					allowed, highestLvl, specificDeny = res, lvl, !res
+				}
+			}
 		}
 
-	root:
 		// Check if the subject can or cannot perform the action on the reference directory
-		res, lvl = enforcer(subject, rootDir, action)
-		if lvl > -1 && lvl <= highestLvl {
-			allowed = res
-			highestLvl = lvl
-		}
-		res, lvl = enforcer(subject, rootDir, negativeAct)
-		if lvl > -1 && lvl <= highestLvl {
-			allowed = !res
-			highestLvl = lvl
+		for _, act := range []string{action, negativeAct} {
+			if res, lvl, ok := check(subject, rootDir, act, rootApplies); ok {
+				allowed, highestLvl, specificDeny = res, lvl, false
+			}
 		}
 	}
 
@@ -110,11 +196,9 @@ func CheckPolicy(enforcer EnforcerFunc, reference string, isRefCreator bool, pus
 		enforce("creator")
 	}
 
-	if !allowed {
-		return fmt.Errorf("reference (%s): not authorized to perform '%s' action", reference, action)
-	}
+	trace.Allowed = allowed
 
-	return nil
+	return trace, nil
 }
 
 // MakePusherPolicyGroups creates a policy group contain the different category of policies
@@ -126,20 +210,23 @@ func CheckPolicy(enforcer EnforcerFunc, reference string, isRefCreator bool, pus
 // - 0: Repo's contributor policy collection (highest precedence)
 // - 1: Repo's namespace's contributor policy collection
 // - 2: Repo's config policy collection
+//
+// Contributor policies that are not active at the given block height are ignored.
 func MakePusherPolicyGroups(
 	pushKeyID string,
 	repoState *state.Repository,
-	namespace *state.Namespace) [][]*state.Policy {
+	namespace *state.Namespace,
+	height uint64) [][]*state.Policy {
 
 	// Gather the policies into groups
 	var groups = make([][]*state.Policy, 3)
 
 	// Find policies in the repo config-level policies
 	// where the subject is "all", "contrib", "creator" or a pusher key ID
-	// and also whose object points to a reference path or name
+	// and also whose object points to a reference path, name or pattern
 	for _, pol := range repoState.Config.Policies {
 		if (funk.ContainsString([]string{"all", "contrib", "creator"}, pol.Subject) || pol.Subject == pushKeyID) &&
-			plumbing.IsReference(pol.Object) {
+			IsValidObject(pol.Object) {
 			groups[2] = append(groups[2], pol)
 		}
 	}
@@ -147,6 +234,9 @@ func MakePusherPolicyGroups(
 	// Add the pusher's namespace-level contributor policies
 	if namespace != nil && namespace.Contributors.Has(pushKeyID) {
 		for _, p := range namespace.Contributors[pushKeyID].Policies {
+			if !p.IsActive(height) {
+				continue
+			}
 			groups[1] = append(groups[1], &state.Policy{Subject: pushKeyID, Object: p.Object, Action: p.Action})
 		}
 	}
@@ -154,6 +244,9 @@ func MakePusherPolicyGroups(
 	// Add the pusher's repo-level contributor policies
 	if repoState.Contributors.Has(pushKeyID) {
 		for _, p := range repoState.Contributors[pushKeyID].Policies {
+			if !p.IsActive(height) {
+				continue
+			}
 			groups[0] = append(groups[0], &state.Policy{Subject: pushKeyID, Object: p.Object, Action: p.Action})
 		}
 	}
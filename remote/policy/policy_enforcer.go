@@ -1,12 +1,28 @@
 package policy
 
 import (
+	"regexp"
+
 	"github.com/make-os/kit/types/state"
 )
 
 type policyItem struct {
 	Policy *state.Policy
 	Level  int
+	re     *regexp.Regexp
+}
+
+// isPattern checks whether the policy object of the item is a pattern
+func (p *policyItem) isPattern() bool {
+	return IsPattern(p.Policy.Object)
+}
+
+// matches checks whether the policy object of the item matches the reference.
+func (p *policyItem) matches(reference string) bool {
+	if p.re != nil {
+		return p.re.MatchString(reference)
+	}
+	return MatchPattern(p.Policy.Object, reference)
 }
 
 type policyItems []*policyItem
@@ -20,6 +36,24 @@ func (p *policyItems) get(sub, obj, act string) *policyItem {
 	return nil
 }
 
+// getByPattern finds the pattern policy with the highest precedence (lowest level)
+// whose subject and action match sub and act and whose object pattern matches ref.
+func (p *policyItems) getByPattern(sub, ref, act string) *policyItem {
+	var found *policyItem
+	for _, item := range *p {
+		if item.Policy.Subject != sub || item.Policy.Action != act || !item.isPattern() {
+			continue
+		}
+		if !item.matches(ref) {
+			continue
+		}
+		if found == nil || item.Level < found.Level {
+			found = item
+		}
+	}
+	return found
+}
+
 func (p *policyItems) add(policy *policyItem) {
 	*p = append(*p, policy)
 }
@@ -57,15 +91,29 @@ func (e *PolicyEnforcer) flatten(orderedPolicies [][]*state.Policy) {
 		for _, policy := range policies {
 			existing := e.policies.get(policy.Subject, policy.Object, policy.Action)
 			if existing == nil {
-				e.policies.add(&policyItem{Policy: policy, Level: level})
+				item := &policyItem{Policy: policy, Level: level}
+				if IsRegexPattern(policy.Object) {
+					item.re, _ = regexp.Compile(policy.Object)
+				}
+				e.policies.add(item)
 			}
 		}
 	}
 }
 
 // Enforce determine whether a request is allowed or disallowed.
+//
+// A policy whose object literally matches obj is preferred. If a pattern
+// policy (glob or regex) also matches obj, the pattern policy is only used
+// when its level has higher precedence (is lower) than the literal policy's.
+// In other words, at the same level, a literal policy beats a pattern policy.
 func (e *PolicyEnforcer) Enforce(sub, obj, act string) (allowed bool, level int) {
 	found := e.policies.get(sub, obj, act)
+	if patFound := e.policies.getByPattern(sub, obj, act); patFound != nil {
+		if found == nil || patFound.Level < found.Level {
+			found = patFound
+		}
+	}
 	if found == nil {
 		return false, -1
 	}
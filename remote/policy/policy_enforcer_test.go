@@ -32,4 +32,57 @@ var _ = Describe("PolicyEnforcerFunc", func() {
 			})
 		})
 	})
+
+	Describe(".Enforce", func() {
+		When("a glob pattern policy matches the object", func() {
+			It("should return true and the level of the pattern policy", func() {
+				pe := policy.NewPolicyEnforcer([][]*state.Policy{
+					{},
+					{{Subject: "sub", Object: "refs/heads/release/*", Action: "act"}},
+				})
+				allowed, lvl := pe.Enforce("sub", "refs/heads/release/v1", "act")
+				Expect(allowed).To(BeTrue())
+				Expect(lvl).To(Equal(1))
+			})
+		})
+
+		When("a regex pattern policy matches the object", func() {
+			It("should return true and the level of the pattern policy", func() {
+				pe := policy.NewPolicyEnforcer([][]*state.Policy{
+					{{Subject: "sub", Object: "^refs/tags/v[0-9]+$", Action: "act"}},
+				})
+				allowed, lvl := pe.Enforce("sub", "refs/tags/v10", "act")
+				Expect(allowed).To(BeTrue())
+				Expect(lvl).To(Equal(0))
+				allowed, lvl = pe.Enforce("sub", "refs/tags/va", "act")
+				Expect(allowed).To(BeFalse())
+				Expect(lvl).To(Equal(-1))
+			})
+		})
+
+		When("a literal and a pattern policy match the object at the same level", func() {
+			It("should select the literal policy", func() {
+				literal := &state.Policy{Subject: "sub", Object: "refs/heads/release/v1", Action: "act"}
+				pe := policy.NewPolicyEnforcer([][]*state.Policy{
+					{},
+					{{Subject: "sub", Object: "refs/heads/release/*", Action: "act"}, literal},
+				})
+				allowed, lvl := pe.Enforce("sub", "refs/heads/release/v1", "act")
+				Expect(allowed).To(BeTrue())
+				Expect(lvl).To(Equal(1))
+			})
+		})
+
+		When("a pattern policy has a lower level than a matching literal policy", func() {
+			It("should select the pattern policy", func() {
+				pe := policy.NewPolicyEnforcer([][]*state.Policy{
+					{{Subject: "sub", Object: "refs/heads/release/*", Action: "act"}},
+					{{Subject: "sub", Object: "refs/heads/release/v1", Action: "act"}},
+				})
+				allowed, lvl := pe.Enforce("sub", "refs/heads/release/v1", "act")
+				Expect(allowed).To(BeTrue())
+				Expect(lvl).To(Equal(0))
+			})
+		})
+	})
 })
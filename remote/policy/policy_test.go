@@ -6,6 +6,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/policy"
 	"github.com/make-os/kit/remote/types"
 	"github.com/make-os/kit/testutil"
@@ -69,7 +70,7 @@ var _ = Describe("Auth", func() {
 					Policies: []*state.ContributorPolicy{contribPolicy},
 				}
 
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, ns)
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, ns, 1)
 			})
 
 			Specify("that each policy group is not empty", func() {
@@ -104,12 +105,48 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
+		When("contributor policies are time-bounded", func() {
+			var pushKeyID string
+			var repoState *state.Repository
+			var ns *state.Namespace
+
+			BeforeEach(func() {
+				pushKeyID = key.PushAddr().String()
+				repoState = state.BareRepository()
+				repoState.Contributors[pushKeyID] = &state.RepoContributor{Policies: []*state.ContributorPolicy{
+					{Object: "refs/heads/dev", Action: "write", StartHeight: 10},
+					{Object: "refs/heads/master", Action: "write", EndHeight: 20},
+				}}
+				ns = &state.Namespace{Contributors: map[string]*state.BaseContributor{
+					pushKeyID: {Policies: []*state.ContributorPolicy{{Object: "refs/heads/about", Action: "write", StartHeight: 5, EndHeight: 15}}},
+				}}
+			})
+
+			It("should exclude policies that have not started", func() {
+				polGroups = policy.MakePusherPolicyGroups(pushKeyID, repoState, ns, 4)
+				Expect(polGroups[0]).To(Equal([]*state.Policy{{Subject: pushKeyID, Object: "refs/heads/master", Action: "write"}}))
+				Expect(polGroups[1]).To(HaveLen(0))
+			})
+
+			It("should include policies within their active range", func() {
+				polGroups = policy.MakePusherPolicyGroups(pushKeyID, repoState, ns, 10)
+				Expect(polGroups[0]).To(HaveLen(2))
+				Expect(polGroups[1]).To(HaveLen(1))
+			})
+
+			It("should exclude policies that have expired", func() {
+				polGroups = policy.MakePusherPolicyGroups(pushKeyID, repoState, ns, 20)
+				Expect(polGroups[0]).To(Equal([]*state.Policy{{Subject: pushKeyID, Object: "refs/heads/dev", Action: "write"}}))
+				Expect(polGroups[1]).To(HaveLen(0))
+			})
+		})
+
 		When("repo config policies include a policy whose subject is not a push key ID or 'all' or 'contrib'", func() {
 			BeforeEach(func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "some_subject", Object: "refs/heads/master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 			})
 
 			It("should not include the policy", func() {
@@ -123,7 +160,7 @@ var _ = Describe("Auth", func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "all", Object: "refs/heads/master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 				Expect(polGroups).To(HaveLen(3))
 				Expect(polGroups[2]).To(HaveLen(1))
 			})
@@ -132,7 +169,7 @@ var _ = Describe("Auth", func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "contrib", Object: "refs/heads/master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 				Expect(polGroups).To(HaveLen(3))
 				Expect(polGroups[2]).To(HaveLen(1))
 			})
@@ -141,7 +178,7 @@ var _ = Describe("Auth", func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "creator", Object: "refs/heads/master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 				Expect(polGroups).To(HaveLen(3))
 				Expect(polGroups[2]).To(HaveLen(1))
 			})
@@ -150,18 +187,41 @@ var _ = Describe("Auth", func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: key.PushAddr().String(), Object: "refs/heads/master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 				Expect(polGroups).To(HaveLen(3))
 				Expect(polGroups[2]).To(HaveLen(1))
 			})
 		})
 
+		When("repo config policies include a policy whose object is a reference pattern", func() {
+			It("should include glob and regex patterns", func() {
+				repoState := state.BareRepository()
+				repoState.Config.Policies = append(repoState.Config.Policies,
+					&state.Policy{Subject: "all", Object: "refs/heads/release/*", Action: "write"},
+					&state.Policy{Subject: "all", Object: "^refs/tags/v[0-9]+$", Action: "write"},
+				)
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
+				Expect(polGroups[2]).To(HaveLen(2))
+			})
+
+			It("should not include invalid patterns", func() {
+				repoState := state.BareRepository()
+				repoState.Config.Policies = append(repoState.Config.Policies,
+					&state.Policy{Subject: "all", Object: "refs/heads/[", Action: "write"},
+					&state.Policy{Subject: "all", Object: "^refs/tags/(", Action: "write"},
+					&state.Policy{Subject: "all", Object: "heads/*", Action: "write"},
+				)
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
+				Expect(polGroups[2]).To(HaveLen(0))
+			})
+		})
+
 		When("repo config policies include a policy whose object is not a recognized reference name", func() {
 			BeforeEach(func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "all", Object: "master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 			})
 
 			It("should not include the policy", func() {
@@ -431,7 +491,144 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
-		Context("check goto in unnamed enforce() method within policy.CheckPolicy", func() {
+		When("action is denied on dir:refs/heads and allowed on refs/heads/master at the same level", func() {
+			It("should return error; root policies take precedence over reference policies at the same level", func() {
+				policies := [][]*state.Policy{
+					{
+						{Subject: pushAddrA, Object: "refs/heads", Action: denyAction},
+						{Subject: pushAddrA, Object: "refs/heads/master", Action: allowAction},
+					},
+				}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/heads/master", false, pushAddrA, false, allowAction)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("reference (refs/heads/master): not authorized to perform 'write' action"))
+			})
+		})
+
+		When("action is allowed on dir:refs/heads and denied on pattern refs/heads/release/* at the same level", func() {
+			It("should return error for a matching reference", func() {
+				policies := [][]*state.Policy{
+					{}, {},
+					{
+						{Subject: "contrib", Object: "refs/heads", Action: allowAction},
+						{Subject: "contrib", Object: "refs/heads/release/*", Action: denyAction},
+					},
+				}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/heads/release/v1", false, pushAddrA, true, allowAction)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("reference (refs/heads/release/v1): not authorized to perform 'write' action"))
+			})
+
+			It("should return nil for a non-matching reference", func() {
+				policies := [][]*state.Policy{
+					{}, {},
+					{
+						{Subject: "contrib", Object: "refs/heads", Action: allowAction},
+						{Subject: "contrib", Object: "refs/heads/release/*", Action: denyAction},
+					},
+				}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/heads/dev", false, pushAddrA, true, allowAction)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("action is allowed on dir:refs/heads and denied on refs/heads/master at the same level", func() {
+			It("should return error; a reference deny policy is not overridden by a root allow policy", func() {
+				policies := [][]*state.Policy{
+					{
+						{Subject: pushAddrA, Object: "refs/heads", Action: allowAction},
+						{Subject: pushAddrA, Object: "refs/heads/master", Action: denyAction},
+					},
+				}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/heads/master", false, pushAddrA, false, allowAction)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("reference (refs/heads/master): not authorized to perform 'write' action"))
+			})
+		})
+
+		When("action is denied on pattern refs/heads/release/* in the repo policies and allowed by the default contributor policies", func() {
+			It("should return error for a matching reference", func() {
+				repoState := state.BareRepository()
+				repoState.Contributors[pushAddrA] = &state.RepoContributor{}
+				policy.AddDefaultPolicies(repoState.Config)
+				repoState.Config.Policies = append(repoState.Config.Policies,
+					&state.Policy{Subject: "contrib", Object: "refs/heads/release/*", Action: denyAction})
+				enforcer = policy.GetPolicyEnforcer(policy.MakePusherPolicyGroups(pushAddrA, repoState, nil, 0))
+				err = policy.CheckPolicy(enforcer, "refs/heads/release/v1", false, pushAddrA, true, allowAction)
+				Expect(err).ToNot(BeNil())
+				err = policy.CheckPolicy(enforcer, "refs/heads/dev", false, pushAddrA, true, allowAction)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("a contributor's policies have expired and the default contributor policies are set", func() {
+			It("should return error when writing to a branch", func() {
+				repoState := state.BareRepository()
+				repoState.Contributors[pushAddrA] = &state.RepoContributor{Policies: []*state.ContributorPolicy{
+					{Object: "refs/heads/dev", Action: allowAction, EndHeight: 10},
+				}}
+				policy.AddDefaultPolicies(repoState.Config)
+
+				enforcer = policy.GetPolicyEnforcer(policy.MakePusherPolicyGroups(pushAddrA, repoState, nil, 9))
+				isContrib := state.IsContributor(pushAddrA, repoState, nil, 9)
+				err = policy.CheckPolicy(enforcer, "refs/heads/master", false, pushAddrA, isContrib, allowAction)
+				Expect(err).To(BeNil())
+
+				enforcer = policy.GetPolicyEnforcer(policy.MakePusherPolicyGroups(pushAddrA, repoState, nil, 10))
+				isContrib = state.IsContributor(pushAddrA, repoState, nil, 10)
+				Expect(isContrib).To(BeFalse())
+				err = policy.CheckPolicy(enforcer, "refs/heads/master", false, pushAddrA, isContrib, allowAction)
+				Expect(err).ToNot(BeNil())
+				err = policy.CheckPolicy(enforcer, "refs/heads/dev", false, pushAddrA, isContrib, allowAction)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("action is allowed on regex pattern ^refs/tags/v[0-9.]+$", func() {
+			It("should return nil for a matching reference", func() {
+				policies := [][]*state.Policy{{{Subject: pushAddrA, Object: "^refs/tags/v[0-9.]+$", Action: allowAction}}}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/tags/v1.0.1", false, pushAddrA, false, allowAction)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return error for a non-matching reference", func() {
+				policies := [][]*state.Policy{{{Subject: pushAddrA, Object: "^refs/tags/v[0-9.]+$", Action: allowAction}}}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/tags/beta", false, pushAddrA, false, allowAction)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("a literal policy allows and a pattern policy denies at the same level", func() {
+			It("should return error; deny wins at the same level", func() {
+				policies := [][]*state.Policy{{
+					{Subject: pushAddrA, Object: "refs/heads/release/*", Action: denyAction},
+					{Subject: pushAddrA, Object: "refs/heads/release/v1", Action: allowAction},
+				}}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/heads/release/v1", false, pushAddrA, false, allowAction)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("a literal policy allows at level 0 and a pattern policy denies at level 1", func() {
+			It("should return nil", func() {
+				policies := [][]*state.Policy{
+					{{Subject: pushAddrA, Object: "refs/heads/release/v1", Action: allowAction}},
+					{{Subject: pushAddrA, Object: "refs/heads/release/*", Action: denyAction}},
+				}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/heads/release/v1", false, pushAddrA, false, allowAction)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		Context("check root reference skip in unnamed enforce() method within policy.CheckPolicy", func() {
 			When("reference=(refs/heads) is a root reference", func() {
 				It("should skip to root reference check "+
 					"(enforcer call count must be 4 (2 for sub:all, 2 for sub:pushKeyID, object=refs/heads/2)", func() {
@@ -447,4 +644,64 @@ var _ = Describe("Auth", func() {
 			})
 		})
 	})
+	Describe(".TraceCheckPolicy", func() {
+		var pushAddrA string
+
+		BeforeEach(func() {
+			pushAddrA = key.PushAddr().String()
+		})
+
+		It("should return error when reference type is unknown", func() {
+			enforcer := policy.GetPolicyEnforcer([][]*state.Policy{})
+			_, err := policy.TraceCheckPolicy(enforcer, "refs/unknown/xyz", false, pushAddrA, false, "write")
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("unknown reference (refs/unknown/xyz)"))
+		})
+
+		It("should record each subject lookup and the final verdict", func() {
+			policies := [][]*state.Policy{
+				{{Subject: pushAddrA, Object: "refs/heads/master", Action: "deny-write"}},
+				{},
+				{{Subject: "all", Object: "refs/heads", Action: "write"}},
+			}
+			enforcer := policy.GetPolicyEnforcer(policies)
+			trace, err := policy.TraceCheckPolicy(enforcer, "refs/heads/master", true, pushAddrA, true, "write")
+			Expect(err).To(BeNil())
+			Expect(trace.Reference).To(Equal("refs/heads/master"))
+			Expect(trace.RootDir).To(Equal("refs/heads"))
+			Expect(trace.Allowed).To(BeFalse())
+
+			// 4 lookups for each of the subjects: all, push key, contrib and creator
+			Expect(trace.Steps).To(HaveLen(16))
+			var subjects []string
+			for i := 0; i < len(trace.Steps); i += 4 {
+				subjects = append(subjects, trace.Steps[i].Subject)
+			}
+			Expect(subjects).To(Equal([]string{"all", pushAddrA, "contrib", "creator"}))
+
+			Expect(trace.Steps[2]).To(Equal(&policy.TraceStep{Subject: "all", Object: "refs/heads",
+				Action: "write", Matched: true, Level: 2, Allowed: true, Applied: true}))
+			Expect(trace.Steps[5]).To(Equal(&policy.TraceStep{Subject: pushAddrA, Object: "refs/heads/master",
+				Action: "deny-write", Matched: true, Level: 0, Allowed: false, Applied: true}))
+			Expect(trace.Steps[0].Matched).To(BeFalse())
+			Expect(trace.Steps[0].Level).To(Equal(-1))
+		})
+
+		It("should use the milestone reference path as the root of a milestone reference", func() {
+			enforcer := policy.GetPolicyEnforcer([][]*state.Policy{})
+			trace, err := policy.TraceCheckPolicy(enforcer, plumbing.MakeMilestoneReference(1), false, pushAddrA, false, "write")
+			Expect(err).To(BeNil())
+			Expect(trace.RootDir).To(Equal(plumbing.MakeMilestoneReferencePath()))
+			Expect(trace.Allowed).To(BeFalse())
+		})
+
+		It("should agree with CheckPolicy", func() {
+			policies := [][]*state.Policy{{{Subject: pushAddrA, Object: "refs/heads/master", Action: "write"}}}
+			enforcer := policy.GetPolicyEnforcer(policies)
+			trace, err := policy.TraceCheckPolicy(enforcer, "refs/heads/master", false, pushAddrA, false, "write")
+			Expect(err).To(BeNil())
+			Expect(trace.Allowed).To(BeTrue())
+			Expect(policy.CheckPolicy(enforcer, "refs/heads/master", false, pushAddrA, false, "write")).To(BeNil())
+		})
+	})
 })
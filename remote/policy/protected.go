@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"github.com/make-os/kit/types/state"
+)
+
+// GetProtectedBranch returns the protected branch entry of the repo config
+// that applies to the given branch reference. An entry whose name literally
+// matches the reference takes precedence over entries with a matching pattern.
+// It returns nil if the branch is not protected.
+func GetProtectedBranch(cfg *state.RepoConfig, reference string) *state.ProtectedBranch {
+	if cfg == nil {
+		return nil
+	}
+
+	var matched *state.ProtectedBranch
+	for _, pb := range cfg.Protected {
+		if pb == nil {
+			continue
+		}
+		if pb.Name == reference {
+			return pb
+		}
+		if matched == nil && MatchPattern(pb.Name, reference) {
+			matched = pb
+		}
+	}
+
+	return matched
+}
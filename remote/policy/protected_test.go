@@ -0,0 +1,32 @@
+package policy_test
+
+import (
+	"github.com/make-os/kit/remote/policy"
+	"github.com/make-os/kit/types/state"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Protected", func() {
+	Describe(".GetProtectedBranch", func() {
+		It("should return nil when config is nil or branch is not protected", func() {
+			Expect(policy.GetProtectedBranch(nil, "refs/heads/master")).To(BeNil())
+			cfg := &state.RepoConfig{Protected: state.ProtectedBranches{{Name: "refs/heads/dev", Approvals: 1}}}
+			Expect(policy.GetProtectedBranch(cfg, "refs/heads/master")).To(BeNil())
+		})
+
+		It("should return entry whose name matches the branch literally", func() {
+			cfg := &state.RepoConfig{Protected: state.ProtectedBranches{{Name: "refs/heads/master", Approvals: 1}}}
+			Expect(policy.GetProtectedBranch(cfg, "refs/heads/master")).To(Equal(cfg.Protected[0]))
+		})
+
+		It("should prefer a literal entry over a pattern entry", func() {
+			cfg := &state.RepoConfig{Protected: state.ProtectedBranches{
+				{Name: "refs/heads/release/*", Approvals: 1},
+				{Name: "refs/heads/release/v1", Approvals: 3},
+			}}
+			Expect(policy.GetProtectedBranch(cfg, "refs/heads/release/v1")).To(Equal(cfg.Protected[1]))
+			Expect(policy.GetProtectedBranch(cfg, "refs/heads/release/v2")).To(Equal(cfg.Protected[0]))
+		})
+	})
+})
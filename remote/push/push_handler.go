@@ -19,6 +19,7 @@ import (
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/policy"
 	"github.com/make-os/kit/remote/push/types"
+	"github.com/make-os/kit/remote/repo"
 	remotetypes "github.com/make-os/kit/remote/types"
 	"github.com/make-os/kit/remote/validation"
 	coretypes "github.com/make-os/kit/types"
@@ -192,6 +193,19 @@ func (h *BasicHandler) enforcePolicy(cmd *packp.Command) error {
 		action = policy.PolicyActionDelete
 	}
 
+	// For a branch update that rewrites history (the new hash
+	// does not descend from the old hash), set action to 'force-write'.
+	if action == policy.PolicyActionWrite && !cmd.Old.IsZero() && isForceWritable(ref) {
+		err := h.Repo.IsAncestor(cmd.Old.String(), cmd.New.String())
+		if err == repo.ErrAncestryUnknown {
+			return fmt.Errorf("reference (%s): cannot verify the update does not rewrite history of a shallow repository", ref)
+		} else if err != nil && err != repo.ErrNotAnAncestor {
+			return errors.Wrap(err, fmt.Sprintf("reference (%s): failed to check commit ancestry", ref))
+		} else if err == repo.ErrNotAnAncestor {
+			action = policy.PolicyActionForceWrite
+		}
+	}
+
 	refState := h.Repo.GetState().References.Get(ref)
 
 	// When the push updated an admin field, set action to 'update'. Ignore if reference is new.
@@ -199,13 +213,19 @@ func (h *BasicHandler) enforcePolicy(cmd *packp.Command) error {
 		action = policy.PolicyActionUpdate
 	}
 
+	// Get the current block height; contributor policies may be time-bounded
+	bi, err := h.Server.GetLogic().SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to get last block info")
+	}
+
 	pusher := h.TxDetails.GetPushKeyID()
-	err := h.PolicyChecker(
+	err = h.PolicyChecker(
 		h.polEnforcer,
 		ref,
 		!refState.IsNil() && refState.Creator.String() == pusher,
 		pusher,
-		h.Repo.IsContributor(pusher),
+		h.Repo.IsContributor(pusher, uint64(bi.Height)),
 		action)
 	if err != nil {
 		return err
@@ -214,6 +234,13 @@ func (h *BasicHandler) enforcePolicy(cmd *packp.Command) error {
 	return nil
 }
 
+// isForceWritable checks whether a reference's history can be rewritten.
+// Only regular branches can be force-pushed; post references (issues,
+// merge requests and milestones) are subject to post validation instead.
+func isForceWritable(ref string) bool {
+	return plumbing.IsBranch(ref) && !plumbing.IsPostReference(ref)
+}
+
 // DoAuth implements Handler. It performs access-level checks.
 func (h *BasicHandler) DoAuth(ur *packp.ReferenceUpdateRequest, targetRef string, ignorePostRefs bool) error {
 	for _, cmd := range ur.Commands {
@@ -221,8 +248,7 @@ func (h *BasicHandler) DoAuth(ur *packp.ReferenceUpdateRequest, targetRef string
 			continue
 		}
 
-		if ignorePostRefs && (plumbing.IsIssueReference(cmd.Name.String()) ||
-			plumbing.IsMergeRequestReference(cmd.Name.String())) {
+		if ignorePostRefs && plumbing.IsPostReference(cmd.Name.String()) {
 			continue
 		}
 
@@ -360,6 +386,12 @@ func (h *BasicHandler) HandleUpdate(targetNote types.PushNote) (err error) {
 		}
 	}
 
+	// Ensure LFS objects referenced in the pushed commits are available
+	h.pktEnc.Encode(plumbing.SidebandInfoln("checking availability of LFS objects"))
+	if err = h.Server.CheckLFSObjects(note); err != nil {
+		return errors.Wrap(err, "failed LFS object check")
+	}
+
 	if err = h.HandlePushNote(note); err != nil {
 		return err
 	}
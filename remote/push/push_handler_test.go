@@ -56,6 +56,8 @@ var _ = Describe("BasicHandler", func() {
 	var mockDHT *mocks.MockDHT
 	var mockGitRcvCmd *mocks.MockCmd
 	var mockPushPool *mocks.MockPushPool
+	var mockSysKeeper *mocks.MockSystemKeeper
+	var blockHeight int64
 	var mockService *mocks.MockService
 
 	BeforeEach(func() {
@@ -72,8 +74,15 @@ var _ = Describe("BasicHandler", func() {
 		mockDHT = mocks.NewMockDHT(ctrl)
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeGit, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeLFS, gomock.Any())
 
 		mockLogic = mocks.NewMockLogic(ctrl)
+		mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+		blockHeight = 1
+		mockLogic.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+		mockSysKeeper.EXPECT().GetLastBlockInfo().DoAndReturn(func() (*state.BlockInfo, error) {
+			return &state.BlockInfo{Height: util.Int64(blockHeight)}, nil
+		}).AnyTimes()
 		mockMempool = mocks.NewMockMempool(ctrl)
 		mockBlockGetter = mocks.NewMockBlockGetter(ctrl)
 		mockGitRcvCmd = mocks.NewMockCmd(ctrl)
@@ -84,6 +93,7 @@ var _ = Describe("BasicHandler", func() {
 		mockRemoteSrv = mocks.NewMockRemoteServer(ctrl)
 		mockRemoteSrv.EXPECT().Log().Return(cfg.G().Log)
 		mockRemoteSrv.EXPECT().GetPushPool().Return(mockPushPool).AnyTimes()
+		mockRemoteSrv.EXPECT().GetLogic().Return(mockLogic).AnyTimes()
 
 		handler = push.NewHandler(testRepo, []*types.TxDetail{}, nil, mockRemoteSrv)
 	})
@@ -350,6 +360,61 @@ var _ = Describe("BasicHandler", func() {
 			Expect(err).To(BeNil())
 		})
 
+		When("a branch is updated", func() {
+			var ref = "refs/heads/master"
+			var hash1, hash2 string
+
+			BeforeEach(func() {
+				remotetestutil.AppendCommit(path, "file.txt", "line 1", "commit 1")
+				hash1 = remotetestutil.GetRecentCommitHash(path, "HEAD")
+				remotetestutil.AppendCommit(path, "file.txt", "line 2", "commit 2")
+				hash2 = remotetestutil.GetRecentCommitHash(path, "HEAD")
+				handler.TxDetails[ref] = &types.TxDetail{}
+			})
+
+			Specify("that policy is 'PolicyActionWrite' when new hash descends from the old hash", func() {
+				ur.Commands = append(ur.Commands, &packp.Command{Name: plumbing.ReferenceName(ref),
+					Old: plumbing.NewHash(hash1), New: plumbing.NewHash(hash2)})
+				handler.PolicyChecker = func(enforcer policy.EnforcerFunc, reference string, isRefCreator bool, pushKeyID string, isContrib bool, action string) error {
+					Expect(action).To(Equal(policy.PolicyActionWrite))
+					return nil
+				}
+				err = handler.DoAuth(ur, "", false)
+				Expect(err).To(BeNil())
+			})
+
+			Specify("that policy is 'PolicyActionForceWrite' when new hash does not descend from the old hash", func() {
+				ur.Commands = append(ur.Commands, &packp.Command{Name: plumbing.ReferenceName(ref),
+					Old: plumbing.NewHash(hash2), New: plumbing.NewHash(hash1)})
+				handler.PolicyChecker = func(enforcer policy.EnforcerFunc, reference string, isRefCreator bool, pushKeyID string, isContrib bool, action string) error {
+					Expect(action).To(Equal(policy.PolicyActionForceWrite))
+					return nil
+				}
+				err = handler.DoAuth(ur, "", false)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return error when the old commit is unknown", func() {
+				unknown := plumbing.ComputeHash(plumbing.CommitObject, util.RandBytes(20))
+				ur.Commands = append(ur.Commands, &packp.Command{Name: plumbing.ReferenceName(ref),
+					Old: unknown, New: plumbing.NewHash(hash1)})
+				err = handler.DoAuth(ur, "", false)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("reference (refs/heads/master): failed to check commit ancestry"))
+			})
+
+			It("should return error when the repository is shallow and the old commit is beyond the shallow boundary", func() {
+				remotetestutil.AppendCommit(path, "file.txt", "line 3", "commit 3")
+				hash3 := remotetestutil.GetRecentCommitHash(path, "HEAD")
+				Expect(plumbing2.MarkShallowCommit(testRepo, hash2)).To(BeNil())
+				ur.Commands = append(ur.Commands, &packp.Command{Name: plumbing.ReferenceName(ref),
+					Old: plumbing.NewHash(hash1), New: plumbing.NewHash(hash3)})
+				err = handler.DoAuth(ur, "", false)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("reference (refs/heads/master): cannot verify the update does not rewrite history of a shallow repository"))
+			})
+		})
+
 		Specify("that for merge reference with newHash=zero, policy is 'PolicyActionMergeDelete'", func() {
 			ref := plumbing2.MakeMergeRequestReference(1)
 			handler.TxDetails[ref] = &types.TxDetail{}
@@ -394,6 +459,35 @@ var _ = Describe("BasicHandler", func() {
 			Expect(err).To(MatchError("error"))
 		})
 
+		When("the pusher is a contributor whose policies have expired and the default policies are set", func() {
+			var pushKeyID string
+
+			BeforeEach(func() {
+				pushKeyID = util.RandString(42)
+				repoState := state.BareRepository()
+				repoState.Contributors[pushKeyID] = &state.RepoContributor{Policies: []*state.ContributorPolicy{
+					{Object: "refs/heads/dev", Action: policy.PolicyActionWrite, EndHeight: 10},
+				}}
+				policy.AddDefaultPolicies(repoState.Config)
+				testRepo.SetState(repoState)
+
+				ref := "refs/heads/master"
+				txDetails := []*types.TxDetail{{Reference: ref, PushKeyID: pushKeyID}}
+				enforcer := policy.GetPolicyEnforcer(policy.MakePusherPolicyGroups(pushKeyID, repoState, nil, 10))
+				mockRemoteSrv.EXPECT().Log().Return(cfg.G().Log)
+				handler = push.NewHandler(testRepo, txDetails, enforcer, mockRemoteSrv)
+				hash := plumbing.ComputeHash(plumbing.CommitObject, util.RandBytes(20))
+				ur.Commands = append(ur.Commands, &packp.Command{Name: plumbing.ReferenceName(ref), New: hash})
+			})
+
+			It("should not be allowed to write to a branch", func() {
+				blockHeight = 10
+				err = handler.DoAuth(ur, "", false)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("reference (refs/heads/master): not authorized to perform 'write' action"))
+			})
+		})
+
 		When("target reference is provided, only the reference is checked", func() {
 			It("should check only ref2 when ref2 is the target reference", func() {
 				ref, ref2 := "refs/heads/master", "refs/heads/dev"
@@ -43,6 +43,7 @@ type RefSync struct {
 	makeReferenceUpdatePack push.MakeReferenceUpdateRequestPackFunc
 	RepoGetter              repo.GetLocalRepoFunc
 	UpdateRepoUsingNote     UpdateRepoUsingNoteFunc
+	GetCrossReferences      plumbing.GetCrossReferencesFunc
 	keepers                 core.Keepers
 	watcher                 reftypes.Watcher
 	queued                  *cache.Cache
@@ -70,6 +71,7 @@ func New(cfg *config.AppConfig,
 		makeReferenceUpdatePack: push.MakeReferenceUpdateRequestPack,
 		RepoGetter:              repo.GetWithGitModule,
 		UpdateRepoUsingNote:     UpdateRepoUsingNote,
+		GetCrossReferences:      plumbing.GetCrossReferences,
 		pool:                    pool,
 		removeRefQueueOnEmpty:   true,
 		announcer:               announcer,
@@ -226,6 +228,27 @@ func (rs *RefSync) updatedTrackInfo(task *reftypes.RefTask) (err error) {
 	return
 }
 
+// indexCrossReferences finds mentions of posts and commits in the new commits
+// of a task's reference and adds them to the repository's cross reference index.
+// Issues closed by the new commits are closed from their most recent comment.
+func (rs *RefSync) indexCrossReferences(targetRepo plumbing.LocalRepo, task *reftypes.RefTask) error {
+	refs, err := rs.GetCrossReferences(targetRepo, task.Ref.Name, task.Ref.OldHash, task.Ref.NewHash)
+	if err != nil {
+		return errors.Wrap(err, "failed to get cross references")
+	} else if len(refs) == 0 {
+		return nil
+	}
+	for _, ref := range refs {
+		if !ref.Closes {
+			continue
+		}
+		if ref.PostHash, err = targetRepo.RefGet(ref.Target); err != nil && err != plumbing.ErrRefNotFound {
+			return errors.Wrapf(err, "failed to get closed issue (%s)", ref.Target)
+		}
+	}
+	return rs.keepers.RepoSyncInfoKeeper().AddCrossReferences(task.RepoName, refs...)
+}
+
 // do takes a pushed reference task and attempts to fetch the objects
 // required to update the reference's local state.
 func (rs *RefSync) do(task *reftypes.RefTask) error {
@@ -298,6 +321,14 @@ func (rs *RefSync) do(task *reftypes.RefTask) error {
 		rs.log.Debug("Successfully updated reference", "Repo", task.RepoName,
 			"Ref", refName, "NewHash", task.Ref.NewHash, "OldHash", task.Ref.OldHash)
 
+		// Index the posts and commits mentioned in the new commits of the reference.
+		// Failure to index should not fail the synchronization.
+		if err == nil {
+			if err := rs.indexCrossReferences(targetRepo, task); err != nil {
+				rs.log.Error("Failed to index cross references", "Err", err.Error(), "Repo", task.RepoName, "Ref", refName)
+			}
+		}
+
 		return err
 	}
 
@@ -59,6 +59,9 @@ var _ = Describe("RefSync", func() {
 		mockKeepers.EXPECT().RepoSyncInfoKeeper().Return(mockRepoSyncInfoKeeper).AnyTimes()
 		mockPushPool = mocks.NewMockPushPool(ctrl)
 		rs = New(cfg, mockPushPool, mockFetcher, nil, mockKeepers)
+		rs.GetCrossReferences = func(repo3.LocalRepo, string, string, string) ([]*repo3.CrossReference, error) {
+			return nil, nil
+		}
 
 		repoName = util.RandString(5)
 		testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
@@ -375,6 +378,63 @@ var _ = Describe("RefSync", func() {
 			})
 		})
 
+		When("the reference was updated", func() {
+			var task *types3.RefTask
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				key, _ := cfg.G().PrivVal.GetKey()
+				task = &types3.RefTask{
+					RepoName:    "repo1",
+					Ref:         &types.PushedReference{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash},
+					NoteCreator: key.PubKey().MustBytes32(),
+				}
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				rs.RepoGetter = func(gitBinPath, path string) (repo3.LocalRepo, error) { return mockRepo, nil }
+				rs.UpdateRepoUsingNote = func(string, push.MakeReferenceUpdateRequestPackFunc, types.PushNote) error { return nil }
+				mockRepo.EXPECT().RefGet(task.Ref.Name).Return(oldHash, nil)
+				mockRepo.EXPECT().IsAncestor(newHash, oldHash).Return(fmt.Errorf("not ancestor"))
+				mockRepoSyncInfoKeeper.EXPECT().GetTracked(task.RepoName).Return(nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateRefLastSyncHeight(task.RepoName, task.Ref.Name, uint64(task.Height)).Return(nil)
+				mockPushPool.EXPECT().HasSeen(task.ID).Return(true)
+			})
+
+			It("should index cross references found in the new commits", func() {
+				xrefs := []*repo3.CrossReference{{Target: repo3.MakeIssueReference(1), Type: repo3.CrossRefSourceCommit, Source: task.Ref.Name, Hash: newHash, Closes: true}}
+				rs.GetCrossReferences = func(repo repo3.LocalRepo, ref, old, new string) ([]*repo3.CrossReference, error) {
+					Expect(ref).To(Equal(task.Ref.Name))
+					Expect(old).To(Equal(oldHash))
+					Expect(new).To(Equal(newHash))
+					return xrefs, nil
+				}
+				mockRepo.EXPECT().RefGet(repo3.MakeIssueReference(1)).Return("issue_tip", nil)
+				mockRepoSyncInfoKeeper.EXPECT().AddCrossReferences(task.RepoName, xrefs[0]).Return(nil)
+				err := rs.do(task)
+				Expect(err).To(BeNil())
+				Expect(xrefs[0].PostHash).To(Equal("issue_tip"))
+			})
+
+			It("should index a close of an issue that does not exist locally without a post hash", func() {
+				xrefs := []*repo3.CrossReference{{Target: repo3.MakeIssueReference(1), Type: repo3.CrossRefSourceCommit, Source: task.Ref.Name, Hash: newHash, Closes: true}}
+				rs.GetCrossReferences = func(repo3.LocalRepo, string, string, string) ([]*repo3.CrossReference, error) {
+					return xrefs, nil
+				}
+				mockRepo.EXPECT().RefGet(repo3.MakeIssueReference(1)).Return("", repo3.ErrRefNotFound)
+				mockRepoSyncInfoKeeper.EXPECT().AddCrossReferences(task.RepoName, xrefs[0]).Return(nil)
+				err := rs.do(task)
+				Expect(err).To(BeNil())
+				Expect(xrefs[0].PostHash).To(BeEmpty())
+			})
+
+			It("should not return error when unable to index cross references", func() {
+				rs.GetCrossReferences = func(repo3.LocalRepo, string, string, string) ([]*repo3.CrossReference, error) {
+					return nil, fmt.Errorf("error")
+				}
+				err := rs.do(task)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		When("target repo is tracked", func() {
 			It("should return error when unable to update tracked repo update height", func() {
 				task := &types3.RefTask{RepoName: "repo1", Ref: &types.PushedReference{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash}, Height: 10}
@@ -28,9 +28,10 @@ import (
 )
 
 var (
-	ErrNotAnAncestor = fmt.Errorf("not an ancestor")
-	ErrPathNotFound  = fmt.Errorf("path not found")
-	ErrPathNotAFile  = fmt.Errorf("path is not a file")
+	ErrNotAnAncestor   = fmt.Errorf("not an ancestor")
+	ErrAncestryUnknown = fmt.Errorf("ancestry unknown: history is shallow")
+	ErrPathNotFound    = plumbing2.ErrPathNotFound
+	ErrPathNotAFile    = fmt.Errorf("path is not a file")
 )
 
 // Get opens a local repository and returns a handle.
@@ -227,6 +228,8 @@ func (r *Repo) SetConfig(cfg *config.Config) error {
 // IsAncestor checks whether commitA is an ancestor to commitB.
 // It returns ErrNotAncestor when not an ancestor.
 // It returns ErrObjectNotFound if commit A or B does not exist.
+// It returns ErrAncestryUnknown if the repository is shallow and
+// commit A was not found before reaching a shallow commit.
 func (r *Repo) IsAncestor(commitA, commitB string) error {
 	cA, err := r.CommitObject(plumbing.NewHash(commitA))
 	if err != nil {
@@ -238,6 +241,13 @@ func (r *Repo) IsAncestor(commitA, commitB string) error {
 		return err
 	}
 
+	shallows, err := r.Storer.Shallow()
+	if err != nil {
+		return err
+	} else if len(shallows) > 0 {
+		return isAncestorInShallowRepo(cA, cB, shallows)
+	}
+
 	yes, err := cA.IsAncestor(cB)
 	if err != nil {
 		return err
@@ -248,6 +258,50 @@ func (r *Repo) IsAncestor(commitA, commitB string) error {
 	return err
 }
 
+// isAncestorInShallowRepo checks whether commit a is an ancestor to commit b
+// in a shallow repository. The parents of shallow commits are not available
+// locally, so they are not walked.
+func isAncestorInShallowRepo(a, b *object.Commit, shallows []plumbing.Hash) error {
+	isShallow := make(map[plumbing.Hash]bool, len(shallows))
+	for _, h := range shallows {
+		isShallow[h] = true
+	}
+
+	var boundaryReached bool
+	seen := map[plumbing.Hash]bool{}
+	queue := []*object.Commit{b}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if seen[c.Hash] {
+			continue
+		}
+		seen[c.Hash] = true
+
+		if c.Hash == a.Hash {
+			return nil
+		}
+
+		if isShallow[c.Hash] {
+			boundaryReached = true
+			continue
+		}
+
+		if err := c.Parents().ForEach(func(parent *object.Commit) error {
+			queue = append(queue, parent)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if boundaryReached {
+		return ErrAncestryUnknown
+	}
+
+	return ErrNotAnAncestor
+}
+
 // GetReferences returns all references in the repo
 func (r *Repo) GetReferences() (refs []plumbing.ReferenceName, err error) {
 	itr, err := r.References()
@@ -293,18 +347,10 @@ func (r *Repo) GetNamespace() *state.Namespace {
 	return r.Namespace
 }
 
-// IsContributor checks whether a push key is a contributor to either
-// the repository or its namespace
-func (r *Repo) IsContributor(pushKeyID string) (isContrib bool) {
-	if s := r.GetState(); s != nil {
-		if s.Contributors.Has(pushKeyID) {
-			return true
-		}
-	}
-	if ns := r.GetNamespace(); ns != nil {
-		return ns.Contributors.Has(pushKeyID)
-	}
-	return
+// IsContributor checks whether a push key is an active contributor to
+// either the repository or its namespace at the given block height
+func (r *Repo) IsContributor(pushKeyID string, height uint64) bool {
+	return state.IsContributor(pushKeyID, r.GetState(), r.GetNamespace(), height)
 }
 
 // GetRemoteURLs returns the remote URLS of the repository.
@@ -261,21 +261,67 @@ var _ = Describe("Repo", func() {
 			Expect(err).ToNot(BeNil())
 			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
 		})
+
+		When("the repository is shallow", func() {
+			var c1, c2, c3, orphan string
+
+			BeforeEach(func() {
+				testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+				c1 = testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+				c2 = testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				testutil2.AppendCommit(path, "file.txt", "line 3", "commit 3")
+				c3 = testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				testutil2.CreateCheckoutOrphanBranch(path, "orphan")
+				testutil2.AppendCommit(path, "file.txt", "orphan", "orphan commit")
+				orphan = testutil2.GetRecentCommitHash(path, "refs/heads/orphan")
+				Expect(rr.MarkShallowCommit(r, c2)).To(BeNil())
+			})
+
+			It("should return no error when the ancestor is reached before the shallow commit", func() {
+				Expect(r.IsAncestor(c2, c3)).To(BeNil())
+			})
+
+			It("should return ErrAncestryUnknown when the shallow commit is reached first", func() {
+				Expect(r.IsAncestor(c1, c3)).To(Equal(repo.ErrAncestryUnknown))
+				Expect(r.IsAncestor(orphan, c3)).To(Equal(repo.ErrAncestryUnknown))
+			})
+
+			It("should return ErrNotAnAncestor when no shallow commit is reached", func() {
+				Expect(r.IsAncestor(c3, orphan)).To(Equal(repo.ErrNotAnAncestor))
+			})
+		})
 	})
 
 	Describe(".IsContributor", func() {
 		It("should return true when push key is a repo contributor", func() {
 			r.(*repo.Repo).State = &state2.Repository{Contributors: map[string]*state2.RepoContributor{key.PushAddr().String(): {}}}
-			Expect(r.IsContributor(key.PushAddr().String())).To(BeTrue())
+			Expect(r.IsContributor(key.PushAddr().String(), 1)).To(BeTrue())
 		})
 
 		It("should return true when push key is a namespace contributor", func() {
 			r.(*repo.Repo).Namespace = &state2.Namespace{Contributors: map[string]*state2.BaseContributor{key.PushAddr().String(): {}}}
-			Expect(r.IsContributor(key.PushAddr().String())).To(BeTrue())
+			Expect(r.IsContributor(key.PushAddr().String(), 1)).To(BeTrue())
 		})
 
 		It("should return false when push key is a namespace or repo contributor", func() {
-			Expect(r.IsContributor(key.PushAddr().String())).To(BeFalse())
+			Expect(r.IsContributor(key.PushAddr().String(), 1)).To(BeFalse())
+		})
+
+		It("should return false when all the repo contributor's policies have expired", func() {
+			r.(*repo.Repo).State = &state2.Repository{Contributors: map[string]*state2.RepoContributor{key.PushAddr().String(): {
+				Policies: []*state2.ContributorPolicy{{Object: "refs/heads/dev", Action: "write", EndHeight: 10}},
+			}}}
+			Expect(r.IsContributor(key.PushAddr().String(), 9)).To(BeTrue())
+			Expect(r.IsContributor(key.PushAddr().String(), 10)).To(BeFalse())
+		})
+
+		It("should return false when the namespace contributor's policies have not started", func() {
+			r.(*repo.Repo).Namespace = &state2.Namespace{Contributors: map[string]*state2.BaseContributor{key.PushAddr().String(): {
+				Policies: []*state2.ContributorPolicy{{Object: "refs/heads/dev", Action: "write", StartHeight: 10}},
+			}}}
+			Expect(r.IsContributor(key.PushAddr().String(), 9)).To(BeFalse())
+			Expect(r.IsContributor(key.PushAddr().String(), 10)).To(BeTrue())
 		})
 	})
 
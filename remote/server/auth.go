@@ -26,6 +26,7 @@ import (
 
 var (
 	ErrPushTokenRequired = fmt.Errorf("push token must be provided")
+	ErrRepoArchived      = fmt.Errorf("repository is archived")
 	fe                   = errors2.FieldErrorWithIndex
 )
 
@@ -78,7 +79,14 @@ func authenticate(
 			detail.RepoNamespace, detail.Nonce
 	}
 
-	return policy.GetPolicyEnforcer(policy.MakePusherPolicyGroups(txDetails[0].PushKeyID, repoState, namespace)), nil
+	// Get the current block height; contributor policies may be time-bounded
+	bi, err := keepers.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get last block info")
+	}
+
+	groups := policy.MakePusherPolicyGroups(txDetails[0].PushKeyID, repoState, namespace, uint64(bi.Height))
+	return policy.GetPolicyEnforcer(groups), nil
 }
 
 // isPullRequest checks whether a request is a pull request
@@ -104,6 +112,22 @@ func (sv *Server) handleAuth(r *http.Request, repo *state.Repository, namespace
 	// Get the request
 	tokens, _, _ := r.BasicAuth()
 
+	return sv.authenticatePushTokens(tokens, repo, namespace)
+}
+
+// authenticatePushTokens decodes and authenticates comma-separated push request token(s).
+//
+// ARGS:
+// - tokens: The push request token(s)
+// - repo: The target repository
+// - namespace: The namespace object. Nil means default namespace.
+func (sv *Server) authenticatePushTokens(tokens string, repo *state.Repository, namespace *state.Namespace) (txDetails []*remotetypes.TxDetail, polEnforcer policy.EnforcerFunc, err error) {
+
+	// Archived repositories are read-only
+	if repo.Archived {
+		return nil, nil, ErrRepoArchived
+	}
+
 	// We expect push token(s) to be provided
 	if tokens == "" {
 		return nil, nil, ErrPushTokenRequired
@@ -40,6 +40,7 @@ var _ = Describe("Auth", func() {
 	var repoName, path string
 	var ctrl *gomock.Controller
 	var mockLogic *mocks.MockLogic
+	var mockSysKeeper *mocks.MockSystemKeeper
 	var key, key2 *ed25519.Key
 	var svr *Server
 
@@ -59,10 +60,12 @@ var _ = Describe("Auth", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mocksObjs := testutil.Mocks(ctrl)
 		mockLogic = mocksObjs.Logic
+		mockSysKeeper = mocksObjs.SysKeeper
 
 		mockDHT := mocks.NewMockDHT(ctrl)
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeGit, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeLFS, gomock.Any())
 
 		mockMempool := mocks.NewMockMempool(ctrl)
 		mockBlockGetter := mocks.NewMockBlockGetter(ctrl)
@@ -154,6 +157,14 @@ var _ = Describe("Auth", func() {
 			Expect(err.Error()).To(Equal("token error (refs/heads/m): bad error"))
 		})
 
+		It("should return error when unable to get last block info", func() {
+			txD := &types.TxDetail{RepoName: "repo1", RepoNamespace: "ns1", Nonce: 1, PushKeyID: key.PushAddr().String()}
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(nil, fmt.Errorf("error"))
+			_, err := authenticate([]*types.TxDetail{txD}, state.BareRepository(), &state.Namespace{}, mockLogic, testCheckTxDetail(nil))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("failed to get last block info: error"))
+		})
+
 		Context("on success", func() {
 			When("push key is a repo contributor", func() {
 				BeforeEach(func() {
@@ -162,6 +173,7 @@ var _ = Describe("Auth", func() {
 					txDetails := []*types.TxDetail{txD, txD2}
 					repoState := state.BareRepository()
 					repoState.Contributors = map[string]*state.RepoContributor{key.PushAddr().String(): {}}
+					mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 1}, nil)
 					_, err = authenticate(txDetails, repoState, &state.Namespace{}, mockLogic, testCheckTxDetail(nil))
 				})
 
@@ -177,6 +189,7 @@ var _ = Describe("Auth", func() {
 					txDetails := []*types.TxDetail{txD, txD2}
 					ns := &state.Namespace{}
 					ns.Contributors = map[string]*state.BaseContributor{key.PushAddr().String(): {}}
+					mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 1}, nil)
 					_, err = authenticate(txDetails, state.BareRepository(), ns, mockLogic, testCheckTxDetail(nil))
 				})
 
@@ -218,7 +231,7 @@ var _ = Describe("Auth", func() {
 					Policies: []*state.ContributorPolicy{contribPolicy},
 				}
 
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, ns)
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, ns, 1)
 			})
 
 			Specify("that each policy group is not empty", func() {
@@ -258,7 +271,7 @@ var _ = Describe("Auth", func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "some_subject", Object: "refs/heads/master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 			})
 
 			It("should not include the policy", func() {
@@ -272,7 +285,7 @@ var _ = Describe("Auth", func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "all", Object: "refs/heads/master", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 			})
 
 			It("should include the policy", func() {
@@ -286,7 +299,7 @@ var _ = Describe("Auth", func() {
 				repoState := state.BareRepository()
 				repoPolicy = &state.Policy{Subject: "all", Object: "origin", Action: "write"}
 				repoState.Config.Policies = append(repoState.Config.Policies, repoPolicy)
-				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace())
+				polGroups = policy.MakePusherPolicyGroups(key.PushAddr().String(), repoState, state.BareNamespace(), 1)
 			})
 
 			It("should not include the policy", func() {
@@ -307,6 +320,15 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
+		When("the repository is archived", func() {
+			It("should return error", func() {
+				req := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(nil))
+				_, _, err := svr.handleAuth(req, &state.Repository{Archived: true}, &state.Namespace{})
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(Equal(ErrRepoArchived))
+			})
+		})
+
 		When("a push token is not provided", func() {
 			It("should return error", func() {
 				req := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(nil))
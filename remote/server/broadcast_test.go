@@ -45,6 +45,7 @@ var _ = Describe("Reactor", func() {
 		mockDHT := mocks.NewMockDHT(ctrl)
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeGit, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeLFS, gomock.Any())
 
 		svr = New(cfg, ":9000", mockObjects.Logic, mockDHT, mocks.NewMockMempool(ctrl), mockObjects.Service, mocks.NewMockBlockGetter(ctrl))
 
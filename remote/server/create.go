@@ -13,6 +13,22 @@ func (sv *Server) InitRepository(name string) error {
 	return repo.InitRepository(name, sv.rootDir, sv.gitBinPath)
 }
 
+// DeleteRepository removes the local data of a repository.
+// It is a no-op if the repository does not exist locally.
+func (sv *Server) DeleteRepository(name string) error {
+	return os.RemoveAll(filepath.Join(sv.rootDir, name))
+}
+
+// RenameRepository moves the local data of a repository to a new name.
+// It is a no-op if the repository does not exist locally.
+func (sv *Server) RenameRepository(name, newName string) error {
+	path := filepath.Join(sv.rootDir, name)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	return os.Rename(path, filepath.Join(sv.rootDir, newName))
+}
+
 // HasRepository returns true if a valid repository exist
 // for the given name
 func (sv *Server) HasRepository(name string) bool {
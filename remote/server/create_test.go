@@ -34,6 +34,7 @@ var _ = Describe("Create", func() {
 		mockDHT = mocks.NewMockDHT(ctrl)
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeGit, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeLFS, gomock.Any())
 
 		mockMempool = mocks.NewMockMempool(ctrl)
 		mockBlockGetter = mocks.NewMockBlockGetter(ctrl)
@@ -70,6 +71,45 @@ var _ = Describe("Create", func() {
 		})
 	})
 
+	Describe(".DeleteRepository", func() {
+		When("the repository exists", func() {
+			It("should remove the repository", func() {
+				err := repoMgr.InitRepository("my_repo")
+				Expect(err).To(BeNil())
+				err = repoMgr.DeleteRepository("my_repo")
+				Expect(err).To(BeNil())
+				Expect(repoMgr.HasRepository("my_repo")).To(BeFalse())
+			})
+		})
+
+		When("the repository does not exist", func() {
+			It("should return nil", func() {
+				err := repoMgr.DeleteRepository("my_repo")
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe(".RenameRepository", func() {
+		When("the repository exists", func() {
+			It("should move the repository to the new name", func() {
+				err := repoMgr.InitRepository("my_repo")
+				Expect(err).To(BeNil())
+				err = repoMgr.RenameRepository("my_repo", "my_repo2")
+				Expect(err).To(BeNil())
+				Expect(repoMgr.HasRepository("my_repo")).To(BeFalse())
+				Expect(repoMgr.HasRepository("my_repo2")).To(BeTrue())
+			})
+		})
+
+		When("the repository does not exist", func() {
+			It("should return nil", func() {
+				err := repoMgr.RenameRepository("my_repo", "my_repo2")
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
 	Describe(".HasRepository", func() {
 		When("repo does not exist", func() {
 			It("should return false", func() {
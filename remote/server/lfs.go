@@ -0,0 +1,442 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/make-os/kit/net/dht/announcer"
+	"github.com/make-os/kit/remote/lfs"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/policy"
+	pushtypes "github.com/make-os/kit/remote/push/types"
+	remotetypes "github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/types/state"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+var (
+	ErrLFSObjectUnavailable = fmt.Errorf("object is not available")
+	ErrLFSTokenRepoMismatch = fmt.Errorf("push token does not target the repository")
+
+	// lfsFetchTimeout is the maximum duration for fetching an LFS object from the DHT
+	lfsFetchTimeout = 5 * time.Minute
+
+	// lfsMaxConcurrentFetches is the maximum number of LFS objects
+	// that can be fetched from the DHT at the same time
+	lfsMaxConcurrentFetches = 8
+
+	// lfsBatchFetchWait is the maximum duration a batch request waits for
+	// the objects it requested to be fetched from the DHT
+	lfsBatchFetchWait = 30 * time.Second
+)
+
+// isLFSRequest checks whether the path of a request targets the LFS API.
+// Expected path: /<namespace>/<repo>/info/lfs/objects/<batch|oid>
+func isLFSRequest(pathParts []string) bool {
+	return len(pathParts) > 4 && pathParts[2] == "info" && pathParts[3] == "lfs" && pathParts[4] == "objects"
+}
+
+// handleLFSRequest handles LFS batch API and object transfer requests
+func (sv *Server) handleLFSRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	repoName string,
+	repoState *state.Repository,
+	namespace *state.Namespace,
+	pathParts []string) {
+
+	if len(pathParts) != 6 {
+		writeLFSError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if pathParts[5] == "batch" {
+		sv.handleLFSBatch(w, r, repoName, repoState, namespace)
+		return
+	}
+
+	sv.handleLFSObject(w, r, repoName, repoState, namespace, pathParts[5])
+}
+
+// handleLFSBatch handles LFS batch API requests.
+// Download requests do not require authentication. Upload requests
+// must be authenticated with push tokens.
+func (sv *Server) handleLFSBatch(
+	w http.ResponseWriter,
+	r *http.Request,
+	repoName string,
+	repoState *state.Repository,
+	namespace *state.Namespace) {
+
+	if r.Method != http.MethodPost {
+		writeLFSError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req lfs.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeLFSError(w, http.StatusUnprocessableEntity, "malformed batch request")
+		return
+	}
+
+	if len(req.Objects) > lfs.MaxBatchObjects {
+		writeLFSError(w, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("batch request cannot have more than %d objects", lfs.MaxBatchObjects))
+		return
+	}
+
+	if len(req.Transfers) > 0 && !funk.ContainsString(req.Transfers, lfs.TransferBasic) {
+		writeLFSError(w, http.StatusUnprocessableEntity, "unsupported transfer adapter")
+		return
+	}
+
+	store := lfs.GetStore(sv.getRepoPath(repoName))
+
+	switch req.Operation {
+	case lfs.OperationUpload:
+		if !sv.authenticateLFSRequest(w, r, repoName, repoState, namespace) {
+			return
+		}
+	case lfs.OperationDownload:
+		sv.fetchMissingLFSObjects(repoName, store, req.Objects)
+	default:
+		writeLFSError(w, http.StatusUnprocessableEntity, "unknown operation")
+		return
+	}
+
+	resp := lfs.BatchResponse{Transfer: lfs.TransferBasic, Objects: []*lfs.Object{}}
+	for _, obj := range req.Objects {
+		res := &lfs.Object{Oid: obj.Oid, Size: obj.Size}
+		resp.Objects = append(resp.Objects, res)
+
+		if !lfs.IsValidOid(obj.Oid) || obj.Size < 0 || obj.Size > lfs.MaxObjectSize {
+			res.Error = &lfs.ObjectError{Code: http.StatusUnprocessableEntity, Message: "invalid object"}
+			continue
+		}
+
+		href := makeLFSObjectURL(r, obj.Oid)
+
+		// For uploads, objects that already exist need no action
+		if req.Operation == lfs.OperationUpload {
+			if !store.Has(obj.Oid) {
+				res.Actions = map[string]*lfs.Action{lfs.OperationUpload: {
+					Href:   href,
+					Header: map[string]string{"Authorization": r.Header.Get("Authorization")},
+				}}
+			}
+			continue
+		}
+
+		if !store.Has(obj.Oid) {
+			res.Error = &lfs.ObjectError{Code: http.StatusNotFound, Message: ErrLFSObjectUnavailable.Error()}
+			continue
+		}
+		res.Actions = map[string]*lfs.Action{lfs.OperationDownload: {Href: href}}
+	}
+
+	w.Header().Set("Content-Type", lfs.MediaType)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// fetchMissingLFSObjects fetches objects that do not exist locally from the
+// DHT. Only objects referenced by pointer files in the repository are fetched.
+// It waits for the fetches for at most lfsBatchFetchWait; Fetches that take
+// longer continue in the background so that a later request can find them.
+func (sv *Server) fetchMissingLFSObjects(repoName string, store lfs.Store, objects []*lfs.Object) {
+	if sv.dht == nil {
+		return
+	}
+
+	var missing []string
+	for _, obj := range objects {
+		if lfs.IsValidOid(obj.Oid) && !store.Has(obj.Oid) {
+			missing = append(missing, obj.Oid)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	targetRepo, err := sv.GetRepo(repoName)
+	if err != nil {
+		sv.log.Debug("Failed to open target repository", "Repo", repoName, "Err", err)
+		return
+	}
+
+	pointers, err := lfs.GetRepoPointers(targetRepo)
+	if err != nil {
+		sv.log.Debug("Failed to get pointer files", "Repo", repoName, "Err", err)
+		return
+	}
+
+	var fetches []<-chan struct{}
+	for _, oid := range missing {
+		if ptr, ok := pointers[oid]; ok {
+			fetches = append(fetches, sv.startLFSFetch(repoName, store, ptr))
+		}
+	}
+
+	timeout := time.After(lfsBatchFetchWait)
+	for _, done := range fetches {
+		select {
+		case <-done:
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// startLFSFetch fetches the object of a pointer file from the DHT in the
+// background and returns a channel that is closed when the fetch ends.
+// Requests for an object that is being fetched share the same fetch and
+// at most lfsMaxConcurrentFetches objects are fetched at the same time.
+func (sv *Server) startLFSFetch(repoName string, store lfs.Store, ptr *lfs.Pointer) <-chan struct{} {
+	key := repoName + "/" + ptr.Oid
+
+	sv.lfsFetchesMtx.Lock()
+	defer sv.lfsFetchesMtx.Unlock()
+	if done, ok := sv.lfsFetches[key]; ok {
+		return done
+	}
+
+	done := make(chan struct{})
+	sv.lfsFetches[key] = done
+
+	go func() {
+		sv.lfsFetchSem <- struct{}{}
+		if err := sv.fetchLFSObject(repoName, store, ptr.Oid, ptr.Size); err != nil {
+			sv.log.Debug("Unable to fetch LFS object", "Repo", repoName, "Oid", ptr.Oid, "Err", err)
+		}
+		<-sv.lfsFetchSem
+
+		sv.lfsFetchesMtx.Lock()
+		delete(sv.lfsFetches, key)
+		sv.lfsFetchesMtx.Unlock()
+		close(done)
+	}()
+
+	return done
+}
+
+// handleLFSObject handles LFS object download (GET) and upload (PUT) requests.
+// Uploaded objects are announced on the DHT.
+func (sv *Server) handleLFSObject(
+	w http.ResponseWriter,
+	r *http.Request,
+	repoName string,
+	repoState *state.Repository,
+	namespace *state.Namespace,
+	oid string) {
+
+	if !lfs.IsValidOid(oid) {
+		writeLFSError(w, http.StatusUnprocessableEntity, lfs.ErrInvalidOid.Error())
+		return
+	}
+
+	store := lfs.GetStore(sv.getRepoPath(repoName))
+
+	switch r.Method {
+	case http.MethodGet:
+		obj, err := store.Open(oid)
+		if err != nil {
+			if err == lfs.ErrObjectNotFound {
+				writeLFSError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			writeLFSError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		defer obj.Close()
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.Copy(w, obj)
+
+	case http.MethodPut:
+		if !sv.authenticateLFSRequest(w, r, repoName, repoState, namespace) {
+			return
+		}
+
+		if r.ContentLength > lfs.MaxObjectSize {
+			writeLFSError(w, http.StatusRequestEntityTooLarge, "object is too large")
+			return
+		}
+
+		body := http.MaxBytesReader(w, r.Body, lfs.MaxObjectSize)
+		if err := store.Put(oid, r.ContentLength, body); err != nil {
+			if err == lfs.ErrOidMismatch || err == lfs.ErrSizeMismatch {
+				writeLFSError(w, http.StatusUnprocessableEntity, err.Error())
+				return
+			}
+			sv.log.Error("Failed to store LFS object", "Repo", repoName, "Oid", oid, "Err", err)
+			writeLFSError(w, http.StatusInternalServerError, "failed to store object")
+			return
+		}
+
+		if sv.dht != nil {
+			oidBz, _ := hex.DecodeString(oid)
+			sv.Announce(announcer.ObjTypeLFS, repoName, oidBz, nil)
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeLFSError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// authenticateLFSRequest authenticates the push tokens of an LFS request.
+// The tokens must target the repository and their push key must be allowed
+// to write to the references of the tokens.
+// It writes an error response and returns false if authentication failed.
+func (sv *Server) authenticateLFSRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	repoName string,
+	repoState *state.Repository,
+	namespace *state.Namespace) bool {
+	tokens, _, _ := r.BasicAuth()
+	txDetails, polEnforcer, err := sv.authenticatePushTokens(tokens, repoState, namespace)
+	if err == nil {
+		err = sv.checkLFSPushTokens(repoName, repoState, namespace, txDetails, polEnforcer)
+	}
+	if err != nil {
+		if err == ErrPushTokenRequired {
+			w.Header().Set("LFS-Authenticate", "Basic")
+			writeLFSError(w, http.StatusUnauthorized, err.Error())
+			return false
+		}
+		writeLFSError(w, http.StatusForbidden, err.Error())
+		return false
+	}
+	return true
+}
+
+// checkLFSPushTokens checks that the given push tokens target the given
+// repository and that their push key is allowed to write to the token references.
+func (sv *Server) checkLFSPushTokens(
+	repoName string,
+	repoState *state.Repository,
+	namespace *state.Namespace,
+	txDetails []*remotetypes.TxDetail,
+	polEnforcer policy.EnforcerFunc) error {
+
+	// Get the current block height; contributor policies may be time-bounded
+	bi, err := sv.logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to get last block info")
+	}
+
+	for _, detail := range txDetails {
+		nsName := detail.RepoNamespace
+		if nsName == "" {
+			nsName = remotetypes.DefaultNS
+		}
+		if target, _, ok := sv.resolveRepoName(nsName, detail.RepoName); !ok || target != repoName {
+			return ErrLFSTokenRepoMismatch
+		}
+
+		pusher := detail.PushKeyID
+		refState := repoState.References.Get(detail.Reference)
+		isContributor := state.IsContributor(pusher, repoState, namespace, uint64(bi.Height))
+		err := policy.CheckPolicy(
+			polEnforcer,
+			detail.Reference,
+			!refState.IsNil() && refState.Creator.String() == pusher,
+			pusher,
+			isContributor,
+			policy.PolicyActionWrite)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchLFSObject fetches an LFS object from the DHT, adds it to the
+// store and announces it.
+func (sv *Server) fetchLFSObject(repoName string, store lfs.Store, oid string, size int64) error {
+	if sv.dht == nil {
+		return ErrLFSObjectUnavailable
+	}
+
+	oidBz, err := hex.DecodeString(oid)
+	if err != nil {
+		return lfs.ErrInvalidOid
+	}
+
+	ctx, cn := context.WithTimeout(context.Background(), lfsFetchTimeout)
+	defer cn()
+	obj, err := sv.dht.ObjectStreamer().GetLFSObject(ctx, repoName, oidBz)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	if err = store.Put(oid, size, obj); err != nil {
+		return err
+	}
+
+	sv.Announce(announcer.ObjTypeLFS, repoName, oidBz, nil)
+
+	return nil
+}
+
+// CheckLFSObjects ensures the LFS objects referenced by pointer files
+// in the pushed commits of a push note are available locally. Objects
+// that do not exist locally are fetched from the DHT.
+func (sv *Server) CheckLFSObjects(note pushtypes.PushNote) error {
+	repo := note.GetTargetRepo()
+	store := lfs.GetStore(repo.GetPath())
+	for _, ref := range note.GetPushedReferences() {
+		if plumbing.IsZeroHash(ref.NewHash) {
+			continue
+		}
+
+		pointers, err := lfs.GetPointers(repo, ref.NewHash, ref.OldHash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get pointer files of reference (%s)", ref.Name)
+		}
+
+		for _, ptr := range pointers {
+			if store.Has(ptr.Oid) {
+				continue
+			}
+			if err := sv.fetchLFSObject(repo.GetName(), store, ptr.Oid, ptr.Size); err != nil {
+				return errors.Wrapf(err, "%s: LFS object (%s) is unavailable", ref.Name, ptr.Oid)
+			}
+		}
+	}
+	return nil
+}
+
+// checkLFSObject implements dht.CheckFunc for checking the
+// existence of an LFS object in the given repository.
+func (sv *Server) checkLFSObject(repo string, key []byte) bool {
+	return lfs.GetStore(sv.getRepoPath(repo)).Has(hex.EncodeToString(key))
+}
+
+// makeLFSObjectURL returns the transfer URL of an object
+// relative to the batch API URL of the given request
+func makeLFSObjectURL(r *http.Request, oid string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	path := strings.TrimSuffix(r.URL.Path, "/batch")
+	return fmt.Sprintf("%s://%s%s/%s", scheme, r.Host, path, oid)
+}
+
+// writeLFSError writes an LFS API error response
+func writeLFSError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", lfs.MediaType)
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(lfs.ErrorResponse{Message: msg})
+}
@@ -0,0 +1,402 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/net/dht/announcer"
+	"github.com/make-os/kit/remote/lfs"
+	"github.com/make-os/kit/remote/policy"
+	"github.com/make-os/kit/remote/push/types"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	remotetypes "github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/remote/validation"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/util"
+	io2 "github.com/make-os/kit/util/io"
+	"github.com/make-os/kit/util/pushtoken"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/phayes/freeport"
+)
+
+var _ = Describe("LFS", func() {
+	var err error
+	var cfg *config.AppConfig
+	var svr *Server
+	var ctrl *gomock.Controller
+	var mockObjects *testutil.MockObjects
+	var mockDHT *mocks.MockDHT
+	var repoName string
+	var repoState *state.Repository
+	var content = "large file content"
+	var oidArr = sha256.Sum256([]byte(content))
+	var oid = hex.EncodeToString(oidArr[:])
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		ctrl = gomock.NewController(GinkgoT())
+		mockObjects = testutil.Mocks(ctrl)
+		mockDHT = mocks.NewMockDHT(ctrl)
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeGit, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeLFS, gomock.Any())
+		port, _ := freeport.GetFreePort()
+		svr = New(cfg, fmt.Sprintf(":%d", port), mockObjects.Logic, mockDHT, nil, mockObjects.Service, nil)
+
+		repoName = util.RandString(5)
+		testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+		repoState = state.BareRepository()
+		repoState.CreatedAt = 1
+	})
+
+	AfterEach(func() {
+		svr.Stop()
+		ctrl.Finish()
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	makePushToken := func(detail *remotetypes.TxDetail, policies []*state.Policy) string {
+		key := ed25519.NewKeyFromIntSeed(1)
+		svr.authenticate = func([]*remotetypes.TxDetail, *state.Repository, *state.Namespace, core.Keepers,
+			validation.TxDetailChecker) (policy.EnforcerFunc, error) {
+			return policy.GetPolicyEnforcer([][]*state.Policy{policies}), nil
+		}
+		mockObjects.SysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 1}, nil).AnyTimes()
+		detail.PushKeyID = key.PushAddr().String()
+		return pushtoken.MakeFromKey(key, detail)
+	}
+
+	allowPush := func() string {
+		return makePushToken(&remotetypes.TxDetail{RepoName: repoName, Reference: "refs/heads/master"}, []*state.Policy{
+			{Subject: ed25519.NewKeyFromIntSeed(1).PushAddr().String(), Object: "refs/heads", Action: policy.PolicyActionWrite},
+		})
+	}
+
+	commitPointer := func() {
+		ptr := &lfs.Pointer{Oid: oid, Size: int64(len(content))}
+		testutil2.AppendCommit(filepath.Join(cfg.GetRepoRoot(), repoName), "file.bin", ptr.String(), "commit 1")
+	}
+
+	do := func(method, path string, body interface{}, user string) *httptest.ResponseRecorder {
+		var bz []byte
+		switch v := body.(type) {
+		case string:
+			bz = []byte(v)
+		case nil:
+		default:
+			bz, _ = json.Marshal(v)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(bz))
+		if user != "" {
+			req.SetBasicAuth(user, "-")
+		}
+		rec := httptest.NewRecorder()
+		svr.gitRequestsHandler(rec, req)
+		return rec
+	}
+
+	decodeBatch := func(rec *httptest.ResponseRecorder) *lfs.BatchResponse {
+		var resp lfs.BatchResponse
+		Expect(json.NewDecoder(rec.Body).Decode(&resp)).To(BeNil())
+		return &resp
+	}
+
+	Describe(".isLFSRequest", func() {
+		It("should return true only for paths under info/lfs/objects", func() {
+			Expect(isLFSRequest(strings.Split("r/repo1/info/lfs/objects/batch", "/"))).To(BeTrue())
+			Expect(isLFSRequest(strings.Split("r/repo1/info/refs", "/"))).To(BeFalse())
+			Expect(isLFSRequest(strings.Split("r/repo1/info/lfs", "/"))).To(BeFalse())
+		})
+	})
+
+	Describe("batch API", func() {
+		BeforeEach(func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState)
+		})
+
+		It("should return a download action for an object that exists locally", func() {
+			store := lfs.GetStore(svr.getRepoPath(repoName))
+			Expect(store.Put(oid, -1, bytes.NewBufferString(content))).To(BeNil())
+			rec := do("POST", "/r/"+repoName+".git/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationDownload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(Equal(lfs.MediaType))
+			resp := decodeBatch(rec)
+			Expect(resp.Objects).To(HaveLen(1))
+			Expect(resp.Objects[0].Error).To(BeNil())
+			Expect(resp.Objects[0].Actions[lfs.OperationDownload].Href).To(Equal(
+				"http://example.com/r/" + repoName + ".git/info/lfs/objects/" + oid))
+		})
+
+		It("should return an object error when an object could not be fetched from the network", func() {
+			commitPointer()
+			mockStreamer := mocks.NewMockStreamer(ctrl)
+			mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer)
+			mockStreamer.EXPECT().GetLFSObject(gomock.Any(), repoName, oidArr[:]).Return(nil, fmt.Errorf("error"))
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationDownload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			resp := decodeBatch(rec)
+			Expect(resp.Objects[0].Actions).To(BeEmpty())
+			Expect(resp.Objects[0].Error.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should fetch an object that does not exist locally from the network and announce it", func() {
+			commitPointer()
+			mockStreamer := mocks.NewMockStreamer(ctrl)
+			mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer)
+			obj, _ := ioutil.TempFile(os.TempDir(), "")
+			obj.WriteString(content)
+			obj.Seek(0, 0)
+			mockStreamer.EXPECT().GetLFSObject(gomock.Any(), repoName, oidArr[:]).Return(obj, nil)
+			mockDHT.EXPECT().Announce(announcer.ObjTypeLFS, repoName, oidArr[:], nil)
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationDownload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			resp := decodeBatch(rec)
+			Expect(resp.Objects[0].Actions).To(HaveKey(lfs.OperationDownload))
+			Expect(lfs.GetStore(svr.getRepoPath(repoName)).Has(oid)).To(BeTrue())
+		})
+
+		It("should not fetch an object that is not referenced by a pointer file in the repository", func() {
+			testutil2.AppendCommit(filepath.Join(cfg.GetRepoRoot(), repoName), "file.txt", "hello", "commit 1")
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationDownload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			resp := decodeBatch(rec)
+			Expect(resp.Objects[0].Actions).To(BeEmpty())
+			Expect(resp.Objects[0].Error.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should not wait for a fetch longer than the batch fetch wait duration", func() {
+			commitPointer()
+			oldWait := lfsBatchFetchWait
+			lfsBatchFetchWait = 10 * time.Millisecond
+			defer func() { lfsBatchFetchWait = oldWait }()
+			release := make(chan struct{})
+			mockStreamer := mocks.NewMockStreamer(ctrl)
+			mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer)
+			mockStreamer.EXPECT().GetLFSObject(gomock.Any(), repoName, oidArr[:]).
+				DoAndReturn(func(context.Context, string, []byte) (io2.ReadSeekerCloser, error) {
+					<-release
+					return nil, fmt.Errorf("error")
+				})
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationDownload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			resp := decodeBatch(rec)
+			Expect(resp.Objects[0].Error.Code).To(Equal(http.StatusNotFound))
+			close(release)
+			Eventually(func() int {
+				svr.lfsFetchesMtx.Lock()
+				defer svr.lfsFetchesMtx.Unlock()
+				return len(svr.lfsFetches)
+			}).Should(Equal(0))
+		})
+
+		It("should return 413 when the batch request has too many objects", func() {
+			objects := make([]*lfs.Object, lfs.MaxBatchObjects+1)
+			for i := range objects {
+				objects[i] = &lfs.Object{Oid: oid, Size: int64(len(content))}
+			}
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationDownload,
+				Objects:   objects,
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		})
+
+		It("should return 403 when the push token targets another repository", func() {
+			token := makePushToken(&remotetypes.TxDetail{RepoName: "other", Reference: "refs/heads/master"}, []*state.Policy{
+				{Subject: ed25519.NewKeyFromIntSeed(1).PushAddr().String(), Object: "refs/heads", Action: policy.PolicyActionWrite},
+			})
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationUpload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, token)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(ContainSubstring(ErrLFSTokenRepoMismatch.Error()))
+		})
+
+		It("should return 403 when the pusher is not allowed to write to the token reference", func() {
+			token := makePushToken(&remotetypes.TxDetail{RepoName: repoName, Reference: "refs/heads/master"}, nil)
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationUpload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, token)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(ContainSubstring("not authorized to perform 'write' action"))
+		})
+
+		It("should return 401 with LFS-Authenticate header when upload request has no push token", func() {
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationUpload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+			Expect(rec.Header().Get("LFS-Authenticate")).To(Equal("Basic"))
+		})
+
+		It("should return 403 when upload request has a malformed push token", func() {
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationUpload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}},
+			}, "invalid")
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return upload actions only for objects that do not exist locally", func() {
+			token := allowPush()
+			otherOid := strings.Repeat("a", lfs.OidLength)
+			store := lfs.GetStore(svr.getRepoPath(repoName))
+			Expect(store.Put(oid, -1, bytes.NewBufferString(content))).To(BeNil())
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationUpload,
+				Objects:   []*lfs.Object{{Oid: oid, Size: int64(len(content))}, {Oid: otherOid, Size: 10}},
+			}, token)
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			resp := decodeBatch(rec)
+			Expect(resp.Objects).To(HaveLen(2))
+			Expect(resp.Objects[0].Actions).To(BeEmpty())
+			action := resp.Objects[1].Actions[lfs.OperationUpload]
+			Expect(action.Href).To(HaveSuffix("/info/lfs/objects/" + otherOid))
+			Expect(action.Header["Authorization"]).To(HavePrefix("Basic "))
+		})
+
+		It("should return an object error for an invalid object", func() {
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{
+				Operation: lfs.OperationDownload,
+				Objects:   []*lfs.Object{{Oid: "abc", Size: 1}},
+			}, "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			resp := decodeBatch(rec)
+			Expect(resp.Objects[0].Error.Code).To(Equal(http.StatusUnprocessableEntity))
+		})
+
+		It("should return 422 when operation is unknown", func() {
+			rec := do("POST", "/r/"+repoName+"/info/lfs/objects/batch", &lfs.BatchRequest{Operation: "delete"}, "")
+			Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	Describe("object transfer", func() {
+		BeforeEach(func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState)
+		})
+
+		It("should store and announce an uploaded object", func() {
+			token := allowPush()
+			mockDHT.EXPECT().Announce(announcer.ObjTypeLFS, repoName, oidArr[:], nil)
+			rec := do("PUT", "/r/"+repoName+"/info/lfs/objects/"+oid, content, token)
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(lfs.GetStore(svr.getRepoPath(repoName)).Has(oid)).To(BeTrue())
+		})
+
+		It("should return 422 when uploaded content does not match the object ID", func() {
+			token := allowPush()
+			rec := do("PUT", "/r/"+repoName+"/info/lfs/objects/"+oid, "bad content", token)
+			Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+			Expect(lfs.GetStore(svr.getRepoPath(repoName)).Has(oid)).To(BeFalse())
+		})
+
+		It("should return 401 when upload has no push token", func() {
+			rec := do("PUT", "/r/"+repoName+"/info/lfs/objects/"+oid, content, "")
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return the content of an object", func() {
+			store := lfs.GetStore(svr.getRepoPath(repoName))
+			Expect(store.Put(oid, -1, bytes.NewBufferString(content))).To(BeNil())
+			rec := do("GET", "/r/"+repoName+"/info/lfs/objects/"+oid, nil, "")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(Equal(content))
+		})
+
+		It("should return 404 when object does not exist", func() {
+			rec := do("GET", "/r/"+repoName+"/info/lfs/objects/"+oid, nil, "")
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe(".CheckLFSObjects", func() {
+		var path string
+		var note *types.Note
+
+		BeforeEach(func() {
+			path = filepath.Join(cfg.GetRepoRoot(), repoName)
+			ptr := &lfs.Pointer{Oid: oid, Size: int64(len(content))}
+			testutil2.AppendCommit(path, "file.bin", ptr.String(), "commit 1")
+			head := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD")))
+			r, err := repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+			Expect(err).To(BeNil())
+			note = &types.Note{TargetRepo: r, References: []*types.PushedReference{
+				{Name: "refs/heads/master", OldHash: strings.Repeat("0", 40), NewHash: head},
+			}}
+		})
+
+		It("should return nil when referenced objects exist locally", func() {
+			Expect(lfs.GetStore(path).Put(oid, -1, bytes.NewBufferString(content))).To(BeNil())
+			Expect(svr.CheckLFSObjects(note)).To(BeNil())
+		})
+
+		It("should return error when a referenced object cannot be fetched", func() {
+			mockStreamer := mocks.NewMockStreamer(ctrl)
+			mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer)
+			mockStreamer.EXPECT().GetLFSObject(gomock.Any(), repoName, oidArr[:]).Return(nil, fmt.Errorf("error"))
+			err := svr.CheckLFSObjects(note)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("refs/heads/master: LFS object (" + oid + ") is unavailable: error"))
+		})
+
+		It("should fetch, store and announce a referenced object that does not exist locally", func() {
+			mockStreamer := mocks.NewMockStreamer(ctrl)
+			mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer)
+			obj, _ := ioutil.TempFile(os.TempDir(), "")
+			obj.WriteString(content)
+			obj.Seek(0, 0)
+			mockStreamer.EXPECT().GetLFSObject(gomock.Any(), repoName, oidArr[:]).Return(obj, nil)
+			mockDHT.EXPECT().Announce(announcer.ObjTypeLFS, repoName, oidArr[:], nil)
+			Expect(svr.CheckLFSObjects(note)).To(BeNil())
+			Expect(lfs.GetStore(path).Has(oid)).To(BeTrue())
+		})
+	})
+
+	Describe(".checkLFSObject", func() {
+		It("should return true only if object exists in the repository's store", func() {
+			Expect(svr.checkLFSObject(repoName, oidArr[:])).To(BeFalse())
+			Expect(lfs.GetStore(svr.getRepoPath(repoName)).Put(oid, -1, bytes.NewBufferString(content))).To(BeNil())
+			Expect(svr.checkLFSObject(repoName, oidArr[:])).To(BeTrue())
+		})
+	})
+})
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"path/filepath"
 	"regexp"
@@ -48,6 +49,7 @@ const (
 )
 
 var services = [][]interface{}{
+	{"^/[^/]+/[^/]+/archive/.+\\.(tar\\.gz|zip)$", service{method: "GET", handle: getArchive}},
 	{"(.*?)/git-upload-pack$", service{method: "POST", handle: serveService}},
 	{"(.*?)/git-receive-pack$", service{method: "POST", handle: serveService}},
 	{"(.*?)/info/refs$", service{method: "GET", handle: getInfoRefs}},
@@ -70,6 +72,7 @@ type Server struct {
 	wg            *sync.WaitGroup             // wait group for waiting for the remote server
 	mux           *http.ServeMux              // The request multiplexer
 	srv           *http.Server                // The http server
+	sshListener   net.Listener                // The ssh server listener
 	rpcHandler    *rpc.Handler                // JSON-RPC 2.0 handler
 	rootDir       string                      // the root directory where all repos are stored
 	validatorKey  *ed25519.Key                // the node's private validator key for signing transactions
@@ -85,6 +88,9 @@ type Server struct {
 	blockGetter   core.BlockGetter            // Provides access to blocks
 	refSyncer     rstypes.RefSync             // Responsible for syncing pushed references in a push transaction
 	tmpRepoMgr    temprepomgr.TempRepoManager // The temporary repo manager
+	lfsFetches    map[string]chan struct{}    // In-progress LFS object fetches keyed by repo name and object ID
+	lfsFetchesMtx sync.Mutex                  // Guards lfsFetches
+	lfsFetchSem   chan struct{}               // Limits the number of concurrent LFS object fetches
 
 	// Indexes
 	noteSenders        *cache.Cache // Store senders of push notes
@@ -150,6 +156,8 @@ func New(
 		blockGetter:             blockGetter,
 		refSyncer:               refsync.New(cfg, pushPool, mFetcher, dht, appLogic),
 		tmpRepoMgr:              temprepomgr.New(),
+		lfsFetches:              make(map[string]chan struct{}),
+		lfsFetchSem:             make(chan struct{}, lfsMaxConcurrentFetches),
 		authenticate:            authenticate,
 		checkPushNote:           validation.CheckPushNote,
 		makeReferenceUpdatePack: push.MakeReferenceUpdateRequestPack,
@@ -186,6 +194,7 @@ func New(
 	if dht != nil {
 		dht.RegisterChecker(announcer.ObjTypeRepoName, server.checkRepo)
 		dht.RegisterChecker(announcer.ObjTypeGit, server.checkRepoObject)
+		dht.RegisterChecker(announcer.ObjTypeLFS, server.checkLFSObject)
 	}
 
 	// Apply repo tracking configurations
@@ -337,6 +346,13 @@ func (sv *Server) Start() error {
 		sv.wg.Done()
 	}()
 
+	// In non-validator mode, serve git requests over SSH if an SSH address is set
+	if !sv.cfg.IsValidatorNode() && sv.cfg.Remote.SSHAddress != "" {
+		if err := sv.startSSH(); err != nil {
+			sv.log.Error("Failed to start SSH server", "Err", err)
+		}
+	}
+
 	go sv.subscribe()
 
 	return nil
@@ -404,45 +420,46 @@ func (sv *Server) gitRequestsHandler(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	// De-construct the URL to get the repo name and operation
+	// Clients (e.g git-lfs) may address a repository with a .git suffix.
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 	namespaceName := pathParts[0]
-	repoName := pathParts[1]
+	pathParts[1] = strings.TrimSuffix(pathParts[1], ".git")
 	op := pathParts[2]
 
-	// Resolve the namespace if the given namespace is not the default
-	var namespace *state.Namespace
-	if namespaceName != remotetypes.DefaultNS {
-
-		// Get the namespace, return 404 if not found
-		namespace = sv.logic.NamespaceKeeper().Get(crypto2.MakeNamespaceHash(namespaceName))
-		if namespace.IsNil() {
-			w.WriteHeader(http.StatusNotFound)
-			sv.log.Debug("Unknown repository", "Name", repoName, "Code", http.StatusNotFound,
-				"Status", http.StatusText(http.StatusNotFound))
-			return
-		}
-
-		// Get the target. If the target is not set or the target is not
-		// prefixed with r/, return 404
-		target := namespace.Domains.Get(repoName)
-		if target == "" || target[:2] != "r/" {
-			w.WriteHeader(http.StatusNotFound)
-			sv.log.Debug("Unknown repository", "Name", repoName, "Code", http.StatusNotFound,
-				"Status", http.StatusText(http.StatusNotFound))
-			return
-		}
-
-		repoName = target[2:]
+	// Resolve the namespace if the given namespace is not the default.
+	// Return 404 if the namespace or its target is unknown.
+	repoName, namespace, ok := sv.resolveRepoName(namespaceName, pathParts[1])
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		sv.log.Debug("Unknown repository", "Name", pathParts[1], "Code", http.StatusNotFound,
+			"Status", http.StatusText(http.StatusNotFound))
+		return
 	}
 
-	// Check if the repository exist
+	// Check if the repository exist. If it does not, but the name belongs
+	// to a renamed repository, redirect the client to the new name.
 	repoState := sv.logic.RepoKeeper().Get(repoName)
 	if repoState.IsEmpty() {
+		if newName := sv.logic.RepoKeeper().GetRedirect(repoName); newName != "" {
+			newPath := "/" + strings.Join(append([]string{remotetypes.DefaultNS, newName}, pathParts[2:]...), "/")
+			if r.URL.RawQuery != "" {
+				newPath += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, newPath, http.StatusMovedPermanently)
+			sv.log.Debug("Redirected renamed repository", "Name", repoName, "NewName", newName)
+			return
+		}
 		w.WriteHeader(http.StatusNotFound)
 		sv.log.Debug("Unknown repository", "Name", repoName, "Code", http.StatusNotFound)
 		return
 	}
 
+	// Serve Git LFS API requests
+	if isLFSRequest(pathParts) {
+		sv.handleLFSRequest(w, r, repoName, repoState, namespace, pathParts)
+		return
+	}
+
 	pktEnc := pktline.NewEncoder(w)
 
 	// Authenticate pusher
@@ -477,14 +494,7 @@ func (sv *Server) gitRequestsHandler(w http.ResponseWriter, r *http.Request) {
 		Operation:   op,
 		TxDetails:   txDetails,
 		PolEnforcer: polEnforcer,
-		Repo: &repo.Repo{
-			Repository:     targetRepo.(*repo.Repo).Repository,
-			BasicGitModule: targetRepo.(*repo.Repo).BasicGitModule,
-			Path:           targetRepo.GetPath(),
-			State:          repoState,
-			NamespaceName:  namespaceName,
-			Namespace:      namespace,
-		},
+		Repo:        makeRequestRepo(targetRepo, repoState, namespaceName, namespace),
 		RepoDir:     targetRepo.GetPath(),
 		ServiceName: getService(r),
 		GitBinPath:  sv.gitBinPath,
@@ -518,6 +528,45 @@ func (sv *Server) gitRequestsHandler(w http.ResponseWriter, r *http.Request) {
 	writeMethodNotAllowed(w, r)
 }
 
+// resolveRepoName resolves the name of the repository targeted by a
+// name in the given namespace. In the default namespace, the name is the
+// repository name. In other namespaces, the name is a domain whose target
+// must point to a repository (r/ prefix). It returns false if the
+// namespace or the domain target is unknown.
+func (sv *Server) resolveRepoName(namespaceName, name string) (string, *state.Namespace, bool) {
+	if namespaceName == remotetypes.DefaultNS {
+		return name, nil, true
+	}
+
+	namespace := sv.logic.NamespaceKeeper().Get(crypto2.MakeNamespaceHash(namespaceName))
+	if namespace.IsNil() {
+		return "", nil, false
+	}
+
+	target := namespace.Domains.Get(name)
+	if target == "" || target[:2] != "r/" {
+		return "", nil, false
+	}
+
+	return target[2:], namespace, true
+}
+
+// makeRequestRepo creates the repository object passed to request handlers
+func makeRequestRepo(
+	targetRepo plumbing.LocalRepo,
+	repoState *state.Repository,
+	namespaceName string,
+	namespace *state.Namespace) *repo.Repo {
+	return &repo.Repo{
+		Repository:     targetRepo.(*repo.Repo).Repository,
+		BasicGitModule: targetRepo.(*repo.Repo).BasicGitModule,
+		Path:           targetRepo.GetPath(),
+		State:          repoState,
+		NamespaceName:  namespaceName,
+		Namespace:      namespace,
+	}
+}
+
 // GetPushKeyGetter implements RepositoryManager
 func (sv *Server) GetPushKeyGetter() core.PushKeyGetter {
 	return sv.pushKeyGetter
@@ -557,6 +606,9 @@ func (sv *Server) Shutdown(ctx context.Context) {
 	if sv.srv != nil {
 		_ = sv.srv.Shutdown(ctx)
 	}
+	if sv.sshListener != nil {
+		_ = sv.sshListener.Close()
+	}
 }
 
 // Stop implements Reactor
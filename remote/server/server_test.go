@@ -2,6 +2,8 @@ package server
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -16,6 +18,7 @@ import (
 	testutil2 "github.com/make-os/kit/remote/testutil"
 	"github.com/make-os/kit/testutil"
 	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -52,6 +55,7 @@ var _ = Describe("Server", func() {
 		mockDHT = mocks.NewMockDHT(ctrl)
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeGit, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeLFS, gomock.Any())
 
 		port, _ := freeport.GetFreePort()
 		svr = New(cfg, fmt.Sprintf(":%d", port), mockObjects.Logic, mockDHT, mockMempool, mockObjects.Service, mockBlockGetter)
@@ -244,6 +248,31 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Describe(".gitRequestsHandler", func() {
+		When("repository does not exist", func() {
+			It("should return 404", func() {
+				mockObjects.RepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+				mockObjects.RepoKeeper.EXPECT().GetRedirect("repo1").Return("")
+				req := httptest.NewRequest("GET", "https://127.0.0.1/r/repo1/info/refs", nil)
+				rec := httptest.NewRecorder()
+				svr.gitRequestsHandler(rec, req)
+				Expect(rec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("repository does not exist but its name redirects to a renamed repository", func() {
+			It("should redirect to the new name of the repository", func() {
+				mockObjects.RepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+				mockObjects.RepoKeeper.EXPECT().GetRedirect("repo1").Return("repo2")
+				req := httptest.NewRequest("GET", "https://127.0.0.1/r/repo1/info/refs?service=git-upload-pack", nil)
+				rec := httptest.NewRecorder()
+				svr.gitRequestsHandler(rec, req)
+				Expect(rec.Code).To(Equal(http.StatusMovedPermanently))
+				Expect(rec.Header().Get("Location")).To(Equal("/r/repo2/info/refs?service=git-upload-pack"))
+			})
+		})
+	})
+
 	Describe(".checkRepoObject", func() {
 		It("should return true if object exists", func() {
 			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
@@ -68,8 +68,17 @@ func endNotFound(w http.ResponseWriter) {
 // repoDir: The directory of the target repository.
 // args: Arguments for the git sub-command
 func execGitCmd(gitBinDir, repoDir string, args ...string) ([]byte, error) {
+	return execGitCmdWithEnv(gitBinDir, repoDir, nil, args...)
+}
+
+// execGitCmdWithEnv is like execGitCmd but adds the given
+// environment variables to the environment of the command.
+func execGitCmdWithEnv(gitBinDir, repoDir string, env []string, args ...string) ([]byte, error) {
 	cmd := exec.Command(gitBinDir, args...)
 	cmd.Dir = repoDir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	out := bytes.NewBuffer(nil)
 	cmd.Stdout = out
 	cmd.Stderr = out
@@ -87,8 +96,48 @@ func getService(r *http.Request) string {
 	return strings.ReplaceAll(service, "git-", "")
 }
 
-func getVersion(r *http.Request) string {
-	return r.Header.Get("Git-Protocol")
+// getProtocolVersion returns the git wire protocol version requested by the
+// client. The Git-Protocol header is a colon-separated list of key=value
+// parameters (e.g version=2:object-format=sha1). Returns 0 if no version
+// was requested.
+func getProtocolVersion(r *http.Request) int {
+	for _, param := range strings.Split(r.Header.Get("Git-Protocol"), ":") {
+		if strings.HasPrefix(param, "version=") {
+			v, _ := strconv.Atoi(strings.TrimPrefix(param, "version="))
+			return v
+		}
+	}
+	return 0
+}
+
+// useProtocolV2 checks whether the request should be served using
+// protocol v2. Only upload-pack (fetch) supports protocol v2; receive-pack
+// (push) requests are always served with protocol v0 so that they continue
+// to go through the push handler.
+func useProtocolV2(service string, r *http.Request) bool {
+	return service == "upload-pack" && getProtocolVersion(r) == 2
+}
+
+// protocolV2Env returns the environment variable that
+// makes git serve a request using protocol v2
+func protocolV2Env() []string {
+	return []string{"GIT_PROTOCOL=version=2"}
+}
+
+// uploadPackConfig contains configuration passed to upload-pack to allow
+// partial clones. Clients may request objects to be filtered out (e.g
+// --filter=blob:none) and later fetch the missing objects on demand.
+var uploadPackConfig = []string{
+	"-c", "uploadpack.allowFilter=true",
+	"-c", "uploadpack.allowReachableSHA1InWant=true",
+}
+
+// makeServiceArgs returns the git arguments for running the given service
+func makeServiceArgs(service string, args ...string) []string {
+	if service != "upload-pack" {
+		return append([]string{service}, args...)
+	}
+	return append(append(append([]string{}, uploadPackConfig...), service), args...)
 }
 
 // hdrNoCache sets no-cache header fields on the given http response
@@ -121,6 +170,74 @@ func packetWrite(str string) []byte {
 	return []byte(s + str)
 }
 
+// ErrShallowRepoFullHistory indicates that a client requested the full history
+// of a repository whose history is shallow on this node.
+var ErrShallowRepoFullHistory = fmt.Errorf("repository history is shallow on this node; " +
+	"fetch with --depth or from a node with the full history")
+
+// isShallowRepo checks whether the repository at dir has a shallow history.
+func isShallowRepo(dir string) bool {
+	for _, p := range []string{filepath.Join(dir, "shallow"), filepath.Join(dir, ".git", "shallow")} {
+		if fi, err := os.Stat(p); err == nil && fi.Size() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// readFetchRequest reads an upload-pack request from r until it is known
+// whether the client wants the full history of the requested commits; that
+// is, it sent "want" lines without "have", "shallow" or "deepen" lines.
+// It returns the bytes read and, for protocol v2, whether the request
+// is an ls-refs command.
+func readFetchRequest(r io.Reader) (request []byte, wantsFullHistory, isLsRefs bool, err error) {
+	buf := bytes.NewBuffer(nil)
+	r = io.TeeReader(r, buf)
+
+	var isV2, hasWant bool
+	var pktLen [4]byte
+	for {
+		if _, err = io.ReadFull(r, pktLen[:]); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return buf.Bytes(), false, isLsRefs, err
+		}
+		n, err := strconv.ParseUint(string(pktLen[:]), 16, 16)
+		if err != nil {
+			return buf.Bytes(), false, isLsRefs, pktline.ErrInvalidPktLen
+		}
+
+		// A flush packet ends a protocol v2 request and a protocol v0/v1
+		// request without wants. Delimiter packets are skipped.
+		if n < 4 {
+			if n == 0 && (isV2 || !hasWant) {
+				return buf.Bytes(), hasWant, isLsRefs, nil
+			}
+			continue
+		}
+
+		line := make([]byte, n-4)
+		if _, err = io.ReadFull(r, line); err != nil {
+			return buf.Bytes(), false, isLsRefs, err
+		}
+
+		switch line = bytes.TrimSpace(line); {
+		case bytes.HasPrefix(line, []byte("command=")):
+			isV2 = true
+			isLsRefs = bytes.Equal(line, []byte("command=ls-refs"))
+		case bytes.HasPrefix(line, []byte("want")):
+			hasWant = true
+		case bytes.HasPrefix(line, []byte("have ")),
+			bytes.HasPrefix(line, []byte("shallow ")),
+			bytes.HasPrefix(line, []byte("deepen")):
+			return buf.Bytes(), false, isLsRefs, nil
+		case bytes.Equal(line, []byte("done")):
+			return buf.Bytes(), hasWant, isLsRefs, nil
+		}
+	}
+}
+
 // writeMethodNotAllowed writes a response indicating that the request method is
 // not allowed or expected.
 func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
@@ -161,6 +278,49 @@ func getIdxFile(s *RequestContext) error {
 	return sendFile(s.Operation, "application/x-git-packed-objects-toc", s)
 }
 
+// getArchive sends an archive of the tree of a reference.
+// Expected path: /<namespace>/<repo>/archive/<ref>.<tar.gz|zip>
+func getArchive(s *RequestContext) error {
+	pathParts := strings.SplitN(strings.Trim(s.R.URL.Path, "/"), "/", 4)
+	if len(pathParts) != 4 {
+		endNotFound(s.W)
+		return fmt.Errorf("bad archive path")
+	}
+
+	ref, format := pathParts[3], ""
+	for _, f := range []string{plumbing.ArchiveFormatTarGz, plumbing.ArchiveFormatZip} {
+		if strings.HasSuffix(ref, "."+f) {
+			ref, format = strings.TrimSuffix(ref, "."+f), f
+			break
+		}
+	}
+
+	archive, err := plumbing.GetArchive(s.Repo, ref, format)
+	if err != nil {
+		if err == plumbing.ErrRefNotFound || err == plumbing.ErrUnknownArchiveFormat {
+			endNotFound(s.W)
+		} else if err == plumbing.ErrArchiveCreationLimit {
+			s.W.Header().Set("Retry-After", "10")
+			s.W.WriteHeader(http.StatusTooManyRequests)
+		} else {
+			s.W.WriteHeader(http.StatusInternalServerError)
+		}
+		return errors.Wrap(err, "failed to get archive")
+	}
+
+	contentType := "application/gzip"
+	if format == plumbing.ArchiveFormatZip {
+		contentType = "application/zip"
+	}
+
+	name := plumbing.MakeArchiveName(strings.TrimSuffix(pathParts[1], ".git"), ref, format)
+	s.W.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	s.W.Header().Set("Content-Type", contentType)
+	s.W.Header().Set("ETag", fmt.Sprintf("%q", archive.TreeHash+"."+format))
+	http.ServeFile(s.W, s.R, archive.Path)
+	return nil
+}
+
 // service describes a git service and its handler
 type service struct {
 	method string
@@ -172,8 +332,9 @@ func getInfoRefs(s *RequestContext) error {
 
 	var err error
 	var refs []byte
-	var version string
-	var args = []string{s.ServiceName, "--stateless-rpc", "--advertise-refs", "."}
+	var env []string
+	var isV2 bool
+	var args = makeServiceArgs(s.ServiceName, "--stateless-rpc", "--advertise-refs", ".")
 	var isDumb = s.ServiceName == ""
 
 	// If this is a request from a dumb client, skip to dumb response section
@@ -181,8 +342,16 @@ func getInfoRefs(s *RequestContext) error {
 		goto dumbReq
 	}
 
-	// Execute git command which will return references
-	refs, err = execGitCmd(s.GitBinPath, s.RepoDir, args...)
+	// For protocol v2, git advertises its capabilities (ls-refs, fetch etc)
+	// instead of references. The client requests the references it is
+	// interested in using the ls-refs command.
+	isV2 = useProtocolV2(s.ServiceName, s.R)
+	if isV2 {
+		env = protocolV2Env()
+	}
+
+	// Execute git command which will return references or capabilities
+	refs, err = execGitCmdWithEnv(s.GitBinPath, s.RepoDir, env, args...)
 	if err != nil {
 		return err
 	}
@@ -192,16 +361,14 @@ func getInfoRefs(s *RequestContext) error {
 	s.W.Header().Set("Content-Type", fmt.Sprintf("application/x-git-%s-advertisement", s.ServiceName))
 	s.W.WriteHeader(http.StatusOK)
 
-	version = getVersion(s.R)
-
 	// If request is not a protocol v2 request, write the smart parameters
 	// describing the service response
-	if version != "2" {
+	if !isV2 {
 		s.W.Write(packetWrite("# service=git-" + s.ServiceName + "\n"))
 		s.W.Write(packetFlush())
 	}
 
-	// Write the references received from the git-upload-pack command
+	// Write the references or capabilities received from the git command
 	s.W.Write(refs)
 	return nil
 
@@ -232,17 +399,43 @@ func serveService(s *RequestContext) error {
 	w.WriteHeader(http.StatusOK)
 	hdrNoCache(w)
 
+	// If the request is compressed, we need to decompress
+	// before we feed it to the git.
+	var err error
+	var reader io.ReadCloser
+	switch r.Header.Get("Content-Encoding") {
+	case "gzip":
+		reader, err = gzip.NewReader(r.Body)
+		if reader != nil {
+			defer reader.Close()
+		}
+	default:
+		reader = r.Body
+		defer reader.Close()
+	}
+
+	// A shallow repository cannot serve the full history of its commits.
+	// Reject such fetch requests instead of silently serving a shallow history.
+	var body io.Reader = reader
+	if op == "upload-pack" && isShallowRepo(dir) {
+		req, wantsFullHistory, _, err := readFetchRequest(reader)
+		if err == nil && wantsFullHistory {
+			w.Write(packetWrite("ERR " + ErrShallowRepoFullHistory.Error()))
+			return ErrShallowRepoFullHistory
+		}
+		body = io.MultiReader(bytes.NewReader(req), reader)
+	}
+
 	// Construct the git command
-	env := os.Environ()
-	args := []string{op, "--stateless-rpc", dir}
+	args := makeServiceArgs(op, "--stateless-rpc", dir)
 	cmd := exec.Command(s.GitBinPath, args...)
-	version := r.Header.Get("Git-Protocol")
 	cmd.Dir = dir
-	cmd.Env = env
+	cmd.Env = os.Environ()
 
-	// If client requested v2 protocol, set protocol flag in env
-	if len(version) != 0 {
-		cmd.Env = append(env, fmt.Sprintf("GIT_PROTOCOL=%s", version))
+	// If client requested v2 protocol for a fetch, set protocol flag in env
+	// so that git serves the requested command (ls-refs or fetch).
+	if useProtocolV2(op, r) {
+		cmd.Env = append(cmd.Env, protocolV2Env()...)
 	}
 
 	// Get the command's stdin pipe
@@ -264,23 +457,9 @@ func serveService(s *RequestContext) error {
 		return errors.Wrap(err, "failed to start command")
 	}
 
-	// If the request is compressed, we need to decompress
-	// before we feed it to the git.
-	var reader io.ReadCloser
-	switch r.Header.Get("Content-Encoding") {
-	case "gzip":
-		reader, err = gzip.NewReader(r.Body)
-		if reader != nil {
-			defer reader.Close()
-		}
-	default:
-		reader = r.Body
-		defer reader.Close()
-	}
-
 	// Handle fetch request
 	if op == "upload-pack" {
-		io.Copy(in, reader)
+		io.Copy(in, body)
 		in.Close()
 		io.Copy(w, stdout)
 		return nil
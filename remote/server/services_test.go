@@ -0,0 +1,312 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Services", func() {
+	var err error
+	var cfg *config.AppConfig
+	var path string
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		repoName := util.RandString(5)
+		path = filepath.Join(cfg.GetRepoRoot(), repoName)
+		testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+		testutil2.AppendCommit(path, "file.txt", "hello", "commit 1")
+		testutil2.ExecGit(path, "branch", "issues/1")
+	})
+
+	AfterEach(func() {
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	newReqCtx := func(method, target, service, protocol string, body []byte) (*RequestContext, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		if protocol != "" {
+			req.Header.Set("Git-Protocol", protocol)
+		}
+		return &RequestContext{
+			W:           rec,
+			R:           req,
+			RepoDir:     path,
+			Operation:   service,
+			ServiceName: service,
+			GitBinPath:  cfg.Node.GitBinPath,
+		}, rec
+	}
+
+	Describe(".getProtocolVersion", func() {
+		It("should return the requested version", func() {
+			req := httptest.NewRequest("GET", "/info/refs", nil)
+			req.Header.Set("Git-Protocol", "version=2")
+			Expect(getProtocolVersion(req)).To(Equal(2))
+			req.Header.Set("Git-Protocol", "object-format=sha1:version=1")
+			Expect(getProtocolVersion(req)).To(Equal(1))
+		})
+
+		It("should return 0 if no version was requested", func() {
+			req := httptest.NewRequest("GET", "/info/refs", nil)
+			Expect(getProtocolVersion(req)).To(Equal(0))
+			req.Header.Set("Git-Protocol", "object-format=sha1")
+			Expect(getProtocolVersion(req)).To(Equal(0))
+		})
+	})
+
+	Describe(".getInfoRefs", func() {
+		It("should advertise all references when protocol v2 was not requested", func() {
+			req, rec := newReqCtx("GET", "/info/refs?service=git-upload-pack", "upload-pack", "", nil)
+			Expect(getInfoRefs(req)).To(BeNil())
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(HavePrefix("001e# service=git-upload-pack\n0000"))
+			Expect(rec.Body.String()).To(ContainSubstring("refs/heads/master"))
+			Expect(rec.Body.String()).To(ContainSubstring("refs/heads/issues/1"))
+		})
+
+		It("should advertise capabilities instead of references when protocol v2 was requested", func() {
+			req, rec := newReqCtx("GET", "/info/refs?service=git-upload-pack", "upload-pack", "version=2", nil)
+			Expect(getInfoRefs(req)).To(BeNil())
+			Expect(rec.Body.String()).To(HavePrefix("000eversion 2\n"))
+			Expect(rec.Body.String()).To(ContainSubstring("ls-refs"))
+			Expect(rec.Body.String()).To(ContainSubstring("fetch"))
+			Expect(rec.Body.String()).ToNot(ContainSubstring("# service="))
+			Expect(rec.Body.String()).ToNot(ContainSubstring("refs/heads/issues/1"))
+		})
+
+		It("should advertise references for receive-pack even when protocol v2 was requested", func() {
+			req, rec := newReqCtx("GET", "/info/refs?service=git-receive-pack", "receive-pack", "version=2", nil)
+			Expect(getInfoRefs(req)).To(BeNil())
+			Expect(rec.Body.String()).To(HavePrefix("001f# service=git-receive-pack\n0000"))
+			Expect(rec.Body.String()).To(ContainSubstring("refs/heads/master"))
+		})
+	})
+
+	Describe(".makeServiceArgs", func() {
+		It("should enable partial clone support for upload-pack", func() {
+			args := makeServiceArgs("upload-pack", "--stateless-rpc", ".")
+			Expect(args).To(Equal([]string{"-c", "uploadpack.allowFilter=true",
+				"-c", "uploadpack.allowReachableSHA1InWant=true", "upload-pack", "--stateless-rpc", "."}))
+		})
+
+		It("should not add upload-pack configuration to other services", func() {
+			args := makeServiceArgs("receive-pack", "--stateless-rpc", ".")
+			Expect(args).To(Equal([]string{"receive-pack", "--stateless-rpc", "."}))
+		})
+	})
+
+	Describe(".serveService", func() {
+		It("should return only references matching the ref-prefix of a protocol v2 ls-refs command", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite("command=ls-refs\n"))
+			body.Write([]byte("0001"))
+			body.Write(packetWrite("ref-prefix refs/heads/master\n"))
+			body.Write(packetFlush())
+			req, rec := newReqCtx("POST", "/git-upload-pack", "git-upload-pack", "version=2", body.Bytes())
+			Expect(serveService(req)).To(BeNil())
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/x-git-upload-pack-result"))
+			Expect(rec.Body.String()).To(ContainSubstring("refs/heads/master"))
+			Expect(rec.Body.String()).ToNot(ContainSubstring("refs/heads/issues/1"))
+		})
+
+		When("cloning over http", func() {
+			var url string
+			var srv *httptest.Server
+
+			BeforeEach(func() {
+				testutil2.AppendCommit(path, "file.txt", "hello world", "commit 2")
+				srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.HasSuffix(r.URL.Path, "/info/refs") {
+						req, _ := newReqCtx("", "/", getService(r), "", nil)
+						req.W, req.R = w, r
+						_ = getInfoRefs(req)
+						return
+					}
+					req, _ := newReqCtx("", "/", "git-upload-pack", "", nil)
+					req.W, req.R = w, r
+					_ = serveService(req)
+				}))
+				url = srv.URL + "/repo"
+			})
+
+			AfterEach(func() {
+				srv.Close()
+			})
+
+			It("should support shallow clones", func() {
+				dest := filepath.Join(cfg.DataDir(), "shallow")
+				testutil2.ExecGit(cfg.DataDir(), "clone", "--depth", "1", url, dest)
+				out := testutil2.ExecGit(dest, "rev-list", "--count", "HEAD")
+				Expect(strings.TrimSpace(string(out))).To(Equal("1"))
+				_, err := os.Stat(filepath.Join(dest, ".git", "shallow"))
+				Expect(err).To(BeNil())
+			})
+
+			It("should support partial clones and fetch missing blobs on demand", func() {
+				dest := filepath.Join(cfg.DataDir(), "partial")
+				testutil2.ExecGit(cfg.DataDir(), "clone", "--no-checkout", "--filter=blob:none", url, dest)
+				out := testutil2.ExecGit(dest, "rev-list", "--objects", "--all", "--missing=print")
+				Expect(string(out)).To(MatchRegexp(`(?m)^\?[0-9a-f]{40}$`))
+				out = testutil2.ExecGit(dest, "show", "HEAD:file.txt")
+				Expect(string(out)).To(ContainSubstring("hello world"))
+			})
+
+			When("the repository is shallow", func() {
+				BeforeEach(func() {
+					head := testutil2.GetRecentCommitHash(path, "HEAD")
+					Expect(ioutil.WriteFile(filepath.Join(path, ".git", "shallow"), []byte(head+"\n"), 0644)).To(BeNil())
+				})
+
+				It("should reject a full clone", func() {
+					cmd := exec.Command(cfg.Node.GitBinPath, "clone", url, filepath.Join(cfg.DataDir(), "full"))
+					cmd.Env = testutil2.GitEnv
+					out, err := cmd.CombinedOutput()
+					Expect(err).ToNot(BeNil())
+					Expect(string(out)).To(ContainSubstring(ErrShallowRepoFullHistory.Error()))
+				})
+
+				It("should support shallow clones", func() {
+					dest := filepath.Join(cfg.DataDir(), "shallow")
+					testutil2.ExecGit(cfg.DataDir(), "clone", "--depth", "1", url, dest)
+					out := testutil2.ExecGit(dest, "rev-list", "--count", "HEAD")
+					Expect(strings.TrimSpace(string(out))).To(Equal("1"))
+				})
+			})
+		})
+	})
+	Describe(".readFetchRequest", func() {
+		hash := strings.Repeat("a", 40)
+
+		It("should indicate that the full history is wanted when no have, shallow or deepen lines were sent", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite("want " + hash + " ofs-delta\n"))
+			body.Write(packetFlush())
+			body.Write(packetWrite("done\n"))
+			req, wantsFullHistory, isLsRefs, err := readFetchRequest(bytes.NewReader(body.Bytes()))
+			Expect(err).To(BeNil())
+			Expect(req).To(Equal(body.Bytes()))
+			Expect(wantsFullHistory).To(BeTrue())
+			Expect(isLsRefs).To(BeFalse())
+		})
+
+		It("should stop at the first have line", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite("want " + hash + "\n"))
+			body.Write(packetFlush())
+			body.Write(packetWrite("have " + hash + "\n"))
+			req, wantsFullHistory, _, err := readFetchRequest(bytes.NewReader(append(body.Bytes(), packetFlush()...)))
+			Expect(err).To(BeNil())
+			Expect(req).To(Equal(body.Bytes()))
+			Expect(wantsFullHistory).To(BeFalse())
+		})
+
+		It("should indicate that the full history is not wanted when a deepen line was sent", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite("want " + hash + "\n"))
+			body.Write(packetWrite("deepen 1\n"))
+			_, wantsFullHistory, _, err := readFetchRequest(bytes.NewReader(body.Bytes()))
+			Expect(err).To(BeNil())
+			Expect(wantsFullHistory).To(BeFalse())
+		})
+
+		It("should stop at the end of a protocol v2 ls-refs command", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite("command=ls-refs\n"))
+			body.Write([]byte("0001"))
+			body.Write(packetWrite("ref-prefix refs/heads/\n"))
+			body.Write(packetFlush())
+			req, wantsFullHistory, isLsRefs, err := readFetchRequest(bytes.NewReader(append(body.Bytes(), packetFlush()...)))
+			Expect(err).To(BeNil())
+			Expect(req).To(Equal(body.Bytes()))
+			Expect(wantsFullHistory).To(BeFalse())
+			Expect(isLsRefs).To(BeTrue())
+		})
+
+		It("should indicate that the full history is wanted by a protocol v2 fetch command without have lines", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite("command=fetch\n"))
+			body.Write([]byte("0001"))
+			body.Write(packetWrite("want " + hash + "\n"))
+			body.Write(packetWrite("done\n"))
+			_, wantsFullHistory, isLsRefs, err := readFetchRequest(bytes.NewReader(body.Bytes()))
+			Expect(err).To(BeNil())
+			Expect(wantsFullHistory).To(BeTrue())
+			Expect(isLsRefs).To(BeFalse())
+		})
+
+		It("should return error if a packet length is invalid", func() {
+			_, _, _, err := readFetchRequest(strings.NewReader("zzzz"))
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
+	Describe(".getArchive", func() {
+		var archiveReqCtx = func(target string) (*RequestContext, *httptest.ResponseRecorder) {
+			req, rec := newReqCtx("GET", target, "", "", nil)
+			req.Repo, err = repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+			Expect(err).To(BeNil())
+			return req, rec
+		}
+
+		It("should send a tar.gz archive of a reference", func() {
+			req, rec := archiveReqCtx("/r/repo1/archive/issues/1.tar.gz")
+			Expect(getArchive(req)).To(BeNil())
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/gzip"))
+			Expect(rec.Header().Get("Content-Disposition")).To(Equal(`attachment; filename="repo1-issues-1.tar.gz"`))
+			Expect(rec.Body.Bytes()[:2]).To(Equal([]byte{0x1f, 0x8b}))
+		})
+
+		It("should send a zip archive of a reference", func() {
+			req, rec := archiveReqCtx("/r/repo1/archive/master.zip")
+			Expect(getArchive(req)).To(BeNil())
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/zip"))
+			Expect(rec.Body.String()).To(HavePrefix("PK"))
+		})
+
+		It("should return 304 if the archive matches the request's entity tag", func() {
+			req, rec := archiveReqCtx("/r/repo1/archive/master.zip")
+			Expect(getArchive(req)).To(BeNil())
+			req, rec2 := archiveReqCtx("/r/repo1/archive/master.zip")
+			req.R.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+			Expect(getArchive(req)).To(BeNil())
+			Expect(rec2.Code).To(Equal(http.StatusNotModified))
+		})
+
+		It("should return 404 if the reference is unknown", func() {
+			req, rec := archiveReqCtx("/r/repo1/archive/unknown.zip")
+			Expect(getArchive(req)).ToNot(BeNil())
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 429 if the max number of concurrent archives is reached", func() {
+			oldMax := plumbing.MaxConcurrentArchives
+			plumbing.MaxConcurrentArchives = 0
+			defer func() { plumbing.MaxConcurrentArchives = oldMax }()
+			req, rec := archiveReqCtx("/r/repo1/archive/master.zip")
+			Expect(getArchive(req)).ToNot(BeNil())
+			Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(rec.Header().Get("Retry-After")).ToNot(BeEmpty())
+		})
+	})
+})
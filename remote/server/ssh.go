@@ -0,0 +1,470 @@
+package server
+
+import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/util"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ErrSSHKeyNotSupported  = fmt.Errorf("only ed25519 keys are supported")
+	ErrSSHUnknownPushKey   = fmt.Errorf("public key is not a registered push key")
+	ErrSSHUnknownCommand   = fmt.Errorf("unknown command; only git-upload-pack and git-receive-pack are allowed")
+	ErrSSHInvalidRepoPath  = fmt.Errorf("invalid repository path; expected <namespace>/<repo>")
+	ErrSSHRepoNotFound     = fmt.Errorf("repository not found")
+	ErrSSHPushKeyMismatch  = fmt.Errorf("push token(s) must be signed by the authenticated push key")
+	ErrSSHUnexpectedHangup = fmt.Errorf("the remote end hung up unexpectedly")
+)
+
+// sshPushKeyIDExt is the SSH permission extension that stores the
+// ID of the push key used to authenticate a connection
+const sshPushKeyIDExt = "push-key-id"
+
+// makeSSHServerConfig creates the SSH server configuration.
+// The host key is loaded from the node's SSH host key file.
+func (sv *Server) makeSSHServerConfig() (*ssh.ServerConfig, error) {
+	signer, err := loadSSHHostKey(sv.cfg.GetSSHHostKeyPath())
+	if err != nil {
+		return nil, err
+	}
+
+	sshCfg := &ssh.ServerConfig{PublicKeyCallback: sv.authenticateSSHKey}
+	sshCfg.AddHostKey(signer)
+	return sshCfg, nil
+}
+
+// loadSSHHostKey loads the ed25519 SSH host key stored at path.
+// If the file does not exist, a new key is generated and stored.
+func loadSSHHostKey(path string) (ssh.Signer, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to read host key")
+	}
+
+	if os.IsNotExist(err) {
+		_, privKey, err := stded25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate host key")
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(privKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode host key")
+		}
+
+		bz = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, errors.Wrap(err, "failed to create host key directory")
+		}
+		if err = ioutil.WriteFile(path, bz, 0600); err != nil {
+			return nil, errors.Wrap(err, "failed to write host key")
+		}
+	}
+
+	signer, err := ssh.ParsePrivateKey(bz)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse host key")
+	}
+
+	if signer.PublicKey().Type() != ssh.KeyAlgoED25519 {
+		return nil, fmt.Errorf("host key must be an ed25519 key")
+	}
+
+	return signer, nil
+}
+
+// authenticateSSHKey implements ssh.ServerConfig.PublicKeyCallback.
+// It accepts only ed25519 keys that have been registered as push keys.
+// The ID of the push key is stored in the connection permissions.
+func (sv *Server) authenticateSSHKey(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	cryptoKey, ok := key.(ssh.CryptoPublicKey)
+	if !ok || key.Type() != ssh.KeyAlgoED25519 {
+		return nil, ErrSSHKeyNotSupported
+	}
+
+	pubKey, ok := cryptoKey.CryptoPublicKey().(stded25519.PublicKey)
+	if !ok {
+		return nil, ErrSSHKeyNotSupported
+	}
+
+	pushKeyID := ed25519.CreatePushKeyID(ed25519.BytesToPublicKey(pubKey))
+	if sv.logic.PushKeyKeeper().Get(pushKeyID).IsNil() {
+		return nil, ErrSSHUnknownPushKey
+	}
+
+	return &ssh.Permissions{Extensions: map[string]string{sshPushKeyIDExt: pushKeyID}}, nil
+}
+
+// startSSH starts the SSH server on the configured SSH address.
+func (sv *Server) startSSH() error {
+	sshCfg, err := sv.makeSSHServerConfig()
+	if err != nil {
+		return err
+	}
+
+	sv.sshListener, err = net.Listen("tcp", sv.cfg.Remote.SSHAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen")
+	}
+
+	sv.log.Info("SSH server has started", "Address", sv.cfg.Remote.SSHAddress)
+
+	go func() {
+		for {
+			conn, err := sv.sshListener.Accept()
+			if err != nil {
+				return
+			}
+			go sv.handleSSHConn(conn, sshCfg)
+		}
+	}()
+
+	return nil
+}
+
+// handleSSHConn performs the SSH handshake on a new connection
+// and serves the session channels opened by the client
+func (sv *Server) handleSSHConn(nConn net.Conn, sshCfg *ssh.ServerConfig) {
+	conn, chans, reqs, err := ssh.NewServerConn(nConn, sshCfg)
+	if err != nil {
+		sv.log.Debug("SSH handshake failed", "Addr", nConn.RemoteAddr().String(), "Err", err)
+		return
+	}
+	defer conn.Close()
+
+	go ssh.DiscardRequests(reqs)
+
+	for newCh := range chans {
+		if newCh.ChannelType() != "session" {
+			_ = newCh.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		ch, chReqs, err := newCh.Accept()
+		if err != nil {
+			sv.log.Debug("Failed to accept SSH channel", "Err", err)
+			continue
+		}
+
+		go sv.handleSSHSession(conn, ch, chReqs)
+	}
+}
+
+// handleSSHSession handles the requests of a session channel.
+// Only 'env' requests for GIT_PROTOCOL and a single 'exec' request
+// are allowed. The channel is closed once the exec request is handled.
+func (sv *Server) handleSSHSession(conn *ssh.ServerConn, ch ssh.Channel, reqs <-chan *ssh.Request) {
+	defer ch.Close()
+
+	var env []string
+	for req := range reqs {
+		switch req.Type {
+		case "env":
+			var kv struct{ Name, Value string }
+			ok := ssh.Unmarshal(req.Payload, &kv) == nil && kv.Name == "GIT_PROTOCOL"
+			if ok {
+				env = append(env, kv.Name+"="+kv.Value)
+			}
+			_ = req.Reply(ok, nil)
+
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				return
+			}
+			_ = req.Reply(true, nil)
+
+			var status uint32
+			if err := sv.handleSSHExec(conn, ch, payload.Command, env); err != nil {
+				_, _ = fmt.Fprintf(ch.Stderr(), "fatal: %s\n", err)
+				status = 1
+			}
+
+			_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+			return
+
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+// parseSSHCommand parses a git command requested by an SSH client
+// (e.g git-upload-pack '/r/repo'). It returns the requested service
+// (without the git- prefix), the namespace name and the repository name.
+func parseSSHCommand(command string) (service, namespaceName, repoName string, err error) {
+	command = strings.TrimSpace(command)
+	if strings.HasPrefix(command, "git ") {
+		command = "git-" + strings.TrimSpace(command[4:])
+	}
+
+	parts := strings.SplitN(command, " ", 2)
+	if len(parts) != 2 {
+		return "", "", "", ErrSSHUnknownCommand
+	}
+
+	switch parts[0] {
+	case "git-upload-pack", "git-receive-pack":
+		service = strings.TrimPrefix(parts[0], "git-")
+	default:
+		return "", "", "", ErrSSHUnknownCommand
+	}
+
+	path := strings.Trim(strings.TrimSpace(parts[1]), "'\"")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] == "" {
+		return "", "", "", ErrSSHInvalidRepoPath
+	}
+
+	return service, pathParts[0], pathParts[1], nil
+}
+
+// getSSHPushTokens returns the push token(s) provided in the SSH username.
+// The password part of a URL user info (e.g <tokens>:-) is ignored.
+func getSSHPushTokens(user string) string {
+	return strings.SplitN(user, ":", 2)[0]
+}
+
+// handleSSHExec serves a git command requested by an SSH client.
+// Push requests are authenticated using the push token(s) provided in the
+// SSH username; the token(s) must be signed by the push key used to
+// authenticate the connection.
+func (sv *Server) handleSSHExec(conn *ssh.ServerConn, ch ssh.Channel, command string, env []string) error {
+
+	sv.log.Debug("New SSH request", "User", conn.User(), "Command", command)
+
+	service, namespaceName, name, err := parseSSHCommand(command)
+	if err != nil {
+		return err
+	}
+
+	repoName, namespace, ok := sv.resolveRepoName(namespaceName, name)
+	if !ok {
+		return ErrSSHRepoNotFound
+	}
+
+	// SSH clients cannot be redirected; serve renamed repositories directly.
+	repoState := sv.logic.RepoKeeper().Get(repoName)
+	if repoState.IsEmpty() {
+		if newName := sv.logic.RepoKeeper().GetRedirect(repoName); newName != "" {
+			repoName, repoState = newName, sv.logic.RepoKeeper().Get(newName)
+		}
+		if repoState.IsEmpty() {
+			return ErrSSHRepoNotFound
+		}
+	}
+
+	targetRepo, err := sv.GetRepo(repoName)
+	if err != nil {
+		return ErrSSHRepoNotFound
+	}
+
+	if service == "upload-pack" {
+		return serveSSHUploadPack(sv.gitBinPath, targetRepo.GetPath(), ch, env)
+	}
+
+	txDetails, polEnforcer, err := sv.authenticatePushTokens(getSSHPushTokens(conn.User()), repoState, namespace)
+	if err != nil {
+		return errors.Wrap(err, "authentication has failed")
+	}
+	if txDetails[0].PushKeyID != conn.Permissions.Extensions[sshPushKeyIDExt] {
+		return errors.Wrap(ErrSSHPushKeyMismatch, "authentication has failed")
+	}
+
+	req := &RequestContext{
+		TxDetails:   txDetails,
+		PolEnforcer: polEnforcer,
+		Repo:        makeRequestRepo(targetRepo, repoState, namespaceName, namespace),
+		RepoDir:     targetRepo.GetPath(),
+		Operation:   service,
+		ServiceName: service,
+		GitBinPath:  sv.gitBinPath,
+		pktEnc:      pktline.NewEncoder(ch),
+	}
+	req.PushHandler = sv.makePushHandler(req.Repo, txDetails, polEnforcer)
+
+	return serveSSHReceivePack(req, ch)
+}
+
+// serveSSHUploadPack runs git-upload-pack with its input and output
+// connected to the SSH channel.
+func serveSSHUploadPack(gitBinPath, dir string, ch ssh.Channel, env []string) error {
+	cmd := exec.Command(gitBinPath, makeServiceArgs("upload-pack", dir)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = ch
+	cmd.Stderr = ch.Stderr()
+
+	// Do not let the command wait for the client to close its end
+	// of the channel after git-upload-pack has exited.
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return errors.Wrap(err, "failed to get stdin pipe")
+	}
+
+	if err = cmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start command")
+	}
+
+	// A shallow repository cannot serve the full history of its commits.
+	// Reject such fetch requests instead of silently serving a shallow history.
+	rejected := make(chan bool, 1)
+	go func() {
+		defer in.Close()
+		for isShallowRepo(dir) {
+			req, wantsFullHistory, isLsRefs, err := readFetchRequest(ch)
+			if err == nil && wantsFullHistory {
+				rejected <- true
+				_ = cmd.Process.Kill()
+				return
+			}
+			if _, err = in.Write(req); err != nil || !isLsRefs {
+				break
+			}
+		}
+		rejected <- false
+		_, _ = io.Copy(in, ch)
+	}()
+
+	err = cmd.Wait()
+	if <-rejected {
+		_, _ = ch.Write(packetWrite("ERR " + ErrShallowRepoFullHistory.Error()))
+		return ErrShallowRepoFullHistory
+	}
+
+	return err
+}
+
+// readSSHPushRequest reads the reference update commands sent by a push
+// client up to the flush packet. It returns the commands and whether any
+// of the commands requires a packfile (i.e. not a delete command).
+func readSSHPushRequest(r io.Reader) (request []byte, hasPack bool, err error) {
+	buf := bytes.NewBuffer(nil)
+	enc := pktline.NewEncoder(buf)
+	scn := pktline.NewScanner(r)
+	for scn.Scan() {
+		line := scn.Bytes()
+		if err := enc.Encode(line); err != nil {
+			return nil, false, err
+		}
+
+		// Stop at the flush packet that terminates the command list
+		if len(line) == 0 {
+			return buf.Bytes(), hasPack, nil
+		}
+
+		// Shallow lines are not commands
+		if bytes.HasPrefix(line, []byte("shallow ")) {
+			continue
+		}
+
+		// A command is "<old-hash> <new-hash> <ref-name>"; delete commands have a zero new hash.
+		if fields := strings.Fields(string(line)); len(fields) >= 2 && !plumbing.IsZeroHash(fields[1]) {
+			hasPack = true
+		}
+	}
+
+	if scn.Err() != nil {
+		return nil, false, scn.Err()
+	}
+
+	return nil, false, ErrSSHUnexpectedHangup
+}
+
+// serveSSHReceivePack runs git-receive-pack and passes the push request
+// of the client through the push handler.
+func serveSSHReceivePack(s *RequestContext, ch ssh.Channel) error {
+	cmd := exec.Command(s.GitBinPath, "receive-pack", s.RepoDir)
+	cmd.Dir = s.RepoDir
+	cmd.Env = os.Environ()
+
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return errors.Wrap(err, "failed to get stdin pipe")
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return errors.Wrap(err, "failed to get stdout pipe")
+	}
+
+	if err = cmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start command")
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_, _ = cmd.Process.Wait()
+	}()
+
+	// Send the reference advertisement of git to the client
+	scn := pktline.NewScanner(stdout)
+	for scn.Scan() {
+		if err := s.pktEnc.Encode(scn.Bytes()); err != nil {
+			return errors.Wrap(err, "failed to write advertisement")
+		}
+		if len(scn.Bytes()) == 0 {
+			break
+		}
+	}
+	if scn.Err() != nil {
+		return errors.Wrap(scn.Err(), "failed to read advertisement")
+	}
+
+	// Read the reference update commands. The client only closes its end
+	// of the channel after sending a packfile, so the packfile is only
+	// read when at least one command requires it.
+	request, hasPack, err := readSSHPushRequest(ch)
+	if err != nil {
+		return err
+	}
+	var reader io.Reader = bytes.NewReader(request)
+	if hasPack {
+		reader = io.MultiReader(reader, ch)
+	}
+
+	// Read, analyse and pass the packfile to git
+	if err := s.PushHandler.HandleStream(reader, in, util.NewWrappedCmd(cmd), s.pktEnc); err != nil {
+		s.pktEnc.Encode(plumbing.SidebandErr(errors.Wrap(err, "push error").Error()))
+		return errors.Wrap(err, "HandleStream error")
+	}
+
+	// Handle validate, revert and broadcast the changes
+	if err := s.PushHandler.HandleUpdate(nil); err != nil {
+		s.pktEnc.Encode(plumbing.SidebandErr(errors.Wrap(err, "push error").Error()))
+		return errors.Wrap(err, "HandleUpdate error")
+	}
+
+	// Wait for the push tx to be added to the mempool.
+	hashOrErr := <-s.PushHandler.WaitForPushTx()
+	if err, isErr := hashOrErr.(error); isErr {
+		s.pktEnc.Encode(plumbing.SidebandErr(errors.Wrap(err, "push error").Error()))
+		return errors.Wrap(err, "WaitForPushTx error")
+	}
+	s.pktEnc.Encode(plumbing.SidebandProgressln(fmt.Sprintf("hash: %s ", hashOrErr)))
+
+	// Write the remaining output from git to the client
+	scn = pktline.NewScanner(stdout)
+	for scn.Scan() {
+		s.pktEnc.Encode(scn.Bytes())
+	}
+
+	return nil
+}
@@ -0,0 +1,305 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	stded25519 "crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/net/dht/announcer"
+	"github.com/make-os/kit/remote/policy"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/remote/validation"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/pushtoken"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/phayes/freeport"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ = Describe("SSH", func() {
+	var err error
+	var cfg *config.AppConfig
+	var svr *Server
+	var ctrl *gomock.Controller
+	var mockObjects *testutil.MockObjects
+	var key = ed25519.NewKeyFromIntSeed(1)
+	var pushKeyID = key.PushAddr().String()
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		ctrl = gomock.NewController(GinkgoT())
+		mockObjects = testutil.Mocks(ctrl)
+		mockDHT := mocks.NewMockDHT(ctrl)
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeGit, gomock.Any())
+		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeLFS, gomock.Any())
+		port, _ := freeport.GetFreePort()
+		svr = New(cfg, fmt.Sprintf(":%d", port), mockObjects.Logic, mockDHT, nil, mockObjects.Service, nil)
+	})
+
+	AfterEach(func() {
+		svr.Stop()
+		ctrl.Finish()
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".parseSSHCommand", func() {
+		It("should parse git-upload-pack and git-receive-pack commands", func() {
+			service, ns, name, err := parseSSHCommand("git-upload-pack '/r/repo1'")
+			Expect(err).To(BeNil())
+			Expect(service).To(Equal("upload-pack"))
+			Expect(ns).To(Equal("r"))
+			Expect(name).To(Equal("repo1"))
+
+			service, ns, name, err = parseSSHCommand("git receive-pack 'ns1/repo1'")
+			Expect(err).To(BeNil())
+			Expect(service).To(Equal("receive-pack"))
+			Expect(ns).To(Equal("ns1"))
+			Expect(name).To(Equal("repo1"))
+		})
+
+		It("should return error if command is not a git transport command", func() {
+			_, _, _, err := parseSSHCommand("ls -la")
+			Expect(err).To(Equal(ErrSSHUnknownCommand))
+			_, _, _, err = parseSSHCommand("git-upload-pack")
+			Expect(err).To(Equal(ErrSSHUnknownCommand))
+		})
+
+		It("should return error if repository path is invalid", func() {
+			_, _, _, err := parseSSHCommand("git-upload-pack '/repo1'")
+			Expect(err).To(Equal(ErrSSHInvalidRepoPath))
+			_, _, _, err = parseSSHCommand("git-upload-pack '/r/repo1/x'")
+			Expect(err).To(Equal(ErrSSHInvalidRepoPath))
+		})
+	})
+
+	Describe(".getSSHPushTokens", func() {
+		It("should return the tokens and ignore the password part of the user info", func() {
+			Expect(getSSHPushTokens("token1,token2:-")).To(Equal("token1,token2"))
+			Expect(getSSHPushTokens("token1")).To(Equal("token1"))
+		})
+	})
+
+	Describe(".readSSHPushRequest", func() {
+		oldHash := "5b9ba1de20344b12cce76256b67cff9bb31e77b2"
+		newHash := "8d998c7de21bbe561f7992bb983cef4b1554993b"
+		zeroHash := strings.Repeat("0", 40)
+
+		It("should return the commands and indicate that a packfile is expected for an update", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite(oldHash + " " + newHash + " refs/heads/master\x00report-status\n"))
+			body.Write(packetFlush())
+			body.WriteString("PACK")
+			request, hasPack, err := readSSHPushRequest(body)
+			Expect(err).To(BeNil())
+			Expect(hasPack).To(BeTrue())
+			Expect(string(request)).To(HaveSuffix("0000"))
+			Expect(body.String()).To(Equal("PACK"))
+		})
+
+		It("should indicate that a packfile is not expected when all commands are deletes", func() {
+			body := bytes.NewBuffer(nil)
+			body.Write(packetWrite(oldHash + " " + zeroHash + " refs/heads/master\x00report-status\n"))
+			body.Write(packetFlush())
+			_, hasPack, err := readSSHPushRequest(body)
+			Expect(err).To(BeNil())
+			Expect(hasPack).To(BeFalse())
+		})
+
+		It("should return error if the client hung up before sending a flush packet", func() {
+			body := bytes.NewBuffer(packetWrite(oldHash + " " + newHash + " refs/heads/master\n"))
+			_, _, err := readSSHPushRequest(body)
+			Expect(err).To(Equal(ErrSSHUnexpectedHangup))
+		})
+	})
+
+	Describe(".loadSSHHostKey", func() {
+		It("should generate and store a host key when none exist", func() {
+			signer, err := loadSSHHostKey(cfg.GetSSHHostKeyPath())
+			Expect(err).To(BeNil())
+			Expect(signer.PublicKey().Type()).To(Equal(ssh.KeyAlgoED25519))
+			Expect(cfg.GetSSHHostKeyPath()).To(BeAnExistingFile())
+		})
+
+		It("should load the stored host key", func() {
+			signer, err := loadSSHHostKey(cfg.GetSSHHostKeyPath())
+			Expect(err).To(BeNil())
+			signer2, err := loadSSHHostKey(cfg.GetSSHHostKeyPath())
+			Expect(err).To(BeNil())
+			Expect(signer2.PublicKey().Marshal()).To(Equal(signer.PublicKey().Marshal()))
+		})
+
+		It("should return error when the stored host key is not an ed25519 key", func() {
+			privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			der, _ := x509.MarshalECPrivateKey(privKey)
+			bz := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+			Expect(os.MkdirAll(filepath.Dir(cfg.GetSSHHostKeyPath()), 0700)).To(BeNil())
+			Expect(ioutil.WriteFile(cfg.GetSSHHostKeyPath(), bz, 0600)).To(BeNil())
+			_, err := loadSSHHostKey(cfg.GetSSHHostKeyPath())
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("host key must be an ed25519 key"))
+		})
+	})
+
+	Describe(".authenticateSSHKey", func() {
+		It("should return error if key is not an ed25519 key", func() {
+			ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			pub, _ := ssh.NewPublicKey(&ecKey.PublicKey)
+			_, err := svr.authenticateSSHKey(nil, pub)
+			Expect(err).To(Equal(ErrSSHKeyNotSupported))
+		})
+
+		It("should return error if key is not a registered push key", func() {
+			mockObjects.PushKeyKeeper.EXPECT().Get(pushKeyID).Return(state.BarePushKey())
+			pub, _ := ssh.NewPublicKey(stded25519.PublicKey(key.PubKey().MustBytes()))
+			_, err := svr.authenticateSSHKey(nil, pub)
+			Expect(err).To(Equal(ErrSSHUnknownPushKey))
+		})
+
+		It("should return the push key ID in the permissions if key is a registered push key", func() {
+			mockObjects.PushKeyKeeper.EXPECT().Get(pushKeyID).Return(&state.PushKey{PubKey: key.PubKey().ToPublicKey()})
+			pub, _ := ssh.NewPublicKey(stded25519.PublicKey(key.PubKey().MustBytes()))
+			perms, err := svr.authenticateSSHKey(nil, pub)
+			Expect(err).To(BeNil())
+			Expect(perms.Extensions[sshPushKeyIDExt]).To(Equal(pushKeyID))
+		})
+	})
+
+	When("serving git requests", func() {
+		var repoName string
+		var repoState *state.Repository
+
+		BeforeEach(func() {
+			repoName = util.RandString(5)
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+			testutil2.AppendCommit(filepath.Join(cfg.GetRepoRoot(), repoName), "file.txt", "hello", "commit 1")
+			repoState = state.BareRepository()
+			repoState.CreatedAt = 1
+
+			port, _ := freeport.GetFreePort()
+			cfg.Remote.SSHAddress = fmt.Sprintf("127.0.0.1:%d", port)
+			Expect(svr.startSSH()).To(BeNil())
+		})
+
+		dial := func(user string) *ssh.Client {
+			mockObjects.PushKeyKeeper.EXPECT().Get(pushKeyID).Return(&state.PushKey{PubKey: key.PubKey().ToPublicKey()})
+			signer, err := ssh.NewSignerFromKey(stded25519.PrivateKey(key.PrivKey().MustBytes()))
+			Expect(err).To(BeNil())
+			client, err := ssh.Dial("tcp", cfg.Remote.SSHAddress, &ssh.ClientConfig{
+				User:            user,
+				Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			})
+			Expect(err).To(BeNil())
+			return client
+		}
+
+		run := func(client *ssh.Client, command string) (string, error) {
+			session, err := client.NewSession()
+			Expect(err).To(BeNil())
+			defer session.Close()
+			stderr := bytes.NewBuffer(nil)
+			session.Stderr = stderr
+			err = session.Run(command)
+			return stderr.String(), err
+		}
+
+		It("should advertise references of the repository for git-upload-pack", func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState)
+			client := dial("git")
+			defer client.Close()
+
+			session, err := client.NewSession()
+			Expect(err).To(BeNil())
+			defer session.Close()
+			stdin, _ := session.StdinPipe()
+			stdout := bytes.NewBuffer(nil)
+			session.Stdout = stdout
+			Expect(session.Start("git-upload-pack '/r/" + repoName + "'")).To(BeNil())
+			stdin.Write(packetFlush())
+			stdin.Close()
+			Expect(session.Wait()).To(BeNil())
+			Expect(stdout.String()).To(ContainSubstring("refs/heads/master"))
+		})
+
+		It("should reject a request for the full history of a shallow repository", func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState)
+			path := filepath.Join(cfg.GetRepoRoot(), repoName)
+			head := testutil2.GetRecentCommitHash(path, "HEAD")
+			Expect(ioutil.WriteFile(filepath.Join(path, ".git", "shallow"), []byte(head+"\n"), 0644)).To(BeNil())
+			client := dial("git")
+			defer client.Close()
+
+			session, err := client.NewSession()
+			Expect(err).To(BeNil())
+			defer session.Close()
+			stdin, _ := session.StdinPipe()
+			stdout := bytes.NewBuffer(nil)
+			session.Stdout = stdout
+			Expect(session.Start("git-upload-pack '/r/" + repoName + "'")).To(BeNil())
+			stdin.Write(packetWrite("want " + head + "\n"))
+			stdin.Write(packetFlush())
+			stdin.Write(packetWrite("done\n"))
+			Expect(session.Wait()).ToNot(BeNil())
+			Expect(stdout.String()).To(ContainSubstring("ERR " + ErrShallowRepoFullHistory.Error()))
+		})
+
+		It("should reject git-receive-pack when no push token is provided", func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState)
+			client := dial("")
+			defer client.Close()
+
+			stderr, err := run(client, "git-receive-pack '/r/"+repoName+"'")
+			Expect(err).ToNot(BeNil())
+			Expect(err.(*ssh.ExitError).ExitStatus()).To(Equal(1))
+			Expect(stderr).To(ContainSubstring(ErrPushTokenRequired.Error()))
+		})
+
+		It("should reject git-receive-pack when push token is not signed by the authenticated push key", func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState)
+			otherKey := ed25519.NewKeyFromIntSeed(2)
+			token := pushtoken.MakeFromKey(otherKey, &types.TxDetail{RepoName: repoName, PushKeyID: otherKey.PushAddr().String()})
+			svr.authenticate = func([]*types.TxDetail, *state.Repository, *state.Namespace, core.Keepers,
+				validation.TxDetailChecker) (policy.EnforcerFunc, error) {
+				return nil, nil
+			}
+			client := dial(token + ":-")
+			defer client.Close()
+
+			stderr, err := run(client, "git-receive-pack '/r/"+repoName+"'")
+			Expect(err).ToNot(BeNil())
+			Expect(stderr).To(ContainSubstring(ErrSSHPushKeyMismatch.Error()))
+		})
+
+		It("should return error if repository does not exist", func() {
+			mockObjects.RepoKeeper.EXPECT().Get("unknown").Return(state.BareRepository())
+			mockObjects.RepoKeeper.EXPECT().GetRedirect("unknown").Return("")
+			client := dial("git")
+			defer client.Close()
+
+			stderr, err := run(client, "git-upload-pack '/r/unknown'")
+			Expect(err).ToNot(BeNil())
+			Expect(stderr).To(ContainSubstring(ErrSSHRepoNotFound.Error()))
+		})
+	})
+})
@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/make-os/kit/crypto/ed25519"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/policy"
+	"github.com/make-os/kit/types/core"
+	"github.com/pkg/errors"
+)
+
+// CheckProtectedBranch ensures that an update to a protected branch points
+// to a commit approved by the number of distinct push keys required by the
+// repository's config.
+//
+// An approval is counted only if:
+//   - it was made for the target reference.
+//   - its signature is valid for the repository, reference and approved commit.
+//   - it was signed by a push key that is an active contributor of the repository.
+//   - it was not signed by the pusher.
+//
+// repo: The target repository
+// ref: The target branch reference
+// commitHash: The new tip of the branch
+// pusherKeyID: The push key ID of the pusher
+// height: The current block height
+// getPushKey: Getter function for fetching push public key
+func CheckProtectedBranch(
+	repo plumbing2.LocalRepo,
+	ref,
+	commitHash,
+	pusherKeyID string,
+	height uint64,
+	getPushKey core.PushKeyGetter) error {
+
+	repoState := repo.GetState()
+	if repoState == nil {
+		return nil
+	}
+
+	pb := policy.GetProtectedBranch(repoState.Config, ref)
+	if pb == nil || pb.Approvals <= 0 {
+		return nil
+	}
+
+	approvals, err := plumbing2.GetApprovals(repo, commitHash)
+	if err != nil {
+		return errors.Wrap(err, "failed to read approvals")
+	}
+
+	approvers := map[string]struct{}{}
+	msg := plumbing2.MakeApprovalMsg(repo.GetName(), ref, commitHash)
+	for _, approval := range approvals {
+		if approval.Reference != ref {
+			continue
+		}
+		if approval.PushKeyID == pusherKeyID || !repo.IsContributor(approval.PushKeyID, height) {
+			continue
+		}
+		if _, ok := approvers[approval.PushKeyID]; ok {
+			continue
+		}
+
+		pk, err := getPushKey(approval.PushKeyID)
+		if err != nil || pk.IsEmpty() {
+			continue
+		}
+		pubKey, err := ed25519.PubKeyFromBytes(pk.Bytes())
+		if err != nil {
+			continue
+		}
+		if ok, err := pubKey.Verify(msg, approval.Signature); err != nil || !ok {
+			continue
+		}
+
+		approvers[approval.PushKeyID] = struct{}{}
+	}
+
+	if len(approvers) < pb.Approvals {
+		return fmt.Errorf("protected branch requires %d approval(s) of commit %s, found %d",
+			pb.Approvals, commitHash, len(approvers))
+	}
+
+	return nil
+}
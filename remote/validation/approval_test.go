@@ -0,0 +1,173 @@
+package validation_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/remote/validation"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Approval", func() {
+	var err error
+	var cfg *config.AppConfig
+	var testRepo plumbing2.LocalRepo
+	var path, repoName, commitHash string
+	var pusher, approver1, approver2 *ed25519.Key
+	var repoState *state.Repository
+
+	var makeApproval = func(k *ed25519.Key, repoName, ref string) *plumbing2.Approval {
+		sig, _ := k.PrivKey().Sign(plumbing2.MakeApprovalMsg(repoName, ref, commitHash))
+		return &plumbing2.Approval{PushKeyID: k.PushAddr().String(), Reference: ref, Signature: sig}
+	}
+
+	var addApprovals = func(approvals ...*plumbing2.Approval) {
+		var lines []string
+		for _, a := range approvals {
+			lines = append(lines, a.String())
+		}
+		Expect(testRepo.AddEntryToNote(plumbing2.ApprovalNoteRef, commitHash, strings.Join(lines, "\n"))).To(BeNil())
+	}
+
+	var approveRef = func(ref string, keys ...*ed25519.Key) {
+		var approvals []*plumbing2.Approval
+		for _, k := range keys {
+			approvals = append(approvals, makeApproval(k, repoName, ref))
+		}
+		addApprovals(approvals...)
+	}
+
+	var approve = func(keys ...*ed25519.Key) {
+		approveRef("refs/heads/master", keys...)
+	}
+
+	var getPushKey = func(pushKeyID string) (ed25519.PublicKey, error) {
+		for _, k := range []*ed25519.Key{pusher, approver1, approver2} {
+			if k.PushAddr().String() == pushKeyID {
+				return k.PubKey().ToPublicKey(), nil
+			}
+		}
+		return ed25519.EmptyPublicKey, nil
+	}
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+
+		pusher = ed25519.NewKeyFromIntSeed(1)
+		approver1 = ed25519.NewKeyFromIntSeed(2)
+		approver2 = ed25519.NewKeyFromIntSeed(3)
+
+		repoName = util.RandString(5)
+		path = filepath.Join(cfg.GetRepoRoot(), repoName)
+		testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+		testRepo, err = repo.GetWithGitModule(cfg.Node.GitBinPath, path)
+		Expect(err).To(BeNil())
+		testutil2.AppendCommit(path, "file.txt", "line 1\n", "commit 1")
+		commitHash = testutil2.GetRecentCommitHash(path, "refs/heads/master")
+
+		repoState = state.BareRepository()
+		repoState.Config.Protected = state.ProtectedBranches{{Name: "refs/heads/master", Approvals: 2}}
+		repoState.Contributors[pusher.PushAddr().String()] = &state.RepoContributor{}
+		repoState.Contributors[approver1.PushAddr().String()] = &state.RepoContributor{}
+		repoState.Contributors[approver2.PushAddr().String()] = &state.RepoContributor{}
+		testRepo.SetState(repoState)
+	})
+
+	AfterEach(func() {
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CheckProtectedBranch", func() {
+		It("should return nil when the branch is not protected", func() {
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/dev", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).To(BeNil())
+		})
+
+		It("should return error when the commit has no approvals", func() {
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("protected branch requires 2 approval(s) of commit " + commitHash + ", found 0"))
+		})
+
+		It("should return nil when the commit has the required approvals", func() {
+			approve(approver1, approver2)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).To(BeNil())
+		})
+
+		It("should not count the pusher's approval", func() {
+			approve(approver1, pusher)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("found 1"))
+		})
+
+		It("should not count duplicate approvals", func() {
+			approve(approver1, approver1)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("found 1"))
+		})
+
+		It("should not count approvals of non-contributors", func() {
+			delete(repoState.Contributors, approver2.PushAddr().String())
+			approve(approver1, approver2)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("found 1"))
+		})
+
+		It("should not count approvals with invalid signatures", func() {
+			sig, _ := approver2.PrivKey().Sign([]byte("something else"))
+			bad := &plumbing2.Approval{PushKeyID: approver2.PushAddr().String(), Reference: "refs/heads/master", Signature: sig}
+			addApprovals(makeApproval(approver1, repoName, "refs/heads/master"), bad)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("found 1"))
+		})
+
+		It("should not count approvals made for another repository", func() {
+			addApprovals(makeApproval(approver1, repoName, "refs/heads/master"), makeApproval(approver2, "other-repo", "refs/heads/master"))
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("found 1"))
+		})
+
+		It("should not count approvals made for another reference", func() {
+			approveRef("refs/heads/dev", approver1, approver2)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("found 0"))
+		})
+
+		It("should not count approvals whose signature was made for another reference", func() {
+			replayed := makeApproval(approver2, repoName, "refs/heads/dev")
+			replayed.Reference = "refs/heads/master"
+			addApprovals(makeApproval(approver1, repoName, "refs/heads/master"), replayed)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/master", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("found 1"))
+		})
+
+		It("should apply protection to branches matching a pattern", func() {
+			repoState.Config.Protected = state.ProtectedBranches{{Name: "refs/heads/release/*", Approvals: 1}}
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/release/v1", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).ToNot(BeNil())
+			approveRef("refs/heads/release/v1", approver1)
+			err = validation.CheckProtectedBranch(testRepo, "refs/heads/release/v1", commitHash, pusher.PushAddr().String(), 1, getPushKey)
+			Expect(err).To(BeNil())
+		})
+	})
+})
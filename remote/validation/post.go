@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
@@ -25,6 +26,8 @@ var (
 	MaxIssueTitleLen          = 256
 	ErrCannotWriteToClosedRef = fmt.Errorf("cannot write to a closed reference")
 	mergeReqFields            = []string{"base", "baseHash", "target", "targetHash"}
+	milestoneFields           = []string{"due", "issues"}
+	MaxMilestoneIssues        = 100
 )
 
 // ValidatePostCommitArg contains arguments for ValidatePostCommit
@@ -141,7 +144,7 @@ type PostCommitChecker func(
 func CheckPostCommit(repo pl.LocalRepo, commit pl.Commit, args *CheckPostCommitArgs) (*pl.PostBody, error) {
 
 	// Reference name must be valid
-	if !pl.IsIssueReference(args.Reference) && !pl.IsMergeRequestReference(args.Reference) {
+	if !pl.IsPostReference(args.Reference) {
 		return nil, fmt.Errorf("post number is not valid. Must be numeric")
 	}
 
@@ -212,17 +215,20 @@ func CheckPostBody(
 	fm map[string]interface{},
 	content []byte) error {
 
-	var commonFields = []string{"title", "reactions", "replyTo", "close"}
+	var commonFields = []string{"title", "reactions", "replyTo", "close", "edit", "delete"}
 	var issueFields = []string{"labels", "assignees"}
 	var allowedFields []string
 	var isIssuePost = pl.IsIssueReference(reference)
 	var isMergeReqPost = pl.IsMergeRequestReference(reference)
+	var isMilestonePost = pl.IsMilestoneReference(reference)
 
 	// Check whether the fields are allowed for the type of post reference
 	if isIssuePost {
 		allowedFields = append(allowedFields, append(commonFields, issueFields...)...)
 	} else if isMergeReqPost {
 		allowedFields = append(allowedFields, append(commonFields, mergeReqFields...)...)
+	} else if isMilestonePost {
+		allowedFields = append(allowedFields, append(commonFields, milestoneFields...)...)
 	} else {
 		return fmt.Errorf("unsupported post type")
 	}
@@ -249,6 +255,11 @@ func CheckPostBody(
 		return CheckMergeRequestPostBody(keepers, repo, commit, reference, isNewRef, fm)
 	}
 
+	// Perform checks for milestone post
+	if isMilestonePost {
+		return CheckMilestonePostBody(commit, fm)
+	}
+
 	return nil
 }
 
@@ -307,6 +318,11 @@ func CheckCommonPostBody(
 		}
 	}
 
+	// Check edit and delete fields if set.
+	if err := checkPostCommentRevision(repo, commit, isNewRef, obj); err != nil {
+		return err
+	}
+
 	// Check reactions if set.
 	if val := reactions.InterSlice(); len(val) > 0 {
 		if len(val) > 10 {
@@ -336,6 +352,75 @@ func CheckCommonPostBody(
 	return nil
 }
 
+// checkPostCommentRevision checks the 'edit' and 'delete' fields of a post body.
+// The target of an edit or delete must be an ancestor of the commit and must
+// have been pushed with the same push key as the commit.
+func checkPostCommentRevision(repo pl.LocalRepo, commit pl.Commit, isNewRef bool, obj objx.Map) error {
+	var commitHash = commit.GetHash().String()
+
+	var field string
+	for _, f := range []string{"edit", "delete"} {
+		val := obj.Get(f)
+		if val.IsNil() {
+			continue
+		}
+		if !val.IsStr() {
+			return fe(-1, makeField(f, commitHash), "expected a string value")
+		}
+		if field != "" {
+			return fe(-1, makeField(f, commitHash), "cannot be combined with '"+field+"'")
+		}
+		field = f
+	}
+	if field == "" {
+		return nil
+	}
+
+	// Ensure edit or delete is not set if the post reference is new
+	if isNewRef {
+		return fe(-1, makeField(field, commitHash), "not expected in a new post commit")
+	}
+
+	// A revision cannot also be a reply
+	if len(obj.Get("replyTo").String()) > 0 {
+		return fe(-1, makeField(field, commitHash), "cannot be combined with 'replyTo'")
+	}
+
+	// Target hash must have len >= 4 or < 40
+	target := obj.Get(field).String()
+	if len(target) < 4 || len(target) > 40 {
+		return fe(-1, makeField(field, commitHash), "invalid hash value")
+	}
+
+	// Ensure the post commit is a descendant of the target
+	if repo.IsAncestor(target, commitHash) != nil {
+		return fe(-1, makeField(field, commitHash), "hash is not a known ancestor")
+	}
+
+	// Ensure the target comment was pushed by the same push key
+	fullTarget, err := repo.ExpandShortHash(target)
+	if err != nil {
+		return fe(-1, makeField(field, commitHash), "hash could not be expanded")
+	}
+	targetCommit, err := repo.CommitObject(plumbing.NewHash(fullTarget))
+	if err != nil {
+		return fe(-1, makeField(field, commitHash), "target comment could not be read")
+	}
+	targetPusher, err := pl.GetCommitPusherKeyID(targetCommit)
+	if err != nil {
+		return fe(-1, makeField(field, commitHash), err.Error())
+	}
+	pusher, err := pl.GetCommitPusherKeyID(commit.UnWrap())
+	if err != nil {
+		return fe(-1, makeField(field, commitHash), err.Error())
+	}
+	if targetPusher != pusher {
+		return fe(-1, makeField(field, commitHash), "only the pusher of the target comment can "+field+" it")
+	}
+
+	return nil
+}
+
 // CheckIssuePostBody performs sanity checks on fields of an issue post body
 func CheckIssuePostBody(commit pl.Commit, fm map[string]interface{}) error {
 
@@ -385,6 +470,44 @@ func CheckIssuePostBody(commit pl.Commit, fm map[string]interface{}) error {
 	return nil
 }
 
+// CheckMilestonePostBody performs sanity checks on fields of a milestone post body
+func CheckMilestonePostBody(commit pl.Commit, fm map[string]interface{}) error {
+
+	commitHash := commit.GetHash().String()
+
+	obj := objx.New(fm)
+	due := obj.Get("due")
+	if !due.IsNil() && !due.IsStr() {
+		return fe(-1, makeField("due", commitHash), "expected a string value")
+	}
+
+	issues := obj.Get("issues")
+	if !issues.IsNil() && !issues.IsInterSlice() {
+		return fe(-1, makeField("issues", commitHash), "expected a list of issue numbers")
+	}
+
+	// Check due date if set.
+	if val := due.String(); val != "" {
+		if _, err := time.Parse(pl.MilestoneDueDateFormat, val); err != nil {
+			return fe(-1, makeField("due", commitHash), "invalid date; expected format YYYY-MM-DD")
+		}
+	}
+
+	// Check issues if set.
+	if val := issues.InterSlice(); len(val) > 0 {
+		if len(val) > MaxMilestoneIssues {
+			return fe(-1, makeField("issues", commitHash), fmt.Sprintf("too many issues; cannot exceed %d", MaxMilestoneIssues))
+		}
+		for i, id := range val {
+			if !isIssueNumber(id) {
+				return fe(i, makeField("issues", commitHash), "expected a non-zero issue number")
+			}
+		}
+	}
+
+	return nil
+}
+
 // CheckMergeRequestPostBody performs sanity and consistency
 // checks on post fields specific to a merge request
 func CheckMergeRequestPostBody(
@@ -542,6 +665,22 @@ func checkMergeRequestPostBodySanity(commit pl.Commit, body map[string]interface
 	return nil
 }
 
+// isIssueNumber checks whether v is a non-zero whole number.
+// A negative number refers to an issue being unlinked.
+func isIssueNumber(v interface{}) bool {
+	switch n := v.(type) {
+	case int:
+		return n != 0
+	case int64:
+		return n != 0
+	case uint64:
+		return n != 0
+	case float64:
+		return n != 0 && n == float64(int64(n))
+	}
+	return false
+}
+
 var makeField = func(name, commitHash string) string {
 	return fmt.Sprintf("<commit#%s>.%s", commitHash[:7], name)
 }
@@ -2,6 +2,7 @@ package validation_test
 
 import (
 	"bytes"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"strings"
@@ -526,6 +527,81 @@ var _ = Describe("Validation", func() {
 				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.replyTo","msg":"hash is not a known ancestor"`))
 			})
 
+			It("should return error when 'edit' or 'delete' is not string", func() {
+				for _, f := range []string{"edit", "delete"} {
+					fm := map[string]interface{}{f: 123}
+					err := validation.CheckPostBody(mockKeepers, nil, ref, wc, false, fm, nil)
+					Expect(err).ToNot(BeNil())
+					Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.` + f + `","msg":"expected a string value"`))
+				}
+			})
+
+			It("should return error when 'edit' and 'delete' are both set", func() {
+				fm := map[string]interface{}{"edit": "abcdef", "delete": "abcdef"}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, false, fm, nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.delete","msg":"cannot be combined with 'edit'"`))
+			})
+
+			It("should return error when 'edit' is set in a new reference", func() {
+				fm := map[string]interface{}{"title": "a title", "edit": "abcdef"}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte("content"))
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.edit","msg":"not expected in a new post commit"`))
+			})
+
+			It("should return error when 'edit' is combined with 'replyTo'", func() {
+				ancestor := "hash_of_ancestor"
+				mockRepo.EXPECT().IsAncestor(ancestor, commit.Hash.String()).Return(nil)
+				fm := map[string]interface{}{"edit": "abcdef", "replyTo": ancestor}
+				err := validation.CheckPostBody(mockKeepers, mockRepo, ref, wc, false, fm, nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.edit","msg":"cannot be combined with 'replyTo'"`))
+			})
+
+			It("should return error when 'delete' length is too low or too high", func() {
+				fm := map[string]interface{}{"delete": "0x1"}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, false, fm, nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.delete","msg":"invalid hash value"`))
+				fm = map[string]interface{}{"delete": strings.Repeat("a", 41)}
+				err = validation.CheckPostBody(mockKeepers, nil, ref, wc, false, fm, nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.delete","msg":"invalid hash value"`))
+			})
+
+			It("should return error when 'edit' hash does not point to an ancestor", func() {
+				mockRepo.EXPECT().IsAncestor("abcdef", commit.Hash.String()).Return(fmt.Errorf("error"))
+				fm := map[string]interface{}{"edit": "abcdef"}
+				err := validation.CheckPostBody(mockKeepers, mockRepo, ref, wc, false, fm, nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.edit","msg":"hash is not a known ancestor"`))
+			})
+
+			It("should return error when the target of 'edit' was pushed by another push key", func() {
+				target := plumbing2.MakeCommitHash("target")
+				sig := pem.EncodeToMemory(&pem.Block{Type: "PGP SIGNATURE", Headers: map[string]string{"pkID": "pk1abc"}, Bytes: []byte("sig")})
+				mockRepo.EXPECT().IsAncestor("abcdef", commit.Hash.String()).Return(nil)
+				mockRepo.EXPECT().ExpandShortHash("abcdef").Return(target.String(), nil)
+				mockRepo.EXPECT().CommitObject(target).Return(&object.Commit{Hash: target, PGPSignature: string(sig)}, nil)
+				fm := map[string]interface{}{"edit": "abcdef"}
+				err := validation.CheckPostBody(mockKeepers, mockRepo, ref, wc, false, fm, nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.edit","msg":"only the pusher of the target comment can edit it"`))
+			})
+
+			It("should return nil when the target of 'delete' was pushed by the same push key", func() {
+				target := plumbing2.MakeCommitHash("target")
+				sig := pem.EncodeToMemory(&pem.Block{Type: "PGP SIGNATURE", Headers: map[string]string{"pkID": "pk1abc"}, Bytes: []byte("sig")})
+				commit.PGPSignature = string(sig)
+				mockRepo.EXPECT().IsAncestor("abcdef", commit.Hash.String()).Return(nil)
+				mockRepo.EXPECT().ExpandShortHash("abcdef").Return(target.String(), nil)
+				mockRepo.EXPECT().CommitObject(target).Return(&object.Commit{Hash: target, PGPSignature: string(sig)}, nil)
+				fm := map[string]interface{}{"delete": "abcdef"}
+				err := validation.CheckPostBody(mockKeepers, mockRepo, ref, wc, false, fm, nil)
+				Expect(err).To(BeNil())
+			})
+
 			It("should return error when 'reaction' values exceed max", func() {
 				fm := map[string]interface{}{"reactions": []interface{}{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}}
 				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, false, fm, nil)
@@ -712,6 +788,67 @@ var _ = Describe("Validation", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		Context("milestone post body check", func() {
+			var ref = plumbing2.MakeMilestoneReference(1)
+
+			It("should return error when an issue field is set", func() {
+				fm := map[string]interface{}{"title": "title", "labels": []interface{}{"bug"}}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.labels","msg":"unexpected field"`))
+			})
+
+			It("should return error when 'due' is not a string", func() {
+				fm := map[string]interface{}{"title": "title", "due": 123}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.due","msg":"expected a string value"`))
+			})
+
+			It("should return error when 'due' is not a valid date", func() {
+				fm := map[string]interface{}{"title": "title", "due": "31-12-2020"}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.due","msg":"invalid date; expected format YYYY-MM-DD"`))
+			})
+
+			It("should return error when 'issues' is not a list", func() {
+				fm := map[string]interface{}{"title": "title", "issues": "1,2"}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.issues","msg":"expected a list of issue numbers"`))
+			})
+
+			It("should return error when 'issues' entries exceeded max", func() {
+				var issues []interface{}
+				for i := 1; i <= validation.MaxMilestoneIssues+1; i++ {
+					issues = append(issues, i)
+				}
+				fm := map[string]interface{}{"title": "title", "issues": issues}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.issues","msg":"too many issues; cannot exceed 100"`))
+			})
+
+			It("should return error when 'issues' entry is not a non-zero number", func() {
+				fm := map[string]interface{}{"title": "title", "issues": []interface{}{1, "2"}}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.issues","index":"1","msg":"expected a non-zero issue number"`))
+
+				fm = map[string]interface{}{"title": "title", "issues": []interface{}{0}}
+				err = validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.issues","index":"0","msg":"expected a non-zero issue number"`))
+			})
+
+			It("should return no error when successful", func() {
+				fm := map[string]interface{}{"title": "title", "due": "2020-12-31", "issues": []interface{}{1, -2, float64(3)}}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Describe(".CheckMergeRequestPostBodyConsistency", func() {
@@ -272,6 +272,12 @@ func CheckPushNoteConsistency(note pptyp.PushNote, logic core.Logic) error {
 		return errors2.FieldError("repo", msg)
 	}
 
+	// Ensure the repository is not archived
+	if repo.Archived {
+		msg := fmt.Sprintf("repository named '%s' is archived", note.GetRepoName())
+		return errors2.FieldError("repo", msg)
+	}
+
 	// If namespace is provide, ensure it exists
 	if note.GetNamespace() != "" {
 		ns := logic.NamespaceKeeper().Get(crypto2.MakeNamespaceHash(note.GetNamespace()))
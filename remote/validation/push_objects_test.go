@@ -319,6 +319,19 @@ var _ = Describe("Validation", func() {
 			})
 		})
 
+		When("the repository is archived", func() {
+			BeforeEach(func() {
+				tx := &types.Note{RepoName: "repo1"}
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(&state.Repository{Balance: "10", Archived: true})
+				err = validation.CheckPushNoteConsistency(tx, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"repo","msg":"repository named 'repo1' is archived"`))
+			})
+		})
+
 		When("namespace is set but does not exist", func() {
 			BeforeEach(func() {
 				tx := &types.Note{Namespace: "ns1"}
@@ -48,9 +48,10 @@ func ValidateChange(
 	refname := change.Item.GetName()
 	isIssueRef := plumbing2.IsIssueReferencePath(refname)
 	isMergeRequestRef := plumbing2.IsMergeRequestReferencePath(refname)
+	isMilestoneRef := plumbing2.IsMilestoneReferencePath(refname)
 
-	// Handle issue or merge request branch validation.
-	if plumbing2.IsBranch(refname) && (isIssueRef || isMergeRequestRef) {
+	// Handle issue, merge request or milestone branch validation.
+	if plumbing2.IsBranch(refname) && (isIssueRef || isMergeRequestRef || isMilestoneRef) {
 		commit, err := localRepo.WrappedCommitObject(plumbing.NewHash(change.Item.GetData()))
 		if err != nil {
 			return errors.Wrap(err, "unable to get commit object")
@@ -72,7 +73,22 @@ func ValidateChange(
 		if err != nil {
 			return errors.Wrap(err, "unable to get commit object")
 		}
-		return CheckCommit(commit, detail, getPushKey)
+		if err = CheckCommit(commit, detail, getPushKey); err != nil {
+			return err
+		}
+
+		// Ensure updates to protected branches have been approved.
+		// Merge proposal fulfilments are exempted as they have been voted on.
+		if detail.MergeProposalID == "" {
+			bi, err := keepers.SysKeeper().GetLastBlockInfo()
+			if err != nil {
+				return errors.Wrap(err, "failed to get last block info")
+			}
+			return CheckProtectedBranch(localRepo, refname, commit.Hash.String(), detail.PushKeyID,
+				uint64(bi.Height), getPushKey)
+		}
+
+		return nil
 	}
 
 	// Handle tag validation
@@ -3,7 +3,9 @@ package api
 import (
 	modulestypes "github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/rpc"
+	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/util"
 	"github.com/spf13/cast"
 	"github.com/stretchr/objx"
@@ -54,6 +56,66 @@ func (a *RepoAPI) upsertOwner(params interface{}) (resp *rpc.Response) {
 	return rpc.Success(a.mods.Repo.UpsertOwner(cast.ToStringMap(params)))
 }
 
+// proposeSpend creates a proposal to spend from a repository's balance
+func (a *RepoAPI) proposeSpend(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.ProposeSpend(cast.ToStringMap(params)))
+}
+
+// proposeArchive creates a proposal to archive a repository
+func (a *RepoAPI) proposeArchive(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.ProposeArchive(cast.ToStringMap(params)))
+}
+
+// proposeDelete creates a proposal to permanently delete a repository
+func (a *RepoAPI) proposeDelete(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.ProposeDelete(cast.ToStringMap(params)))
+}
+
+// proposeRename creates a proposal to rename a repository
+func (a *RepoAPI) proposeRename(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.ProposeRename(cast.ToStringMap(params)))
+}
+
+// proposeNamespaceUpdate creates a proposal to update a namespace owned by a repository
+func (a *RepoAPI) proposeNamespaceUpdate(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.ProposeNamespaceUpdate(cast.ToStringMap(params)))
+}
+
+// watchProposals sends lifecycle events of proposals of the given repositories
+// as notifications to the websocket connection the request was received on.
+func (a *RepoAPI) watchProposals(params interface{}, ctx *rpc.CallContext) (resp *rpc.Response) {
+	if ctx.Conn == nil {
+		return rpc.Error(types.ErrCodeWebsocketRequired, "a websocket connection is required", nil)
+	}
+
+	obj := objx.New(cast.ToStringMap(params))
+	names := cast.ToStringSlice(obj.Get("names").InterSlice())
+	stop := a.mods.Repo.WatchProposals(names, func(evt *core.ProposalEvent) {
+		_ = ctx.Conn.Notify(constants.NamespaceRepo+"_proposalEvent", evt)
+	})
+	go func() {
+		<-ctx.Conn.Closed()
+		stop()
+	}()
+
+	return rpc.StatusOK()
+}
+
+// withdrawProposal withdraws a proposal
+func (a *RepoAPI) withdrawProposal(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.WithdrawProposal(cast.ToStringMap(params)))
+}
+
+// cancelProposal cancels a queued proposal
+func (a *RepoAPI) cancelProposal(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.CancelProposal(cast.ToStringMap(params)))
+}
+
+// delegateVote delegates the proposal voting power of a repository owner
+func (a *RepoAPI) delegateVote(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.DelegateVote(cast.ToStringMap(params)))
+}
+
 // depositPropFee deposit fees into a proposal
 func (a *RepoAPI) depositPropFee(params interface{}) (resp *rpc.Response) {
 	return rpc.Success(a.mods.Repo.DepositProposalFee(cast.ToStringMap(params)))
@@ -77,6 +139,21 @@ func (a *RepoAPI) tracked(interface{}) (resp *rpc.Response) {
 	return rpc.Success(a.mods.Repo.GetTracked())
 }
 
+// checkPolicy simulates a policy check and returns its evaluation trace
+func (a *RepoAPI) checkPolicy(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.CheckPolicy(cast.ToStringMap(params)))
+}
+
+// listProposals returns the proposals of a repository that match the given filters
+func (a *RepoAPI) listProposals(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.ListProposals(cast.ToStringMap(params)))
+}
+
+// getProposal returns a repository proposal and the votes cast on it
+func (a *RepoAPI) getProposal(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.GetProposal(cast.ToStringMap(params)))
+}
+
 // listByCreator returns names of repos created by an address
 func (a *RepoAPI) listByCreator(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
@@ -122,6 +199,11 @@ func (a *RepoAPI) readFile(params interface{}) (resp *rpc.Response) {
 	})
 }
 
+// archive returns the base64-encoded archive of a repository reference
+func (a *RepoAPI) archive(params interface{}) (resp *rpc.Response) {
+	return rpc.Success(a.mods.Repo.ReadArchive(cast.ToStringMap(params)))
+}
+
 // getBranches returns a list of branches in a repository
 func (a *RepoAPI) getBranches(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
@@ -226,8 +308,9 @@ func (a *RepoAPI) reopenIssue(params interface{}) (resp *rpc.Response) {
 func (a *RepoAPI) listIssues(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
 	name := m.Get("name").Str()
+	query := m.Get("query").MSI()
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ListIssues(name),
+		"data": a.mods.Repo.ListIssues(name, query),
 	})
 }
 
@@ -237,7 +320,17 @@ func (a *RepoAPI) readIssue(params interface{}) (resp *rpc.Response) {
 	name := m.Get("name").Str()
 	reference := m.Get("reference").Str()
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ReadIssue(name, reference),
+		"data":         a.mods.Repo.ReadIssue(name, reference),
+		"closed":       a.mods.Repo.IsIssueClosed(name, reference),
+		"referencedBy": a.mods.Repo.GetReferencedBy(name, reference),
+	})
+}
+
+// getReferencedBy gets the commits and post comments that reference a post or commit
+func (a *RepoAPI) getReferencedBy(params interface{}) (resp *rpc.Response) {
+	m := objx.New(cast.ToStringMap(params))
+	return rpc.Success(util.Map{
+		"references": a.mods.Repo.GetReferencedBy(m.Get("name").Str(), m.Get("target").Str()),
 	})
 }
 
@@ -275,8 +368,9 @@ func (a *RepoAPI) reopenMergeRequest(params interface{}) (resp *rpc.Response) {
 func (a *RepoAPI) listMergeRequests(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
 	name := m.Get("name").Str()
+	query := m.Get("query").MSI()
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ListIssues(name),
+		"data": a.mods.Repo.ListMergeRequests(name, query),
 	})
 }
 
@@ -286,7 +380,46 @@ func (a *RepoAPI) readMergeRequest(params interface{}) (resp *rpc.Response) {
 	name := m.Get("name").Str()
 	reference := m.Get("reference").Str()
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ReadIssue(name, reference),
+		"data": a.mods.Repo.ReadMergeRequest(name, reference),
+	})
+}
+
+// createMilestone creates a milestone
+func (a *RepoAPI) createMilestone(params interface{}) (resp *rpc.Response) {
+	m := objx.New(cast.ToStringMap(params))
+	name := m.Get("name").Str()
+	callParams := m.Get("params").MSI()
+	return rpc.Success(util.Map{
+		"data": a.mods.Repo.CreateMilestone(name, callParams),
+	})
+}
+
+// closeMilestone closes a milestone
+func (a *RepoAPI) closeMilestone(params interface{}) (resp *rpc.Response) {
+	m := objx.New(cast.ToStringMap(params))
+	name := m.Get("name").Str()
+	reference := m.Get("reference").Str()
+	return rpc.Success(util.Map{
+		"data": a.mods.Repo.CloseMilestone(name, reference),
+	})
+}
+
+// listMilestones lists milestones of a repository
+func (a *RepoAPI) listMilestones(params interface{}) (resp *rpc.Response) {
+	m := objx.New(cast.ToStringMap(params))
+	name := m.Get("name").Str()
+	return rpc.Success(util.Map{
+		"data": a.mods.Repo.ListMilestones(name),
+	})
+}
+
+// readMilestone reads a milestone from a repository
+func (a *RepoAPI) readMilestone(params interface{}) (resp *rpc.Response) {
+	m := objx.New(cast.ToStringMap(params))
+	name := m.Get("name").Str()
+	reference := m.Get("reference").Str()
+	return rpc.Success(util.Map{
+		"data": a.mods.Repo.ReadMilestone(name, reference),
 	})
 }
 
@@ -297,7 +430,18 @@ func (a *RepoAPI) APIs() rpc.APISet {
 		{Name: "create", Namespace: ns, Func: a.createRepo, Desc: "Create a repository"},
 		{Name: "update", Namespace: ns, Func: a.update, Desc: "Update a repository"},
 		{Name: "upsertOwner", Namespace: ns, Func: a.upsertOwner, Desc: "Add or update one or more owners"},
+		{Name: "proposeSpend", Namespace: ns, Func: a.proposeSpend, Desc: "Propose a spend from a repository's balance"},
+		{Name: "proposeArchive", Namespace: ns, Func: a.proposeArchive, Desc: "Propose to archive a repository"},
+		{Name: "proposeDelete", Namespace: ns, Func: a.proposeDelete, Desc: "Propose to permanently delete a repository"},
+		{Name: "proposeRename", Namespace: ns, Func: a.proposeRename, Desc: "Propose to rename a repository"},
+		{Name: "proposeNamespaceUpdate", Namespace: ns, Func: a.proposeNamespaceUpdate, Desc: "Propose to update a namespace owned by a repository"},
+		{Name: "watchProposals", Namespace: ns, Func: a.watchProposals, Desc: "Receive proposal lifecycle events over a websocket connection"},
 		{Name: "depositPropFee", Namespace: ns, Func: a.depositPropFee, Desc: "Deposit fee into a proposal"},
+		{Name: "withdrawProposal", Namespace: ns, Func: a.withdrawProposal, Desc: "Withdraw a proposal"},
+		{Name: "delegateVote", Namespace: ns, Func: a.delegateVote, Desc: "Delegate proposal voting power to another address"},
+		{Name: "cancelProposal", Namespace: ns, Func: a.cancelProposal, Desc: "Cancel a queued proposal"},
+		{Name: "listProposals", Namespace: ns, Func: a.listProposals, Desc: "List and filter the proposals of a repository"},
+		{Name: "getProposal", Namespace: ns, Func: a.getProposal, Desc: "Get a proposal and the votes cast on it"},
 		{Name: "get", Namespace: ns, Func: a.getRepo, Desc: "Get a repository"},
 		{Name: "addContributor", Namespace: ns, Func: a.addContributor, Desc: "Add one or more contributors"},
 		{Name: "vote", Namespace: ns, Func: a.vote, Desc: "Cast a vote on a repository's proposal"},
@@ -305,9 +449,11 @@ func (a *RepoAPI) APIs() rpc.APISet {
 		{Name: "untrack", Namespace: ns, Func: a.untrack, Desc: "Untrack one or more repositories", Private: true},
 		{Name: "tracked", Namespace: ns, Func: a.tracked, Desc: "Get all tracked repositories"},
 		{Name: "listByCreator", Namespace: ns, Func: a.listByCreator, Desc: "List repositories created by an address"},
+		{Name: "checkPolicy", Namespace: ns, Func: a.checkPolicy, Desc: "Simulate a policy check and get its evaluation trace"},
 		{Name: "ls", Namespace: ns, Func: a.ls, Desc: "List files and directories of a repository"},
 		{Name: "readFileLines", Namespace: ns, Func: a.readFileLines, Desc: "Gets the lines of a file in a repository"},
 		{Name: "readFile", Namespace: ns, Func: a.readFile, Desc: "Get the string content of a file in a repository"},
+		{Name: "archive", Namespace: ns, Func: a.archive, Desc: "Get the base64-encoded archive of a repository reference"},
 		{Name: "getBranches", Namespace: ns, Func: a.getBranches, Desc: "Get a list of branches in a repository"},
 		{Name: "getLatestCommit", Namespace: ns, Func: a.getLatestCommit, Desc: "Gets the latest commit of a branch in a repository"},
 		{Name: "getCommits", Namespace: ns, Func: a.getCommits, Desc: "Get a list of commits in a branch of a repository"},
@@ -321,10 +467,15 @@ func (a *RepoAPI) APIs() rpc.APISet {
 		{Name: "reopenIssue", Namespace: ns, Func: a.reopenIssue, Desc: "Reopen an issue"},
 		{Name: "listIssues", Namespace: ns, Func: a.listIssues, Desc: "List issues in a repository"},
 		{Name: "readIssue", Namespace: ns, Func: a.readIssue, Desc: "Read an issue in a repository"},
+		{Name: "getReferencedBy", Namespace: ns, Func: a.getReferencedBy, Desc: "Get commits and posts that reference a post or commit"},
 		{Name: "createMergeRequest", Namespace: ns, Func: a.createMergeRequest, Desc: "Create, add comment or edit a merge request"},
 		{Name: "closeMergeRequest", Namespace: ns, Func: a.closeMergeRequest, Desc: "Close a merge request"},
 		{Name: "reopenMergeRequest", Namespace: ns, Func: a.reopenMergeRequest, Desc: "Reopen a merge request"},
 		{Name: "listMergeRequests", Namespace: ns, Func: a.listMergeRequests, Desc: "List merge requests in a repository"},
 		{Name: "readMergeRequest", Namespace: ns, Func: a.readMergeRequest, Desc: "Read a merge request in a repository"},
+		{Name: "createMilestone", Namespace: ns, Func: a.createMilestone, Desc: "Create, add comment or update a milestone"},
+		{Name: "closeMilestone", Namespace: ns, Func: a.closeMilestone, Desc: "Close a milestone"},
+		{Name: "listMilestones", Namespace: ns, Func: a.listMilestones, Desc: "List milestones and their progress in a repository"},
+		{Name: "readMilestone", Namespace: ns, Func: a.readMilestone, Desc: "Read a milestone and its progress in a repository"},
 	}
 }
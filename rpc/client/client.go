@@ -2,15 +2,18 @@ package client
 
 import (
 	"bytes"
+	"encoding/base64"
 	encJson "encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/gorilla/rpc/v2/json"
+	"github.com/gorilla/websocket"
 	"github.com/make-os/kit/rpc"
 	"github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/util"
@@ -167,6 +170,69 @@ func (c *RPCClient) Call(method string, params interface{}) (res util.Map, statu
 	return m, resp.StatusCode, nil
 }
 
+// Subscribe calls a method over a websocket connection and passes the params
+// of each notification received afterwards to the handler. It blocks until
+// the connection is closed or the handler returns an error.
+func (c *RPCClient) Subscribe(method string, params interface{}, handler func(params encJson.RawMessage) error) error {
+
+	var header = http.Header{}
+	if c.opts.User != "" && c.opts.Password != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(c.opts.User + ":" + c.opts.Password))
+		header.Set("Authorization", "Basic "+auth)
+	}
+
+	url := "ws" + strings.TrimPrefix(c.opts.URL(), "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		return errors.ReqErr(500, ErrCodeConnect, "", err.Error())
+	}
+	defer conn.Close()
+
+	msg, err := encJson.Marshal(map[string]interface{}{
+		"method":  method,
+		"params":  params,
+		"id":      uint64(rand.Int63()),
+		"jsonrpc": "2.0",
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+		return errors.ReqErr(500, ErrCodeConnect, "", err.Error())
+	}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return errors.ReqErr(500, ErrCodeConnect, "", err.Error())
+		}
+
+		var m struct {
+			Method string             `json:"method"`
+			Params encJson.RawMessage `json:"params"`
+			Err    *rpc.Err           `json:"error"`
+		}
+		if err = encJson.Unmarshal(msg, &m); err != nil {
+			return errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+		}
+
+		if m.Err != nil {
+			data, _ := m.Err.Data.(string)
+			return errors.ReqErr(400, m.Err.Code, data, m.Err.Message)
+		}
+
+		// Skip the response of the subscription call
+		if m.Method == "" {
+			continue
+		}
+
+		if err = handler(m.Params); err != nil {
+			return err
+		}
+	}
+}
+
 // makeClientReqErr creates a ReqError representing a client error
 func makeClientReqErr(msg string, args ...interface{}) *errors.ReqError {
 	return errors.ReqErr(0, ErrCodeClient, "", fmt.Sprintf(msg, args...))
@@ -1,16 +1,22 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
 	"github.com/make-os/kit/crypto/ed25519"
 	types2 "github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/rpc"
 	"github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/errors"
 	. "github.com/onsi/ginkgo"
@@ -41,6 +47,60 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe(".Subscribe", func() {
+		var server *httptest.Server
+		var messages [][]byte
+
+		BeforeEach(func() {
+			messages = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer c.Close()
+				c.ReadMessage()
+				for _, msg := range messages {
+					c.WriteMessage(websocket.BinaryMessage, msg)
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should return error when unable to connect", func() {
+			c := NewClient(&types.Options{Host: "127.0.0.1", Port: 1})
+			err := c.Subscribe("repo_watchProposals", nil, func(params json.RawMessage) error { return nil })
+			Expect(err).ToNot(BeNil())
+			Expect(err.(*errors.ReqError).Code).To(Equal(ErrCodeConnect))
+		})
+
+		It("should return error when the server responds with an error", func() {
+			messages = [][]byte{rpc.Error(40002, "a websocket connection is required", nil).ToJSON()}
+			c := NewClient(&types.Options{Host: server.URL})
+			err := c.Subscribe("repo_watchProposals", nil, func(params json.RawMessage) error { return nil })
+			Expect(err).ToNot(BeNil())
+			Expect(err.(*errors.ReqError).Code).To(Equal("40002"))
+			Expect(err.(*errors.ReqError).Msg).To(Equal("a websocket connection is required"))
+		})
+
+		It("should pass notification params to the handler and return the handler's error", func() {
+			notification, _ := json.Marshal(rpc.Request{JSONRPCVersion: "2.0", Method: "repo_proposalEvent",
+				Params: map[string]interface{}{"name": "repo1"}})
+			messages = [][]byte{rpc.StatusOK().ToJSON(), notification}
+			c := NewClient(&types.Options{Host: server.URL})
+			var received []string
+			err := c.Subscribe("repo_watchProposals", nil, func(params json.RawMessage) error {
+				received = append(received, string(params))
+				return fmt.Errorf("stop")
+			})
+			Expect(err).To(MatchError("stop"))
+			Expect(received).To(Equal([]string{`{"name":"repo1"}`}))
+		})
+	})
+
 	Describe(".GetOptions", func() {
 		It("should return options", func() {
 			opts := &types.Options{Host: "hostA", Port: 9000}
@@ -457,6 +517,228 @@ var _ = Describe("RepoAPI", func() {
 			Expect(resp.Hash).To(Equal("0x123"))
 		})
 	})
+
+	Describe(".CheckPolicy", func() {
+		It("should return ReqError when call failed", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				Expect(method).To(Equal("repo_checkPolicy"))
+				Expect(params).To(Equal(util.Map{
+					"name":      "repo1",
+					"namespace": "",
+					"pushKeyID": "pk1abc",
+					"reference": "refs/heads/master",
+					"action":    "write",
+				}))
+				return nil, 0, fmt.Errorf("error")
+			}
+			_, err := client.Repo().CheckPolicy(&api.BodyRepoCheckPolicy{
+				RepoName:  "repo1",
+				PushKeyID: "pk1abc",
+				Reference: "refs/heads/master",
+				Action:    "write",
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(&errors.ReqError{
+				Code:     ErrCodeUnexpected,
+				HttpCode: 0,
+				Msg:      "error",
+				Field:    "",
+			}))
+		})
+
+		It("should return expected result on success", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				return util.Map{
+					"reference": "refs/heads/master",
+					"allowed":   true,
+					"steps":     []interface{}{map[string]interface{}{"subject": "all", "level": 2, "matched": true}},
+				}, 0, nil
+			}
+			resp, err := client.Repo().CheckPolicy(&api.BodyRepoCheckPolicy{})
+			Expect(err).To(BeNil())
+			Expect(resp.Reference).To(Equal("refs/heads/master"))
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Steps).To(HaveLen(1))
+			Expect(resp.Steps[0].Subject).To(Equal("all"))
+			Expect(resp.Steps[0].Level).To(Equal(2))
+		})
+	})
+
+	Describe(".ListProposals", func() {
+		It("should return ReqError when call failed", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				Expect(method).To(Equal("repo_listProposals"))
+				Expect(params).To(Equal(util.Map{
+					"name":    "repo1",
+					"status":  "voting",
+					"action":  txns.TxTypeRepoProposalSpend,
+					"creator": "",
+					"offset":  2,
+					"limit":   10,
+				}))
+				return nil, 0, fmt.Errorf("error")
+			}
+			_, err := client.Repo().ListProposals(&api.BodyRepoListProposals{
+				RepoName: "repo1",
+				Status:   "voting",
+				Action:   txns.TxTypeRepoProposalSpend,
+				Offset:   2,
+				Limit:    10,
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(&errors.ReqError{
+				Code:     ErrCodeUnexpected,
+				HttpCode: 0,
+				Msg:      "error",
+				Field:    "",
+			}))
+		})
+
+		It("should return expected result on success", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				return util.Map{
+					"total": 3,
+					"proposals": []interface{}{
+						map[string]interface{}{"id": "1", "status": "voting", "creator": "addr1", "endAt": "100"},
+					},
+				}, 0, nil
+			}
+			resp, err := client.Repo().ListProposals(&api.BodyRepoListProposals{RepoName: "repo1"})
+			Expect(err).To(BeNil())
+			Expect(resp.Total).To(Equal(3))
+			Expect(resp.Proposals).To(HaveLen(1))
+			Expect(resp.Proposals[0].ID).To(Equal("1"))
+			Expect(resp.Proposals[0].Status).To(Equal("voting"))
+			Expect(resp.Proposals[0].Creator).To(Equal("addr1"))
+			Expect(resp.Proposals[0].EndAt.UInt64()).To(Equal(uint64(100)))
+		})
+	})
+
+	Describe(".Archive", func() {
+		It("should return ReqError when call failed", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				Expect(method).To(Equal("repo_archive"))
+				Expect(params).To(Equal(util.Map{"name": "repo1", "ref": "master", "format": "zip"}))
+				return nil, 0, fmt.Errorf("error")
+			}
+			_, err := client.Repo().Archive(&api.BodyRepoArchive{RepoName: "repo1", Ref: "master", Format: "zip"})
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(&errors.ReqError{
+				Code:     ErrCodeUnexpected,
+				HttpCode: 0,
+				Msg:      "error",
+				Field:    "",
+			}))
+		})
+
+		It("should return expected result on success", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				return util.Map{"treeHash": "abc", "format": "zip", "size": 3, "content": "YWJj"}, 0, nil
+			}
+			resp, err := client.Repo().Archive(&api.BodyRepoArchive{RepoName: "repo1", Ref: "master", Format: "zip"})
+			Expect(err).To(BeNil())
+			Expect(resp).To(Equal(&api.ResultRepoArchive{TreeHash: "abc", Format: "zip", Size: 3, Content: "YWJj"}))
+		})
+	})
+
+	Describe(".GetReferencedBy", func() {
+		It("should return ReqError when call failed", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				Expect(method).To(Equal("repo_getReferencedBy"))
+				Expect(params).To(Equal(util.Map{"name": "repo1", "target": "refs/heads/issues/1"}))
+				return nil, 0, fmt.Errorf("error")
+			}
+			_, err := client.Repo().GetReferencedBy(&api.BodyRepoGetReferencedBy{RepoName: "repo1", Target: "refs/heads/issues/1"})
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(&errors.ReqError{
+				Code:     ErrCodeUnexpected,
+				HttpCode: 0,
+				Msg:      "error",
+				Field:    "",
+			}))
+		})
+
+		It("should return expected result on success", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				return util.Map{"references": []interface{}{
+					map[string]interface{}{"target": "refs/heads/issues/1", "type": "commit", "source": "refs/heads/master", "hash": "abc", "closes": true, "postHash": "def"},
+				}}, 0, nil
+			}
+			resp, err := client.Repo().GetReferencedBy(&api.BodyRepoGetReferencedBy{RepoName: "repo1", Target: "refs/heads/issues/1"})
+			Expect(err).To(BeNil())
+			Expect(resp.References).To(HaveLen(1))
+			Expect(resp.References[0].Hash).To(Equal("abc"))
+			Expect(resp.References[0].Closes).To(BeTrue())
+			Expect(resp.References[0].PostHash).To(Equal("def"))
+		})
+	})
+
+	Describe(".GetProposal", func() {
+		It("should return ReqError when call failed", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				Expect(method).To(Equal("repo_getProposal"))
+				Expect(params).To(Equal(util.Map{"name": "repo1", "id": "1", "offset": 0, "limit": 5}))
+				return nil, 0, fmt.Errorf("error")
+			}
+			_, err := client.Repo().GetProposal(&api.BodyRepoGetProposal{RepoName: "repo1", ProposalID: "1", Limit: 5})
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(Equal(&errors.ReqError{
+				Code:     ErrCodeUnexpected,
+				HttpCode: 0,
+				Msg:      "error",
+				Field:    "",
+			}))
+		})
+
+		It("should return expected result on success", func() {
+			client.call = func(method string, params interface{}) (res util.Map, statusCode int, err error) {
+				return util.Map{
+					"id":         "1",
+					"status":     "closed",
+					"outcome":    1,
+					"totalVotes": 1,
+					"votes":      []interface{}{map[string]interface{}{"voter": "addr1", "vote": 1, "power": 2.5}},
+				}, 0, nil
+			}
+			resp, err := client.Repo().GetProposal(&api.BodyRepoGetProposal{RepoName: "repo1", ProposalID: "1"})
+			Expect(err).To(BeNil())
+			Expect(resp.ID).To(Equal("1"))
+			Expect(resp.Status).To(Equal("closed"))
+			Expect(resp.Outcome).To(Equal(state.ProposalOutcomeAccepted))
+			Expect(resp.TotalVotes).To(Equal(1))
+			Expect(resp.Votes).To(Equal([]*core.ProposalVote{{Voter: "addr1", Vote: 1, Power: 2.5}}))
+		})
+	})
+
+	Describe(".WatchProposals", func() {
+		It("should send the repository names and pass decoded events to the handler", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer c.Close()
+				_, msg, _ := c.ReadMessage()
+				var req rpc.Request
+				json.Unmarshal(msg, &req)
+				notification, _ := json.Marshal(rpc.Request{JSONRPCVersion: "2.0", Method: "repo_proposalEvent",
+					Params: map[string]interface{}{"type": "created", "name": req.Params.(map[string]interface{})["names"].([]interface{})[0], "id": "1"}})
+				c.WriteMessage(websocket.BinaryMessage, notification)
+			}))
+			defer server.Close()
+
+			client = NewClient(&types.Options{Host: server.URL})
+			var received *core.ProposalEvent
+			err := client.Repo().WatchProposals([]string{"repo1"}, func(evt *core.ProposalEvent) error {
+				received = evt
+				return fmt.Errorf("stop")
+			})
+			Expect(err).To(MatchError("stop"))
+			Expect(received.Type).To(Equal(core.ProposalEventCreated))
+			Expect(received.RepoName).To(Equal("repo1"))
+			Expect(received.ProposalID).To(Equal("1"))
+		})
+	})
 })
 
 var _ = Describe("RPCAPI", func() {
@@ -1,9 +1,11 @@
 package client
 
 import (
+	encJson "encoding/json"
 	"time"
 
 	"github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
@@ -155,3 +157,159 @@ func (c *RepoAPI) VoteProposal(body *api.BodyRepoVote) (*api.ResultHash, error)
 
 	return &r, nil
 }
+
+// ProposeSpend creates transaction to propose a spend from a repository's balance
+func (c *RepoAPI) ProposeSpend(body *api.BodyRepoProposeSpend) (*api.ResultHash, error) {
+
+	if body.SigningKey == nil {
+		return nil, errors.ReqErr(400, ErrCodeBadParam, "signingKey", "signing key is required")
+	}
+
+	tx := txns.NewBareRepoProposalSpend()
+	tx.RepoName = body.RepoName
+	tx.ID = body.ProposalID
+	tx.To = body.To
+	tx.Amount = util.String(cast.ToString(body.Amount))
+	tx.Value = util.String(cast.ToString(body.Value))
+	tx.Nonce = body.Nonce
+	tx.Fee = util.String(cast.ToString(body.Fee))
+	tx.Timestamp = time.Now().Unix()
+	tx.SenderPubKey = body.SigningKey.PubKey().ToPublicKey()
+
+	var err error
+	tx.Sig, err = tx.Sign(body.SigningKey.PrivKey().Base58())
+	if err != nil {
+		return nil, errors.ReqErr(400, ErrCodeClient, "privkey", err.Error())
+	}
+
+	resp, statusCode, err := c.c.call("repo_proposeSpend", tx.ToMap())
+	if err != nil {
+		return nil, makeReqErrFromCallErr(statusCode, err)
+	}
+
+	var r api.ResultHash
+	if err = util.DecodeMap(resp, &r); err != nil {
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	return &r, nil
+}
+
+// CheckPolicy simulates a policy check and returns its evaluation trace
+func (c *RepoAPI) CheckPolicy(body *api.BodyRepoCheckPolicy) (*api.ResultCheckPolicy, error) {
+	params := util.Map{
+		"name":      body.RepoName,
+		"namespace": body.Namespace,
+		"pushKeyID": body.PushKeyID,
+		"reference": body.Reference,
+		"action":    body.Action,
+	}
+	resp, statusCode, err := c.c.call("repo_checkPolicy", params)
+	if err != nil {
+		return nil, makeReqErrFromCallErr(statusCode, err)
+	}
+
+	var r api.ResultCheckPolicy
+	if err = util.DecodeMap(resp, &r); err != nil {
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	return &r, nil
+}
+
+// ListProposals returns the proposals of a repository that match the given filters
+func (c *RepoAPI) ListProposals(body *api.BodyRepoListProposals) (*api.ResultRepoProposals, error) {
+	params := util.Map{
+		"name":    body.RepoName,
+		"status":  body.Status,
+		"action":  body.Action,
+		"creator": body.Creator,
+		"offset":  body.Offset,
+		"limit":   body.Limit,
+	}
+	resp, statusCode, err := c.c.call("repo_listProposals", params)
+	if err != nil {
+		return nil, makeReqErrFromCallErr(statusCode, err)
+	}
+
+	var r api.ResultRepoProposals
+	if err = util.DecodeMap(resp, &r); err != nil {
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	return &r, nil
+}
+
+// GetProposal returns a repository proposal and the votes cast on it
+func (c *RepoAPI) GetProposal(body *api.BodyRepoGetProposal) (*api.ResultRepoProposal, error) {
+	params := util.Map{
+		"name":   body.RepoName,
+		"id":     body.ProposalID,
+		"offset": body.Offset,
+		"limit":  body.Limit,
+	}
+	resp, statusCode, err := c.c.call("repo_getProposal", params)
+	if err != nil {
+		return nil, makeReqErrFromCallErr(statusCode, err)
+	}
+
+	var r api.ResultRepoProposal
+	if err = util.DecodeMap(resp, &r); err != nil {
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	return &r, nil
+}
+
+// Archive returns an archive of the tree of a repository reference
+func (c *RepoAPI) Archive(body *api.BodyRepoArchive) (*api.ResultRepoArchive, error) {
+	params := util.Map{
+		"name":   body.RepoName,
+		"ref":    body.Ref,
+		"format": body.Format,
+	}
+	resp, statusCode, err := c.c.call("repo_archive", params)
+	if err != nil {
+		return nil, makeReqErrFromCallErr(statusCode, err)
+	}
+
+	var r api.ResultRepoArchive
+	if err = util.DecodeMap(resp, &r); err != nil {
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	return &r, nil
+}
+
+// GetReferencedBy returns the commits and post comments that reference a post or commit
+func (c *RepoAPI) GetReferencedBy(body *api.BodyRepoGetReferencedBy) (*api.ResultRepoReferencedBy, error) {
+	params := util.Map{
+		"name":   body.RepoName,
+		"target": body.Target,
+	}
+	resp, statusCode, err := c.c.call("repo_getReferencedBy", params)
+	if err != nil {
+		return nil, makeReqErrFromCallErr(statusCode, err)
+	}
+
+	var r api.ResultRepoReferencedBy
+	if err = util.DecodeMap(resp, &r); err != nil {
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	return &r, nil
+}
+
+// WatchProposals passes lifecycle events of proposals of the given repositories
+// to the handler. If no repository is given, events of all repositories are
+// passed. It blocks until the connection is closed or the handler returns an error.
+func (c *RepoAPI) WatchProposals(names []string, handler func(evt *core.ProposalEvent) error) error {
+	params := map[string]interface{}{"names": names}
+	return c.c.Subscribe("repo_watchProposals", params, func(params encJson.RawMessage) error {
+		var evt core.ProposalEvent
+		if err := encJson.Unmarshal(params, &evt); err != nil {
+			return errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+		}
+		return handler(&evt)
+	})
+}
@@ -21,6 +21,10 @@ type CallContext struct {
 
 	// IsLocal indicates that the request originated locally
 	IsLocal bool
+
+	// Conn is the websocket connection the request was received on.
+	// It is nil if the request was not received over a websocket connection.
+	Conn *Conn
 }
 
 type Method func(params interface{}) *Response
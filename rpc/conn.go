@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Conn represents a websocket connection on which requests are received.
+// It allows responses and notifications to be written concurrently.
+type Conn struct {
+	mtx       sync.Mutex
+	c         *websocket.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// newConn creates an instance of Conn
+func newConn(c *websocket.Conn) *Conn {
+	return &Conn{c: c, closed: make(chan struct{})}
+}
+
+// write writes a message to the connection
+func (c *Conn) write(msg []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.c.WriteMessage(websocket.BinaryMessage, msg)
+}
+
+// Notify sends a JSON-RPC 2.0 notification to the connection
+func (c *Conn) Notify(method string, params interface{}) error {
+	msg, err := json.Marshal(Request{JSONRPCVersion: "2.0", Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	return c.write(msg)
+}
+
+// Closed returns a channel that is closed when the connection is closed
+func (c *Conn) Closed() <-chan struct{} {
+	return c.closed
+}
+
+// close closes the connection
+func (c *Conn) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.c.Close()
+	})
+}
@@ -115,22 +115,23 @@ func (s *Handler) handle(w http.ResponseWriter, r *http.Request) (resp *Response
 		return nil
 	}
 
-	var err error
-	var c *websocket.Conn
+	var c *Conn
 	isWebSocket := r.Header.Get("Sec-Websocket-Version") != ""
 	if isWebSocket {
-		c, err = s.upgrader.Upgrade(w, r, nil)
+		wc, err := s.upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			resp = Error(-32603, "websocket upgrade failed", nil)
 			_ = json.NewEncoder(w).Encode(resp)
 			return
 		}
+		c = newConn(wc)
+		defer c.close()
 	}
 
 	writeResp := func() {
 		if c != nil {
-			c.WriteMessage(websocket.BinaryMessage, resp.ToJSON())
+			c.write(resp.ToJSON())
 			return
 		}
 		json.NewEncoder(w).Encode(resp)
@@ -173,7 +174,7 @@ func (s *Handler) handle(w http.ResponseWriter, r *http.Request) (resp *Response
 
 		var newReq Request
 		if c != nil {
-			_, message, err := c.ReadMessage()
+			_, message, err := c.c.ReadMessage()
 			if err != nil {
 				resp = Error(-32603, "failed to read message", nil)
 				writeResp()
@@ -228,7 +229,7 @@ func (s *Handler) handle(w http.ResponseWriter, r *http.Request) (resp *Response
 			if funcVal.Type().ConvertibleTo(reflect.TypeOf((Method)(nil))) {
 				resp = funcVal.Call([]reflect.Value{params})[0].Interface().(*Response)
 			} else if funcVal.Type().ConvertibleTo(reflect.TypeOf((MethodWithContext)(nil))) {
-				apiCtx := &CallContext{IsLocal: strings.HasPrefix(r.RemoteAddr, "127.0.0.1")}
+				apiCtx := &CallContext{IsLocal: strings.HasPrefix(r.RemoteAddr, "127.0.0.1"), Conn: c}
 				in := []reflect.Value{params, reflect.ValueOf(apiCtx)}
 				resp = funcVal.Call(in)[0].Interface().(*Response)
 			} else {
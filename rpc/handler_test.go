@@ -471,6 +471,62 @@ var _ = Describe("RPC", func() {
 
 			handler.ServeHTTP(rr, req)
 		})
+
+		It("should pass context with nil Conn if request was not received over websocket", func() {
+			rpc.apiSet.Add(MethodInfo{Name: "add", Namespace: "math",
+				Func: func(params interface{}, ctx *CallContext) *Response {
+					Expect(ctx.Conn).To(BeNil())
+					return nil
+				},
+			})
+
+			data, _ := json.Marshal(Request{JSONRPCVersion: "2.0", ID: "123", Method: "math_add", Params: map[string]interface{}{}})
+			req, _ := http.NewRequest("POST", "/rpc", bytes.NewReader(data))
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				resp := rpc.handle(w, r)
+				Expect(resp.Err).To(BeNil())
+			})
+
+			handler.ServeHTTP(rr, req)
+		})
+
+		It("should pass context with Conn that can send notifications if request was received over websocket", func() {
+			rpc.apiSet.Add(MethodInfo{Name: "watch", Namespace: "math",
+				Func: func(params interface{}, ctx *CallContext) *Response {
+					Expect(ctx.Conn).ToNot(BeNil())
+					go ctx.Conn.Notify("math_event", map[string]interface{}{"x": 1})
+					return Success(util.Map{"subscribed": true})
+				},
+			})
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rpc.handle(w, r)
+			}))
+			defer server.Close()
+			url := "ws" + strings.TrimPrefix(server.URL, "http")
+			ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+			Expect(err).To(BeNil())
+			defer ws.Close()
+
+			body, _ := json.Marshal(Request{JSONRPCVersion: "2.0", Method: "math_watch", ID: 1})
+			ws.WriteMessage(websocket.BinaryMessage, body)
+
+			var resp Response
+			var notification Request
+			for i := 0; i < 2; i++ {
+				_, msg, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				if bytes.Contains(msg, []byte("math_event")) {
+					Expect(json.Unmarshal(msg, &notification)).To(BeNil())
+					continue
+				}
+				Expect(json.Unmarshal(msg, &resp)).To(BeNil())
+			}
+			Expect(resp.Result["subscribed"]).To(BeTrue())
+			Expect(notification.ID).To(BeNil())
+			Expect(notification.Params).To(Equal(map[string]interface{}{"x": 1.0}))
+		})
 	})
 
 	When("target method returns nil response", func() {
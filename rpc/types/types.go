@@ -6,6 +6,7 @@ import (
 
 	"github.com/make-os/kit/rpc"
 	"github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/util"
 )
 
@@ -117,6 +118,27 @@ type Repo interface {
 
 	// VoteProposal creates transaction to vote for/against a repository's proposal
 	VoteProposal(body *api.BodyRepoVote) (*api.ResultHash, error)
+
+	// ProposeSpend creates transaction to propose a spend from a repository's balance
+	ProposeSpend(body *api.BodyRepoProposeSpend) (*api.ResultHash, error)
+
+	// CheckPolicy simulates a policy check and returns its evaluation trace
+	CheckPolicy(body *api.BodyRepoCheckPolicy) (*api.ResultCheckPolicy, error)
+
+	// ListProposals returns the proposals of a repository that match the given filters
+	ListProposals(body *api.BodyRepoListProposals) (*api.ResultRepoProposals, error)
+
+	// GetProposal returns a repository proposal and the votes cast on it
+	GetProposal(body *api.BodyRepoGetProposal) (*api.ResultRepoProposal, error)
+
+	// Archive returns an archive of the tree of a repository reference
+	Archive(body *api.BodyRepoArchive) (*api.ResultRepoArchive, error)
+
+	// GetReferencedBy returns the commits and post comments that reference a post or commit
+	GetReferencedBy(body *api.BodyRepoGetReferencedBy) (*api.ResultRepoReferencedBy, error)
+
+	// WatchProposals passes lifecycle events of proposals of the given repositories to the handler
+	WatchProposals(names []string, handler func(evt *core.ProposalEvent) error) error
 }
 
 // RPC provides access to the rpc server-related methods
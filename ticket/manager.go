@@ -1,6 +1,7 @@
 package ticket
 
 import (
+	"math"
 	"sort"
 
 	storagetypes "github.com/make-os/kit/storage/types"
@@ -405,6 +406,53 @@ func (m *Manager) ValueOfAllTickets(maturityHeight uint64) (float64, error) {
 	return sumF, nil
 }
 
+// QuadraticValueOfNonDelegatedTickets returns the sum of the square root of
+// the value of non-delegated, unexpired tickets of each proposer;
+// Includes both validator and host tickets.
+//
+// maturityHeight: if set to non-zero, only tickets that reached maturity before
+// or on the given height are selected. Otherwise, the current chain height is used.
+func (m *Manager) QuadraticValueOfNonDelegatedTickets(maturityHeight uint64) (float64, error) {
+
+	// Get the last committed block
+	bi, err := m.logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return 0, err
+	}
+
+	if maturityHeight <= 0 {
+		maturityHeight = uint64(bi.Height)
+	}
+
+	result := m.s.Query(func(t *tickettypes.Ticket) bool {
+		return t.MatureBy <= maturityHeight && // is mature
+			(t.ExpireBy > uint64(bi.Height) || (t.ExpireBy == 0 && t.Type == txns.TxTypeHostTicket)) && // not expired
+			t.Delegator == ""
+	})
+
+	// Group the value of tickets by their proposer
+	var proposers []string
+	valByProposer := make(map[string]decimal.Decimal)
+	for _, res := range result {
+		pk := res.ProposerPubKey.HexStr()
+		if _, ok := valByProposer[pk]; !ok {
+			proposers = append(proposers, pk)
+			valByProposer[pk] = decimal.Zero
+		}
+		valByProposer[pk] = valByProposer[pk].Add(res.Value.Decimal())
+	}
+
+	// Sum the square root of the proposers' stake in a fixed order
+	sort.Strings(proposers)
+	var sum float64
+	for _, pk := range proposers {
+		val, _ := valByProposer[pk].Float64()
+		sum += math.Sqrt(val)
+	}
+
+	return sum, nil
+}
+
 // Query finds and returns tickets that match the given query
 func (m *Manager) Query(qf func(t *tickettypes.Ticket) bool, queryOpt ...interface{}) []*tickettypes.Ticket {
 	return m.s.Query(qf, queryOpt...)
@@ -665,6 +665,43 @@ var _ = Describe("Manager", func() {
 		})
 	})
 
+	Describe(".QuadraticValueOfNonDelegatedTickets", func() {
+		When("a proposer has two non-delegated tickets of value 3 and 6, another has one of value 4 and a delegated ticket of value 5", func() {
+			ticket := &tickettypes.Ticket{Hash: util.StrToHexBytes("h1"), Type: txns.TxTypeValidatorTicket, ProposerPubKey: key.PubKey().MustBytes32(), Height: 2, Index: 2, MatureBy: 10, ExpireBy: 100, Value: "3"}
+			ticket2 := &tickettypes.Ticket{Hash: util.StrToHexBytes("h2"), Type: txns.TxTypeHostTicket, ProposerPubKey: key.PubKey().MustBytes32(), Height: 2, Index: 1, MatureBy: 10, ExpireBy: 100, Value: "6"}
+			ticket3 := &tickettypes.Ticket{Hash: util.StrToHexBytes("h3"), Type: txns.TxTypeValidatorTicket, ProposerPubKey: key2.PubKey().MustBytes32(), Height: 2, Index: 3, MatureBy: 10, ExpireBy: 100, Value: "4"}
+			ticket4 := &tickettypes.Ticket{Hash: util.StrToHexBytes("h4"), Type: txns.TxTypeValidatorTicket, ProposerPubKey: key2.PubKey().MustBytes32(), Delegator: key.Addr().String(), Height: 2, Index: 4, MatureBy: 10, ExpireBy: 100, Value: "5"}
+			BeforeEach(func() {
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 11}, nil)
+				mgr.logic = mockLogic
+				err := mgr.s.Add(ticket, ticket2, ticket3, ticket4)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return sum=5", func() {
+				val, err := mgr.QuadraticValueOfNonDelegatedTickets(0)
+				Expect(err).To(BeNil())
+				Expect(val).To(Equal(float64(5)))
+			})
+		})
+
+		When("maturity height is 5", func() {
+			ticket := &tickettypes.Ticket{Hash: util.StrToHexBytes("h1"), Type: txns.TxTypeValidatorTicket, ProposerPubKey: key.PubKey().MustBytes32(), Height: 2, Index: 2, MatureBy: 10, ExpireBy: 100, Value: "4"}
+			BeforeEach(func() {
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 11}, nil)
+				mgr.logic = mockLogic
+				err := mgr.s.Add(ticket)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return 0", func() {
+				val, err := mgr.QuadraticValueOfNonDelegatedTickets(5)
+				Expect(err).To(BeNil())
+				Expect(val).To(Equal(float64(0)))
+			})
+		})
+	})
+
 	Describe(".ValueOfNonDelegatedTickets", func() {
 		When("pubkey is proposer of a ticket with value=3 and delegator of a ticket with value=4", func() {
 			ticket := &tickettypes.Ticket{Hash: util.StrToHexBytes("h1"), Type: txns.TxTypeValidatorTicket, ProposerPubKey: key.PubKey().MustBytes32(), Height: 2, Index: 2, MatureBy: 10, ExpireBy: 100, Value: "3"}
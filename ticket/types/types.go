@@ -106,6 +106,14 @@ type TicketManager interface {
 	// or on the given height are selected. Otherwise, the current chain height is used.
 	ValueOfAllTickets(maturityHeight uint64) (float64, error)
 
+	// QuadraticValueOfNonDelegatedTickets returns the sum of the square root of
+	// the value of non-delegated, unexpired tickets of each proposer;
+	// Includes both validator and host tickets.
+	//
+	// maturityHeight: if set to non-zero, only tickets that reached maturity before
+	// or on the given height are selected. Otherwise, the current chain height is used.
+	QuadraticValueOfNonDelegatedTickets(maturityHeight uint64) (float64, error)
+
 	// GetUnExpiredTickets finds unexpired tickets that have the given proposer
 	// public key as the proposer or the delegator;
 	//
@@ -2,8 +2,11 @@ package api
 
 import (
 	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/rpc"
 	tickettypes "github.com/make-os/kit/ticket/types"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/identifier"
@@ -106,6 +109,18 @@ type BodyRepoVote struct {
 	SigningKey *ed25519.Key
 }
 
+// BodyRepoProposeSpend contains arguments for proposing a spend from a repository's balance
+type BodyRepoProposeSpend struct {
+	RepoName   string
+	ProposalID string
+	To         string
+	Amount     float64
+	Value      float64
+	Fee        float64
+	Nonce      uint64
+	SigningKey *ed25519.Key
+}
+
 // BodyRegisterPushKey contains arguments for registering a push key
 type BodyRegisterPushKey struct {
 	Nonce      uint64
@@ -138,6 +153,101 @@ type BodyAddRepoContribs struct {
 	SigningKey    *ed25519.Key
 }
 
+// BodyRepoCheckPolicy contains arguments for simulating a repository policy check
+type BodyRepoCheckPolicy struct {
+	RepoName  string
+	Namespace string
+	PushKeyID string
+	Reference string
+	Action    string
+}
+
+// ResultPolicyTraceStep describes a single policy lookup of a policy check
+type ResultPolicyTraceStep struct {
+	Subject string `json:"subject"`
+	Object  string `json:"object"`
+	Action  string `json:"action"`
+	Matched bool   `json:"matched"`
+	Level   int    `json:"level"`
+	Allowed bool   `json:"allowed"`
+	Applied bool   `json:"applied"`
+}
+
+// ResultCheckPolicy is the result for a request to simulate a repository policy check
+type ResultCheckPolicy struct {
+	Reference     string                   `json:"reference"`
+	RootDir       string                   `json:"rootDir"`
+	Action        string                   `json:"action"`
+	PushKeyID     string                   `json:"pushKeyID"`
+	IsContributor bool                     `json:"isContributor"`
+	IsRefCreator  bool                     `json:"isRefCreator"`
+	Steps         []*ResultPolicyTraceStep `json:"steps"`
+	Allowed       bool                     `json:"allowed"`
+}
+
+// BodyRepoListProposals contains arguments for listing the proposals of a repository
+type BodyRepoListProposals struct {
+	RepoName string
+	Status   string
+	Action   types.TxCode
+	Creator  string
+	Offset   int
+	Limit    int
+}
+
+// BodyRepoGetProposal contains arguments for fetching a repository proposal
+type BodyRepoGetProposal struct {
+	RepoName   string
+	ProposalID string
+	Offset     int
+	Limit      int
+}
+
+// ResultRepoProposal describes a repository proposal and the votes cast on it
+type ResultRepoProposal struct {
+	state.RepoProposal `json:",flatten,squash"`
+	ID                 string               `json:"id"`
+	Status             string               `json:"status"`
+	Votes              []*core.ProposalVote `json:"votes,omitempty"`
+	TotalVotes         int                  `json:"totalVotes,omitempty"`
+}
+
+// ResultRepoProposals is the result for a request to list the proposals of a repository
+type ResultRepoProposals struct {
+	Proposals []*ResultRepoProposal `json:"proposals"`
+	Total     int                   `json:"total"`
+}
+
+// BodyRepoArchive contains arguments for fetching an archive of a repository reference
+type BodyRepoArchive struct {
+	RepoName string
+	Ref      string
+	Format   string
+}
+
+// ResultRepoArchive is the result for a request to fetch an archive of a repository reference
+type ResultRepoArchive struct {
+	TreeHash string `json:"treeHash"`
+	Format   string `json:"format"`
+	Size     int64  `json:"size"`
+
+	// Content is the base64-encoded content of the archive
+	Content string `json:"content"`
+}
+
+// BodyRepoGetReferencedBy contains arguments for fetching the commits
+// and post comments that reference a post or commit
+type BodyRepoGetReferencedBy struct {
+	RepoName string
+	Target   string
+}
+
+// ResultRepoReferencedBy is the result for a request to fetch the commits
+// and post comments that reference a post or commit
+type ResultRepoReferencedBy struct {
+	References []*plumbing.CrossReference `json:"references"`
+}
+
 // ResultGetMethod is the response for RPC server methods
 type ResultGetMethod struct {
 	Methods []rpc.MethodInfo
@@ -34,6 +34,11 @@ const (
 	ActionDataKeyDescription   = "desc"
 	ActionDataKeyNamespace     = "ns"
 	ActionDataKeyNamespaceOnly = "nso"
+	ActionDataKeyRecipient     = "to"
+	ActionDataKeyAmount        = "amt"
+	ActionDataKeyNewName       = "nn"
+	ActionDataKeyNamespaces    = "nss"
+	ActionDataKeyDomains       = "dom"
 )
 
 const (
@@ -2,9 +2,11 @@ package core
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/pkgs/tree"
+	"github.com/make-os/kit/remote/plumbing"
 	storagetypes "github.com/make-os/kit/storage/types"
 	tickettypes "github.com/make-os/kit/ticket/types"
 	"github.com/make-os/kit/types"
@@ -117,6 +119,8 @@ type RepoSyncInfoKeeper interface {
 	UnTrack(repos string) error
 	UpdateRefLastSyncHeight(repo, ref string, height uint64) error
 	GetRefLastSyncHeight(repo, ref string) (uint64, error)
+	AddCrossReferences(repo string, refs ...*plumbing.CrossReference) error
+	GetCrossReferences(repo, target string) []*plumbing.CrossReference
 }
 
 // RepoKeeper describes an interface for accessing repository data
@@ -150,23 +154,73 @@ type RepoKeeper interface {
 	//  - udp: The updated repository object to replace the existing object.
 	Update(name string, upd *state.Repository)
 
+	// Remove deletes the repository with the given name from the state.
+	//
+	// ARGS:
+	//  - name: The name of the repository to remove
+	Remove(name string)
+
+	// SetRedirect makes a repository name redirect to
+	// another name until the given height.
+	//
+	// ARGS:
+	//  - name: The redirected name
+	//  - target: The name to redirect to
+	//  - untilHeight: The chain height when the redirect expires
+	SetRedirect(name, target string, untilHeight uint64) error
+
+	// GetRedirect returns the name a repository name redirects to.
+	// It returns an empty string if no redirect exist or it has expired.
+	//
+	// ARGS:
+	//  - name: The redirected name
+	GetRedirect(name string) string
+
+	// MoveProposalIndexes re-indexes the proposal votes, closed proposal
+	// markers and the end and execution height indexes of the unfinalized
+	// and queued proposals of a repository under a new name.
+	//
+	// ARGS:
+	//  - name: The current name of the repository
+	//  - newName: The new name of the repository
+	//  - proposals: The proposals of the repository
+	MoveProposalIndexes(name, newName string, proposals state.RepoProposals) error
+
 	// IndexProposalVote indexes a proposal vote.
+	// An existing vote of the voter is replaced.
 	// //
 	// // ARGS:
 	// //  - name: The name of the repository
 	// //  - propID: The target proposal
 	// //  - voterAddr: The address of the voter
 	// //  - vote: Indicates the vote choice
-	IndexProposalVote(name, propID, voterAddr string, vote int) error
+	// //  - power: The voting power counted for the vote
+	IndexProposalVote(name, propID, voterAddr string, vote int, power float64) error
 
-	// GetProposalVote returns the vote choice of the
-	// // given voter for the given proposal
+	// GetProposalVote returns the vote choice and voting
+	// // power of the given voter for the given proposal
 	// //
 	// // ARGS:
 	// //  - name: The name of the repository
 	// //  - propID: The target proposal
 	// //  - voterAddr: The address of the voter
-	GetProposalVote(name, propID, voterAddr string) (vote int, found bool, err error)
+	GetProposalVote(name, propID, voterAddr string) (vote int, power float64, found bool, err error)
+
+	// GetProposalVotes returns the indexed votes of a proposal
+	// ordered by the address of the voters
+	//
+	// ARGS:
+	//  - name: The name of the repository
+	//  - propID: The target proposal
+	GetProposalVotes(name, propID string) ([]*ProposalVote, error)
+
+	// HasProposalVotes checks whether any vote has been indexed for a
+	// proposal, including votes cast with no voting power
+	//
+	// ARGS:
+	//  - name: The name of the repository
+	//  - propID: The target proposal
+	HasProposalVotes(name, propID string) bool
 
 	// IndexProposalEnd indexes a proposal by its end height so it can be
 	// tracked and finalized at the given height
@@ -183,12 +237,29 @@ type RepoKeeper interface {
 	//  - height: The chain height when the proposal will stop accepting votes.
 	GetProposalsEndingAt(height uint64) []*EndingProposals
 
+	// IndexProposalExec indexes an accepted proposal by the height
+	// at which its queued action will be applied
+	//
+	// ARGS:
+	//  - name: The name of the repository
+	//  - propID: The target proposal
+	//  - execHeight: The chain height when the proposal action will be applied.
+	IndexProposalExec(name, propID string, execHeight uint64) error
+
+	// GetProposalsExecutingAt finds queued repo proposals to be applied at the
+	// given height. EndHeight of each result is the execution height.
+	//
+	// ARGS:
+	//  - height: The chain height when the proposal action will be applied.
+	GetProposalsExecutingAt(height uint64) []*EndingProposals
+
 	// MarkProposalAsClosed makes a proposal as "closed"
 	//
 	// ARGS:
 	//  - name: The name of the repository
 	//  - propID: The target proposal
-	MarkProposalAsClosed(name, propID string) error
+	//  - outcome: The outcome the proposal was closed with
+	MarkProposalAsClosed(name, propID string, outcome state.ProposalOutcome) error
 
 	// IsProposalClosed checks whether a proposal has been marked "closed"
 	//
@@ -218,6 +289,13 @@ type EndingProposals struct {
 	EndHeight  uint64
 }
 
+// ProposalVote describes the vote of a voter on a proposal
+type ProposalVote struct {
+	Voter string  `json:"voter" mapstructure:"voter"`
+	Vote  int     `json:"vote" mapstructure:"vote"`
+	Power float64 `json:"power" mapstructure:"power"`
+}
+
 // NamespaceKeeper describes an interface for accessing namespace data
 type NamespaceKeeper interface {
 	// Get finds a namespace by name.
@@ -429,12 +507,44 @@ type SystemContract interface {
 
 // ProposalApplyArgs contains arguments passed to a proposal contract Apply function
 type ProposalApplyArgs struct {
+	RepoName    string
 	Proposal    state.Proposal
 	Repo        *state.Repository
 	Keepers     Keepers
 	ChainHeight uint64
 }
 
+// ProposalEventType describes a stage in the lifecycle of a proposal
+type ProposalEventType string
+
+// Proposal event types
+const (
+	ProposalEventCreated      ProposalEventType = "created"
+	ProposalEventVoted        ProposalEventType = "voted"
+	ProposalEventFeeDeposited ProposalEventType = "feeDeposited"
+	ProposalEventEnded        ProposalEventType = "ended"
+	ProposalEventApplied      ProposalEventType = "applied"
+	ProposalEventFailed       ProposalEventType = "failed"
+)
+
+// ProposalEvent describes a change in the lifecycle of a repository proposal
+type ProposalEvent struct {
+	Type       ProposalEventType     `json:"type" mapstructure:"type"`
+	RepoName   string                `json:"name" mapstructure:"name"`
+	ProposalID string                `json:"id" mapstructure:"id"`
+	Action     types.TxCode          `json:"action,omitempty" mapstructure:"action"`
+	Height     uint64                `json:"height" mapstructure:"height"`
+	Actor      string                `json:"actor,omitempty" mapstructure:"actor"`     // The address of the creator, voter or depositor
+	Vote       *int                  `json:"vote,omitempty" mapstructure:"vote"`       // The vote choice of a voter
+	Value      string                `json:"value,omitempty" mapstructure:"value"`     // The proposal fee paid or deposited
+	Outcome    state.ProposalOutcome `json:"outcome,omitempty" mapstructure:"outcome"` // The outcome of an ended proposal
+}
+
+// ErrProposalActionFailed is returned by a proposal contract when the action
+// of an accepted proposal can no longer be executed (e.g the repo balance is
+// too low). The proposal is then finalized with a failed outcome.
+var ErrProposalActionFailed = fmt.Errorf("proposal action failed")
+
 // ProposalContract represents a system contract that is able to execute proposal transactions
 // and apply proposal changes to the world state.
 type ProposalContract interface {
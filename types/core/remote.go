@@ -65,6 +65,12 @@ type RemoteServer interface {
 	// InitRepository creates a local git repository
 	InitRepository(name string) error
 
+	// DeleteRepository removes the local data of a repository
+	DeleteRepository(name string) error
+
+	// RenameRepository moves the local data of a repository to a new name
+	RenameRepository(name, newName string) error
+
 	// BroadcastMsg broadcast messages to peers
 	BroadcastMsg(ch byte, msg []byte)
 
@@ -84,6 +90,10 @@ type RemoteServer interface {
 	// CheckNote validates a push note
 	CheckNote(note pushtypes.PushNote) error
 
+	// CheckLFSObjects ensures the LFS objects referenced by pointer files
+	// in the pushed commits of a push note are available locally
+	CheckLFSObjects(note pushtypes.PushNote) error
+
 	// TryScheduleReSync may schedule a local reference for resynchronization if the pushed
 	// reference old state does not match the current network state of the reference
 	TryScheduleReSync(note pushtypes.PushNote, ref string, fromBeginning bool) error
@@ -6,6 +6,7 @@ import "fmt"
 const (
 	ErrCodeInvalidAuthHeader      = 40000
 	ErrCodeInvalidAuthCredentials = 40001
+	ErrCodeWebsocketRequired      = 40002
 	ErrRPCServerError             = 50000
 )
 
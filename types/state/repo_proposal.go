@@ -101,6 +101,8 @@ const (
 	ProposalTallyMethodNetStake
 	ProposalTallyMethodNetStakeNonDelegated
 	ProposalTallyMethodNetStakeOfDelegators
+	ProposalTallyMethodQuadraticCoinWeighted
+	ProposalTallyMethodQuadraticNetStake
 )
 
 // IsValidProposalTallyMethod checks if v is a valid ProposalTallyMethod
@@ -111,6 +113,8 @@ func IsValidProposalTallyMethod(v *int) bool {
 		ProposalTallyMethodNetStake,
 		ProposalTallyMethodNetStakeNonDelegated,
 		ProposalTallyMethodNetStakeOfDelegators,
+		ProposalTallyMethodQuadraticCoinWeighted,
+		ProposalTallyMethodQuadraticNetStake,
 	}, ProposalTallyMethod(pointer.GetInt(v)))
 }
 
@@ -176,9 +180,14 @@ type Proposal interface {
 	IsFinalized() bool
 	SetOutcome(v ProposalOutcome)
 	IncrAccept()
+	AddVote(vote int, power float64)
 	IsFeeDepositEnabled() bool
 	IsDepositedFeeOK() bool
 	IsDepositPeriod(curChainHeight uint64) bool
+	GetExecDelay() uint64
+	GetExecAt() uint64
+	SetExecAt(height uint64)
+	IsQueued() bool
 }
 
 // ProposalOutcome describes a proposal outcome
@@ -198,8 +207,24 @@ const (
 	ProposalOutcomeQuorumNotMet
 	ProposalOutcomeBelowThreshold
 	ProposalOutcomeInsufficientDeposit
+	ProposalOutcomeWithdrawn
+	ProposalOutcomeQueued
+	ProposalOutcomeCancelled
+	ProposalOutcomeFailed // Accepted, but its action could not be executed
 )
 
+// Proposal statuses
+const (
+	ProposalStatusDeposit = "deposit" // Awaiting fee deposits
+	ProposalStatusVoting  = "voting"  // Accepting votes
+	ProposalStatusClosed  = "closed"  // Finalized
+)
+
+// IsValidProposalStatus checks whether the given status is a known proposal status
+func IsValidProposalStatus(status string) bool {
+	return status == ProposalStatusDeposit || status == ProposalStatusVoting || status == ProposalStatusClosed
+}
+
 // RepoProposal represents a repository proposal
 type RepoProposal struct {
 	util.CodecUtil     `json:"-" msgpack:"-"`
@@ -219,6 +244,7 @@ type RepoProposal struct {
 	Abstain            float64               `json:"abstain" mapstructure:"abstain" msgpack:"abstain"`                                  // Count of explicit "abstain" votes
 	Fees               ProposalFees          `json:"fees" mapstructure:"fees" msgpack:"fees"`                                           // Count of explicit "abstain" votes
 	Outcome            ProposalOutcome       `json:"outcome" mapstructure:"outcome" msgpack:"outcome"`                                  // The outcome of the proposal vote.
+	ExecAt             util.UInt64           `json:"execAt" mapstructure:"execAt" msgpack:"execAt"`                                     // The height at which a queued proposal will be applied.
 }
 
 // ProposalActionData represents action data of a proposal
@@ -247,6 +273,17 @@ func (p *RepoProposal) IsDepositPeriod(chainHeight uint64) bool {
 	return p.FeeDepositEndAt != 0 && p.FeeDepositEndAt >= util.UInt64(chainHeight)
 }
 
+// GetStatus returns the status of the proposal at the given chain height
+func (p *RepoProposal) GetStatus(chainHeight uint64) string {
+	if p.IsFinalized() {
+		return ProposalStatusClosed
+	}
+	if p.IsDepositPeriod(chainHeight) {
+		return ProposalStatusDeposit
+	}
+	return ProposalStatusVoting
+}
+
 // IsFeeDepositEnabled checks whether fee deposit is enabled on the proposal
 func (p *RepoProposal) IsFeeDepositEnabled() bool {
 	return p.FeeDepositEndAt != 0
@@ -282,7 +319,8 @@ func (p *RepoProposal) EncodeMsgpack(enc *msgpack.Encoder) error {
 		p.NoWithVetoByOwners,
 		p.Abstain,
 		p.Fees,
-		p.Outcome)
+		p.Outcome,
+		p.ExecAt)
 }
 
 // DecodeMsgpack implements msgpack.CustomDecoder
@@ -303,7 +341,8 @@ func (p *RepoProposal) DecodeMsgpack(dec *msgpack.Decoder) error {
 		&p.NoWithVetoByOwners,
 		&p.Abstain,
 		&p.Fees,
-		&p.Outcome)
+		&p.Outcome,
+		&p.ExecAt)
 }
 
 // IsFinalized implements Proposal
@@ -326,6 +365,42 @@ func (p *RepoProposal) IncrAccept() {
 	p.Yes++
 }
 
+// AddVote adds power to the count of the given vote choice.
+// A negative power removes a previously counted vote.
+func (p *RepoProposal) AddVote(vote int, power float64) {
+	switch vote {
+	case ProposalVoteYes:
+		p.Yes += power
+	case ProposalVoteNo:
+		p.No += power
+	case ProposalVoteNoWithVeto:
+		p.NoWithVeto += power
+	case ProposalVoteAbstain:
+		p.Abstain += power
+	}
+}
+
+// GetExecDelay implements Proposal
+func (p *RepoProposal) GetExecDelay() uint64 {
+	return cast.ToUint64(pointer.GetString(p.Config.PropExecDelay))
+}
+
+// GetExecAt implements Proposal
+func (p *RepoProposal) GetExecAt() uint64 {
+	return p.ExecAt.UInt64()
+}
+
+// SetExecAt implements Proposal
+func (p *RepoProposal) SetExecAt(height uint64) {
+	p.ExecAt = util.UInt64(height)
+}
+
+// IsQueued checks whether the proposal was accepted and
+// is waiting for its execution height to be applied
+func (p *RepoProposal) IsQueued() bool {
+	return p.Outcome == ProposalOutcomeQueued
+}
+
 // GetVoterType implements Proposal
 func (p *RepoProposal) GetVoterType() VoterType {
 	return VoterType(pointer.GetInt(p.Config.Voter))
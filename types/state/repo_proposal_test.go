@@ -70,3 +70,47 @@ var _ = Describe("RepoProposals", func() {
 		})
 	})
 })
+
+var _ = Describe("RepoProposal", func() {
+	Describe(".AddVote", func() {
+		It("should add power to the vote choice", func() {
+			prop := &RepoProposal{}
+			prop.AddVote(ProposalVoteYes, 2)
+			prop.AddVote(ProposalVoteNo, 3)
+			prop.AddVote(ProposalVoteNoWithVeto, 4)
+			prop.AddVote(ProposalVoteAbstain, 5)
+			Expect(prop.Yes).To(Equal(float64(2)))
+			Expect(prop.No).To(Equal(float64(3)))
+			Expect(prop.NoWithVeto).To(Equal(float64(4)))
+			Expect(prop.Abstain).To(Equal(float64(5)))
+		})
+
+		It("should remove power from the vote choice when power is negative", func() {
+			prop := &RepoProposal{Yes: 5}
+			prop.AddVote(ProposalVoteYes, -2)
+			Expect(prop.Yes).To(Equal(float64(3)))
+		})
+	})
+
+	Describe(".GetStatus", func() {
+		It("should return 'closed' if the proposal has an outcome", func() {
+			prop := &RepoProposal{Outcome: ProposalOutcomeAccepted, FeeDepositEndAt: 100}
+			Expect(prop.GetStatus(10)).To(Equal(ProposalStatusClosed))
+		})
+
+		It("should return 'deposit' if the proposal is in its fee deposit period", func() {
+			prop := &RepoProposal{FeeDepositEndAt: 100}
+			Expect(prop.GetStatus(10)).To(Equal(ProposalStatusDeposit))
+		})
+
+		It("should return 'voting' if the fee deposit period has ended", func() {
+			prop := &RepoProposal{FeeDepositEndAt: 100}
+			Expect(prop.GetStatus(101)).To(Equal(ProposalStatusVoting))
+		})
+
+		It("should return 'voting' if fee deposit is not enabled", func() {
+			prop := &RepoProposal{}
+			Expect(prop.GetStatus(10)).To(Equal(ProposalStatusVoting))
+		})
+	})
+})
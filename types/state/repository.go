@@ -98,6 +98,7 @@ type RepoOwner struct {
 	Creator  bool        `json:"creator" mapstructure:"creator" msgpack:"creator,omitempty"`
 	JoinedAt util.UInt64 `json:"joinedAt" mapstructure:"joinedAt" msgpack:"joinedAt,omitempty"`
 	Veto     bool        `json:"veto" mapstructure:"veto" msgpack:"veto,omitempty"`
+	Delegate string      `json:"delegate,omitempty" mapstructure:"delegate,omitempty" msgpack:"delegate,omitempty"`
 }
 
 // RepoOwners represents an index of owners of a repository.
@@ -114,6 +115,17 @@ func (r RepoOwners) Get(address string) *RepoOwner {
 	return r[address]
 }
 
+// IsDelegate checks whether the given address is the
+// proposal vote delegate of at least one owner
+func (r RepoOwners) IsDelegate(address string) bool {
+	for _, o := range r {
+		if o.Delegate != "" && o.Delegate == address {
+			return true
+		}
+	}
+	return false
+}
+
 // ForEach iterates through the collection passing each item to the iter callback
 func (r RepoOwners) ForEach(iter func(o *RepoOwner, addr string)) {
 	for key := range r {
@@ -128,6 +140,8 @@ type RepoConfigGovernance struct {
 	PropDuration         *string `json:"propDur,omitempty" mapstructure:"propDur,omitempty" msgpack:"propDur,omitempty"`
 	PropFee              *string `json:"propFee,omitempty" mapstructure:"propFee,omitempty" msgpack:"propFee,omitempty"`
 	PropFeeDepositDur    *string `json:"propFeeDepDur,omitempty" mapstructure:"propFeeDepDur,omitempty" msgpack:"propFeeDepDur,omitempty"`
+	PropExecDelay        *string `json:"propExecDelay,omitempty" mapstructure:"propExecDelay,omitempty" msgpack:"propExecDelay,omitempty"`
+	RedirectDur          *string `json:"redirectDur,omitempty" mapstructure:"redirectDur,omitempty" msgpack:"redirectDur,omitempty"`
 	PropQuorum           *string `json:"propQuorum,omitempty" mapstructure:"propQuorum,omitempty" msgpack:"propQuorum,omitempty"`
 	PropVetoQuorum       *string `json:"propVetoQuorum,omitempty" mapstructure:"propVetoQuorum,omitempty" msgpack:"propVetoQuorum,omitempty"`
 	PropVetoOwnersQuorum *string `json:"propVetoOwnersQuorum,omitempty" mapstructure:"propVetoOwnersQuorum,omitempty" msgpack:"propVetoOwnersQuorum,omitempty"`
@@ -168,29 +182,63 @@ type Policy struct {
 type ContributorPolicy struct {
 	Object string `json:"obj,omitempty" mapstructure:"obj,omitempty" msgpack:"obj,omitempty"`
 	Action string `json:"act,omitempty" mapstructure:"act,omitempty" msgpack:"act,omitempty"`
+
+	// StartHeight is the block height from which the policy takes effect (0 = immediately)
+	StartHeight uint64 `json:"startHeight,omitempty" mapstructure:"startHeight,omitempty" msgpack:"startHeight,omitempty"`
+
+	// EndHeight is the block height at which the policy expires (0 = never)
+	EndHeight uint64 `json:"endHeight,omitempty" mapstructure:"endHeight,omitempty" msgpack:"endHeight,omitempty"`
+}
+
+// IsActive checks whether the policy is in effect at the given block height.
+// A policy is active from its start height until (excluding) its end height.
+func (p *ContributorPolicy) IsActive(height uint64) bool {
+	if p.StartHeight > 0 && height < p.StartHeight {
+		return false
+	}
+	if p.EndHeight > 0 && height >= p.EndHeight {
+		return false
+	}
+	return true
 }
 
 // RepoPolicies represents an index of repo Policies policies
 // key is policy id
 type RepoPolicies []*Policy
 
+// ProtectedBranch describes a branch that can only be updated
+// after its new tip has been approved by other contributors.
+type ProtectedBranch struct {
+	// Name is the full branch reference name or a reference pattern
+	Name string `json:"name" mapstructure:"name" msgpack:"name,omitempty"`
+
+	// Approvals is the number of distinct push key approvals required
+	Approvals int `json:"approvals" mapstructure:"approvals" msgpack:"approvals,omitempty"`
+}
+
+// ProtectedBranches represents a collection of protected branches
+type ProtectedBranches []*ProtectedBranch
+
 // RepoConfig contains repo-specific configuration settings
 type RepoConfig struct {
 	util.CodecUtil `json:"-" mapstructure:"-" msgpack:"-"`
 	Gov            *RepoConfigGovernance `json:"governance,omitempty" mapstructure:"governance,omitempty" msgpack:"governance,omitempty"`
 	Policies       RepoPolicies          `json:"policies,omitempty" mapstructure:"policies,omitempty" msgpack:"policies,omitempty"`
+	Protected      ProtectedBranches     `json:"protected,omitempty" mapstructure:"protected,omitempty" msgpack:"protected,omitempty"`
 }
 
 func (c *RepoConfig) EncodeMsgpack(enc *msgpack.Encoder) error {
 	return c.EncodeMulti(enc,
 		c.Gov,
-		c.Policies)
+		c.Policies,
+		c.Protected)
 }
 
 func (c *RepoConfig) DecodeMsgpack(dec *msgpack.Decoder) error {
 	return c.DecodeMulti(dec,
 		&c.Gov,
-		&c.Policies)
+		&c.Policies,
+		&c.Protected)
 }
 
 // Clone clones c
@@ -209,7 +257,7 @@ func (c *RepoConfig) Merge(upd map[string]interface{}) error {
 
 // IsEmpty checks if c considered empty
 func (c *RepoConfig) IsEmpty() bool {
-	return (c.Gov == nil || len(util.ToMap(c.Gov)) == 0) && len(c.Policies) == 0
+	return (c.Gov == nil || len(util.ToMap(c.Gov)) == 0) && len(c.Policies) == 0 && len(c.Protected) == 0
 }
 
 // ToJSONToMap converts c to a JSON map and the map to go map.
@@ -247,6 +295,8 @@ func MakeDefaultRepoConfig() *RepoConfig {
 			PropFee:              pointer.ToString(cast.ToString(params.DefaultMinProposalFee)),
 			PropFeeRefundType:    ProposalFeeRefundNo.Ptr(),
 			PropFeeDepositDur:    pointer.ToString("0"),
+			PropExecDelay:        pointer.ToString("0"),
+			RedirectDur:          pointer.ToString(cast.ToString(params.RepoRedirectDur)),
 			NoPropFeeForMergeReq: pointer.ToBool(true),
 		},
 		Policies: []*Policy{},
@@ -270,6 +320,8 @@ func MakeZeroValueRepoConfig() *RepoConfig {
 			PropFee:              pointer.ToString("0"),
 			PropFeeRefundType:    pointer.ToInt(0),
 			PropFeeDepositDur:    pointer.ToString("0"),
+			PropExecDelay:        pointer.ToString("0"),
+			RedirectDur:          pointer.ToString("0"),
 			NoPropFeeForMergeReq: pointer.ToBool(false),
 		},
 		Policies: []*Policy{},
@@ -300,6 +352,13 @@ func (rc *BaseContributors) Has(pushKeyID string) bool {
 	return ok
 }
 
+// IsActive checks whether a push key id exists and is
+// an active contributor at the given block height
+func (rc *BaseContributors) IsActive(pushKeyID string, height uint64) bool {
+	c, ok := (*rc)[pushKeyID]
+	return ok && hasActivePolicy(c.Policies, height)
+}
+
 // RepoContributor represents a repository contributor
 type RepoContributor struct {
 	FeeMode  FeeMode              `json:"feeMode" mapstructure:"feeMode" msgpack:"feeMode"`
@@ -317,6 +376,39 @@ func (rc *RepoContributors) Has(pushKeyID string) bool {
 	return ok
 }
 
+// IsActive checks whether a push key id exists and is
+// an active contributor at the given block height
+func (rc *RepoContributors) IsActive(pushKeyID string, height uint64) bool {
+	c, ok := (*rc)[pushKeyID]
+	return ok && hasActivePolicy(c.Policies, height)
+}
+
+// hasActivePolicy checks whether a contributor with the given policies is
+// active at the given block height. A contributor without policies is not
+// time-bound; otherwise, at least one of its policies must be in effect.
+func hasActivePolicy(policies []*ContributorPolicy, height uint64) bool {
+	if len(policies) == 0 {
+		return true
+	}
+	for _, p := range policies {
+		if p.IsActive(height) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsContributor checks whether a push key is an active contributor of
+// the given repository or namespace at the given block height.
+// A contributor whose policies have all expired (or not yet started)
+// is not considered a contributor.
+func IsContributor(pushKeyID string, repo *Repository, namespace *Namespace, height uint64) bool {
+	if repo != nil && repo.Contributors.IsActive(pushKeyID, height) {
+		return true
+	}
+	return namespace != nil && namespace.Contributors.IsActive(pushKeyID, height)
+}
+
 // BareRepository returns an empty repository object
 func BareRepository() *Repository {
 	return &Repository{
@@ -360,6 +452,34 @@ type Repository struct {
 
 	// UpdatedAt is the block height the reference was last updated
 	UpdatedAt util.UInt64 `json:"updatedAt" mapstructure:"updatedAt" msgpack:"updatedAt,omitempty"`
+
+	// Archived indicates that the repository is read-only
+	Archived bool `json:"archived" mapstructure:"archived" msgpack:"archived,omitempty"`
+
+	// PrevNames contains the names previously used by the repository,
+	// ordered from the oldest to the most recent.
+	PrevNames []*RepoPrevName `json:"prevNames" mapstructure:"prevNames" msgpack:"prevNames,omitempty"`
+}
+
+// RepoPrevName describes a name previously used by a repository
+type RepoPrevName struct {
+	util.CodecUtil `json:"-" msgpack:"-" mapstructure:"-"`
+
+	// Name is the previous name
+	Name string `json:"name" mapstructure:"name" msgpack:"name,omitempty"`
+
+	// Height is the block height where the repository stopped using the name
+	Height util.UInt64 `json:"height" mapstructure:"height" msgpack:"height,omitempty"`
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (p *RepoPrevName) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return p.EncodeMulti(enc, p.Name, p.Height)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder
+func (p *RepoPrevName) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return p.DecodeMulti(dec, &p.Name, &p.Height)
 }
 
 // GetBalance implements types.BalanceAccount
@@ -390,7 +510,20 @@ func (r *Repository) IsEmpty() bool {
 		len(r.Contributors) == 0 &&
 		r.Config.IsEmpty() &&
 		r.CreatedAt == 0 &&
-		r.UpdatedAt == 0
+		r.UpdatedAt == 0 &&
+		!r.Archived &&
+		len(r.PrevNames) == 0
+}
+
+// NameAt returns the name the repository used at the given block height.
+// curName is the current name of the repository.
+func (r *Repository) NameAt(curName string, height uint64) string {
+	for _, pn := range r.PrevNames {
+		if height < pn.Height.UInt64() {
+			return pn.Name
+		}
+	}
+	return curName
 }
 
 // EncodeMsgpack implements msgpack.CustomEncoder
@@ -405,6 +538,8 @@ func (r *Repository) EncodeMsgpack(enc *msgpack.Encoder) error {
 		r.Contributors,
 		r.CreatedAt,
 		r.UpdatedAt,
+		r.Archived,
+		r.PrevNames,
 	)
 }
 
@@ -420,6 +555,8 @@ func (r *Repository) DecodeMsgpack(dec *msgpack.Decoder) error {
 		&r.Contributors,
 		&r.CreatedAt,
 		&r.UpdatedAt,
+		&r.Archived,
+		&r.PrevNames,
 	)
 	return err
 }
@@ -98,6 +98,58 @@ var _ = Describe("Repository", func() {
 				Expect(r.Bytes()).To(Equal(res.Bytes()))
 			})
 		})
+
+		Context("Decode Archived", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				r.Archived = true
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Archived).To(BeTrue())
+				Expect(res.IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("Decode PrevNames", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				r.PrevNames = []*RepoPrevName{{Name: "repo1", Height: 10}}
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.PrevNames).To(HaveLen(1))
+				Expect(res.PrevNames[0].Name).To(Equal("repo1"))
+				Expect(res.PrevNames[0].Height.UInt64()).To(Equal(uint64(10)))
+				Expect(res.IsEmpty()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe(".NameAt", func() {
+		var r *Repository
+
+		BeforeEach(func() {
+			r = BareRepository()
+			r.PrevNames = []*RepoPrevName{{Name: "repo1", Height: 10}, {Name: "repo2", Height: 20}}
+		})
+
+		It("should return the name used at the given height", func() {
+			Expect(r.NameAt("repo3", 5)).To(Equal("repo1"))
+			Expect(r.NameAt("repo3", 10)).To(Equal("repo2"))
+			Expect(r.NameAt("repo3", 19)).To(Equal("repo2"))
+			Expect(r.NameAt("repo3", 20)).To(Equal("repo3"))
+		})
+
+		It("should return the current name when the repo has not been renamed", func() {
+			Expect(BareRepository().NameAt("repo1", 5)).To(Equal("repo1"))
+		})
 	})
 
 	Describe("BareRepository.IsEmpty", func() {
@@ -187,6 +239,17 @@ var _ = Describe("Repository", func() {
 			})
 		})
 
+		Describe(".IsDelegate", func() {
+			It("should return false when no owner delegated to the address", func() {
+				Expect(v.IsDelegate("aaa")).To(BeFalse())
+			})
+
+			It("should return true when an owner delegated to the address", func() {
+				v["abc"].Delegate = "aaa"
+				Expect(v.IsDelegate("aaa")).To(BeTrue())
+			})
+		})
+
 		Describe(".ForEach", func() {
 			It("should pass all values", func() {
 				var owners []string
@@ -215,4 +278,36 @@ var _ = Describe("Repository", func() {
 			Expect(fmt.Sprintf("%p", base.Gov)).ToNot(Equal(fmt.Sprintf("%p", clone.Gov)))
 		})
 	})
+
+	Describe("ContributorPolicy.IsActive", func() {
+		It("should return true when policy has no start or end height", func() {
+			Expect((&ContributorPolicy{}).IsActive(0)).To(BeTrue())
+			Expect((&ContributorPolicy{}).IsActive(1000)).To(BeTrue())
+		})
+
+		It("should return false before start height and true from start height", func() {
+			p := &ContributorPolicy{StartHeight: 10}
+			Expect(p.IsActive(9)).To(BeFalse())
+			Expect(p.IsActive(10)).To(BeTrue())
+		})
+
+		It("should return false from end height", func() {
+			p := &ContributorPolicy{StartHeight: 10, EndHeight: 20}
+			Expect(p.IsActive(19)).To(BeTrue())
+			Expect(p.IsActive(20)).To(BeFalse())
+		})
+	})
+
+	Describe("ContributorPolicy encoding", func() {
+		It("should preserve start and end height", func() {
+			repo := BareRepository()
+			repo.Contributors["pk1"] = &RepoContributor{Policies: []*ContributorPolicy{
+				{Object: "refs/heads/dev", Action: "write", StartHeight: 10, EndHeight: 20},
+			}}
+			res, err := NewRepositoryFromBytes(repo.Bytes())
+			Expect(err).To(BeNil())
+			Expect(res.Contributors["pk1"].Policies[0].StartHeight).To(Equal(uint64(10)))
+			Expect(res.Contributors["pk1"].Policies[0].EndHeight).To(Equal(uint64(20)))
+		})
+	})
 })
@@ -31,6 +31,14 @@ const (
 	TxTypeRepoProposalRegisterPushKey                         // For adding push keys to a repo
 	TxTypeUpDelPushKey                                        // For updating or deleting a push key
 	TxTypeMergeRequestProposalAction                          // For identifying merge request proposal
+	TxTypeRepoProposalSpend                                   // For creating a proposal to spend from a repo's balance
+	TxTypeRepoProposalWithdraw                                // For withdrawing a proposal
+	TxTypeRepoVoteDelegate                                    // For delegating an owner's proposal voting power
+	TxTypeRepoProposalCancel                                  // For cancelling a queued proposal
+	TxTypeRepoProposalArchive                                 // For creating a proposal to archive a repo
+	TxTypeRepoProposalDelete                                  // For creating a proposal to delete a repo
+	TxTypeRepoProposalRename                                  // For creating a proposal to rename a repo
+	TxTypeRepoProposalUpdateNamespace                         // For creating a proposal to update a namespace owned by a repo
 )
 
 // TxType implements some of BaseTx, it includes type information about a transaction
@@ -324,6 +332,22 @@ func getBareTxObject(txType types.TxCode) (types.BaseTx, error) {
 		tx = NewBareRepoProposalRegisterPushKey()
 	case TxTypeUpDelPushKey:
 		tx = NewBareTxUpDelPushKey()
+	case TxTypeRepoProposalSpend:
+		tx = NewBareRepoProposalSpend()
+	case TxTypeRepoProposalWithdraw:
+		tx = NewBareRepoProposalWithdraw()
+	case TxTypeRepoVoteDelegate:
+		tx = NewBareRepoVoteDelegate()
+	case TxTypeRepoProposalCancel:
+		tx = NewBareRepoProposalCancel()
+	case TxTypeRepoProposalArchive:
+		tx = NewBareRepoProposalArchive()
+	case TxTypeRepoProposalDelete:
+		tx = NewBareRepoProposalDelete()
+	case TxTypeRepoProposalRename:
+		tx = NewBareRepoProposalRename()
+	case TxTypeRepoProposalUpdateNamespace:
+		tx = NewBareRepoProposalUpdateNamespace()
 	default:
 		return nil, fmt.Errorf("unsupported tx type")
 	}
@@ -0,0 +1,111 @@
+package txns
+
+import (
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/vmihailenco/msgpack"
+)
+
+// TxRepoProposalArchive implements BaseTx, it describes a repository proposal
+// transaction for archiving a repository
+type TxRepoProposalArchive struct {
+	*TxCommon         `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxType           `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxProposalCommon `json:",flatten" msgpack:"-" mapstructure:"-"`
+}
+
+// NewBareRepoProposalArchive returns an instance of TxRepoProposalArchive with zero values
+func NewBareRepoProposalArchive() *TxRepoProposalArchive {
+	return &TxRepoProposalArchive{
+		TxCommon:         NewBareTxCommon(),
+		TxType:           &TxType{Type: TxTypeRepoProposalArchive},
+		TxProposalCommon: &TxProposalCommon{Value: "0", RepoName: "", ID: ""},
+	}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (tx *TxRepoProposalArchive) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return tx.EncodeMulti(enc,
+		tx.Type,
+		tx.Nonce,
+		tx.Value,
+		tx.Fee,
+		tx.Sig,
+		tx.Timestamp,
+		tx.SenderPubKey,
+		tx.RepoName,
+		tx.ID)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder
+func (tx *TxRepoProposalArchive) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return tx.DecodeMulti(dec,
+		&tx.Type,
+		&tx.Nonce,
+		&tx.Value,
+		&tx.Fee,
+		&tx.Sig,
+		&tx.Timestamp,
+		&tx.SenderPubKey,
+		&tx.RepoName,
+		&tx.ID)
+}
+
+// Bytes returns the serialized transaction
+func (tx *TxRepoProposalArchive) Bytes() []byte {
+	return util.ToBytes(tx)
+}
+
+// GetBytesNoSig returns the serialized the transaction excluding the signature
+func (tx *TxRepoProposalArchive) GetBytesNoSig() []byte {
+	sig := tx.Sig
+	tx.Sig = nil
+	bz := tx.Bytes()
+	tx.Sig = sig
+	return bz
+}
+
+// ComputeHash computes the hash of the transaction
+func (tx *TxRepoProposalArchive) ComputeHash() util.Bytes32 {
+	return util.BytesToBytes32(tmhash.Sum(tx.Bytes()))
+}
+
+// GetHash returns the hash of the transaction
+func (tx *TxRepoProposalArchive) GetHash() util.HexBytes {
+	return tx.ComputeHash().ToHexBytes()
+}
+
+// GetID returns the id of the transaction (also the hash)
+func (tx *TxRepoProposalArchive) GetID() string {
+	return tx.ComputeHash().HexStr()
+}
+
+// GetEcoSize returns the size of the transaction for use in protocol economics
+func (tx *TxRepoProposalArchive) GetEcoSize() int64 {
+	return tx.GetSize()
+}
+
+// GetSize returns the size of the tx object (excluding nothing)
+func (tx *TxRepoProposalArchive) GetSize() int64 {
+	return int64(len(tx.Bytes()))
+}
+
+// Sign signs the transaction
+func (tx *TxRepoProposalArchive) Sign(privKey string) ([]byte, error) {
+	return SignTransaction(tx, privKey)
+}
+
+// ToMap returns a map equivalent of the transaction
+func (tx *TxRepoProposalArchive) ToMap() map[string]interface{} {
+	return util.ToJSONMap(tx)
+}
+
+// FromMap populates tx with a map generated by tx.ToMap.
+func (tx *TxRepoProposalArchive) FromMap(data map[string]interface{}) error {
+	err := tx.TxCommon.FromMap(data)
+	err = errors.CallIfNil(err, func() error { return tx.TxType.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return tx.TxProposalCommon.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return util.DecodeMap(data, &tx) })
+	return err
+}
@@ -0,0 +1,110 @@
+package txns
+
+import (
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/vmihailenco/msgpack"
+)
+
+// TxRepoProposalCancel implements BaseTx, it describes a transaction for
+// cancelling a queued repository proposal by a veto owner
+type TxRepoProposalCancel struct {
+	*TxCommon  `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxType    `json:",flatten" msgpack:"-" mapstructure:"-"`
+	RepoName   string `json:"name" msgpack:"name" mapstructure:"name"`
+	ProposalID string `json:"id" msgpack:"id" mapstructure:"id"`
+}
+
+// NewBareRepoProposalCancel returns an instance of TxRepoProposalCancel with zero values
+func NewBareRepoProposalCancel() *TxRepoProposalCancel {
+	return &TxRepoProposalCancel{
+		TxCommon:   NewBareTxCommon(),
+		TxType:     &TxType{Type: TxTypeRepoProposalCancel},
+		RepoName:   "",
+		ProposalID: "",
+	}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (tx *TxRepoProposalCancel) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return tx.EncodeMulti(enc,
+		tx.Type,
+		tx.Nonce,
+		tx.Fee,
+		tx.Sig,
+		tx.Timestamp,
+		tx.SenderPubKey,
+		tx.RepoName,
+		tx.ProposalID)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder
+func (tx *TxRepoProposalCancel) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return tx.DecodeMulti(dec,
+		&tx.Type,
+		&tx.Nonce,
+		&tx.Fee,
+		&tx.Sig,
+		&tx.Timestamp,
+		&tx.SenderPubKey,
+		&tx.RepoName,
+		&tx.ProposalID)
+}
+
+// Bytes returns the serialized transaction
+func (tx *TxRepoProposalCancel) Bytes() []byte {
+	return util.ToBytes(tx)
+}
+
+// GetBytesNoSig returns the serialized the transaction excluding the signature
+func (tx *TxRepoProposalCancel) GetBytesNoSig() []byte {
+	sig := tx.Sig
+	tx.Sig = nil
+	bz := tx.Bytes()
+	tx.Sig = sig
+	return bz
+}
+
+// ComputeHash computes the hash of the transaction
+func (tx *TxRepoProposalCancel) ComputeHash() util.Bytes32 {
+	return util.BytesToBytes32(tmhash.Sum(tx.Bytes()))
+}
+
+// GetHash returns the hash of the transaction
+func (tx *TxRepoProposalCancel) GetHash() util.HexBytes {
+	return tx.ComputeHash().ToHexBytes()
+}
+
+// GetID returns the id of the transaction (also the hash)
+func (tx *TxRepoProposalCancel) GetID() string {
+	return tx.ComputeHash().HexStr()
+}
+
+// GetEcoSize returns the size of the transaction for use in protocol economics
+func (tx *TxRepoProposalCancel) GetEcoSize() int64 {
+	return tx.GetSize()
+}
+
+// GetSize returns the size of the tx object (excluding nothing)
+func (tx *TxRepoProposalCancel) GetSize() int64 {
+	return int64(len(tx.Bytes()))
+}
+
+// Sign signs the transaction
+func (tx *TxRepoProposalCancel) Sign(privKey string) ([]byte, error) {
+	return SignTransaction(tx, privKey)
+}
+
+// ToMap returns a map equivalent of the transaction
+func (tx *TxRepoProposalCancel) ToMap() map[string]interface{} {
+	return util.ToJSONMap(tx)
+}
+
+// FromMap populates tx with a map generated by tx.ToMap.
+func (tx *TxRepoProposalCancel) FromMap(data map[string]interface{}) error {
+	err := tx.TxCommon.FromMap(data)
+	err = errors.CallIfNil(err, func() error { return tx.TxType.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return util.DecodeMap(data, &tx) })
+	return err
+}
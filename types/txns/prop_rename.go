@@ -0,0 +1,120 @@
+package txns
+
+import (
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/vmihailenco/msgpack"
+)
+
+// TxRepoProposalRename implements BaseTx, it describes a repository proposal
+// transaction for renaming a repository and rewiring the namespace
+// domains that target it
+type TxRepoProposalRename struct {
+	*TxCommon         `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxType           `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxProposalCommon `json:",flatten" msgpack:"-" mapstructure:"-"`
+	NewName           string   `json:"newName" msgpack:"newName" mapstructure:"newName"`
+	Namespaces        []string `json:"namespaces" msgpack:"namespaces" mapstructure:"namespaces"`
+}
+
+// NewBareRepoProposalRename returns an instance of TxRepoProposalRename with zero values
+func NewBareRepoProposalRename() *TxRepoProposalRename {
+	return &TxRepoProposalRename{
+		TxCommon:         NewBareTxCommon(),
+		TxType:           &TxType{Type: TxTypeRepoProposalRename},
+		TxProposalCommon: &TxProposalCommon{Value: "0", RepoName: "", ID: ""},
+		NewName:          "",
+		Namespaces:       []string{},
+	}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (tx *TxRepoProposalRename) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return tx.EncodeMulti(enc,
+		tx.Type,
+		tx.Nonce,
+		tx.Value,
+		tx.Fee,
+		tx.Sig,
+		tx.Timestamp,
+		tx.SenderPubKey,
+		tx.RepoName,
+		tx.ID,
+		tx.NewName,
+		tx.Namespaces)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder
+func (tx *TxRepoProposalRename) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return tx.DecodeMulti(dec,
+		&tx.Type,
+		&tx.Nonce,
+		&tx.Value,
+		&tx.Fee,
+		&tx.Sig,
+		&tx.Timestamp,
+		&tx.SenderPubKey,
+		&tx.RepoName,
+		&tx.ID,
+		&tx.NewName,
+		&tx.Namespaces)
+}
+
+// Bytes returns the serialized transaction
+func (tx *TxRepoProposalRename) Bytes() []byte {
+	return util.ToBytes(tx)
+}
+
+// GetBytesNoSig returns the serialized the transaction excluding the signature
+func (tx *TxRepoProposalRename) GetBytesNoSig() []byte {
+	sig := tx.Sig
+	tx.Sig = nil
+	bz := tx.Bytes()
+	tx.Sig = sig
+	return bz
+}
+
+// ComputeHash computes the hash of the transaction
+func (tx *TxRepoProposalRename) ComputeHash() util.Bytes32 {
+	return util.BytesToBytes32(tmhash.Sum(tx.Bytes()))
+}
+
+// GetHash returns the hash of the transaction
+func (tx *TxRepoProposalRename) GetHash() util.HexBytes {
+	return tx.ComputeHash().ToHexBytes()
+}
+
+// GetID returns the id of the transaction (also the hash)
+func (tx *TxRepoProposalRename) GetID() string {
+	return tx.ComputeHash().HexStr()
+}
+
+// GetEcoSize returns the size of the transaction for use in protocol economics
+func (tx *TxRepoProposalRename) GetEcoSize() int64 {
+	return tx.GetSize()
+}
+
+// GetSize returns the size of the tx object (excluding nothing)
+func (tx *TxRepoProposalRename) GetSize() int64 {
+	return int64(len(tx.Bytes()))
+}
+
+// Sign signs the transaction
+func (tx *TxRepoProposalRename) Sign(privKey string) ([]byte, error) {
+	return SignTransaction(tx, privKey)
+}
+
+// ToMap returns a map equivalent of the transaction
+func (tx *TxRepoProposalRename) ToMap() map[string]interface{} {
+	return util.ToJSONMap(tx)
+}
+
+// FromMap populates tx with a map generated by tx.ToMap.
+func (tx *TxRepoProposalRename) FromMap(data map[string]interface{}) error {
+	err := tx.TxCommon.FromMap(data)
+	err = errors.CallIfNil(err, func() error { return tx.TxType.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return tx.TxProposalCommon.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return util.DecodeMap(data, &tx) })
+	return err
+}
@@ -0,0 +1,119 @@
+package txns
+
+import (
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/vmihailenco/msgpack"
+)
+
+// TxRepoProposalSpend implements BaseTx, it describes a repository proposal
+// transaction for transferring coins from a repository's balance to an address
+type TxRepoProposalSpend struct {
+	*TxCommon         `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxType           `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxProposalCommon `json:",flatten" msgpack:"-" mapstructure:"-"`
+	To                string      `json:"to" msgpack:"to" mapstructure:"to"`
+	Amount            util.String `json:"amount" msgpack:"amount" mapstructure:"amount"`
+}
+
+// NewBareRepoProposalSpend returns an instance of TxRepoProposalSpend with zero values
+func NewBareRepoProposalSpend() *TxRepoProposalSpend {
+	return &TxRepoProposalSpend{
+		TxCommon:         NewBareTxCommon(),
+		TxType:           &TxType{Type: TxTypeRepoProposalSpend},
+		TxProposalCommon: &TxProposalCommon{Value: "0", RepoName: "", ID: ""},
+		To:               "",
+		Amount:           "0",
+	}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (tx *TxRepoProposalSpend) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return tx.EncodeMulti(enc,
+		tx.Type,
+		tx.Nonce,
+		tx.Value,
+		tx.Fee,
+		tx.Sig,
+		tx.Timestamp,
+		tx.SenderPubKey,
+		tx.RepoName,
+		tx.ID,
+		tx.To,
+		tx.Amount)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder
+func (tx *TxRepoProposalSpend) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return tx.DecodeMulti(dec,
+		&tx.Type,
+		&tx.Nonce,
+		&tx.Value,
+		&tx.Fee,
+		&tx.Sig,
+		&tx.Timestamp,
+		&tx.SenderPubKey,
+		&tx.RepoName,
+		&tx.ID,
+		&tx.To,
+		&tx.Amount)
+}
+
+// Bytes returns the serialized transaction
+func (tx *TxRepoProposalSpend) Bytes() []byte {
+	return util.ToBytes(tx)
+}
+
+// GetBytesNoSig returns the serialized the transaction excluding the signature
+func (tx *TxRepoProposalSpend) GetBytesNoSig() []byte {
+	sig := tx.Sig
+	tx.Sig = nil
+	bz := tx.Bytes()
+	tx.Sig = sig
+	return bz
+}
+
+// ComputeHash computes the hash of the transaction
+func (tx *TxRepoProposalSpend) ComputeHash() util.Bytes32 {
+	return util.BytesToBytes32(tmhash.Sum(tx.Bytes()))
+}
+
+// GetHash returns the hash of the transaction
+func (tx *TxRepoProposalSpend) GetHash() util.HexBytes {
+	return tx.ComputeHash().ToHexBytes()
+}
+
+// GetID returns the id of the transaction (also the hash)
+func (tx *TxRepoProposalSpend) GetID() string {
+	return tx.ComputeHash().HexStr()
+}
+
+// GetEcoSize returns the size of the transaction for use in protocol economics
+func (tx *TxRepoProposalSpend) GetEcoSize() int64 {
+	return tx.GetSize()
+}
+
+// GetSize returns the size of the tx object (excluding nothing)
+func (tx *TxRepoProposalSpend) GetSize() int64 {
+	return int64(len(tx.Bytes()))
+}
+
+// Sign signs the transaction
+func (tx *TxRepoProposalSpend) Sign(privKey string) ([]byte, error) {
+	return SignTransaction(tx, privKey)
+}
+
+// ToMap returns a map equivalent of the transaction
+func (tx *TxRepoProposalSpend) ToMap() map[string]interface{} {
+	return util.ToJSONMap(tx)
+}
+
+// FromMap populates tx with a map generated by tx.ToMap.
+func (tx *TxRepoProposalSpend) FromMap(data map[string]interface{}) error {
+	err := tx.TxCommon.FromMap(data)
+	err = errors.CallIfNil(err, func() error { return tx.TxType.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return tx.TxProposalCommon.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return util.DecodeMap(data, &tx) })
+	return err
+}
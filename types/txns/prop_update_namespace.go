@@ -0,0 +1,124 @@
+package txns
+
+import (
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/vmihailenco/msgpack"
+)
+
+// TxRepoProposalUpdateNamespace implements BaseTx, it describes a repository proposal
+// transaction for updating the domains and contributors of a namespace
+// owned by a repository
+type TxRepoProposalUpdateNamespace struct {
+	*TxCommon          `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxType            `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxProposalCommon  `json:",flatten" msgpack:"-" mapstructure:"-"`
+	Namespace          string            `json:"namespace" msgpack:"namespace" mapstructure:"namespace"`
+	Domains            map[string]string `json:"domains" msgpack:"domains" mapstructure:"domains"`
+	RemoveContributors []string          `json:"removeContributors" msgpack:"removeContributors" mapstructure:"removeContributors"`
+}
+
+// NewBareRepoProposalUpdateNamespace returns an instance of TxRepoProposalUpdateNamespace with zero values
+func NewBareRepoProposalUpdateNamespace() *TxRepoProposalUpdateNamespace {
+	return &TxRepoProposalUpdateNamespace{
+		TxCommon:           NewBareTxCommon(),
+		TxType:             &TxType{Type: TxTypeRepoProposalUpdateNamespace},
+		TxProposalCommon:   &TxProposalCommon{Value: "0", RepoName: "", ID: ""},
+		Namespace:          "",
+		Domains:            make(map[string]string),
+		RemoveContributors: []string{},
+	}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (tx *TxRepoProposalUpdateNamespace) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return tx.EncodeMulti(enc,
+		tx.Type,
+		tx.Nonce,
+		tx.Value,
+		tx.Fee,
+		tx.Sig,
+		tx.Timestamp,
+		tx.SenderPubKey,
+		tx.RepoName,
+		tx.ID,
+		tx.Namespace,
+		tx.Domains,
+		tx.RemoveContributors)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder
+func (tx *TxRepoProposalUpdateNamespace) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return tx.DecodeMulti(dec,
+		&tx.Type,
+		&tx.Nonce,
+		&tx.Value,
+		&tx.Fee,
+		&tx.Sig,
+		&tx.Timestamp,
+		&tx.SenderPubKey,
+		&tx.RepoName,
+		&tx.ID,
+		&tx.Namespace,
+		&tx.Domains,
+		&tx.RemoveContributors)
+}
+
+// Bytes returns the serialized transaction
+func (tx *TxRepoProposalUpdateNamespace) Bytes() []byte {
+	return util.ToBytes(tx)
+}
+
+// GetBytesNoSig returns the serialized the transaction excluding the signature
+func (tx *TxRepoProposalUpdateNamespace) GetBytesNoSig() []byte {
+	sig := tx.Sig
+	tx.Sig = nil
+	bz := tx.Bytes()
+	tx.Sig = sig
+	return bz
+}
+
+// ComputeHash computes the hash of the transaction
+func (tx *TxRepoProposalUpdateNamespace) ComputeHash() util.Bytes32 {
+	return util.BytesToBytes32(tmhash.Sum(tx.Bytes()))
+}
+
+// GetHash returns the hash of the transaction
+func (tx *TxRepoProposalUpdateNamespace) GetHash() util.HexBytes {
+	return tx.ComputeHash().ToHexBytes()
+}
+
+// GetID returns the id of the transaction (also the hash)
+func (tx *TxRepoProposalUpdateNamespace) GetID() string {
+	return tx.ComputeHash().HexStr()
+}
+
+// GetEcoSize returns the size of the transaction for use in protocol economics
+func (tx *TxRepoProposalUpdateNamespace) GetEcoSize() int64 {
+	return tx.GetSize()
+}
+
+// GetSize returns the size of the tx object (excluding nothing)
+func (tx *TxRepoProposalUpdateNamespace) GetSize() int64 {
+	return int64(len(tx.Bytes()))
+}
+
+// Sign signs the transaction
+func (tx *TxRepoProposalUpdateNamespace) Sign(privKey string) ([]byte, error) {
+	return SignTransaction(tx, privKey)
+}
+
+// ToMap returns a map equivalent of the transaction
+func (tx *TxRepoProposalUpdateNamespace) ToMap() map[string]interface{} {
+	return util.ToJSONMap(tx)
+}
+
+// FromMap populates tx with a map generated by tx.ToMap.
+func (tx *TxRepoProposalUpdateNamespace) FromMap(data map[string]interface{}) error {
+	err := tx.TxCommon.FromMap(data)
+	err = errors.CallIfNil(err, func() error { return tx.TxType.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return tx.TxProposalCommon.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return util.DecodeMap(data, &tx) })
+	return err
+}
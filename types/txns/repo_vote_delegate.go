@@ -0,0 +1,111 @@
+package txns
+
+import (
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/vmihailenco/msgpack"
+)
+
+// TxRepoVoteDelegate implements BaseTx, it describes a transaction for
+// delegating the proposal voting power of a repository owner to another
+// address. An empty delegate revokes an existing delegation.
+type TxRepoVoteDelegate struct {
+	*TxCommon `json:",flatten" msgpack:"-" mapstructure:"-"`
+	*TxType   `json:",flatten" msgpack:"-" mapstructure:"-"`
+	RepoName  string `json:"name" msgpack:"name" mapstructure:"name"`
+	Delegate  string `json:"delegate" msgpack:"delegate" mapstructure:"delegate"`
+}
+
+// NewBareRepoVoteDelegate returns an instance of TxRepoVoteDelegate with zero values
+func NewBareRepoVoteDelegate() *TxRepoVoteDelegate {
+	return &TxRepoVoteDelegate{
+		TxCommon: NewBareTxCommon(),
+		TxType:   &TxType{Type: TxTypeRepoVoteDelegate},
+		RepoName: "",
+		Delegate: "",
+	}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (tx *TxRepoVoteDelegate) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return tx.EncodeMulti(enc,
+		tx.Type,
+		tx.Nonce,
+		tx.Fee,
+		tx.Sig,
+		tx.Timestamp,
+		tx.SenderPubKey,
+		tx.RepoName,
+		tx.Delegate)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder
+func (tx *TxRepoVoteDelegate) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return tx.DecodeMulti(dec,
+		&tx.Type,
+		&tx.Nonce,
+		&tx.Fee,
+		&tx.Sig,
+		&tx.Timestamp,
+		&tx.SenderPubKey,
+		&tx.RepoName,
+		&tx.Delegate)
+}
+
+// Bytes returns the serialized transaction
+func (tx *TxRepoVoteDelegate) Bytes() []byte {
+	return util.ToBytes(tx)
+}
+
+// GetBytesNoSig returns the serialized the transaction excluding the signature
+func (tx *TxRepoVoteDelegate) GetBytesNoSig() []byte {
+	sig := tx.Sig
+	tx.Sig = nil
+	bz := tx.Bytes()
+	tx.Sig = sig
+	return bz
+}
+
+// ComputeHash computes the hash of the transaction
+func (tx *TxRepoVoteDelegate) ComputeHash() util.Bytes32 {
+	return util.BytesToBytes32(tmhash.Sum(tx.Bytes()))
+}
+
+// GetHash returns the hash of the transaction
+func (tx *TxRepoVoteDelegate) GetHash() util.HexBytes {
+	return tx.ComputeHash().ToHexBytes()
+}
+
+// GetID returns the id of the transaction (also the hash)
+func (tx *TxRepoVoteDelegate) GetID() string {
+	return tx.ComputeHash().HexStr()
+}
+
+// GetEcoSize returns the size of the transaction for use in protocol economics
+func (tx *TxRepoVoteDelegate) GetEcoSize() int64 {
+	return tx.GetSize()
+}
+
+// GetSize returns the size of the tx object (excluding nothing)
+func (tx *TxRepoVoteDelegate) GetSize() int64 {
+	return int64(len(tx.Bytes()))
+}
+
+// Sign signs the transaction
+func (tx *TxRepoVoteDelegate) Sign(privKey string) ([]byte, error) {
+	return SignTransaction(tx, privKey)
+}
+
+// ToMap returns a map equivalent of the transaction
+func (tx *TxRepoVoteDelegate) ToMap() map[string]interface{} {
+	return util.ToJSONMap(tx)
+}
+
+// FromMap populates tx with a map generated by tx.ToMap.
+func (tx *TxRepoVoteDelegate) FromMap(data map[string]interface{}) error {
+	err := tx.TxCommon.FromMap(data)
+	err = errors.CallIfNil(err, func() error { return tx.TxType.FromMap(data) })
+	err = errors.CallIfNil(err, func() error { return util.DecodeMap(data, &tx) })
+	return err
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/core"
 )
 
 // NextNonceGetter describes a function for getting the next nonce of an account.
@@ -105,3 +106,47 @@ func VoteRepoProposal(req *api.BodyRepoVote, c types.Client) (hash string, err e
 	}
 	return resp.Hash, nil
 }
+
+// RepoSpendProposer describes a function for proposing a spend from a repo's balance
+type RepoSpendProposer func(req *api.BodyRepoProposeSpend, c types.Client) (hash string, err error)
+
+// ProposeRepoSpend creates a transaction to propose a spend from a repo's balance
+func ProposeRepoSpend(req *api.BodyRepoProposeSpend, c types.Client) (hash string, err error) {
+	resp, err := c.Repo().ProposeSpend(req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Hash, nil
+}
+
+// RepoPolicyChecker describes a function for simulating a repository policy check
+type RepoPolicyChecker func(req *api.BodyRepoCheckPolicy, c types.Client) (*api.ResultCheckPolicy, error)
+
+// CheckRepoPolicy simulates a repository policy check and returns its evaluation trace
+func CheckRepoPolicy(req *api.BodyRepoCheckPolicy, c types.Client) (*api.ResultCheckPolicy, error) {
+	return c.Repo().CheckPolicy(req)
+}
+
+// RepoProposalsWatcher describes a function for watching lifecycle events of repository proposals
+type RepoProposalsWatcher func(names []string, handler func(evt *core.ProposalEvent) error, c types.Client) error
+
+// WatchRepoProposals passes lifecycle events of proposals of the given repositories to the handler
+func WatchRepoProposals(names []string, handler func(evt *core.ProposalEvent) error, c types.Client) error {
+	return c.Repo().WatchProposals(names, handler)
+}
+
+// RepoProposalsLister describes a function for listing the proposals of a repository
+type RepoProposalsLister func(req *api.BodyRepoListProposals, c types.Client) (*api.ResultRepoProposals, error)
+
+// ListRepoProposals returns the proposals of a repository that match the given filters
+func ListRepoProposals(req *api.BodyRepoListProposals, c types.Client) (*api.ResultRepoProposals, error) {
+	return c.Repo().ListProposals(req)
+}
+
+// RepoProposalGetter describes a function for fetching a repository proposal
+type RepoProposalGetter func(req *api.BodyRepoGetProposal, c types.Client) (*api.ResultRepoProposal, error)
+
+// GetRepoProposal returns a repository proposal and the votes cast on it
+func GetRepoProposal(req *api.BodyRepoGetProposal, c types.Client) (*api.ResultRepoProposal, error) {
+	return c.Repo().GetProposal(req)
+}
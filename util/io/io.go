@@ -64,6 +64,20 @@ func ConfirmInput(title string, def bool) bool {
 	return confirm
 }
 
+// SelectInputReader describes a function for selecting one of many options.
+// It returns the index of the selected option.
+type SelectInputReader func(title string, options []string) (int, error)
+
+// SelectInput renders a console input for selecting one of many options
+func SelectInput(title string, options []string) (int, error) {
+	var selected int
+	prompt := &survey.Select{Message: title, Options: options}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return 0, err
+	}
+	return selected, nil
+}
+
 // readPasswordInput starts a prompt to collect single line password input
 func readPasswordInput() (string, error) {
 	password, err := gopass.GetPasswdMasked()
@@ -155,7 +155,7 @@ func CheckTxUnbondTicketConsistency(
 func CheckTxRepoCreateConsistency(tx *txns.TxRepoCreate, index int, logic core.Logic) error {
 
 	repoState := logic.RepoKeeper().Get(tx.Name)
-	if !repoState.IsEmpty() {
+	if !repoState.IsEmpty() || logic.RepoKeeper().GetRedirect(tx.Name) != "" {
 		return feI(index, "name", "name is not available. choose another")
 	}
 
@@ -256,6 +256,10 @@ func CheckTxPushConsistency(tx *txns.TxPush, _ int, logic core.Logic) error {
 		return fmt.Errorf("repo not found")
 	}
 
+	if repoState.Archived {
+		return fmt.Errorf("repo is archived")
+	}
+
 	hosts, err := logic.GetTicketManager().GetTopHosts(params.NumTopHostsLimit)
 	if err != nil {
 		return errors.Wrap(err, "failed to get top hosts")
@@ -385,6 +389,10 @@ func CheckTxNamespaceDomainUpdateConsistency(tx *txns.TxNamespaceDomainUpdate, i
 	}
 
 	if ns.Owner != pubKey.Addr().String() {
+		if !identifier.Address(ns.Owner).IsUserAddress() {
+			return feI(index, "senderPubKey", "namespace is owned by a repository; "+
+				"update it through a repository proposal")
+		}
 		return feI(index, "senderPubKey", "sender not permitted to perform this operation")
 	}
 
@@ -473,6 +481,131 @@ func CheckTxRepoProposalUpsertOwnerConsistency(
 	return nil
 }
 
+// CheckTxRepoProposalSpendConsistency performs consistency checks on TxRepoProposalSpend
+func CheckTxRepoProposalSpendConsistency(
+	tx *txns.TxRepoProposalSpend,
+	index int,
+	logic core.Logic) error {
+
+	repo, err := CheckProposalCommonConsistency(tx.TxProposalCommon, tx.TxCommon, index, logic)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the repository can afford the spend amount at the time of proposal.
+	// The balance is checked again when the proposal is applied.
+	if repo.Balance.Decimal().LessThan(tx.Amount.Decimal()) {
+		return feI(index, "amount", "repo balance is insufficient")
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalArchiveConsistency performs consistency checks on TxRepoProposalArchive
+func CheckTxRepoProposalArchiveConsistency(
+	tx *txns.TxRepoProposalArchive,
+	index int,
+	logic core.Logic) error {
+
+	repo, err := CheckProposalCommonConsistency(tx.TxProposalCommon, tx.TxCommon, index, logic)
+	if err != nil {
+		return err
+	}
+
+	if repo.Archived {
+		return feI(index, "name", "repo is already archived")
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalDeleteConsistency performs consistency checks on TxRepoProposalDelete
+func CheckTxRepoProposalDeleteConsistency(
+	tx *txns.TxRepoProposalDelete,
+	index int,
+	logic core.Logic) error {
+
+	_, err := CheckProposalCommonConsistency(tx.TxProposalCommon, tx.TxCommon, index, logic)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalRenameConsistency performs consistency checks on TxRepoProposalRename
+func CheckTxRepoProposalRenameConsistency(
+	tx *txns.TxRepoProposalRename,
+	index int,
+	logic core.Logic) error {
+
+	// Ensure the new name is not used by another repository
+	// or reserved by the redirect of a renamed repository.
+	// A repository can take back a name that redirects to it.
+	repoKeeper := logic.RepoKeeper()
+	if !repoKeeper.Get(tx.NewName).IsEmpty() {
+		return feI(index, "newName", "name is not available. choose another")
+	}
+	if target := repoKeeper.GetRedirect(tx.NewName); target != "" && target != tx.RepoName {
+		return feI(index, "newName", "name is not available. choose another")
+	}
+
+	// Ensure each namespace exists and either is owned by
+	// the repository or has a domain that targets it.
+	for i, name := range tx.Namespaces {
+		field := fmt.Sprintf("namespaces[%d]", i)
+		ns := logic.NamespaceKeeper().Get(crypto2.MakeNamespaceHash(name))
+		if ns.IsNil() {
+			return feI(index, field, "namespace not found")
+		}
+		targetsRepo := ns.Owner == tx.RepoName
+		for _, target := range ns.Domains {
+			targetsRepo = targetsRepo || target == "r/"+tx.RepoName
+		}
+		if !targetsRepo {
+			return feI(index, field, "namespace is not owned by and has no domain targeting the repository")
+		}
+	}
+
+	_, err := CheckProposalCommonConsistency(tx.TxProposalCommon, tx.TxCommon, index, logic)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalUpdateNamespaceConsistency performs consistency
+// checks on TxRepoProposalUpdateNamespace
+func CheckTxRepoProposalUpdateNamespaceConsistency(
+	tx *txns.TxRepoProposalUpdateNamespace,
+	index int,
+	logic core.Logic) error {
+
+	// Ensure the namespace exists and is owned by the repository
+	ns := logic.NamespaceKeeper().Get(crypto2.MakeNamespaceHash(tx.Namespace))
+	if ns.IsNil() {
+		return feI(index, "namespace", "namespace not found")
+	}
+	if ns.Owner != tx.RepoName {
+		return feI(index, "namespace", "namespace not owned by the target repository")
+	}
+
+	// Ensure the contributors to be removed exist
+	for i, pkID := range tx.RemoveContributors {
+		if !ns.Contributors.Has(pkID) {
+			return feI(index, fmt.Sprintf("removeContributors[%d]", i), "contributor not found in namespace")
+		}
+	}
+
+	_, err := CheckProposalCommonConsistency(tx.TxProposalCommon, tx.TxCommon, index, logic)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CheckTxVoteConsistency performs consistency checks on CheckTxVote
 func CheckTxVoteConsistency(
 	tx *txns.TxRepoProposalVote,
@@ -512,28 +645,167 @@ func CheckTxVoteConsistency(
 		return feI(index, "id", "total deposited proposal fee is insufficient")
 	}
 
-	// If the proposal is targeted at repo owners, then
-	// the sender must be an owner
-	senderOwner := repoState.Owners.Get(tx.GetFrom().String())
-	if *proposal.GetVoterType().Ptr() == *state.VoterOwner.Ptr() && senderOwner == nil {
+	// If the proposal is targeted at repo owners, then the sender
+	// must be an owner or the vote delegate of an owner
+	senderAddr := tx.GetFrom().String()
+	senderOwner := repoState.Owners.Get(senderAddr)
+	if *proposal.GetVoterType().Ptr() == *state.VoterOwner.Ptr() &&
+		senderOwner == nil && !repoState.Owners.IsDelegate(senderAddr) {
 		return feI(index, "senderPubKey", "sender is not one of the repo owners")
 	}
 
 	// If the proposal is targeted at repo owners and
 	// the vote is a NoWithVeto, then the sender must have veto rights.
 	if *proposal.GetVoterType().Ptr() == *state.VoterOwner.Ptr() &&
-		tx.Vote == state.ProposalVoteNoWithVeto && !senderOwner.Veto {
+		tx.Vote == state.ProposalVoteNoWithVeto && (senderOwner == nil || !senderOwner.Veto) {
 		return feI(index, "senderPubKey", "sender cannot vote 'no with veto' because "+
 			"they have no veto right")
 	}
 
-	// Ensure the sender had not previously voted
-	_, voted, err := logic.RepoKeeper().
-		GetProposalVote(tx.RepoName, tx.ProposalID, tx.GetFrom().String())
+	// A sender that has previously voted may change their
+	// vote but not cast the same vote choice again
+	vote, _, voted, err := logic.RepoKeeper().GetProposalVote(tx.RepoName, tx.ProposalID, senderAddr)
 	if err != nil {
 		return errors.Wrap(err, "failed to check proposal vote")
-	} else if voted {
-		return feI(index, "id", "vote already cast on the target proposal")
+	} else if voted && vote == tx.Vote {
+		return feI(index, "vote", "vote choice is the same as the previous vote")
+	}
+
+	pubKey, _ := ed25519.PubKeyFromBytes(tx.GetSenderPubKey().Bytes())
+	if err = logic.DrySend(pubKey, "0",
+		tx.Fee,
+		tx.GetNonce(),
+		tx.HasMetaKey(types.TxMetaKeyAllowNonceGap),
+		uint64(bi.Height)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalWithdrawConsistency performs consistency checks on TxRepoProposalWithdraw
+func CheckTxRepoProposalWithdrawConsistency(
+	tx *txns.TxRepoProposalWithdraw,
+	index int,
+	logic core.Logic) error {
+
+	// The repo must exist
+	repoState := logic.RepoKeeper().Get(tx.RepoName)
+	if repoState.IsEmpty() {
+		return feI(index, "name", "repo not found")
+	}
+
+	// The proposal must exist
+	proposal := repoState.Proposals.Get(tx.ProposalID)
+	if proposal == nil {
+		return feI(index, "id", "proposal not found")
+	}
+
+	// Ensure the proposal has not been finalized
+	if proposal.IsFinalized() {
+		return feI(index, "id", "proposal has concluded")
+	}
+
+	// Only the creator can withdraw the proposal
+	if proposal.Creator != tx.GetFrom().String() {
+		return feI(index, "senderPubKey", "sender is not the creator of the proposal")
+	}
+
+	bi, err := logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch current block info")
+	}
+
+	// The proposal can only be withdrawn during the fee deposit
+	// period or before any vote has been cast.
+	if !proposal.IsDepositPeriod(uint64(bi.Height+1)) && logic.RepoKeeper().HasProposalVotes(tx.RepoName, tx.ProposalID) {
+		return feI(index, "id", "proposal cannot be withdrawn after votes have been cast")
+	}
+
+	pubKey, _ := ed25519.PubKeyFromBytes(tx.GetSenderPubKey().Bytes())
+	if err = logic.DrySend(pubKey, "0",
+		tx.Fee,
+		tx.GetNonce(),
+		tx.HasMetaKey(types.TxMetaKeyAllowNonceGap),
+		uint64(bi.Height)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalCancelConsistency performs consistency checks on TxRepoProposalCancel
+func CheckTxRepoProposalCancelConsistency(
+	tx *txns.TxRepoProposalCancel,
+	index int,
+	logic core.Logic) error {
+
+	// The repo must exist
+	repoState := logic.RepoKeeper().Get(tx.RepoName)
+	if repoState.IsEmpty() {
+		return feI(index, "name", "repo not found")
+	}
+
+	// The proposal must exist
+	proposal := repoState.Proposals.Get(tx.ProposalID)
+	if proposal == nil {
+		return feI(index, "id", "proposal not found")
+	}
+
+	// Only proposals waiting for their execution height can be cancelled
+	if !proposal.IsQueued() {
+		return feI(index, "id", "proposal is not queued for execution")
+	}
+
+	// Only owners with veto right can cancel the proposal
+	senderOwner := repoState.Owners.Get(tx.GetFrom().String())
+	if senderOwner == nil || !senderOwner.Veto {
+		return feI(index, "senderPubKey", "sender is not a repo owner with veto right")
+	}
+
+	bi, err := logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch current block info")
+	}
+
+	pubKey, _ := ed25519.PubKeyFromBytes(tx.GetSenderPubKey().Bytes())
+	if err = logic.DrySend(pubKey, "0",
+		tx.Fee,
+		tx.GetNonce(),
+		tx.HasMetaKey(types.TxMetaKeyAllowNonceGap),
+		uint64(bi.Height)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoVoteDelegateConsistency performs consistency checks on TxRepoVoteDelegate
+func CheckTxRepoVoteDelegateConsistency(
+	tx *txns.TxRepoVoteDelegate,
+	index int,
+	logic core.Logic) error {
+
+	// The repo must exist
+	repoState := logic.RepoKeeper().Get(tx.RepoName)
+	if repoState.IsEmpty() {
+		return feI(index, "name", "repo not found")
+	}
+
+	// Only owners can delegate their voting power
+	senderAddr := tx.GetFrom().String()
+	if !repoState.Owners.Has(senderAddr) {
+		return feI(index, "senderPubKey", "sender is not one of the repo owners")
+	}
+
+	// The sender cannot delegate to themselves
+	if tx.Delegate == senderAddr {
+		return feI(index, "delegate", "sender cannot delegate to themselves")
+	}
+
+	bi, err := logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch current block info")
 	}
 
 	pubKey, _ := ed25519.PubKeyFromBytes(tx.GetSenderPubKey().Bytes())
@@ -637,5 +909,16 @@ func CheckTxRepoProposalRegisterPushKeyConsistency(
 		return err
 	}
 
+	// Ensure time-bounded policies have not already expired
+	bi, err := logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch current block info")
+	}
+	for i, pol := range tx.Policies {
+		if pol != nil && pol.EndHeight > 0 && pol.EndHeight <= uint64(bi.Height) {
+			return feI(index, fmt.Sprintf("policies[%d].endHeight", i), "must be greater than the current block height")
+		}
+	}
+
 	return nil
 }
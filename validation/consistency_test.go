@@ -380,6 +380,21 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
+		When("repo name is reserved by the redirect of a renamed repo", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareTxRepoCreate()
+				tx.Name = "repo1"
+				mockRepoKeeper.EXPECT().Get(tx.Name).Return(state.BareRepository())
+				mockRepoKeeper.EXPECT().GetRedirect(tx.Name).Return("repo2")
+				err = validation.CheckTxRepoCreateConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"name","msg":"name is not available. choose another"`))
+			})
+		})
+
 		When("coin transfer dry-run fails", func() {
 			BeforeEach(func() {
 				tx := txns.NewBareTxRepoCreate()
@@ -388,6 +403,7 @@ var _ = Describe("TxValidator", func() {
 
 				repo := state.BareRepository()
 				mockRepoKeeper.EXPECT().Get(tx.Name).Return(repo)
+				mockRepoKeeper.EXPECT().GetRedirect(tx.Name).Return("")
 
 				mockLogic.EXPECT().DrySend(key.PubKey(), tx.Value, tx.Fee, tx.Nonce, false, uint64(0)).Return(fmt.Errorf("error"))
 
@@ -702,6 +718,21 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
+		When("target namespace is owned by a repository", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareTxNamespaceDomainUpdate()
+				tx.Name = "name1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				mockNSKeeper.EXPECT().Get(tx.Name).Return(&state.Namespace{GraceEndAt: 10, Owner: "repo1"})
+				err = validation.CheckTxNamespaceDomainUpdateConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"senderPubKey","msg":"namespace is owned by a repository; update it through a repository proposal"`))
+			})
+		})
+
 		When("balance sufficiency dry-run fails", func() {
 			BeforeEach(func() {
 				name := "name1"
@@ -741,6 +772,22 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
+		When("repository is archived", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareTxPush()
+				tx.Note.(*types.Note).RepoName = "repo1"
+				repo := state.BareRepository()
+				repo.Archived = true
+				mockRepoKeeper.EXPECT().Get(tx.Note.(*types.Note).RepoName).Return(repo)
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("repo is archived"))
+			})
+		})
+
 		When("unable to get top hosts", func() {
 			BeforeEach(func() {
 				tx := txns.NewBareTxPush()
@@ -944,6 +991,83 @@ var _ = Describe("TxValidator", func() {
 		})
 	})
 
+	Describe(".CheckTxRepoProposalUpdateNamespaceConsistency", func() {
+		var tx *txns.TxRepoProposalUpdateNamespace
+		var repo *state.Repository
+		var pkID = "pk1dmqxfznwyhmkcgcfthlvvt88vajyhnxq7w8nsw"
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalUpdateNamespace()
+			tx.RepoName = "repo1"
+			tx.Namespace = "ns1"
+			tx.Value = "101"
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+			repo = state.BareRepository()
+			repo.Config = state.MakeZeroValueRepoConfig()
+			repo.Config.Gov.PropFee = pointer.ToString("100")
+			repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+			repo.Owners[key.Addr().String()] = &state.RepoOwner{}
+		})
+
+		When("namespace does not exist", func() {
+			BeforeEach(func() {
+				mockNSKeeper.EXPECT().Get(crypto2.MakeNamespaceHash("ns1")).Return(state.BareNamespace())
+				err = validation.CheckTxRepoProposalUpdateNamespaceConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"namespace","msg":"namespace not found"`))
+			})
+		})
+
+		When("namespace is not owned by the repo", func() {
+			BeforeEach(func() {
+				ns := state.BareNamespace()
+				ns.Owner = key.Addr().String()
+				mockNSKeeper.EXPECT().Get(crypto2.MakeNamespaceHash("ns1")).Return(ns)
+				err = validation.CheckTxRepoProposalUpdateNamespaceConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"namespace","msg":"namespace not owned by the target repository"`))
+			})
+		})
+
+		When("a contributor to remove is not a contributor of the namespace", func() {
+			BeforeEach(func() {
+				tx.RemoveContributors = []string{pkID}
+				ns := state.BareNamespace()
+				ns.Owner = tx.RepoName
+				mockNSKeeper.EXPECT().Get(crypto2.MakeNamespaceHash("ns1")).Return(ns)
+				err = validation.CheckTxRepoProposalUpdateNamespaceConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"removeContributors[0]","msg":"contributor not found in namespace"`))
+			})
+		})
+
+		When("namespace is owned by the repo and contributors to remove exist", func() {
+			BeforeEach(func() {
+				tx.RemoveContributors = []string{pkID}
+				ns := state.BareNamespace()
+				ns.Owner = tx.RepoName
+				ns.Contributors[pkID] = &state.BaseContributor{}
+				mockNSKeeper.EXPECT().Get(crypto2.MakeNamespaceHash("ns1")).Return(ns)
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalUpdateNamespaceConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
 	Describe(".CheckTxVoteConsistency", func() {
 		When("repo is unknown", func() {
 			BeforeEach(func() {
@@ -1088,7 +1212,7 @@ var _ = Describe("TxValidator", func() {
 				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
 
 				mockRepoKeeper.EXPECT().GetProposalVote(tx.RepoName, tx.ProposalID,
-					key.Addr().String()).Return(0, false, fmt.Errorf("error"))
+					key.Addr().String()).Return(0, float64(0), false, fmt.Errorf("error"))
 				err = validation.CheckTxVoteConsistency(tx, -1, mockLogic)
 			})
 
@@ -1098,12 +1222,13 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
-		When("sender already voted on the proposal", func() {
+		When("sender already voted on the proposal with the same vote choice", func() {
 			BeforeEach(func() {
 				tx := txns.NewBareRepoProposalVote()
 				tx.RepoName = "repo1"
 				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
 				tx.ProposalID = "proposal1"
+				tx.Vote = state.ProposalVoteYes
 
 				repo := state.BareRepository()
 				repo.Config.Gov.Voter = state.VoterNetStakers.Ptr()
@@ -1114,13 +1239,41 @@ var _ = Describe("TxValidator", func() {
 				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
 
 				mockRepoKeeper.EXPECT().GetProposalVote(tx.RepoName, tx.ProposalID,
-					key.Addr().String()).Return(0, true, nil)
+					key.Addr().String()).Return(state.ProposalVoteYes, float64(1), true, nil)
 				err = validation.CheckTxVoteConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
 				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(Equal(`"field":"id","msg":"vote already cast on the target proposal"`))
+				Expect(err.Error()).To(Equal(`"field":"vote","msg":"vote choice is the same as the previous vote"`))
+			})
+		})
+
+		When("sender already voted on the proposal with a different vote choice", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalVote()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ProposalID = "proposal1"
+				tx.Vote = state.ProposalVoteNo
+
+				repo := state.BareRepository()
+				repo.Config.Gov.Voter = state.VoterNetStakers.Ptr()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config: repo.Config.Gov,
+				})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+
+				mockRepoKeeper.EXPECT().GetProposalVote(tx.RepoName, tx.ProposalID,
+					key.Addr().String()).Return(state.ProposalVoteYes, float64(1), true, nil)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxVoteConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
 			})
 		})
 
@@ -1148,6 +1301,63 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
+		When("sender is the vote delegate of an owner of a repo whose proposal is targetted at repo owners", func() {
+			When("sender votes NoWithVeto", func() {
+				BeforeEach(func() {
+					tx := txns.NewBareRepoProposalVote()
+					tx.RepoName = "repo1"
+					tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+					tx.ProposalID = "proposal1"
+					tx.Vote = state.ProposalVoteNoWithVeto
+
+					repo := state.BareRepository()
+					repo.AddOwner(key2.Addr().String(), &state.RepoOwner{Veto: true, Delegate: key.Addr().String()})
+					repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+					repo.Proposals.Add("proposal1", &state.RepoProposal{
+						Config: repo.Config.Gov,
+					})
+					mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+					mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+
+					err = validation.CheckTxVoteConsistency(tx, -1, mockLogic)
+				})
+
+				It("should return err", func() {
+					Expect(err).ToNot(BeNil())
+					Expect(err.Error()).To(Equal(`"field":"senderPubKey","msg":"sender cannot vote 'no with veto' because they have no veto right"`))
+				})
+			})
+
+			When("sender votes Yes", func() {
+				BeforeEach(func() {
+					tx := txns.NewBareRepoProposalVote()
+					tx.RepoName = "repo1"
+					tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+					tx.ProposalID = "proposal1"
+					tx.Vote = state.ProposalVoteYes
+
+					repo := state.BareRepository()
+					repo.AddOwner(key2.Addr().String(), &state.RepoOwner{Delegate: key.Addr().String()})
+					repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+					repo.Proposals.Add("proposal1", &state.RepoProposal{
+						Config: repo.Config.Gov,
+					})
+					mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+					mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+					mockRepoKeeper.EXPECT().GetProposalVote(tx.RepoName, tx.ProposalID,
+						key.Addr().String()).Return(0, float64(0), false, nil)
+					mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+						gomock.Any(), gomock.Any()).Return(nil)
+
+					err = validation.CheckTxVoteConsistency(tx, -1, mockLogic)
+				})
+
+				It("should return no error", func() {
+					Expect(err).To(BeNil())
+				})
+			})
+		})
+
 		When("sender is an owner of a repo whose proposal is targetted at repo owners", func() {
 			When("sender has no veto right but votes NoWithVeto", func() {
 				BeforeEach(func() {
@@ -1177,15 +1387,20 @@ var _ = Describe("TxValidator", func() {
 		})
 	})
 
-	Describe(".CheckTxRepoProposalSendFeeConsistency", func() {
+	Describe(".CheckTxRepoProposalCancelConsistency", func() {
+		var tx *txns.TxRepoProposalCancel
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalCancel()
+			tx.RepoName = "repo1"
+			tx.ProposalID = "1"
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+		})
+
 		When("repo is unknown", func() {
 			BeforeEach(func() {
-				tx := txns.NewBareRepoProposalFeeSend()
-				tx.RepoName = "repo1"
-				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
-				repo := state.BareRepository()
-				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
-				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(state.BareRepository())
+				err = validation.CheckTxRepoProposalCancelConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
@@ -1196,15 +1411,10 @@ var _ = Describe("TxValidator", func() {
 
 		When("repo does not include the proposal", func() {
 			BeforeEach(func() {
-				tx := txns.NewBareRepoProposalFeeSend()
-				tx.RepoName = "repo1"
-				tx.ID = "proposal_xyz"
-				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
-
 				repo := state.BareRepository()
-				repo.Proposals.Add("proposal1", &state.RepoProposal{})
+				repo.Proposals.Add("2", &state.RepoProposal{})
 				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
-				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+				err = validation.CheckTxRepoProposalCancelConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
@@ -1213,147 +1423,426 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
-		When("the proposal has been finalized/concluded", func() {
+		When("proposal is not queued", func() {
 			BeforeEach(func() {
-				tx := txns.NewBareRepoProposalFeeSend()
-				tx.RepoName = "repo1"
-				tx.ID = "proposal1"
-				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
-
 				repo := state.BareRepository()
-				repo.Proposals.Add("proposal1", &state.RepoProposal{Outcome: 1})
+				repo.Proposals.Add("1", &state.RepoProposal{Outcome: state.ProposalOutcomeAccepted})
 				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
-				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+				err = validation.CheckTxRepoProposalCancelConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
 				Expect(err).ToNot(BeNil())
-				Expect(err).To(MatchError(`"field":"id","msg":"proposal has concluded"`))
+				Expect(err).To(MatchError(`"field":"id","msg":"proposal is not queued for execution"`))
 			})
 		})
 
-		When("unable to get current block info", func() {
+		When("sender is an owner without veto right", func() {
 			BeforeEach(func() {
-				tx := txns.NewBareRepoProposalFeeSend()
-				tx.RepoName = "repo1"
-				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
-				tx.ID = "proposal1"
-
 				repo := state.BareRepository()
-				repo.Proposals.Add("proposal1", &state.RepoProposal{
-					Config: repo.Config.Gov,
-				})
+				repo.AddOwner(key.Addr().String(), &state.RepoOwner{})
+				repo.Proposals.Add("1", &state.RepoProposal{Outcome: state.ProposalOutcomeQueued})
 				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
-				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(nil, fmt.Errorf("error"))
-
-				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+				err = validation.CheckTxRepoProposalCancelConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
 				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(Equal("failed to fetch current block info: error"))
+				Expect(err).To(MatchError(`"field":"senderPubKey","msg":"sender is not a repo owner with veto right"`))
 			})
 		})
 
-		When("fee deposit is not enabled for a proposal", func() {
+		When("sender is an owner with veto right", func() {
 			BeforeEach(func() {
-				tx := txns.NewBareRepoProposalFeeSend()
-				tx.RepoName = "repo1"
-				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
-				tx.ID = "proposal1"
-
 				repo := state.BareRepository()
-				repo.Proposals.Add("proposal1", &state.RepoProposal{
-					Config:          repo.Config.Gov,
-					FeeDepositEndAt: 0,
-				})
+				repo.AddOwner(key.Addr().String(), &state.RepoOwner{Veto: true})
+				repo.Proposals.Add("1", &state.RepoProposal{Outcome: state.ProposalOutcomeQueued})
 				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
 				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
-
-				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalCancelConsistency(tx, -1, mockLogic)
 			})
 
-			It("should return err", func() {
-				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(Equal(`"field":"id","msg":"fee deposit not enabled for the proposal"`))
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
 			})
 		})
+	})
 
-		When("a proposal is not in proposal fee deposit period", func() {
-			BeforeEach(func() {
-				tx := txns.NewBareRepoProposalFeeSend()
-				tx.RepoName = "repo1"
-				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
-				tx.ID = "proposal1"
+	Describe(".CheckTxRepoVoteDelegateConsistency", func() {
+		var tx *txns.TxRepoVoteDelegate
 
-				repo := state.BareRepository()
-				repo.Proposals.Add("proposal1", &state.RepoProposal{
-					Config:          repo.Config.Gov,
-					FeeDepositEndAt: 100,
-				})
-				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
-				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+		BeforeEach(func() {
+			tx = txns.NewBareRepoVoteDelegate()
+			tx.RepoName = "repo1"
+			tx.Delegate = key2.Addr().String()
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+		})
 
-				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+		When("repo is unknown", func() {
+			BeforeEach(func() {
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(state.BareRepository())
+				err = validation.CheckTxRepoVoteDelegateConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
 				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(Equal(`"field":"id","msg":"proposal fee deposit period has closed"`))
+				Expect(err).To(MatchError(`"field":"name","msg":"repo not found"`))
 			})
 		})
 
-		When("failed value transfer dry-run", func() {
+		When("sender is not an owner", func() {
 			BeforeEach(func() {
-				tx := txns.NewBareRepoProposalFeeSend()
-				tx.RepoName = "repo1"
-				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
-				tx.ID = "proposal1"
-
 				repo := state.BareRepository()
-				repo.Proposals.Add("proposal1", &state.RepoProposal{
-					Config:          repo.Config.Gov,
-					FeeDepositEndAt: 100,
-				})
-
+				repo.AddOwner(key2.Addr().String(), &state.RepoOwner{})
 				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
-				bi := &state.BlockInfo{Height: 10}
-				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(bi, nil)
-				mockLogic.EXPECT().DrySend(key.PubKey(), tx.Value, tx.Fee, tx.Nonce, false, uint64(bi.Height)).Return(fmt.Errorf("error"))
-
-				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+				err = validation.CheckTxRepoVoteDelegateConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
 				Expect(err).ToNot(BeNil())
-				Expect(err).To(MatchError("error"))
+				Expect(err).To(MatchError(`"field":"senderPubKey","msg":"sender is not one of the repo owners"`))
 			})
 		})
-	})
 
-	Describe(".CheckProposalCommonConsistency", func() {
-		When("repo is unknown", func() {
+		When("delegate is the sender", func() {
 			BeforeEach(func() {
-				txProposal := &txns.TxProposalCommon{RepoName: "repo1"}
-				txCommon := &txns.TxCommon{}
-				txCommon.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.Delegate = key.Addr().String()
 				repo := state.BareRepository()
-
-				mockRepoKeeper.EXPECT().Get(txProposal.RepoName).Return(repo)
-				_, err = validation.CheckProposalCommonConsistency(txProposal, txCommon, -1, mockLogic)
+				repo.AddOwner(key.Addr().String(), &state.RepoOwner{})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				err = validation.CheckTxRepoVoteDelegateConsistency(tx, -1, mockLogic)
 			})
 
 			It("should return err", func() {
 				Expect(err).ToNot(BeNil())
-				Expect(err).To(MatchError(`"field":"name","msg":"repo not found"`))
+				Expect(err).To(MatchError(`"field":"delegate","msg":"sender cannot delegate to themselves"`))
 			})
 		})
 
-		When("proposal with matching ID exist", func() {
+		When("sender is an owner and delegate is another address", func() {
 			BeforeEach(func() {
-				txProposal := &txns.TxProposalCommon{RepoName: "repo1"}
-				txCommon := &txns.TxCommon{}
-				txCommon.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				repo := state.BareRepository()
+				repo.AddOwner(key.Addr().String(), &state.RepoOwner{})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoVoteDelegateConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe(".CheckTxRepoProposalWithdrawConsistency", func() {
+		var tx *txns.TxRepoProposalWithdraw
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalWithdraw()
+			tx.RepoName = "repo1"
+			tx.ProposalID = "1"
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+		})
+
+		When("repo is unknown", func() {
+			BeforeEach(func() {
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(state.BareRepository())
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"name","msg":"repo not found"`))
+			})
+		})
+
+		When("repo does not include the proposal", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Proposals.Add("2", &state.RepoProposal{})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"id","msg":"proposal not found"`))
+			})
+		})
+
+		When("the proposal has been finalized", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Proposals.Add("1", &state.RepoProposal{Outcome: state.ProposalOutcomeAccepted})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"id","msg":"proposal has concluded"`))
+			})
+		})
+
+		When("the sender is not the proposal creator", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Proposals.Add("1", &state.RepoProposal{Creator: "addr2"})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"senderPubKey","msg":"sender is not the creator of the proposal"`))
+			})
+		})
+
+		When("unable to get current block info", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Proposals.Add("1", &state.RepoProposal{Creator: key.Addr().String()})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(nil, fmt.Errorf("error"))
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("failed to fetch current block info: error"))
+			})
+		})
+
+		When("votes have been cast and the proposal is not in fee deposit period", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Proposals.Add("1", &state.RepoProposal{Creator: key.Addr().String()})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockRepoKeeper.EXPECT().HasProposalVotes(tx.RepoName, tx.ProposalID).Return(true)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"id","msg":"proposal cannot be withdrawn after votes have been cast"`))
+			})
+		})
+
+		When("the proposal is in fee deposit period", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Proposals.Add("1", &state.RepoProposal{Creator: key.Addr().String(), FeeDepositEndAt: 100})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+				mockLogic.EXPECT().DrySend(key.PubKey(), util.String("0"), tx.Fee, tx.Nonce, false, uint64(50)).Return(nil)
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no err", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("balance sufficiency dry-run fails", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Proposals.Add("1", &state.RepoProposal{Creator: key.Addr().String()})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockRepoKeeper.EXPECT().HasProposalVotes(tx.RepoName, tx.ProposalID).Return(false)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+				mockLogic.EXPECT().DrySend(key.PubKey(), util.String("0"), tx.Fee, tx.Nonce, false, uint64(50)).Return(fmt.Errorf("error"))
+				err = validation.CheckTxRepoProposalWithdrawConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("error"))
+			})
+		})
+	})
+
+	Describe(".CheckTxRepoProposalSendFeeConsistency", func() {
+		When("repo is unknown", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				repo := state.BareRepository()
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"name","msg":"repo not found"`))
+			})
+		})
+
+		When("repo does not include the proposal", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.ID = "proposal_xyz"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"id","msg":"proposal not found"`))
+			})
+		})
+
+		When("the proposal has been finalized/concluded", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.ID = "proposal1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{Outcome: 1})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"id","msg":"proposal has concluded"`))
+			})
+		})
+
+		When("unable to get current block info", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ID = "proposal1"
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config: repo.Config.Gov,
+				})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(nil, fmt.Errorf("error"))
+
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("failed to fetch current block info: error"))
+			})
+		})
+
+		When("fee deposit is not enabled for a proposal", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ID = "proposal1"
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config:          repo.Config.Gov,
+					FeeDepositEndAt: 0,
+				})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 50}, nil)
+
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"id","msg":"fee deposit not enabled for the proposal"`))
+			})
+		})
+
+		When("a proposal is not in proposal fee deposit period", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ID = "proposal1"
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config:          repo.Config.Gov,
+					FeeDepositEndAt: 100,
+				})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"id","msg":"proposal fee deposit period has closed"`))
+			})
+		})
+
+		When("failed value transfer dry-run", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ID = "proposal1"
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config:          repo.Config.Gov,
+					FeeDepositEndAt: 100,
+				})
+
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				bi := &state.BlockInfo{Height: 10}
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(bi, nil)
+				mockLogic.EXPECT().DrySend(key.PubKey(), tx.Value, tx.Fee, tx.Nonce, false, uint64(bi.Height)).Return(fmt.Errorf("error"))
+
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("error"))
+			})
+		})
+	})
+
+	Describe(".CheckProposalCommonConsistency", func() {
+		When("repo is unknown", func() {
+			BeforeEach(func() {
+				txProposal := &txns.TxProposalCommon{RepoName: "repo1"}
+				txCommon := &txns.TxCommon{}
+				txCommon.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				repo := state.BareRepository()
+
+				mockRepoKeeper.EXPECT().Get(txProposal.RepoName).Return(repo)
+				_, err = validation.CheckProposalCommonConsistency(txProposal, txCommon, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"name","msg":"repo not found"`))
+			})
+		})
+
+		When("proposal with matching ID exist", func() {
+			BeforeEach(func() {
+				txProposal := &txns.TxProposalCommon{RepoName: "repo1"}
+				txCommon := &txns.TxCommon{}
+				txCommon.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
 				txProposal.ID = "1"
 				repo := state.BareRepository()
 				repo.Proposals[txProposal.ID] = &state.RepoProposal{EndAt: 1000}
@@ -1495,6 +1984,257 @@ var _ = Describe("TxValidator", func() {
 		})
 	})
 
+	Describe(".CheckTxRepoProposalSpendConsistency", func() {
+		var tx *txns.TxRepoProposalSpend
+		var repo *state.Repository
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalSpend()
+			tx.RepoName = "repo1"
+			tx.Value = "101"
+			tx.Amount = "50"
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+			repo = state.BareRepository()
+			repo.Config = state.MakeZeroValueRepoConfig()
+			repo.Config.Gov.PropFee = pointer.ToString("100")
+			repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+			repo.Owners[key.Addr().String()] = &state.RepoOwner{}
+		})
+
+		When("target repo does not exist", func() {
+			BeforeEach(func() {
+				tx.RepoName = "unknown"
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(state.BareRepository())
+				err = validation.CheckTxRepoProposalSpendConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"name","msg":"repo not found"`))
+			})
+		})
+
+		When("repo balance is less than the spend amount", func() {
+			BeforeEach(func() {
+				repo.Balance = "49"
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalSpendConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"amount","msg":"repo balance is insufficient"`))
+			})
+		})
+
+		When("repo balance covers the spend amount", func() {
+			BeforeEach(func() {
+				repo.Balance = "50"
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalSpendConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe(".CheckTxRepoProposalArchiveConsistency", func() {
+		var tx *txns.TxRepoProposalArchive
+		var repo *state.Repository
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalArchive()
+			tx.RepoName = "repo1"
+			tx.Value = "101"
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+			repo = state.BareRepository()
+			repo.Config = state.MakeZeroValueRepoConfig()
+			repo.Config.Gov.PropFee = pointer.ToString("100")
+			repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+			repo.Owners[key.Addr().String()] = &state.RepoOwner{}
+		})
+
+		When("target repo does not exist", func() {
+			BeforeEach(func() {
+				tx.RepoName = "unknown"
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(state.BareRepository())
+				err = validation.CheckTxRepoProposalArchiveConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"name","msg":"repo not found"`))
+			})
+		})
+
+		When("repo is already archived", func() {
+			BeforeEach(func() {
+				repo.Archived = true
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalArchiveConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"name","msg":"repo is already archived"`))
+			})
+		})
+
+		When("repo is not archived", func() {
+			BeforeEach(func() {
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalArchiveConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe(".CheckTxRepoProposalRenameConsistency", func() {
+		var tx *txns.TxRepoProposalRename
+		var repo *state.Repository
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalRename()
+			tx.RepoName = "repo1"
+			tx.NewName = "repo2"
+			tx.Value = "101"
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+			repo = state.BareRepository()
+			repo.Config = state.MakeZeroValueRepoConfig()
+			repo.Config.Gov.PropFee = pointer.ToString("100")
+			repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+			repo.Owners[key.Addr().String()] = &state.RepoOwner{}
+		})
+
+		When("new name is used by another repo", func() {
+			BeforeEach(func() {
+				mockRepoKeeper.EXPECT().Get(tx.NewName).Return(repo)
+				err = validation.CheckTxRepoProposalRenameConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"newName","msg":"name is not available. choose another"`))
+			})
+		})
+
+		When("new name redirects to another repo", func() {
+			BeforeEach(func() {
+				mockRepoKeeper.EXPECT().Get(tx.NewName).Return(state.BareRepository())
+				mockRepoKeeper.EXPECT().GetRedirect(tx.NewName).Return("repo3")
+				err = validation.CheckTxRepoProposalRenameConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"newName","msg":"name is not available. choose another"`))
+			})
+		})
+
+		When("a namespace does not exist", func() {
+			BeforeEach(func() {
+				tx.Namespaces = []string{"ns1"}
+				mockRepoKeeper.EXPECT().Get(tx.NewName).Return(state.BareRepository())
+				mockRepoKeeper.EXPECT().GetRedirect(tx.NewName).Return("")
+				mockNSKeeper.EXPECT().Get(crypto2.MakeNamespaceHash("ns1")).Return(state.BareNamespace())
+				err = validation.CheckTxRepoProposalRenameConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"namespaces[0]","msg":"namespace not found"`))
+			})
+		})
+
+		When("a namespace is not owned by and has no domain targeting the repo", func() {
+			BeforeEach(func() {
+				tx.Namespaces = []string{"ns1"}
+				mockRepoKeeper.EXPECT().Get(tx.NewName).Return(state.BareRepository())
+				mockRepoKeeper.EXPECT().GetRedirect(tx.NewName).Return("")
+				ns := state.BareNamespace()
+				ns.Owner = "addr"
+				ns.Domains["app"] = "r/repo3"
+				mockNSKeeper.EXPECT().Get(crypto2.MakeNamespaceHash("ns1")).Return(ns)
+				err = validation.CheckTxRepoProposalRenameConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"namespaces[0]","msg":"namespace is not owned by and has no domain targeting the repository"`))
+			})
+		})
+
+		When("the new name redirects to the repo and a namespace has a domain targeting the repo", func() {
+			BeforeEach(func() {
+				tx.Namespaces = []string{"ns1"}
+				mockRepoKeeper.EXPECT().Get(tx.NewName).Return(state.BareRepository())
+				mockRepoKeeper.EXPECT().GetRedirect(tx.NewName).Return(tx.RepoName)
+				ns := state.BareNamespace()
+				ns.Owner = "addr"
+				ns.Domains["app"] = "r/repo1"
+				mockNSKeeper.EXPECT().Get(crypto2.MakeNamespaceHash("ns1")).Return(ns)
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalRenameConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe(".CheckTxRepoProposalDeleteConsistency", func() {
+		var tx *txns.TxRepoProposalDelete
+		var repo *state.Repository
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalDelete()
+			tx.RepoName = "repo1"
+			tx.Value = "101"
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+			repo = state.BareRepository()
+			repo.Config = state.MakeZeroValueRepoConfig()
+			repo.Config.Gov.PropFee = pointer.ToString("100")
+			repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+			repo.Owners[key.Addr().String()] = &state.RepoOwner{}
+		})
+
+		When("target repo does not exist", func() {
+			BeforeEach(func() {
+				tx.RepoName = "unknown"
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(state.BareRepository())
+				err = validation.CheckTxRepoProposalDeleteConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"name","msg":"repo not found"`))
+			})
+		})
+
+		When("repo exists and sender can create the proposal", func() {
+			BeforeEach(func() {
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				err = validation.CheckTxRepoProposalDeleteConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
 	Describe(".CheckTxRepoProposalUpdateConsistency", func() {
 		When("target repo does not exist", func() {
 			BeforeEach(func() {
@@ -1574,6 +2314,50 @@ var _ = Describe("TxValidator", func() {
 				Expect(err).ToNot(MatchError(`"field":"namespace","msg":"namespace not owned by the target repository"`))
 			})
 		})
+
+		When("a policy has expired at the current block height", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalRegisterPushKey()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.Policies = []*state.ContributorPolicy{
+					{Object: "refs/heads/dev", Action: "write", EndHeight: 200},
+					{Object: "refs/heads/master", Action: "write", EndHeight: 100},
+				}
+				repo := state.BareRepository()
+				repo.Balance = "10"
+				repo.Config.Gov.PropCreator = state.ProposalCreatorAny.Ptr()
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), tx.Value, tx.Fee, tx.Nonce, false, uint64(0)).Return(nil)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+				err = validation.CheckTxRepoProposalRegisterPushKeyConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"policies[1].endHeight","msg":"must be greater than the current block height"`))
+			})
+		})
+
+		When("policies have not expired", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalRegisterPushKey()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.Policies = []*state.ContributorPolicy{{Object: "refs/heads/dev", Action: "write", StartHeight: 50, EndHeight: 101}}
+				repo := state.BareRepository()
+				repo.Balance = "10"
+				repo.Config.Gov.PropCreator = state.ProposalCreatorAny.Ptr()
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), tx.Value, tx.Fee, tx.Nonce, false, uint64(0)).Return(nil)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+				err = validation.CheckTxRepoProposalRegisterPushKeyConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 })
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/AlekSi/pointer"
+	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/policy"
 	"github.com/make-os/kit/remote/validation"
 	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/state"
@@ -269,6 +271,20 @@ func CheckRepoConfig(cfg *state.RepoConfig, index int) error {
 		}
 	}
 
+	if govCfg.PropExecDelay != nil {
+		propExecDelay, err := util.PtrStrToFloatE(govCfg.PropExecDelay)
+		if err != nil || propExecDelay < 0 {
+			return feI(index, "governance.propExecDelay", fmt.Sprintf("must be a non-negative number"))
+		}
+	}
+
+	if govCfg.RedirectDur != nil {
+		redirectDur, err := util.PtrStrToFloatE(govCfg.RedirectDur)
+		if err != nil || redirectDur < 0 {
+			return feI(index, "governance.redirectDur", fmt.Sprintf("must be a non-negative number"))
+		}
+	}
+
 	if govCfg.PropQuorum != nil {
 		propQuorum, err := util.PtrStrToFloatE(govCfg.PropQuorum)
 		if err != nil || propQuorum < 0 {
@@ -313,21 +329,52 @@ func CheckRepoConfig(cfg *state.RepoConfig, index int) error {
 		}
 	}
 
-	// When proposer is ProposerOwner, tally method cannot be CoinWeighted or Identity
+	// When proposer is not ProposerOwner, tally method cannot be CoinWeighted,
+	// QuadraticCoinWeighted or Identity
 	if govCfg.Voter != nil && govCfg.PropTallyMethod != nil {
 		tallyMethod := govCfg.PropTallyMethod
 		isNotOwnerProposer := pointer.GetInt(govCfg.Voter) != pointer.GetInt(state.VoterOwner.Ptr())
 		if isNotOwnerProposer {
 			if *tallyMethod == *state.ProposalTallyMethodCoinWeighted.Ptr() ||
+				*tallyMethod == *state.ProposalTallyMethodQuadraticCoinWeighted.Ptr() ||
 				*tallyMethod == *state.ProposalTallyMethodIdentity.Ptr() {
 				return feI(index, "config", "when proposer is not 'ProposerOwner', tally methods "+
-					"'CoinWeighted' and 'Identity' are not allowed")
+					"'CoinWeighted', 'QuadraticCoinWeighted' and 'Identity' are not allowed")
 			}
 		}
 	}
 
 policy:
-	// TODO: policy validation here
+	// Ensure policy objects that are reference patterns are valid
+	for i, pol := range cfg.Policies {
+		if pol == nil || !policy.IsPattern(pol.Object) {
+			continue
+		}
+		if err := policy.CheckPattern(pol.Object); err != nil {
+			return feI(index, fmt.Sprintf("policies[%d].obj", i), err.Error())
+		}
+	}
+
+	// Ensure protected branches target branches and require at least one approval
+	for i, pb := range cfg.Protected {
+		if pb == nil {
+			continue
+		}
+		field := fmt.Sprintf("protected[%d].name", i)
+		if policy.IsPattern(pb.Name) {
+			if err := policy.CheckPattern(pb.Name); err != nil {
+				return feI(index, field, err.Error())
+			}
+			if !strings.HasPrefix(strings.TrimPrefix(pb.Name, policy.RegexPatternPrefix), "refs/heads/") {
+				return feI(index, field, "pattern must target branches (refs/heads/)")
+			}
+		} else if !plumbing.IsBranch(pb.Name) || !plumbing.IsReference(pb.Name) {
+			return feI(index, field, "must be a full branch reference name or pattern")
+		}
+		if pb.Approvals < 1 {
+			return feI(index, fmt.Sprintf("protected[%d].approvals", i), "must be at least 1")
+		}
+	}
 
 	return nil
 }
@@ -645,6 +692,211 @@ func CheckTxRepoProposalUpsertOwner(tx *txns.TxRepoProposalUpsertOwner, index in
 	return nil
 }
 
+// CheckTxRepoProposalSpend performs sanity checks on TxRepoProposalSpend
+func CheckTxRepoProposalSpend(tx *txns.TxRepoProposalSpend, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoProposalSpend, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	if err := CheckProposalID(tx.ID, false, index); err != nil {
+		return err
+	}
+
+	if err := checkProposalFee(tx.Value, index); err != nil {
+		return err
+	}
+
+	if err := v.Validate(tx.To,
+		v.Required.Error(feI(index, "to", "recipient address is required").Error()),
+		v.By(validAddrRule(feI(index, "to", "recipient address is not valid"))),
+	); err != nil {
+		return err
+	}
+
+	if err := v.Validate(tx.Amount,
+		v.Required.Error(feI(index, "amount", "amount is required").Error()),
+		v.By(validValueRule("amount", index)),
+	); err != nil {
+		return err
+	}
+
+	if tx.Amount.Decimal().LessThanOrEqual(decimal.Zero) {
+		return feI(index, "amount", "amount must be a positive number")
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalArchive performs sanity checks on TxRepoProposalArchive
+func CheckTxRepoProposalArchive(tx *txns.TxRepoProposalArchive, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoProposalArchive, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	if err := CheckProposalID(tx.ID, false, index); err != nil {
+		return err
+	}
+
+	if err := checkProposalFee(tx.Value, index); err != nil {
+		return err
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalDelete performs sanity checks on TxRepoProposalDelete
+func CheckTxRepoProposalDelete(tx *txns.TxRepoProposalDelete, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoProposalDelete, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	if err := CheckProposalID(tx.ID, false, index); err != nil {
+		return err
+	}
+
+	if err := checkProposalFee(tx.Value, index); err != nil {
+		return err
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalRename performs sanity checks on TxRepoProposalRename
+func CheckTxRepoProposalRename(tx *txns.TxRepoProposalRename, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoProposalRename, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	if err := CheckProposalID(tx.ID, false, index); err != nil {
+		return err
+	}
+
+	if err := v.Validate(tx.NewName,
+		v.Required.Error(feI(index, "newName", "new name is required").Error()),
+		v.By(validObjectNameRule("newName", index)),
+	); err != nil {
+		return err
+	}
+
+	if tx.NewName == tx.RepoName {
+		return feI(index, "newName", "new name must be different from the current name")
+	}
+
+	for i, ns := range tx.Namespaces {
+		field := fmt.Sprintf("namespaces[%d]", i)
+		if identifier.IsValidResourceName(ns) != nil {
+			return feI(index, field, "value format is not valid")
+		}
+		if funk.ContainsString(tx.Namespaces[:i], ns) {
+			return feI(index, field, "namespace is a duplicate")
+		}
+	}
+
+	if err := checkProposalFee(tx.Value, index); err != nil {
+		return err
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalUpdateNamespace performs sanity checks on TxRepoProposalUpdateNamespace
+func CheckTxRepoProposalUpdateNamespace(tx *txns.TxRepoProposalUpdateNamespace, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoProposalUpdateNamespace, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	if err := CheckProposalID(tx.ID, false, index); err != nil {
+		return err
+	}
+
+	if err := v.Validate(tx.Namespace,
+		v.Required.Error(feI(index, "namespace", "namespace is required").Error()),
+		v.By(validObjectNameRule("namespace", index)),
+	); err != nil {
+		return err
+	}
+
+	if len(tx.Domains) == 0 && len(tx.RemoveContributors) == 0 {
+		return feI(index, "domains", "domains or contributors to remove are required")
+	}
+
+	// Domains with empty targets are to be removed; Check the others.
+	domains := make(map[string]string)
+	for domain, target := range tx.Domains {
+		if target == "" {
+			if identifier.IsValidResourceNameNoMinLen(domain) != nil {
+				return feI(index, "domains", fmt.Sprintf("domains.%s: name is invalid", domain))
+			}
+			continue
+		}
+		domains[domain] = target
+	}
+	if err := CheckNamespaceDomains(domains, index); err != nil {
+		return err
+	}
+
+	for i, pkID := range tx.RemoveContributors {
+		field := fmt.Sprintf("removeContributors[%d]", i)
+		if !crypto2.IsValidPushAddr(pkID) {
+			return feI(index, field, "push key id is not valid")
+		}
+		if funk.ContainsString(tx.RemoveContributors[:i], pkID) {
+			return feI(index, field, "push key id is a duplicate")
+		}
+	}
+
+	if err := checkProposalFee(tx.Value, index); err != nil {
+		return err
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CheckTxVote performs sanity checks on TxRepoProposalVote
 func CheckTxVote(tx *txns.TxRepoProposalVote, index int) error {
 
@@ -673,6 +925,75 @@ func CheckTxVote(tx *txns.TxRepoProposalVote, index int) error {
 	return nil
 }
 
+// CheckTxRepoProposalWithdraw performs sanity checks on TxRepoProposalWithdraw
+func CheckTxRepoProposalWithdraw(tx *txns.TxRepoProposalWithdraw, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoProposalWithdraw, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	if err := CheckProposalID(tx.ProposalID, true, index); err != nil {
+		return err
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoProposalCancel performs sanity checks on TxRepoProposalCancel
+func CheckTxRepoProposalCancel(tx *txns.TxRepoProposalCancel, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoProposalCancel, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	if err := CheckProposalID(tx.ProposalID, true, index); err != nil {
+		return err
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckTxRepoVoteDelegate performs sanity checks on TxRepoVoteDelegate
+func CheckTxRepoVoteDelegate(tx *txns.TxRepoVoteDelegate, index int) error {
+
+	if err := checkType(tx.TxType, txns.TxTypeRepoVoteDelegate, index); err != nil {
+		return err
+	}
+
+	if err := checkRepoName(tx.RepoName, index); err != nil {
+		return err
+	}
+
+	// An empty delegate revokes the current delegation
+	if tx.Delegate != "" {
+		if err := validAddrRule(feI(index, "delegate", "delegate address is not valid"))(tx.Delegate); err != nil {
+			return err
+		}
+	}
+
+	if err := CheckCommon(tx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CheckTxRepoProposalSendFee performs sanity checks on TxRepoProposalSendFee
 func CheckTxRepoProposalSendFee(tx *txns.TxRepoProposalSendFee, index int) error {
 
@@ -862,6 +1183,24 @@ func CheckTxRepoProposalRegisterPushKey(tx *txns.TxRepoProposalRegisterPushKey,
 		}
 	}
 
+	for i, pol := range tx.Policies {
+		if pol == nil {
+			continue
+		}
+
+		// Ensure policy objects that are reference patterns are valid
+		if policy.IsPattern(pol.Object) {
+			if err := policy.CheckPattern(pol.Object); err != nil {
+				return feI(index, fmt.Sprintf("policies[%d].obj", i), err.Error())
+			}
+		}
+
+		// Ensure the policy does not expire before it starts
+		if pol.EndHeight > 0 && pol.EndHeight <= pol.StartHeight {
+			return feI(index, fmt.Sprintf("policies[%d].endHeight", i), "must be greater than start height")
+		}
+	}
+
 	if err := CheckCommon(tx, index); err != nil {
 		return err
 	}
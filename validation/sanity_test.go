@@ -481,6 +481,34 @@ var _ = Describe("TxValidator", func() {
 					"propDur": "1a",
 				}},
 			},
+			{
+				"desc": "proposal execution delay has negative value",
+				"err":  `"field":"governance.propExecDelay","msg":"must be a non-negative number"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propExecDelay": "-1",
+				}},
+			},
+			{
+				"desc": "proposal execution delay has an invalid value",
+				"err":  `"field":"governance.propExecDelay","msg":"must be a non-negative number"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propExecDelay": "1a",
+				}},
+			},
+			{
+				"desc": "redirect duration has negative value",
+				"err":  `"field":"governance.redirectDur","msg":"must be a non-negative number"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"redirectDur": "-1",
+				}},
+			},
+			{
+				"desc": "redirect duration has an invalid value",
+				"err":  `"field":"governance.redirectDur","msg":"must be a non-negative number"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"redirectDur": "1a",
+				}},
+			},
 			{
 				"desc": "proposal fee deposit duration has negative value",
 				"err":  `"field":"governance.propFeeDepDur","msg":"must be a non-negative number"`,
@@ -567,20 +595,80 @@ var _ = Describe("TxValidator", func() {
 			},
 			{
 				"desc": "when voter type is not ProposerOwner and tally method is CoinWeighted",
-				"err":  `"field":"config","msg":"when proposer is not 'ProposerOwner', tally methods 'CoinWeighted' and 'Identity' are not allowed"`,
+				"err":  `"field":"config","msg":"when proposer is not 'ProposerOwner', tally methods 'CoinWeighted', 'QuadraticCoinWeighted' and 'Identity' are not allowed"`,
 				"data": map[string]interface{}{"governance": map[string]interface{}{
 					"propVoter":       state.VoterNetStakers,
 					"propTallyMethod": state.ProposalTallyMethodCoinWeighted,
 				}},
 			},
+			{
+				"desc": "when voter type is not ProposerOwner and tally method is QuadraticCoinWeighted",
+				"err":  `"field":"config","msg":"when proposer is not 'ProposerOwner', tally methods 'CoinWeighted', 'QuadraticCoinWeighted' and 'Identity' are not allowed"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propVoter":       state.VoterNetStakers,
+					"propTallyMethod": state.ProposalTallyMethodQuadraticCoinWeighted,
+				}},
+			},
 			{
 				"desc": "when voter is not ProposerOwner and tally method is Identity",
-				"err":  `"field":"config","msg":"when proposer is not 'ProposerOwner', tally methods 'CoinWeighted' and 'Identity' are not allowed"`,
+				"err":  `"field":"config","msg":"when proposer is not 'ProposerOwner', tally methods 'CoinWeighted', 'QuadraticCoinWeighted' and 'Identity' are not allowed"`,
 				"data": map[string]interface{}{"governance": map[string]interface{}{
 					"propVoter":       state.VoterNetStakers,
 					"propTallyMethod": state.ProposalTallyMethodIdentity,
 				}},
 			},
+			{
+				"desc": "when a policy object is a malformed glob pattern",
+				"err":  `"field":"policies[0].obj","msg":"invalid glob pattern"`,
+				"data": map[string]interface{}{"policies": []interface{}{
+					map[string]interface{}{"sub": "all", "obj": "refs/heads/[", "act": "write"},
+				}},
+			},
+			{
+				"desc": "when a policy object is a regex pattern not under refs/",
+				"err":  `"field":"policies[1].obj","msg":"regex pattern must start with '^refs/'"`,
+				"data": map[string]interface{}{"policies": []interface{}{
+					map[string]interface{}{"sub": "all", "obj": "refs/heads/release/*", "act": "write"},
+					map[string]interface{}{"sub": "all", "obj": "^heads/.*", "act": "write"},
+				}},
+			},
+			{
+				"desc": "when policy objects are valid patterns",
+				"err":  "",
+				"data": map[string]interface{}{"policies": []interface{}{
+					map[string]interface{}{"sub": "all", "obj": "refs/heads/release/*", "act": "write"},
+					map[string]interface{}{"sub": "all", "obj": "^refs/tags/v[0-9]+$", "act": "write"},
+				}},
+			},
+			{
+				"desc": "when a protected branch name is not a branch",
+				"err":  `"field":"protected[0].name","msg":"must be a full branch reference name or pattern"`,
+				"data": map[string]interface{}{"protected": []interface{}{
+					map[string]interface{}{"name": "refs/tags/v1", "approvals": 1},
+				}},
+			},
+			{
+				"desc": "when a protected branch pattern does not target branches",
+				"err":  `"field":"protected[0].name","msg":"pattern must target branches (refs/heads/)"`,
+				"data": map[string]interface{}{"protected": []interface{}{
+					map[string]interface{}{"name": "refs/tags/*", "approvals": 1},
+				}},
+			},
+			{
+				"desc": "when a protected branch requires no approval",
+				"err":  `"field":"protected[0].approvals","msg":"must be at least 1"`,
+				"data": map[string]interface{}{"protected": []interface{}{
+					map[string]interface{}{"name": "refs/heads/master", "approvals": 0},
+				}},
+			},
+			{
+				"desc": "when protected branches are valid",
+				"err":  "",
+				"data": map[string]interface{}{"protected": []interface{}{
+					map[string]interface{}{"name": "refs/heads/master", "approvals": 2},
+					map[string]interface{}{"name": "^refs/heads/release-[0-9]+$", "approvals": 1},
+				}},
+			},
 			{
 				"desc": "when fee refund type is unknown",
 				"err":  `"field":"governance.propFeeRefundType","msg":"unknown value"`,
@@ -1151,6 +1239,287 @@ var _ = Describe("TxValidator", func() {
 		})
 	})
 
+	Describe(".CheckTxRepoProposalSpend", func() {
+		var tx *txns.TxRepoProposalSpend
+
+		BeforeEach(func() {
+			params.DefaultMinProposalFee = 10
+			tx = txns.NewBareRepoProposalSpend()
+			tx.Timestamp = time.Now().Unix()
+			tx.Value = "11"
+			tx.ID = "123"
+			tx.RepoName = "repo1"
+		})
+
+		It("should return error when repo name is not provided", func() {
+			tx.RepoName = ""
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when proposal id is not valid", func() {
+			tx.ID = "abc123"
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"id","msg":"proposal id is not valid"`))
+		})
+
+		It("should return error when value below minimum network proposal fee", func() {
+			tx.Value = "1"
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"value","msg":"proposal creation fee cannot be less than network minimum"`))
+		})
+
+		It("should return error when recipient address is not provided", func() {
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"to","msg":"recipient address is required"`))
+		})
+
+		It("should return error when recipient address is not valid", func() {
+			tx.To = "invalid_addr"
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"to","msg":"recipient address is not valid"`))
+		})
+
+		It("should return error when amount is not provided", func() {
+			tx.To = key.Addr().String()
+			tx.Amount = ""
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"amount","msg":"amount is required"`))
+		})
+
+		It("should return error when amount is not numeric", func() {
+			tx.To = key.Addr().String()
+			tx.Amount = "ten"
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"amount","msg":"invalid number; must be numeric"`))
+		})
+
+		It("should return error when amount is zero", func() {
+			tx.To = key.Addr().String()
+			tx.Amount = "0"
+			err := validation.CheckTxRepoProposalSpend(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"amount","msg":"amount must be a positive number"`))
+		})
+	})
+
+	Describe(".CheckTxRepoProposalArchive", func() {
+		var tx *txns.TxRepoProposalArchive
+
+		BeforeEach(func() {
+			params.DefaultMinProposalFee = 10
+			tx = txns.NewBareRepoProposalArchive()
+			tx.Timestamp = time.Now().Unix()
+			tx.Value = "11"
+			tx.ID = "123"
+			tx.RepoName = "repo1"
+		})
+
+		It("should return error when repo name is not provided", func() {
+			tx.RepoName = ""
+			err := validation.CheckTxRepoProposalArchive(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when proposal id is not valid", func() {
+			tx.ID = "abc123"
+			err := validation.CheckTxRepoProposalArchive(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"id","msg":"proposal id is not valid"`))
+		})
+
+		It("should return error when value below minimum network proposal fee", func() {
+			tx.Value = "1"
+			err := validation.CheckTxRepoProposalArchive(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"value","msg":"proposal creation fee cannot be less than network minimum"`))
+		})
+	})
+
+	Describe(".CheckTxRepoProposalDelete", func() {
+		var tx *txns.TxRepoProposalDelete
+
+		BeforeEach(func() {
+			params.DefaultMinProposalFee = 10
+			tx = txns.NewBareRepoProposalDelete()
+			tx.Timestamp = time.Now().Unix()
+			tx.Value = "11"
+			tx.ID = "123"
+			tx.RepoName = "repo1"
+		})
+
+		It("should return error when repo name is not provided", func() {
+			tx.RepoName = ""
+			err := validation.CheckTxRepoProposalDelete(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when proposal id is not valid", func() {
+			tx.ID = "abc123"
+			err := validation.CheckTxRepoProposalDelete(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"id","msg":"proposal id is not valid"`))
+		})
+
+		It("should return error when value below minimum network proposal fee", func() {
+			tx.Value = "1"
+			err := validation.CheckTxRepoProposalDelete(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"value","msg":"proposal creation fee cannot be less than network minimum"`))
+		})
+	})
+
+	Describe(".CheckTxRepoProposalRename", func() {
+		var tx *txns.TxRepoProposalRename
+
+		BeforeEach(func() {
+			params.DefaultMinProposalFee = 10
+			tx = txns.NewBareRepoProposalRename()
+			tx.Timestamp = time.Now().Unix()
+			tx.Value = "11"
+			tx.ID = "123"
+			tx.RepoName = "repo1"
+			tx.NewName = "repo2"
+		})
+
+		It("should return error when repo name is not provided", func() {
+			tx.RepoName = ""
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when proposal id is not valid", func() {
+			tx.ID = "abc123"
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"id","msg":"proposal id is not valid"`))
+		})
+
+		It("should return error when new name is not provided", func() {
+			tx.NewName = ""
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"newName","msg":"new name is required"`))
+		})
+
+		It("should return error when new name is not valid", func() {
+			tx.NewName = "*&^"
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"newName","msg":"invalid identifier; only alphanumeric, _, and - characters are allowed"`))
+		})
+
+		It("should return error when new name is the same as the current name", func() {
+			tx.NewName = tx.RepoName
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"newName","msg":"new name must be different from the current name"`))
+		})
+
+		It("should return error when a namespace is not valid", func() {
+			tx.Namespaces = []string{"ns1", "*&^"}
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"namespaces[1]","msg":"value format is not valid"`))
+		})
+
+		It("should return error when a namespace is a duplicate", func() {
+			tx.Namespaces = []string{"ns1", "ns1"}
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"namespaces[1]","msg":"namespace is a duplicate"`))
+		})
+
+		It("should return error when value below minimum network proposal fee", func() {
+			tx.Value = "1"
+			err := validation.CheckTxRepoProposalRename(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"value","msg":"proposal creation fee cannot be less than network minimum"`))
+		})
+	})
+
+	Describe(".CheckTxRepoProposalUpdateNamespace", func() {
+		var tx *txns.TxRepoProposalUpdateNamespace
+		var pkID = "pk1dmqxfznwyhmkcgcfthlvvt88vajyhnxq7w8nsw"
+
+		BeforeEach(func() {
+			params.DefaultMinProposalFee = 10
+			tx = txns.NewBareRepoProposalUpdateNamespace()
+			tx.Timestamp = time.Now().Unix()
+			tx.Value = "11"
+			tx.ID = "123"
+			tx.RepoName = "repo1"
+			tx.Namespace = "ns1"
+			tx.Domains = map[string]string{"app": "r/repo1"}
+		})
+
+		It("should return error when repo name is not provided", func() {
+			tx.RepoName = ""
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when namespace is not provided", func() {
+			tx.Namespace = ""
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"namespace","msg":"namespace is required"`))
+		})
+
+		It("should return error when domains and contributors to remove are not provided", func() {
+			tx.Domains = nil
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"domains","msg":"domains or contributors to remove are required"`))
+		})
+
+		It("should return error when a domain target is not valid", func() {
+			tx.Domains = map[string]string{"app": "repo1"}
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"domains","msg":"domains.app: target is invalid"`))
+		})
+
+		It("should return error when the name of a domain to remove is not valid", func() {
+			tx.Domains = map[string]string{"*&^": ""}
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"domains","msg":"domains.*&^: name is invalid"`))
+		})
+
+		It("should return error when a contributor to remove is not valid", func() {
+			tx.RemoveContributors = []string{"pk1_abc"}
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"removeContributors[0]","msg":"push key id is not valid"`))
+		})
+
+		It("should return error when a contributor to remove is a duplicate", func() {
+			tx.RemoveContributors = []string{pkID, pkID}
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"removeContributors[1]","msg":"push key id is a duplicate"`))
+		})
+
+		It("should return error when value below minimum network proposal fee", func() {
+			tx.Value = "1"
+			err := validation.CheckTxRepoProposalUpdateNamespace(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"value","msg":"proposal creation fee cannot be less than network minimum"`))
+		})
+	})
+
 	Describe(".CheckTxVote", func() {
 		var tx *txns.TxRepoProposalVote
 
@@ -1207,6 +1576,104 @@ var _ = Describe("TxValidator", func() {
 		})
 	})
 
+	Describe(".CheckTxRepoProposalWithdraw", func() {
+		var tx *txns.TxRepoProposalWithdraw
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalWithdraw()
+			tx.Timestamp = time.Now().Unix()
+		})
+
+		It("should return error when repo name is not provided", func() {
+			err := validation.CheckTxRepoProposalWithdraw(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when proposal id is not provided", func() {
+			tx.RepoName = "repo1"
+			err := validation.CheckTxRepoProposalWithdraw(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"id","msg":"proposal id is required"`))
+		})
+
+		It("should return error when proposal id is not numerical", func() {
+			tx.RepoName = "repo1"
+			tx.ProposalID = "abc"
+			err := validation.CheckTxRepoProposalWithdraw(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"id","msg":"proposal id is not valid"`))
+		})
+
+		It("should return error when nonce is not set", func() {
+			tx.RepoName = "repo1"
+			tx.ProposalID = "MR1"
+			err := validation.CheckTxRepoProposalWithdraw(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"nonce","msg":"nonce is required"`))
+		})
+	})
+
+	Describe(".CheckTxRepoProposalCancel", func() {
+		var tx *txns.TxRepoProposalCancel
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoProposalCancel()
+			tx.Timestamp = time.Now().Unix()
+		})
+
+		It("should return error when repo name is not provided", func() {
+			err := validation.CheckTxRepoProposalCancel(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when proposal id is not provided", func() {
+			tx.RepoName = "repo1"
+			err := validation.CheckTxRepoProposalCancel(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"id","msg":"proposal id is required"`))
+		})
+
+		It("should return error when nonce is not set", func() {
+			tx.RepoName = "repo1"
+			tx.ProposalID = "1"
+			err := validation.CheckTxRepoProposalCancel(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"nonce","msg":"nonce is required"`))
+		})
+	})
+
+	Describe(".CheckTxRepoVoteDelegate", func() {
+		var tx *txns.TxRepoVoteDelegate
+
+		BeforeEach(func() {
+			tx = txns.NewBareRepoVoteDelegate()
+			tx.Timestamp = time.Now().Unix()
+		})
+
+		It("should return error when repo name is not provided", func() {
+			err := validation.CheckTxRepoVoteDelegate(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"name","msg":"repo name is required"`))
+		})
+
+		It("should return error when delegate is not a valid address", func() {
+			tx.RepoName = "repo1"
+			tx.Delegate = "invalid"
+			err := validation.CheckTxRepoVoteDelegate(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"delegate","msg":"delegate address is not valid"`))
+		})
+
+		It("should not return delegate error when delegate is empty", func() {
+			tx.RepoName = "repo1"
+			err := validation.CheckTxRepoVoteDelegate(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"nonce","msg":"nonce is required"`))
+		})
+	})
+
 	Describe(".CheckTxRepoProposalSendFee", func() {
 		var tx *txns.TxRepoProposalSendFee
 
@@ -1443,6 +1910,18 @@ var _ = Describe("TxValidator", func() {
 			Expect(err).To(MatchError(`"field":"keys","msg":"push key id (pk1dmqxfznwyhmkcgcfthlvvt88vajyhnxq7w8nsw) is a duplicate"`))
 		})
 
+		It("should return error when a policy end height is not greater than its start height", func() {
+			tx.RepoName = "good-repo"
+			tx.Value = "1"
+			tx.Policies = []*state.ContributorPolicy{
+				{Object: "refs/heads/dev", Action: "write", EndHeight: 10},
+				{Object: "refs/heads/master", Action: "write", StartHeight: 10, EndHeight: 10},
+			}
+			err := validation.CheckTxRepoProposalRegisterPushKey(tx, -1)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(`"field":"policies[1].endHeight","msg":"must be greater than start height"`))
+		})
+
 		It("should return error when fee mode is unknown", func() {
 			tx.RepoName = "good-repo"
 			tx.Value = "1"
@@ -72,6 +72,22 @@ func ValidateTxSanity(tx types.BaseTx, index int) error {
 		return CheckTxRepoProposalSendFee(o, index)
 	case *txns.TxRepoProposalRegisterPushKey:
 		return CheckTxRepoProposalRegisterPushKey(o, index)
+	case *txns.TxRepoProposalSpend:
+		return CheckTxRepoProposalSpend(o, index)
+	case *txns.TxRepoProposalWithdraw:
+		return CheckTxRepoProposalWithdraw(o, index)
+	case *txns.TxRepoVoteDelegate:
+		return CheckTxRepoVoteDelegate(o, index)
+	case *txns.TxRepoProposalCancel:
+		return CheckTxRepoProposalCancel(o, index)
+	case *txns.TxRepoProposalArchive:
+		return CheckTxRepoProposalArchive(o, index)
+	case *txns.TxRepoProposalDelete:
+		return CheckTxRepoProposalDelete(o, index)
+	case *txns.TxRepoProposalRename:
+		return CheckTxRepoProposalRename(o, index)
+	case *txns.TxRepoProposalUpdateNamespace:
+		return CheckTxRepoProposalUpdateNamespace(o, index)
 	default:
 		return feI(index, "type", "unsupported transaction type")
 	}
@@ -113,6 +129,22 @@ func ValidateTxConsistency(tx types.BaseTx, index int, logic core.Logic) error {
 		return CheckTxRepoProposalSendFeeConsistency(o, index, logic)
 	case *txns.TxRepoProposalRegisterPushKey:
 		return CheckTxRepoProposalRegisterPushKeyConsistency(o, index, logic)
+	case *txns.TxRepoProposalSpend:
+		return CheckTxRepoProposalSpendConsistency(o, index, logic)
+	case *txns.TxRepoProposalWithdraw:
+		return CheckTxRepoProposalWithdrawConsistency(o, index, logic)
+	case *txns.TxRepoVoteDelegate:
+		return CheckTxRepoVoteDelegateConsistency(o, index, logic)
+	case *txns.TxRepoProposalCancel:
+		return CheckTxRepoProposalCancelConsistency(o, index, logic)
+	case *txns.TxRepoProposalArchive:
+		return CheckTxRepoProposalArchiveConsistency(o, index, logic)
+	case *txns.TxRepoProposalDelete:
+		return CheckTxRepoProposalDeleteConsistency(o, index, logic)
+	case *txns.TxRepoProposalRename:
+		return CheckTxRepoProposalRenameConsistency(o, index, logic)
+	case *txns.TxRepoProposalUpdateNamespace:
+		return CheckTxRepoProposalUpdateNamespaceConsistency(o, index, logic)
 	default:
 		return feI(index, "type", "unsupported transaction type")
 	}